@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/permission"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
 	"github.com/gravadigital/telescopio-api/internal/handlers"
 	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/mail"
 	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
 	"github.com/gravadigital/telescopio-api/internal/middleware/events"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/oidc"
+	"github.com/gravadigital/telescopio-api/internal/ratelimit"
+	"github.com/gravadigital/telescopio-api/internal/scheduler"
 	"github.com/gravadigital/telescopio-api/internal/storage"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
@@ -26,6 +35,20 @@ func main() {
 	log := logger.Get()
 
 	gin.SetMode(cfg.Server.GinMode)
+	if err := cfg.CheckSecrets(); err != nil {
+		if cfg.Server.GinMode == gin.ReleaseMode {
+			log.Fatal("Refusing to start in release mode", "error", err)
+		}
+		log.Warn("Insecure configuration, do not use in production", "error", err)
+	}
+
+	keySet, err := auth.LoadKeySet(cfg)
+	if err != nil {
+		log.Fatal("Failed to load token signing keys", "error", err)
+	}
+	auth.SetKeySet(keySet)
+	auth.SetAccessTokenTTL(time.Duration(cfg.Auth.AccessTokenTTLMinutes) * time.Minute)
+	log.Info("Token signing keys loaded", "algorithm", keySet.Algorithm(), "kid", keySet.SigningKeyID(), "accepted", keySet.KeyIDs())
 
 	db, err := postgres.Connect(cfg)
 	if err != nil {
@@ -38,6 +61,12 @@ func main() {
 
 	router := gin.Default()
 
+	// Client IPs, which rate limits count by, only come from X-Forwarded-For when the
+	// request passed through a trusted proxy
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Invalid trusted proxies", "error", err)
+	}
+
 	corsConfig := cors.DefaultConfig()
 	if cfg.CORS.AllowOrigins == "*" {
 		corsConfig.AllowAllOrigins = true
@@ -54,6 +83,7 @@ func main() {
 	userRepo := postgres.NewPostgresUserRepository(db)
 	attachmentRepo := postgres.NewPostgresAttachmentRepository(db)
 	voteRepo := postgres.NewPostgresVoteRepository(db)
+	container := postgres.NewContainerWithDB(db)
 
 	// Initialize file storage
 	fileStorage, err := storage.NewFileStorage(cfg)
@@ -62,18 +92,78 @@ func main() {
 	}
 	log.Info("File storage initialized", "provider", cfg.Storage.Provider)
 
-	eventHandler := handlers.NewEventHandler(eventRepo, userRepo, attachmentRepo, cfg)
-	attachmentHandler := handlers.NewAttachmentHandler(attachmentRepo, eventRepo, userRepo, fileStorage, cfg)
-	userHandler := handlers.NewUserHandler(userRepo, eventRepo, cfg)
-	googleAuthHandler := handlers.NewGoogleAuthHandler(userRepo, cfg)
+	mailer, err := mail.NewMailer(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize mail delivery", "error", err)
+	}
+	log.Info("Mail delivery initialized", "provider", cfg.Mail.Provider)
+
+	oidcProviders, err := oidc.NewRegistry(cfg, nil)
+	if err != nil {
+		log.Fatal("Failed to configure OIDC providers", "error", err)
+	}
+	log.Info("OIDC providers configured", "count", len(oidcProviders.List()))
 
 	configRepo := postgres.NewPostgresVotingConfigurationRepository(db)
 	resultsRepo := postgres.NewPostgresVotingResultsRepository(db)
-	distributedVoteHandler := handlers.NewDistributedVoteHandler(voteRepo, eventRepo, attachmentRepo, userRepo, configRepo, resultsRepo, cfg)
+	// Background removal of stored files for deleted events and attachments
+	cleanupWorker := storage.NewCleanupWorker(container, fileStorage, time.Duration(cfg.Storage.CleanupIntervalSeconds)*time.Second)
+	go cleanupWorker.Start(context.Background())
+
+	rateLimitStore, err := ratelimit.NewStore(cfg, db)
+	if err != nil {
+		log.Fatal("Failed to initialize rate limiting", "error", err)
+	}
+	log.Info("Rate limiting initialized", "store", cfg.RateLimit.Store)
+
+	limiter := ratelimit.NewLimiter(rateLimitStore)
+	lockout := ratelimit.NewLockout(rateLimitStore, ratelimit.LockoutPolicy{
+		Threshold: int(cfg.Lockout.Threshold),
+		BaseDelay: time.Duration(cfg.Lockout.BaseDelaySeconds) * time.Second,
+		MaxDelay:  time.Duration(cfg.Lockout.MaxDelaySeconds) * time.Second,
+		Window:    time.Duration(cfg.Lockout.FailureWindowMinutes) * time.Minute,
+	})
+	rateLimitRule := func(name string, limit int64) ratelimit.Rule {
+		return ratelimit.Rule{Name: name, Limit: int(limit), Window: time.Duration(cfg.RateLimit.WindowSeconds) * time.Second}
+	}
+
+	accountService := handlers.NewAccountService(container, mailer, cfg)
+
+	eventHandler := handlers.NewEventHandler(container, eventRepo, userRepo, attachmentRepo, cleanupWorker, accountService, cfg)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentRepo, eventRepo, userRepo, fileStorage, cleanupWorker, cfg)
+	sessionService := handlers.NewSessionService(container, cfg)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	accountHandler := handlers.NewAccountHandler(accountService, userRepo)
+	twoFactorService := handlers.NewTwoFactorService(container, cfg)
+	userHandler := handlers.NewUserHandler(userRepo, eventRepo, sessionService, accountService, twoFactorService, lockout, cfg)
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService, sessionService, lockout, userRepo)
+	googleAuthHandler := handlers.NewGoogleAuthHandler(userRepo, sessionService, twoFactorService, oidcProviders, cfg)
+	oidcAuthHandler := handlers.NewOIDCAuthHandler(container, oidcProviders, sessionService, twoFactorService, cfg)
+	eventTemplateHandler := handlers.NewEventTemplateHandler(container, cfg)
+	eventInvitationHandler := handlers.NewEventInvitationHandler(container, cfg)
+	waitlistHandler := handlers.NewWaitlistHandler(container)
+	apiKeyHandler := handlers.NewAPIKeyHandler(container)
+	userAdminHandler := handlers.NewUserAdminHandler(container, handlers.NewUserAdminService(container, lockout))
+	organizationHandler := handlers.NewOrganizationHandler(container, handlers.NewOrganizationService(container))
+	eventMemberHandler := handlers.NewEventMemberHandler(container)
+	submissionFormHandler := handlers.NewSubmissionFormHandler(container)
+	notificationHandler := handlers.NewNotificationHandler(container.Notifications())
+	calendarHandler := handlers.NewCalendarHandler(container, cfg)
+
+	distributedVoteHandler := handlers.NewDistributedVoteHandler(container, voteRepo, eventRepo, attachmentRepo, userRepo, configRepo, resultsRepo, cfg)
 
 	voteDraftRepo := postgres.NewPostgresVoteDraftRepository(db)
 	voteDraftHandler := handlers.NewVoteDraftHandler(voteDraftRepo, voteRepo)
 
+	// Automatic stage transitions (safe with multiple replicas via advisory locks)
+	if cfg.Scheduler.Enabled {
+		stageScheduler := scheduler.NewStageScheduler(container, time.Duration(cfg.Scheduler.IntervalSeconds)*time.Second, int(cfg.Scheduler.VotingDays))
+		go stageScheduler.Start(context.Background())
+	}
+
+	// Public keys for other services to verify our access tokens
+	router.GET("/.well-known/jwks.json", handlers.NewJWKSHandler(keySet).GetJWKS)
+
 	// Test database connection
 	router.GET("/health", func(c *gin.Context) {
 		sqlDB, err := db.DB()
@@ -104,12 +194,79 @@ func main() {
 	})
 
 	api := router.Group("/api/v1")
+	// Every request is served for one organization: the one of the credentials, else of the
+	// subdomain, else the default one. Records of other organizations are reported as missing.
+	api.Use(
+		tenant.Resolve(container.Organizations(), cfg, auth.CredentialOrganization(container.APIKeys())),
+		tenant.Guard(container),
+	)
 	{
+		// Organization of the request - Branding is public; members and settings for org admins
+		api.GET("/organization", organizationHandler.GetOrganization)
+		org := api.Group("/organization")
+		org.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			org.PATCH("", auth.RequirePermission(eventRepo, permission.OrganizationManage), organizationHandler.UpdateOrganization)
+			org.GET("/members", auth.RequirePermission(eventRepo, permission.UsersManage), organizationHandler.ListMembers)
+			org.PATCH("/members/:user_id", auth.RequirePermission(eventRepo, permission.UsersManage), organizationHandler.ChangeMemberRole) // Revokes the member's access tokens
+			org.DELETE("/members/:user_id", auth.RequirePermission(eventRepo, permission.UsersManage), organizationHandler.RemoveMember)
+		}
+
+		// Organizations of the deployment - Admins (global role) only, never org admins
+		platform := api.Group("/platform")
+		platform.Use(auth.JWTAuthMiddleware(userRepo), auth.RequirePermission(eventRepo, permission.PlatformManage))
+		{
+			platform.POST("/organizations", organizationHandler.CreateOrganization) // Optional admin_user_id becomes its first org admin
+			platform.GET("/organizations", organizationHandler.ListOrganizations)
+		}
+
 		// User management - Public endpoints (no auth required)
 		users := api.Group("/users")
 		{
-			users.POST("", userHandler.CreateUser)                    // Register new user (returns JWT)
-			users.POST("/authenticate", userHandler.AuthenticateUser) // Login (returns JWT)
+			// Register new user (returns JWT)
+			users.POST("",
+				limiter.Limit(rateLimitRule("signup_ip", cfg.RateLimit.SignupPerIP), ratelimit.ByIP),
+				userHandler.CreateUser)
+
+			// Login (returns JWT) - Accounts are locked for a while after repeated failed passwords
+			users.POST("/authenticate",
+				limiter.Limit(rateLimitRule("login_ip", cfg.RateLimit.LoginPerIP), ratelimit.ByIP),
+				limiter.Limit(rateLimitRule("login_account", cfg.RateLimit.LoginPerAccount), ratelimit.ByAccount),
+				userHandler.AuthenticateUser)
+		}
+
+		// Sessions - Refresh and logout are authenticated by the refresh token
+		sessions := api.Group("/auth")
+		{
+			sessions.POST("/refresh", sessionHandler.RefreshSession)
+			sessions.POST("/logout", sessionHandler.Logout)
+			sessions.POST("/logout-all", auth.JWTAuthMiddleware(userRepo), sessionHandler.LogoutEverywhere) // Revokes every token of the user
+		}
+
+		// Two-factor authentication (TOTP) - Enrolment by the signed-in user; the challenge
+		// endpoints finish a sign-in and are authenticated by the challenge token
+		twoFactor := api.Group("/auth/two-factor")
+		{
+			twoFactor.GET("", auth.JWTAuthMiddleware(userRepo), twoFactorHandler.GetTwoFactorStatus)
+			twoFactor.POST("/enroll", auth.JWTAuthMiddleware(userRepo), twoFactorHandler.EnrollTwoFactor)   // Returns the secret and otpauth:// URI
+			twoFactor.POST("/confirm", auth.JWTAuthMiddleware(userRepo), twoFactorHandler.ConfirmTwoFactor) // Returns the recovery codes
+			twoFactor.POST("/recovery-codes", auth.JWTAuthMiddleware(userRepo), twoFactorHandler.RegenerateRecoveryCodes)
+			twoFactor.POST("/disable", auth.JWTAuthMiddleware(userRepo), twoFactorHandler.DisableTwoFactor)
+			twoFactor.POST("/challenge",
+				limiter.Limit(rateLimitRule("login_ip", cfg.RateLimit.LoginPerIP), ratelimit.ByIP),
+				twoFactorHandler.CompleteSignIn)
+			twoFactor.POST("/challenge/enroll",
+				limiter.Limit(rateLimitRule("login_ip", cfg.RateLimit.LoginPerIP), ratelimit.ByIP),
+				twoFactorHandler.EnrollForSignIn)
+		}
+
+		// Password reset and email verification - Authenticated by the mailed token
+		accounts := api.Group("/auth")
+		{
+			accounts.POST("/password/forgot", accountHandler.ForgotPassword) // Mails a reset link; same answer for unknown emails
+			accounts.POST("/password/reset", accountHandler.ResetPassword)   // Sets a new password and revokes every session
+			accounts.POST("/email/verification", auth.JWTAuthMiddleware(userRepo), accountHandler.SendEmailVerification)
+			accounts.POST("/email/verify", accountHandler.VerifyEmail)
 		}
 
 		// Google OAuth - Public endpoints (no auth required)
@@ -119,9 +276,19 @@ func main() {
 			googleAuth.POST("/register", googleAuthHandler.RegisterGoogleUser)
 		}
 
+		// OpenID Connect providers (institutional SSO, Keycloak, ...) - ID tokens verified locally
+		oidcAuth := api.Group("/auth/oidc")
+		{
+			oidcAuth.GET("/providers", oidcAuthHandler.ListProviders)
+			oidcAuth.POST("/:provider/nonce", oidcAuthHandler.IssueNonce)
+			oidcAuth.POST("/:provider/verify", oidcAuthHandler.VerifyIDToken)
+			oidcAuth.POST("/:provider/register", oidcAuthHandler.RegisterOIDCUser)
+			oidcAuth.POST("/:provider/link", auth.JWTAuthMiddleware(userRepo), oidcAuthHandler.LinkIdentity) // Link to the signed-in account
+		}
+
 	// Protected user endpoints (require authentication)
 	usersProtected := api.Group("/users")
-	usersProtected.Use(auth.JWTAuthMiddleware())
+	usersProtected.Use(auth.JWTAuthMiddleware(userRepo))
 	{
 		usersProtected.GET("/:user_id", userHandler.GetUser)
 		usersProtected.GET("/:user_id/events", userHandler.GetUserEvents) // Get events where user participates
@@ -133,74 +300,337 @@ func main() {
 			eventsPublic.GET("", eventHandler.GetAllEvents)                            // List all events
 			eventsPublic.GET("/:event_id", eventHandler.GetEvent)                      // Get event details
 			eventsPublic.GET("/:event_id/share", eventHandler.GetShareableEventInfo)   // Get shareable metadata
-			eventsPublic.POST("/:event_id/register", eventHandler.RegisterParticipant) // Register for event (creates user if doesn't exist)
+			eventsPublic.GET("/:event_id/calendar.ics", calendarHandler.GetEventCalendar) // iCalendar of the event dates and stage deadlines
+
+			// Register for event (creates user if doesn't exist; non-public events need an invitation token)
+			eventsPublic.POST("/:event_id/register",
+				limiter.Limit(rateLimitRule("event_register_ip", cfg.RateLimit.EventRegisterPerIP), ratelimit.ByIP),
+				limiter.Limit(rateLimitRule("event_register_account", cfg.RateLimit.EventRegisterPerAccount), ratelimit.ByAccount),
+				auth.RequireWritableEvent(eventRepo),
+				eventHandler.RegisterParticipant)
+		}
+
+		// Event lifecycle - Only event owner or admin (allowed on archived events)
+		eventLifecycle := api.Group("/events")
+		eventLifecycle.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			eventLifecycle.POST("/:event_id/archive",
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
+				eventHandler.ArchiveEvent)
+			eventLifecycle.POST("/:event_id/unarchive",
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
+				eventHandler.UnarchiveEvent)
+			eventLifecycle.DELETE("/:event_id",
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
+				eventHandler.DeleteEvent)
+		}
+
+		// Event copies - Only event owner/organizer/admin (allowed on archived events, which are only read)
+		eventCopies := api.Group("/events")
+		eventCopies.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			// Clone event into a new event in creation stage
+			eventCopies.POST("/:event_id/clone",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventTemplateHandler.CloneEvent)
+
+			// Save event as a named template
+			eventCopies.POST("/:event_id/templates",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventTemplateHandler.CreateTemplateFromEvent)
 		}
 
 		// Event management - Protected endpoints (require authentication)
+		// Archived events are read-only: every write below is rejected for them
 		events := api.Group("/events")
-		events.Use(auth.JWTAuthMiddleware())
+		events.Use(auth.JWTAuthMiddleware(userRepo), auth.RequireWritableEvent(eventRepo))
 		{
-			// Create event - Any authenticated user can create events
-			events.POST("", eventHandler.CreateEvent)
-
 			// Update event stage - Only event owner or admin
 			events.PATCH("/:event_id/stage",
-				auth.RequireEventOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
 				eventHandler.UpdateEventStage)
 
+			// Revert to the previous stage with a justification - Only event owner or admin
+			events.POST("/:event_id/stage/revert",
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
+				eventHandler.RevertEventStage)
+
+			// Stage history log - Event owner/co-organizers/observers, organizers and admins
+			events.GET("/:event_id/history",
+				auth.RequirePermission(eventRepo, permission.EventHistoryView),
+				eventHandler.GetEventHistory)
+
 			// Update estimated end date - Only event owner
 			events.PATCH("/:event_id/estimated-end-date",
-				auth.RequireEventOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.EventLifecycle),
 				eventHandler.UpdateEstimatedEndDate)
 
-			// Get event participants - Any authenticated user
-			events.GET("/:event_id/participants", eventHandler.GetEventParticipants)
+			// Visibility (public, link_only, invite_only) - Only event owner/organizer/admin
+			events.PATCH("/:event_id/visibility",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.UpdateEventVisibility)
+
+			// Registration requirements (verified email) - Only event owner/organizer/admin
+			events.PATCH("/:event_id/registration-requirements",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.UpdateRegistrationRequirements)
+
+			// Results visibility (managers, members, public) - Only event owner/organizer/admin
+			events.PATCH("/:event_id/results-visibility",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.UpdateResultsVisibility)
+
+			// Participant limit; raising it promotes waitlisted registrants - Only event owner/organizer/admin
+			events.PATCH("/:event_id/participant-limit",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				waitlistHandler.UpdateParticipantLimit)
+
+			// Move a waitlist entry - Only event owner/organizer/admin
+			events.PATCH("/:event_id/waitlist/:entry_id",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				waitlistHandler.MoveWaitlistEntry)
+
+			// Leave the waitlist - Waitlisted user or event owner/organizer/admin (checked in handler)
+			events.DELETE("/:event_id/waitlist/:entry_id", waitlistHandler.LeaveWaitlist)
 
 			// Attachment management - Participant or event owner
+			// (uploading again adds a new version of the main document or of the file in "slot")
 			events.POST("/:event_id/participant/:participant_id/attachment",
-				auth.RequireParticipantOrOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.MemberAct),
 				attachmentHandler.UploadAttachment)
 
 			// Get event attachments - Any authenticated user
 			events.GET("/:event_id/attachments", attachmentHandler.GetEventAttachments)
 
+			// Full-text proposal search - Only event owner/co-organizer/organizer/admin
+			events.GET("/:event_id/attachments/search",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				attachmentHandler.SearchEventAttachments)
+
+			// Submission form schema - Read by any authenticated user, edited by event owner/co-organizer/organizer/admin
+			events.GET("/:event_id/submission-form", submissionFormHandler.GetSubmissionForm)
+			events.PUT("/:event_id/submission-form",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				submissionFormHandler.UpdateSubmissionForm)
+			events.DELETE("/:event_id/submission-form",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				submissionFormHandler.DeleteSubmissionForm)
+
 			// Voting configuration - Only event owner/organizer/admin
 			events.POST("/:event_id/voting-config",
-				auth.RequireEventOwnerOrOrganizer(eventRepo),
+				auth.RequirePermission(eventRepo, permission.EventManage),
 				distributedVoteHandler.CreateVotingConfiguration)
 
+			// Amendment deadline - Only event owner/organizer/admin
+			events.PATCH("/:event_id/voting-config/amendment-deadline",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				distributedVoteHandler.UpdateAmendmentDeadline)
+
 			// Generate assignments - Only event owner/organizer/admin
 			events.POST("/:event_id/generate-assignments",
-				auth.RequireEventOwnerOrOrganizer(eventRepo),
+				auth.RequirePermission(eventRepo, permission.EventManage),
 				distributedVoteHandler.GenerateAssignments)
 
 			// Get participant assignment - Participant themselves or event owner
 			events.GET("/:event_id/participants/:participant_id/assignment",
-				auth.RequireParticipantOrOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.MemberAct),
 				distributedVoteHandler.GetParticipantAssignment)
 
 			// Submit ranking votes - Participant themselves or event owner
 			events.POST("/:event_id/participants/:participant_id/ranking-votes",
-				auth.RequireParticipantOrOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.MemberAct),
 				distributedVoteHandler.SubmitRankingVotes)
 
 			// Vote draft - save/restore partial ranking selections before submit
 			events.PUT("/:event_id/participants/:participant_id/vote-draft",
-				auth.RequireParticipantOrOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.MemberAct),
 				voteDraftHandler.SaveDraft)
 			events.GET("/:event_id/participants/:participant_id/vote-draft",
-				auth.RequireParticipantOrOwner(eventRepo),
+				auth.RequirePermission(eventRepo, permission.MemberAct),
 				voteDraftHandler.GetDraft)
+		}
 
-			// Get results - Any authenticated user
-			events.GET("/:event_id/distributed-results", distributedVoteHandler.GetDistributedResults)
+		// Automation endpoints - Also accept API keys granted the scope of each group
+		// (X-API-Key header or "Authorization: Bearer tsk_..."); keys act as their owner
+		apiKeys := container.APIKeys()
+		organizations := container.Organizations()
 
-			// Get voting statistics - Any authenticated user
-			events.GET("/:event_id/voting-statistics", distributedVoteHandler.GetVotingStatistics)
+		eventsRead := api.Group("/events")
+		eventsRead.Use(auth.JWTOrAPIKeyAuthMiddleware(userRepo, apiKeys, organizations, session.ScopeEventsRead))
+		{
+			// Archived events of the caller (all archived events for admins)
+			eventsRead.GET("/archived", eventHandler.GetArchivedEvents)
+
+			// Export proposals with their form answers (CSV or JSON) - Only event owner/co-organizer/organizer/admin
+			eventsRead.GET("/:event_id/submissions/export",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				submissionFormHandler.ExportSubmissions)
 		}
 
-		// Attachment download - Available to authenticated users
-		api.GET("/attachments/:attachment_id/download", attachmentHandler.DownloadAttachment)
+		eventsWrite := api.Group("/events")
+		eventsWrite.Use(auth.JWTOrAPIKeyAuthMiddleware(userRepo, apiKeys, organizations, session.ScopeEventsWrite))
+		{
+			// Create event - Any authenticated user can create events
+			eventsWrite.POST("",
+				auth.RequirePermission(eventRepo, permission.EventCreate),
+				eventHandler.CreateEvent)
+		}
+
+		participantsRead := api.Group("/events")
+		participantsRead.Use(auth.JWTOrAPIKeyAuthMiddleware(userRepo, apiKeys, organizations, session.ScopeParticipantsRead))
+		{
+			// Get event participants - Any authenticated user
+			participantsRead.GET("/:event_id/participants", eventHandler.GetEventParticipants)
+
+			// Registration invitations - Only event owner/organizer/admin
+			participantsRead.GET("/:event_id/invitations",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.ListInvitations)
+			participantsRead.GET("/:event_id/invitations/:invitation_id",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.GetInvitation)
+
+			// Waitlist of full events - Only event owner/organizer/admin
+			participantsRead.GET("/:event_id/waitlist",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				waitlistHandler.GetWaitlist)
+		}
+
+		// Archived events are read-only: every write below is rejected for them
+		participantsWrite := api.Group("/events")
+		participantsWrite.Use(auth.JWTOrAPIKeyAuthMiddleware(userRepo, apiKeys, organizations, session.ScopeParticipantsWrite), auth.RequireWritableEvent(eventRepo))
+		{
+			// Registration invitations - Only event owner/organizer/admin
+			participantsWrite.POST("/:event_id/invitations",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.IssueInvitation)
+			participantsWrite.DELETE("/:event_id/invitations/:invitation_id",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventInvitationHandler.RevokeInvitation)
+
+			// Per-event roles (participant, co_organizer, reviewer, observer) - Only event owner/co-organizer/organizer/admin
+			// (granting co-organizer rights is reserved to the owner, checked in handler)
+			participantsWrite.PUT("/:event_id/members/:participant_id/role",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				eventMemberHandler.SetMemberRole)
+
+			// Remove a participant or withdraw yourself - Participant themselves or event owner
+			// (the next registrant on the waitlist is promoted automatically)
+			participantsWrite.DELETE("/:event_id/participants/:participant_id",
+				auth.RequirePermission(eventRepo, permission.MemberAct),
+				eventHandler.RemoveParticipant)
+
+			// Promote a waitlisted registrant - Only event owner/organizer/admin
+			participantsWrite.POST("/:event_id/waitlist/:entry_id/promote",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				waitlistHandler.PromoteWaitlistEntry)
+		}
+
+		resultsRead := api.Group("/events")
+		resultsRead.Use(auth.JWTOrAPIKeyAuthMiddleware(userRepo, apiKeys, organizations, session.ScopeResultsRead))
+		{
+			// Get results - Event managers; once the event reaches the result stage, also
+			// whoever the event's results visibility allows
+			resultsRead.GET("/:event_id/distributed-results",
+				auth.RequirePermission(eventRepo, permission.ResultsView),
+				distributedVoteHandler.GetDistributedResults)
+
+			// Get voting statistics - Event managers, observers and whoever can see the results
+			resultsRead.GET("/:event_id/voting-statistics",
+				auth.RequirePermission(eventRepo, permission.ProgressView),
+				distributedVoteHandler.GetVotingStatistics)
+
+			// Vote amendment history - Only event owner/organizer/admin
+			resultsRead.GET("/:event_id/vote-history",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				distributedVoteHandler.GetVoteHistory)
+
+			// Inter-rater reliability report - Only event owner/organizer/admin
+			resultsRead.GET("/:event_id/reliability-report",
+				auth.RequirePermission(eventRepo, permission.EventManage),
+				distributedVoteHandler.GetReliabilityReport)
+		}
+
+		// API keys of the caller - Managed with a signed-in session only, never with a key
+		apiKeyRoutes := api.Group("/api-keys")
+		apiKeyRoutes.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			apiKeyRoutes.POST("", apiKeyHandler.CreateAPIKey) // The plain key is returned once
+			apiKeyRoutes.GET("", apiKeyHandler.ListAPIKeys)   // ?owner_id= lists a service account's keys (admins)
+			apiKeyRoutes.DELETE("/:key_id", apiKeyHandler.RevokeAPIKey)
+		}
+
+		// Service accounts (own API keys, can't sign in) - Admin only
+		serviceAccounts := api.Group("/service-accounts")
+		serviceAccounts.Use(auth.JWTAuthMiddleware(userRepo), auth.RequirePermission(eventRepo, permission.UsersManage))
+		{
+			serviceAccounts.POST("", apiKeyHandler.CreateServiceAccount)
+			serviceAccounts.GET("", apiKeyHandler.ListServiceAccounts)
+		}
+
+		// User administration - Admin only; every change is written to the audit log
+		admin := api.Group("/admin")
+		admin.Use(auth.JWTAuthMiddleware(userRepo), auth.RequirePermission(eventRepo, permission.UsersManage))
+		{
+			admin.GET("/users", userAdminHandler.ListUsers) // ?q=&role=&status=&type=&page=&limit=
+			admin.GET("/users/:user_id", userAdminHandler.GetUser)
+			admin.PATCH("/users/:user_id/role", userAdminHandler.ChangeUserRole)      // Revokes the user's access tokens
+			admin.POST("/users/:user_id/deactivate", userAdminHandler.DeactivateUser) // Blocks login, tokens and API keys
+			admin.POST("/users/:user_id/reactivate", userAdminHandler.ReactivateUser)
+			admin.POST("/users/:user_id/unlock", userAdminHandler.UnlockUser)                   // Lifts a lockout after failed sign-ins
+			admin.POST("/users/:user_id/two-factor/reset", userAdminHandler.ResetUserTwoFactor) // For a lost authenticator and recovery codes
+			admin.POST("/users/:user_id/merge", userAdminHandler.MergeUsers)                    // Folds source_user_id into this account
+			admin.GET("/users/:user_id/audit-log", userAdminHandler.GetAuditLog)
+			admin.GET("/audit-log", userAdminHandler.GetAuditLog)
+		}
+
+		// Event templates - Owner of the template or admin
+		eventTemplates := api.Group("/event-templates")
+		eventTemplates.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			eventTemplates.GET("", eventTemplateHandler.ListTemplates)
+			eventTemplates.GET("/:template_id", eventTemplateHandler.GetTemplate)
+			eventTemplates.POST("/:template_id/events", eventTemplateHandler.InstantiateTemplate)
+			eventTemplates.DELETE("/:template_id", eventTemplateHandler.DeleteTemplate)
+		}
+
+		// In-app notifications of the authenticated user
+		notifications := api.Group("/notifications")
+		notifications.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			notifications.GET("", notificationHandler.GetNotifications)
+			notifications.POST("/:notification_id/read", notificationHandler.MarkNotificationRead)
+		}
+
+		// Private calendar feed of the authenticated user (URL, rotation and revocation)
+		calendarFeed := api.Group("/calendar/feed")
+		calendarFeed.Use(auth.JWTAuthMiddleware(userRepo))
+		{
+			calendarFeed.GET("", calendarHandler.GetCalendarFeed)
+			calendarFeed.POST("/rotate", calendarHandler.RotateCalendarFeed)
+			calendarFeed.DELETE("", calendarHandler.DeleteCalendarFeed)
+		}
+
+		// Calendar feed subscription - Public, authenticated by the token in the URL
+		api.GET("/calendar/feeds/:token", calendarHandler.ServeCalendarFeed)
+
+		// Attachment download - Authors, event members, event owner/co-organizer or admin
+		api.GET("/attachments/:attachment_id/download", auth.JWTAuthMiddleware(userRepo), attachmentHandler.DownloadAttachment)
+
+		// Attachment deletion - Attachment owner, event owner or admin
+		api.DELETE("/attachments/:attachment_id", auth.JWTAuthMiddleware(userRepo), attachmentHandler.DeleteAttachment)
+
+		// Proposal co-authors - Attachment owner, event owner/co-organizer or admin
+		api.PUT("/attachments/:attachment_id/co-authors", auth.JWTAuthMiddleware(userRepo), attachmentHandler.SetCoAuthors)
+
+		// Submission form answers of a proposal - Attachment owner, event owner/co-organizer or admin
+		api.PUT("/attachments/:attachment_id/answers", auth.JWTAuthMiddleware(userRepo), attachmentHandler.UpdateAnswers)
+
+		// Proposal files and their versions - Any authenticated user (history, new versions and removal: authors, event owner/co-organizer or admin)
+		api.GET("/attachments/:attachment_id/files", auth.JWTAuthMiddleware(userRepo), attachmentHandler.GetAttachmentFiles)
+		api.GET("/attachments/:attachment_id/files/:file_id/download", auth.JWTAuthMiddleware(userRepo), attachmentHandler.DownloadAttachmentFile)
+		api.POST("/attachments/:attachment_id/files", auth.JWTAuthMiddleware(userRepo), attachmentHandler.UploadAttachmentFile)
+		api.DELETE("/attachments/:attachment_id/files/:file_id", auth.JWTAuthMiddleware(userRepo), attachmentHandler.RemoveAttachmentFile)
 	}
 
 	log.Info("Starting Telescopio API server", "port", cfg.Server.Port)
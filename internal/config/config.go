@@ -1,12 +1,18 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// DefaultSecret is the signing secret used when none is configured. It is only fit for
+// development and the server refuses to start with it in release mode.
+const DefaultSecret = "telescopio-dev-secret-change-in-production"
+
 // Config holds all configuration for the application
 type Config struct {
 	DB struct {
@@ -22,6 +28,9 @@ type Config struct {
 		Port        string
 		GinMode     string
 		FrontendURL string
+		PublicURL   string // Base URL of this API, used in links handed to external clients
+		// Reverse proxies whose X-Forwarded-For header names the client IP; none when empty
+		TrustedProxies []string
 	}
 
 	Upload struct {
@@ -38,6 +47,8 @@ type Config struct {
 		MinIOBucket    string
 		MinIOUseSSL    bool
 		MinIORegion    string
+
+		CleanupIntervalSeconds int64 // Background removal of files from deleted events/attachments
 	}
 
 	CORS struct {
@@ -49,6 +60,90 @@ type Config struct {
 	Google struct {
 		ClientID string
 	}
+
+	OIDC struct {
+		Providers       []OIDCProvider
+		NonceSecret     string
+		NonceTTLMinutes int64
+		KeyCacheMinutes int64 // How long provider signing keys are cached
+	}
+
+	Auth struct {
+		AccessTokenTTLMinutes int64
+		RefreshTokenTTLHours  int64
+		SigningAlgorithm      string   // HS256, RS256 or EdDSA
+		JWTSecret             string   // HS256 signing secret
+		PreviousSecrets       []string // Retired HS256 secrets still accepted while their tokens expire
+		KeysDir               string   // Directory of <kid>.pem keys for RS256 and EdDSA
+		SigningKeyID          string   // kid of the key that signs new tokens
+		VerifyLegacyHS256     bool     // Keep accepting HS256 tokens after moving to asymmetric keys
+	}
+
+	Scheduler struct {
+		Enabled         bool
+		IntervalSeconds int64
+		VotingDays      int64 // Length of a voting stage opened by the scheduler; 0 leaves opening voting to organizers
+	}
+
+	Invitations struct {
+		SigningSecret   string
+		DefaultTTLHours int64
+	}
+
+	Mail struct {
+		Provider     string // "outbox" (writes .eml files) or "smtp"
+		From         string
+		OutboxDir    string
+		SMTPHost     string
+		SMTPPort     string
+		SMTPUsername string
+		SMTPPassword string
+	}
+
+	Calendar struct {
+		SigningSecret          string
+		RefreshIntervalMinutes int64
+	}
+
+	RateLimit struct {
+		Store         string // "memory" (single instance) or "postgres" (shared by replicas)
+		WindowSeconds int64
+		// Requests allowed per window; 0 disables the limit
+		LoginPerIP              int64
+		LoginPerAccount         int64
+		SignupPerIP             int64
+		EventRegisterPerIP      int64
+		EventRegisterPerAccount int64
+	}
+
+	Lockout struct {
+		Threshold            int64 // Failed passwords before the account is locked; 0 disables lockouts
+		BaseDelaySeconds     int64 // Doubled for every further failure
+		MaxDelaySeconds      int64
+		FailureWindowMinutes int64 // Failures older than this are forgotten
+	}
+
+	TwoFactor struct {
+		Issuer              string   // Name shown in authenticator apps
+		RequiredRoles       []string // Roles that must enrol before they can sign in; org_admin covers organization admins
+		ChallengeTTLMinutes int64    // Time allowed between the password and the code
+	}
+
+	Tenant struct {
+		BaseDomain          string // Requests to <slug>.<BaseDomain> are served for that organization; empty disables subdomains
+		DefaultOrganization string // Slug of the organization serving requests that name none
+	}
+}
+
+// OIDCProvider configures an OpenID Connect sign-in provider such as Keycloak or an
+// institutional SSO
+type OIDCProvider struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientIDs    []string
+	JWKSURL      string // Optional: discovered from the issuer when empty
+	RequireNonce bool
 }
 
 // Load loads configuration from environment variables
@@ -67,6 +162,8 @@ func Load() *Config {
 	config.Server.Port = getEnv("PORT", "8080")
 	config.Server.GinMode = getEnv("GIN_MODE", "debug")
 	config.Server.FrontendURL = getEnv("FRONTEND_URL", "http://localhost:3000")
+	config.Server.PublicURL = getEnv("PUBLIC_API_URL", "") // Defaults to the host of the request
+	config.Server.TrustedProxies = splitList(getEnv("TRUSTED_PROXIES", ""))
 
 	config.Upload.Dir = getEnv("UPLOADS_DIR", "./uploads")
 	config.Upload.MaxFileSize = getEnvAsInt64("MAX_FILE_SIZE", 10485760)
@@ -80,16 +177,116 @@ func Load() *Config {
 	config.Storage.MinIOBucket = getEnv("MINIO_BUCKET", "telescopio")
 	config.Storage.MinIOUseSSL = getEnvAsBool("MINIO_USE_SSL", false)
 	config.Storage.MinIORegion = getEnv("MINIO_REGION", "us-east-1")
+	config.Storage.CleanupIntervalSeconds = getEnvAsInt64("STORAGE_CLEANUP_INTERVAL_SECONDS", 60)
 
 	config.CORS.AllowOrigins = getEnv("CORS_ALLOW_ORIGINS", "*")
 	config.CORS.AllowMethods = getEnv("CORS_ALLOW_METHODS", "GET,POST,PUT,PATCH,DELETE,HEAD,OPTIONS")
-	config.CORS.AllowHeaders = getEnv("CORS_ALLOW_HEADERS", "Origin,Content-Length,Content-Type,Authorization")
+	config.CORS.AllowHeaders = getEnv("CORS_ALLOW_HEADERS", "Origin,Content-Length,Content-Type,Authorization,Idempotency-Key")
 
 	config.Google.ClientID = getEnv("GOOGLE_CLIENT_ID", "")
 
+	// OpenID Connect providers, e.g. OIDC_PROVIDERS=keycloak with OIDC_KEYCLOAK_ISSUER and
+	// OIDC_KEYCLOAK_CLIENT_ID. Google is added automatically when GOOGLE_CLIENT_ID is set.
+	for _, name := range splitList(getEnv("OIDC_PROVIDERS", "")) {
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		config.OIDC.Providers = append(config.OIDC.Providers, OIDCProvider{
+			Name:         strings.ToLower(name),
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientIDs:    splitList(getEnv(prefix+"CLIENT_ID", "")),
+			JWKSURL:      getEnv(prefix+"JWKS_URL", ""),
+			RequireNonce: getEnvAsBool(prefix+"REQUIRE_NONCE", true),
+		})
+	}
+	config.OIDC.NonceSecret = getEnv("OIDC_NONCE_SECRET", getEnv("JWT_SECRET", DefaultSecret))
+	config.OIDC.NonceTTLMinutes = getEnvAsInt64("OIDC_NONCE_TTL_MINUTES", 10)
+	config.OIDC.KeyCacheMinutes = getEnvAsInt64("OIDC_KEY_CACHE_MINUTES", 60)
+
+	// Short-lived access tokens, extended with rotating refresh tokens
+	config.Auth.AccessTokenTTLMinutes = getEnvAsInt64("ACCESS_TOKEN_TTL_MINUTES", 15)
+	config.Auth.RefreshTokenTTLHours = getEnvAsInt64("REFRESH_TOKEN_TTL_HOURS", 720)
+
+	// Access token signing keys, identified by the kid header so they can be rotated
+	config.Auth.SigningAlgorithm = getEnv("JWT_SIGNING_ALG", "HS256")
+	config.Auth.JWTSecret = getEnv("JWT_SECRET", DefaultSecret)
+	config.Auth.PreviousSecrets = splitList(getEnv("JWT_PREVIOUS_SECRETS", ""))
+	config.Auth.KeysDir = getEnv("JWT_KEYS_DIR", "")
+	config.Auth.SigningKeyID = getEnv("JWT_SIGNING_KEY_ID", "")
+	config.Auth.VerifyLegacyHS256 = getEnvAsBool("JWT_VERIFY_LEGACY_HS256", false)
+
+	// Automatic stage transitions based on estimated end dates
+	config.Scheduler.Enabled = getEnvAsBool("SCHEDULER_ENABLED", true)
+	config.Scheduler.IntervalSeconds = getEnvAsInt64("SCHEDULER_INTERVAL_SECONDS", 300)
+	config.Scheduler.VotingDays = getEnvAsInt64("SCHEDULER_VOTING_DAYS", 14)
+
+	// Invitation tokens for link-only and invite-only events
+	config.Invitations.SigningSecret = getEnv("INVITATION_SIGNING_SECRET", getEnv("JWT_SECRET", DefaultSecret))
+	config.Invitations.DefaultTTLHours = getEnvAsInt64("INVITATION_DEFAULT_TTL_HOURS", 168)
+
+	// Outgoing email (password reset and email verification)
+	config.Mail.Provider = getEnv("MAIL_PROVIDER", "outbox")
+	config.Mail.From = getEnv("MAIL_FROM", "Telescopio <no-reply@telescopio.local>")
+	config.Mail.OutboxDir = getEnv("MAIL_OUTBOX_DIR", "./outbox")
+	config.Mail.SMTPHost = getEnv("SMTP_HOST", "")
+	config.Mail.SMTPPort = getEnv("SMTP_PORT", "587")
+	config.Mail.SMTPUsername = getEnv("SMTP_USERNAME", "")
+	config.Mail.SMTPPassword = getEnv("SMTP_PASSWORD", "")
+
+	// Private iCalendar feed URLs and how often subscribed clients should refresh them
+	config.Calendar.SigningSecret = getEnv("CALENDAR_SIGNING_SECRET", getEnv("JWT_SECRET", DefaultSecret))
+	config.Calendar.RefreshIntervalMinutes = getEnvAsInt64("CALENDAR_REFRESH_INTERVAL_MINUTES", 60)
+
+	// Throttling of the public sign-in and registration endpoints
+	config.RateLimit.Store = getEnv("RATE_LIMIT_STORE", "memory")
+	config.RateLimit.WindowSeconds = getEnvAsInt64("RATE_LIMIT_WINDOW_SECONDS", 60)
+	config.RateLimit.LoginPerIP = getEnvAsInt64("RATE_LIMIT_LOGIN_PER_IP", 20)
+	config.RateLimit.LoginPerAccount = getEnvAsInt64("RATE_LIMIT_LOGIN_PER_ACCOUNT", 10)
+	config.RateLimit.SignupPerIP = getEnvAsInt64("RATE_LIMIT_SIGNUP_PER_IP", 5)
+	config.RateLimit.EventRegisterPerIP = getEnvAsInt64("RATE_LIMIT_EVENT_REGISTER_PER_IP", 20)
+	config.RateLimit.EventRegisterPerAccount = getEnvAsInt64("RATE_LIMIT_EVENT_REGISTER_PER_ACCOUNT", 5)
+
+	// Progressive lockout of accounts after repeated failed passwords
+	config.Lockout.Threshold = getEnvAsInt64("LOGIN_LOCKOUT_THRESHOLD", 5)
+	config.Lockout.BaseDelaySeconds = getEnvAsInt64("LOGIN_LOCKOUT_BASE_SECONDS", 60)
+	config.Lockout.MaxDelaySeconds = getEnvAsInt64("LOGIN_LOCKOUT_MAX_SECONDS", 3600)
+	config.Lockout.FailureWindowMinutes = getEnvAsInt64("LOGIN_FAILURE_WINDOW_MINUTES", 1440)
+
+	// TOTP second factor at password sign-in
+	config.TwoFactor.Issuer = getEnv("TWO_FACTOR_ISSUER", "Telescopio")
+	config.TwoFactor.RequiredRoles = splitList(getEnv("TWO_FACTOR_REQUIRED_ROLES", "admin,org_admin"))
+	config.TwoFactor.ChallengeTTLMinutes = getEnvAsInt64("TWO_FACTOR_CHALLENGE_TTL_MINUTES", 5)
+
+	config.Tenant.BaseDomain = strings.ToLower(strings.TrimPrefix(getEnv("TENANT_BASE_DOMAIN", ""), "."))
+	config.Tenant.DefaultOrganization = getEnv("TENANT_DEFAULT_ORGANIZATION", "default")
+
 	return config
 }
 
+// CheckSecrets returns an error naming the signing secrets still set to DefaultSecret,
+// which must never be used in release mode
+func (c *Config) CheckSecrets() error {
+	var insecure []string
+	if c.Auth.SigningAlgorithm == "HS256" || c.Auth.VerifyLegacyHS256 {
+		if c.Auth.JWTSecret == DefaultSecret {
+			insecure = append(insecure, "JWT_SECRET")
+		}
+	}
+	if c.OIDC.NonceSecret == DefaultSecret {
+		insecure = append(insecure, "OIDC_NONCE_SECRET")
+	}
+	if c.Invitations.SigningSecret == DefaultSecret {
+		insecure = append(insecure, "INVITATION_SIGNING_SECRET")
+	}
+	if c.Calendar.SigningSecret == DefaultSecret {
+		insecure = append(insecure, "CALENDAR_SIGNING_SECRET")
+	}
+
+	if len(insecure) > 0 {
+		return fmt.Errorf("default development secret in use for %s", strings.Join(insecure, ", "))
+	}
+	return nil
+}
+
 // GetDatabaseURL returns the database connection URL
 func (c *Config) GetDatabaseURL() string {
 	return "postgres://" + c.DB.User + ":" + c.DB.Password + "@" + c.DB.Host + ":" + c.DB.Port + "/" + c.DB.Name + "?sslmode=" + c.DB.SSLMode
@@ -103,6 +300,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitList splits a comma-separated environment value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvAsInt64 gets an environment variable as int64 or returns a default value
 func getEnvAsInt64(key string, defaultValue int64) int64 {
 	if value := os.Getenv(key); value != "" {
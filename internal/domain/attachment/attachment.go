@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
 )
 
 type Attachment struct {
@@ -18,6 +20,28 @@ type Attachment struct {
 	MimeType      string    `json:"mime_type" gorm:"not null"`
 	VoteCount     int       `json:"vote_count" gorm:"default:0"`
 	UploadedAt    time.Time `json:"uploaded_at" gorm:"autoCreateTime"`
+
+	// Answers to the event's submission form, keyed by field key
+	Answers submission.Answers `json:"answers,omitempty" gorm:"type:jsonb"`
+
+	// ExtractedText is the text of the current main document, indexed for full-text search
+	ExtractedText string `json:"-" gorm:"not null;default:''"`
+
+	// CoAuthors are the registered users of the event who co-wrote the proposal
+	// alongside its primary author (ParticipantID)
+	CoAuthors []CoAuthor `json:"co_authors,omitempty" gorm:"foreignKey:AttachmentID"`
+}
+
+// CoAuthor links a proposal to one of its co-authors
+type CoAuthor struct {
+	AttachmentID uuid.UUID `json:"attachment_id" gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	AddedAt      time.Time `json:"added_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name
+func (CoAuthor) TableName() string {
+	return "attachment_co_authors"
 }
 
 // TableName overrides the table name
@@ -61,3 +85,27 @@ func (a *Attachment) GetOriginalName() string {
 func (a *Attachment) GetParticipantID() uuid.UUID {
 	return a.ParticipantID
 }
+
+// GetCoAuthorIDs returns the user IDs of the co-authors
+func (a *Attachment) GetCoAuthorIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(a.CoAuthors))
+	for i, coAuthor := range a.CoAuthors {
+		ids[i] = coAuthor.UserID
+	}
+	return ids
+}
+
+// AuthorIDs returns the primary author followed by the co-authors
+func (a *Attachment) AuthorIDs() []uuid.UUID {
+	return append([]uuid.UUID{a.ParticipantID}, a.GetCoAuthorIDs()...)
+}
+
+// HasAuthor checks if the user is the primary author or a co-author of the proposal
+func (a *Attachment) HasAuthor(userID uuid.UUID) bool {
+	for _, id := range a.AuthorIDs() {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package attachment
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MainSlot is the slot of a proposal's main document. Supplementary files such as
+// appendices use their own slot names.
+const MainSlot = "main"
+
+// MaxSlotsPerProposal limits the number of files (main document plus appendices) of a proposal
+const MaxSlotsPerProposal = 10
+
+var slotPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// IsValidSlot checks a slot name: lowercase letters, digits, '-' and '_', up to 50 characters
+func IsValidSlot(slot string) bool {
+	return slotPattern.MatchString(slot)
+}
+
+// File is one stored version of a proposal document. Every slot keeps its full version
+// history; re-uploading to a slot adds a version and marks the previous one as replaced.
+type File struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AttachmentID uuid.UUID  `json:"attachment_id" gorm:"type:uuid;not null"`
+	Slot         string     `json:"slot" gorm:"not null"`
+	Version      int        `json:"version" gorm:"not null"`
+	Filename     string     `json:"filename" gorm:"not null"`
+	OriginalName string     `json:"original_name" gorm:"not null"`
+	FilePath     string     `json:"-" gorm:"not null"`
+	FileSize     int64      `json:"file_size" gorm:"not null"`
+	MimeType     string     `json:"mime_type" gorm:"not null"`
+	UploadedBy   uuid.UUID  `json:"uploaded_by" gorm:"type:uuid;not null"`
+	UploadedAt   time.Time  `json:"uploaded_at" gorm:"autoCreateTime"`
+	ReplacedAt   *time.Time `json:"replaced_at,omitempty"` // set when a newer version is uploaded or the file is removed
+
+	// InReview marks the versions that were current when voting opened; reviewers always get these
+	InReview bool `json:"in_review" gorm:"not null;default:false"`
+}
+
+// TableName overrides the table name
+func (File) TableName() string {
+	return "attachment_files"
+}
+
+// BeforeCreate will set a UUID rather than numeric ID.
+func (f *File) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsCurrent reports whether this is the latest version of its slot
+func (f *File) IsCurrent() bool {
+	return f.ReplacedAt == nil
+}
+
+// IsMain reports whether the file is a version of the proposal's main document
+func (f *File) IsMain() bool {
+	return f.Slot == MainSlot
+}
+
+// MainFile returns the first version of the main document of a new proposal
+func (a *Attachment) MainFile() *File {
+	return &File{
+		AttachmentID: a.ID,
+		Slot:         MainSlot,
+		Version:      1,
+		Filename:     a.Filename,
+		OriginalName: a.OriginalName,
+		FilePath:     a.FilePath,
+		FileSize:     a.FileSize,
+		MimeType:     a.MimeType,
+		UploadedBy:   a.ParticipantID,
+		UploadedAt:   a.UploadedAt,
+	}
+}
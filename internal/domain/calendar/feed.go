@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidFeedToken is returned for malformed feed tokens or tokens with a bad signature
+var ErrInvalidFeedToken = errors.New("invalid calendar feed token")
+
+// Feed is the private calendar subscription of a user. Its token is the only credential
+// calendar clients send, so each user has at most one feed and rotating it replaces the row.
+type Feed struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+}
+
+// TableName overrides the table name used by GORM
+func (Feed) TableName() string {
+	return "calendar_feeds"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (f *Feed) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewFeed creates a calendar feed for a user
+func NewFeed(userID uuid.UUID) *Feed {
+	return &Feed{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// SignFeedToken builds the token embedded in the feed URL. It carries the feed ID signed
+// with HMAC-SHA256, so guessed or tampered tokens are rejected before touching the database.
+func SignFeedToken(secret []byte, feed *Feed) string {
+	return base64.RawURLEncoding.EncodeToString(feed.ID[:]) + "." +
+		base64.RawURLEncoding.EncodeToString(signFeedPayload(secret, feed.ID[:]))
+}
+
+// ParseFeedToken verifies the token signature and returns the feed ID it carries
+func ParseFeedToken(secret []byte, token string) (uuid.UUID, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return uuid.Nil, ErrInvalidFeedToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 16 {
+		return uuid.Nil, ErrInvalidFeedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signFeedPayload(secret, payload)) {
+		return uuid.Nil, ErrInvalidFeedToken
+	}
+
+	var feedID uuid.UUID
+	copy(feedID[:], payload)
+	return feedID, nil
+}
+
+func signFeedPayload(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("telescopio-calendar-feed:"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,201 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+)
+
+// ContentType is the media type of iCalendar documents
+const ContentType = "text/calendar; charset=utf-8"
+
+// productID identifies the generator in the PRODID property
+const productID = "-//Telescopio//Telescopio API//EN"
+
+// maxLineOctets is the longest content line allowed by RFC 5545, excluding the CRLF
+const maxLineOctets = 75
+
+// Calendar is an iCalendar (RFC 5545) document made of all-day entries
+type Calendar struct {
+	Name            string
+	RefreshInterval time.Duration // hint for subscribing clients; zero omits it
+	Entries         []Entry
+}
+
+// Entry is an all-day VEVENT. End is exclusive, as DTEND is for dates.
+type Entry struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Modified    time.Time
+	Reminder    time.Duration // a display alarm this long before Start; zero for none
+}
+
+// EntriesForEvent returns the calendar entries of an event: the event period and, when
+// set, the estimated ends of the participation and voting stages. UIDs are stable, so
+// clients update the existing entries when the dates change.
+func EntriesForEvent(evt *event.Event, eventURL string) []Entry {
+	description := evt.Description
+	if evt.Organizer != "" {
+		description = "Organizer: " + evt.Organizer + "\n\n" + description
+	}
+
+	entries := []Entry{{
+		UID:         entryUID(evt, "event"),
+		Summary:     evt.Name,
+		Description: description,
+		URL:         eventURL,
+		Start:       evt.StartDate,
+		End:         evt.EndDate.AddDate(0, 0, 1),
+		Modified:    evt.UpdatedAt,
+	}}
+
+	if evt.ParticipationEstimatedEndDate != nil {
+		entries = append(entries, deadlineEntry(evt, eventURL, "participation-deadline",
+			"Participation deadline: "+evt.Name,
+			"Last day to register and submit proposals.",
+			*evt.ParticipationEstimatedEndDate))
+	}
+
+	if evt.VotingEstimatedEndDate != nil {
+		entries = append(entries, deadlineEntry(evt, eventURL, "voting-deadline",
+			"Voting deadline: "+evt.Name,
+			"Last day to submit your votes.",
+			*evt.VotingEstimatedEndDate))
+	}
+
+	return entries
+}
+
+func deadlineEntry(evt *event.Event, eventURL, kind, summary, description string, date time.Time) Entry {
+	return Entry{
+		UID:         entryUID(evt, kind),
+		Summary:     summary,
+		Description: description,
+		URL:         eventURL,
+		Start:       date,
+		End:         date.AddDate(0, 0, 1),
+		Modified:    evt.UpdatedAt,
+		Reminder:    24 * time.Hour,
+	}
+}
+
+func entryUID(evt *event.Event, kind string) string {
+	return evt.ID.String() + "-" + kind + "@telescopio"
+}
+
+// Marshal encodes the calendar with CRLF line endings and folded lines
+func (c *Calendar) Marshal() []byte {
+	w := &lineWriter{}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + productID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		duration := formatDuration(c.RefreshInterval)
+		w.line("REFRESH-INTERVAL;VALUE=DURATION:" + duration)
+		w.line("X-PUBLISHED-TTL:" + duration)
+	}
+
+	for _, entry := range c.Entries {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + entry.UID)
+		w.line("DTSTAMP:" + formatTimestamp(entry.Modified))
+		w.line("LAST-MODIFIED:" + formatTimestamp(entry.Modified))
+		w.line("DTSTART;VALUE=DATE:" + formatDate(entry.Start))
+		w.line("DTEND;VALUE=DATE:" + formatDate(entry.End))
+		w.line("SUMMARY:" + escapeText(entry.Summary))
+		if entry.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(entry.Description))
+		}
+		if entry.URL != "" {
+			w.line("URL:" + entry.URL)
+		}
+		w.line("TRANSP:TRANSPARENT")
+		if entry.Reminder > 0 {
+			w.line("BEGIN:VALARM")
+			w.line("ACTION:DISPLAY")
+			w.line("DESCRIPTION:" + escapeText(entry.Summary))
+			w.line("TRIGGER:-" + formatDuration(entry.Reminder))
+			w.line("END:VALARM")
+		}
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// lineWriter writes content lines, folding them at 75 octets without splitting UTF-8 sequences
+type lineWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *lineWriter) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func formatDate(t time.Time) string {
+	return t.UTC().Format("20060102")
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDuration renders a positive duration as an RFC 5545 duration, e.g. P1D or PT1H30M
+func formatDuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+
+	d = d.Round(time.Minute)
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+
+	result := "PT"
+	if hours > 0 {
+		result += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 || hours == 0 {
+		result += fmt.Sprintf("%dM", minutes)
+	}
+	return result
+}
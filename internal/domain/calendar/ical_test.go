@@ -0,0 +1,189 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+)
+
+// writeLine folds one content line and returns the physical lines without their CRLF
+func writeLine(t *testing.T, content string) []string {
+	t.Helper()
+
+	w := &lineWriter{}
+	w.line(content)
+
+	out := w.buf.String()
+	if !strings.HasSuffix(out, "\r\n") {
+		t.Fatalf("line %q does not end with CRLF", out)
+	}
+	return strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+}
+
+// unfold joins folded lines back into the content line, as RFC 5545 section 3.1 describes
+func unfold(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			line = strings.TrimPrefix(line, " ")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantLines int
+	}{
+		{"short line", "SUMMARY:Deep Field Survey", 1},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67), 1},
+		{"76 octets", "SUMMARY:" + strings.Repeat("a", 68), 2},
+		{"several continuations", "DESCRIPTION:" + strings.Repeat("a", 300), 5},
+		{"two-byte runes", "SUMMARY:" + strings.Repeat("ñ", 100), 3},
+		{"four-byte runes across the boundary", "SUMMARY:" + strings.Repeat("🔭", 40), 3},
+		{"mixed widths", "DESCRIPTION:" + strings.Repeat("Observación de galaxias, ", 12), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := writeLine(t, tt.content)
+
+			if len(lines) != tt.wantLines {
+				t.Errorf("folded into %d lines, want %d", len(lines), tt.wantLines)
+			}
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d has %d octets, more than %d", i+1, len(line), maxLineOctets)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space", i+1)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i+1, line)
+				}
+			}
+			if got := unfold(lines); got != tt.content {
+				t.Errorf("unfolded line = %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Deep Field Survey", "Deep Field Survey"},
+		{"Galaxies, stars; nebulae", `Galaxies\, stars\; nebulae`},
+		{`C:\data`, `C:\\data`},
+		{`already\,escaped`, `already\\\,escaped`},
+		{"first\nsecond", `first\nsecond`},
+		{"first\r\nsecond", `first\nsecond`},
+		{"first\rsecond", `first\nsecond`},
+		{"blank\n\nline", `blank\n\nline`},
+		{"Colon: kept", "Colon: kept"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{24 * time.Hour, "P1D"},
+		{7 * 24 * time.Hour, "P7D"},
+		{time.Hour, "PT1H"},
+		{25 * time.Hour, "PT25H"},
+		{90 * time.Minute, "PT1H30M"},
+		{15 * time.Minute, "PT15M"},
+		{30 * time.Second, "PT1M"},
+		{10 * time.Second, "PT0M"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.in); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	deadline := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	evt := &event.Event{
+		ID:                            uuid.MustParse("5f0c6d2e-8f1b-4a36-9a2e-1d9f0b7c4e21"),
+		Name:                          "Observing Run, 2026B",
+		Description:                   "Proposals for the\nsecond semester",
+		Organizer:                     "Grava",
+		StartDate:                     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:                       time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+		ParticipationEstimatedEndDate: &deadline,
+		UpdatedAt:                     time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}
+
+	cal := &Calendar{
+		Name:            "Telescopio",
+		RefreshInterval: time.Hour,
+		Entries:         EntriesForEvent(evt, "https://telescopio.example.org/events/2026b"),
+	}
+	out := string(cal.Marshal())
+
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("calendar has bare LF line endings")
+	}
+
+	var unfolded []string
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if strings.HasPrefix(line, " ") && len(unfolded) > 0 {
+			unfolded[len(unfolded)-1] += line[1:]
+			continue
+		}
+		unfolded = append(unfolded, line)
+	}
+
+	want := []string{
+		"BEGIN:VCALENDAR",
+		"X-WR-CALNAME:Telescopio",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"UID:5f0c6d2e-8f1b-4a36-9a2e-1d9f0b7c4e21-event@telescopio",
+		"DTSTAMP:20261018T093000Z",
+		"DTSTART;VALUE=DATE:20261001",
+		"DTEND;VALUE=DATE:20261216",
+		`SUMMARY:Observing Run\, 2026B`,
+		`DESCRIPTION:Organizer: Grava\n\nProposals for the\nsecond semester`,
+		"URL:https://telescopio.example.org/events/2026b",
+		"UID:5f0c6d2e-8f1b-4a36-9a2e-1d9f0b7c4e21-participation-deadline@telescopio",
+		"DTSTART;VALUE=DATE:20261101",
+		"DTEND;VALUE=DATE:20261102",
+		"TRIGGER:-P1D",
+		"END:VCALENDAR",
+	}
+	lines := make(map[string]bool, len(unfolded))
+	for _, line := range unfolded {
+		lines[line] = true
+	}
+	for _, line := range want {
+		if !lines[line] {
+			t.Errorf("calendar is missing line %q", line)
+		}
+	}
+
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("calendar has %d entries, want the event and its participation deadline", got)
+	}
+}
@@ -16,6 +16,7 @@ type AttachmentInterface interface {
 	GetID() uuid.UUID
 	GetOriginalName() string
 	GetParticipantID() uuid.UUID
+	GetCoAuthorIDs() []uuid.UUID
 }
 
 type VoteInterface interface {
@@ -12,8 +12,17 @@ type EventParticipantRole string
 const (
 	RoleCreator     EventParticipantRole = "creator"
 	RoleParticipant EventParticipantRole = "participant"
+	// RoleCoOrganizer manages the event alongside its creator without submitting or reviewing
+	RoleCoOrganizer EventParticipantRole = "co_organizer"
+	// RoleReviewer evaluates submissions without submitting one
+	RoleReviewer EventParticipantRole = "reviewer"
+	// RoleObserver can follow the event's progress but neither submits nor reviews
+	RoleObserver EventParticipantRole = "observer"
 )
 
+// AssignableRoles lists the roles an event manager can give to members of the event
+var AssignableRoles = []EventParticipantRole{RoleParticipant, RoleCoOrganizer, RoleReviewer, RoleObserver}
+
 // String returns the string representation of the role
 func (r EventParticipantRole) String() string {
 	return string(r)
@@ -21,7 +30,36 @@ func (r EventParticipantRole) String() string {
 
 // IsValid checks if the role is valid
 func (r EventParticipantRole) IsValid() bool {
-	return r == RoleCreator || r == RoleParticipant
+	switch r {
+	case RoleCreator, RoleParticipant, RoleCoOrganizer, RoleReviewer, RoleObserver:
+		return true
+	}
+	return false
+}
+
+// IsAssignable checks if the role can be given to a member by an event manager
+func (r EventParticipantRole) IsAssignable() bool {
+	return r.IsValid() && r != RoleCreator
+}
+
+// CanManage checks if the role grants management rights over the event
+func (r EventParticipantRole) CanManage() bool {
+	return r == RoleCreator || r == RoleCoOrganizer
+}
+
+// CanSubmit checks if the role may upload submissions
+func (r EventParticipantRole) CanSubmit() bool {
+	return r == RoleParticipant
+}
+
+// CanReview checks if the role takes part in the distributed evaluation
+func (r EventParticipantRole) CanReview() bool {
+	return r == RoleParticipant || r == RoleReviewer
+}
+
+// TakesSeat checks if the role counts towards the event's participant limit
+func (r EventParticipantRole) TakesSeat() bool {
+	return r == RoleParticipant
 }
 
 // EventParticipant represents the relationship between a user and an event with a role
@@ -48,13 +86,13 @@ func (ep *EventParticipant) IsParticipant() bool {
 }
 
 // CanManageEvent checks if this participant can manage the event
-// (change stages, configure voting, etc.)
+// (configure voting, manage members, etc.)
 func (ep *EventParticipant) CanManageEvent() bool {
-	return ep.IsCreator()
+	return ep.Role.CanManage()
 }
 
-// CanVote checks if this participant can vote
-// (all participants can vote, including creators)
+// CanVote checks if this participant evaluates submissions
+// (participants and reviewers; co-organizers and observers do not)
 func (ep *EventParticipant) CanVote() bool {
-	return true // Both creators and participants can vote
+	return ep.Role.CanReview()
 }
@@ -8,24 +8,33 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
 )
 
 // Event represents a voting event for telescope time allocation
 type Event struct {
-	ID                            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	Name                          string     `json:"name" gorm:"not null"`
-	Description                   string     `json:"description" gorm:"not null"`
-	AuthorID                      uuid.UUID  `json:"author_id" gorm:"type:uuid;not null"`
-	StartDate                     time.Time  `json:"start_date" gorm:"not null"`
-	EndDate                       time.Time  `json:"end_date" gorm:"not null"`
-	Organizer                     string     `json:"organizer" gorm:"default:''"`
-	Stage                         Stage      `json:"stage" gorm:"type:event_stage;not null;default:'creation'"`
-	MaxParticipants               *int       `json:"max_participants,omitempty" gorm:"default:null"`
-	ParticipationEstimatedEndDate *time.Time `json:"participation_estimated_end_date,omitempty" gorm:"type:date"`
-	VotingEstimatedEndDate        *time.Time `json:"voting_estimated_end_date,omitempty" gorm:"type:date"`
-	ShareableLink                 string     `json:"shareable_link,omitempty" gorm:"default:''"`
-	CreatedAt                     time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt                     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                            uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name                          string            `json:"name" gorm:"not null"`
+	Description                   string            `json:"description" gorm:"not null"`
+	AuthorID                      uuid.UUID         `json:"author_id" gorm:"type:uuid;not null"`
+	OrganizationID                uuid.UUID         `json:"organization_id" gorm:"type:uuid;not null"`
+	StartDate                     time.Time         `json:"start_date" gorm:"not null"`
+	EndDate                       time.Time         `json:"end_date" gorm:"not null"`
+	Organizer                     string            `json:"organizer" gorm:"default:''"`
+	Stage                         Stage             `json:"stage" gorm:"type:event_stage;not null;default:'creation'"`
+	MaxParticipants               *int              `json:"max_participants,omitempty" gorm:"default:null"`
+	ParticipationEstimatedEndDate *time.Time        `json:"participation_estimated_end_date,omitempty" gorm:"type:date"`
+	VotingEstimatedEndDate        *time.Time        `json:"voting_estimated_end_date,omitempty" gorm:"type:date"`
+	ShareableLink                 string            `json:"shareable_link,omitempty" gorm:"default:''"`
+	Visibility                    Visibility        `json:"visibility" gorm:"not null;default:'public'"`
+	RequireVerifiedEmail          bool              `json:"require_verified_email" gorm:"not null;default:false"` // only registrants with a verified email can join
+	ResultsVisibility             ResultsVisibility `json:"results_visibility" gorm:"not null;default:'members'"`
+	SubmissionForm                *submission.Form  `json:"submission_form,omitempty" gorm:"type:jsonb"`
+	ArchivedAt                    *time.Time        `json:"archived_at,omitempty"`
+	ArchivedBy                    *uuid.UUID        `json:"archived_by,omitempty" gorm:"type:uuid"`
+	CreatedAt                     time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                     time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName overrides the table name used by GORM
@@ -45,17 +54,30 @@ func (e *Event) BeforeCreate(tx *gorm.DB) error {
 func NewEvent(name, description string, authorID uuid.UUID, startDate, endDate time.Time, organizer string) *Event {
 	id := uuid.New()
 	return &Event{
-		ID:            id,
-		Name:          name,
-		Description:   description,
-		AuthorID:      authorID,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Organizer:     organizer,
-		Stage:         StageCreation,
-		ShareableLink: "/events/" + id.String(),
-		CreatedAt:     time.Now(),
+		ID:                id,
+		Name:              name,
+		Description:       description,
+		AuthorID:          authorID,
+		StartDate:         startDate,
+		EndDate:           endDate,
+		Organizer:         organizer,
+		Stage:             StageCreation,
+		ShareableLink:     "/events/" + id.String(),
+		Visibility:        VisibilityPublic,
+		ResultsVisibility: ResultsVisibilityMembers,
+		CreatedAt:         time.Now(),
+	}
+}
+
+// DefaultMaxParticipants is the participant limit of events that do not set max_participants
+const DefaultMaxParticipants = 20
+
+// ParticipantLimit returns the maximum number of participants, excluding the creator
+func (e *Event) ParticipantLimit() int {
+	if e.MaxParticipants != nil && *e.MaxParticipants > 0 {
+		return *e.MaxParticipants
 	}
+	return DefaultMaxParticipants
 }
 
 // IsAuthor checks if the given user ID is the author of this event
@@ -63,6 +85,17 @@ func (e *Event) IsAuthor(userID uuid.UUID) bool {
 	return e.AuthorID == userID
 }
 
+// IsArchived reports whether the event has been archived (read-only and hidden from listings)
+func (e *Event) IsArchived() bool {
+	return e.ArchivedAt != nil
+}
+
+// CanHardDelete reports whether the event may be permanently deleted.
+// Events that already started must be archived first.
+func (e *Event) CanHardDelete() bool {
+	return e.Stage == StageCreation || e.IsArchived()
+}
+
 // CanTransitionTo checks if the event can transition to a new stage
 func (e *Event) CanTransitionTo(newStage Stage) bool {
 	transitions := map[Stage][]Stage{
@@ -80,6 +113,53 @@ func (e *Event) CanTransitionTo(newStage Stage) bool {
 	return slices.Contains(allowedTransitions, newStage)
 }
 
+// CanRevertTo checks if the event can be rolled back to an earlier stage.
+// Only the immediately preceding stage can be reopened once participants are involved.
+func (e *Event) CanRevertTo(previousStage Stage) bool {
+	reverseTransitions := map[Stage][]Stage{
+		StageVoting: {StageParticipation},
+		StageResult: {StageVoting},
+	}
+
+	return slices.Contains(reverseTransitions[e.Stage], previousStage)
+}
+
+// NextStage returns the stage that follows the current one, if any
+func (e *Event) NextStage() (Stage, bool) {
+	switch e.Stage {
+	case StageCreation:
+		return StageParticipation, true
+	case StageParticipation:
+		return StageVoting, true
+	case StageVoting:
+		return StageResult, true
+	default:
+		return e.Stage, false
+	}
+}
+
+// StageEstimatedEndDate returns the estimated end date of the current stage, if one was set
+func (e *Event) StageEstimatedEndDate() *time.Time {
+	switch e.Stage {
+	case StageParticipation:
+		return e.ParticipationEstimatedEndDate
+	case StageVoting:
+		return e.VotingEstimatedEndDate
+	default:
+		return nil
+	}
+}
+
+// IsStageOverdue reports whether the estimated end date of the current stage has passed.
+// Estimated end dates are inclusive: a stage ending today is overdue starting tomorrow.
+func (e *Event) IsStageOverdue(now time.Time) bool {
+	endDate := e.StageEstimatedEndDate()
+	if endDate == nil {
+		return false
+	}
+	return endDate.Format("2006-01-02") < now.Format("2006-01-02")
+}
+
 // UpdateStage updates the stage if the transition is valid
 func (e *Event) UpdateStage(newStage Stage) error {
 	if !e.CanTransitionTo(newStage) {
@@ -103,6 +183,9 @@ func (e *Event) Validate() error {
 	if e.EndDate.Before(e.StartDate) {
 		return fmt.Errorf("end_date must be after start_date")
 	}
+	if e.Visibility != "" && !e.Visibility.IsValid() {
+		return fmt.Errorf("invalid visibility %q", e.Visibility)
+	}
 	return nil
 }
 
@@ -0,0 +1,214 @@
+package event
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Visibility controls who can find an event and register for it
+type Visibility string
+
+const (
+	// VisibilityPublic events are listed and open to anyone
+	VisibilityPublic Visibility = "public"
+	// VisibilityLinkOnly events are not listed; registering needs any valid invitation,
+	// typically a reusable one shared as a link
+	VisibilityLinkOnly Visibility = "link_only"
+	// VisibilityInviteOnly events are not listed; registering needs an invitation issued
+	// for the registrant's email
+	VisibilityInviteOnly Visibility = "invite_only"
+)
+
+// IsValid checks if the visibility is one of the supported values
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityLinkOnly, VisibilityInviteOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequiresInvitation reports whether registration needs an invitation token
+func (v Visibility) RequiresInvitation() bool {
+	return v == VisibilityLinkOnly || v == VisibilityInviteOnly
+}
+
+// IsListed reports whether events with this visibility appear in public listings
+func (v Visibility) IsListed() bool {
+	return v == "" || v == VisibilityPublic
+}
+
+// ErrInvalidInvitationToken is returned for malformed tokens or tokens with a bad signature
+var ErrInvalidInvitationToken = errors.New("invalid invitation token")
+
+// Invitation lets someone register for an event that is not public.
+// MaxUses is nil for invitations that can be redeemed any number of times before they expire.
+type Invitation struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID        uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	Email          *string    `json:"email,omitempty"`
+	MaxUses        *int       `json:"max_uses,omitempty"`
+	UseCount       int        `json:"use_count" gorm:"not null;default:0"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	IssuedBy       uuid.UUID  `json:"issued_by" gorm:"type:uuid;not null"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy      *uuid.UUID `json:"revoked_by,omitempty" gorm:"type:uuid"`
+	LastRedeemedAt *time.Time `json:"last_redeemed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (Invitation) TableName() string {
+	return "event_invitations"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (i *Invitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewInvitation creates an invitation for an event. email and maxUses are optional.
+func NewInvitation(eventID, issuedBy uuid.UUID, email string, maxUses *int, expiresAt time.Time) *Invitation {
+	invitation := &Invitation{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+		IssuedBy:  issuedBy,
+		CreatedAt: time.Now(),
+	}
+
+	if email = normalizeEmail(email); email != "" {
+		invitation.Email = &email
+	}
+
+	return invitation
+}
+
+// IsRevoked reports whether an organizer revoked the invitation
+func (i *Invitation) IsRevoked() bool {
+	return i.RevokedAt != nil
+}
+
+// IsExpired reports whether the invitation expired at the given time
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsExhausted reports whether the invitation has no redemptions left
+func (i *Invitation) IsExhausted() bool {
+	return i.MaxUses != nil && i.UseCount >= *i.MaxUses
+}
+
+// IsSingleUse reports whether the invitation can only be redeemed once
+func (i *Invitation) IsSingleUse() bool {
+	return i.MaxUses != nil && *i.MaxUses == 1
+}
+
+// MatchesEmail reports whether the invitation can be redeemed by the given email.
+// Invitations without an email can be redeemed by anyone.
+func (i *Invitation) MatchesEmail(email string) bool {
+	return i.Email == nil || *i.Email == normalizeEmail(email)
+}
+
+// Status summarizes the invitation state: active, revoked, expired or exhausted
+func (i *Invitation) Status(now time.Time) string {
+	switch {
+	case i.IsRevoked():
+		return "revoked"
+	case i.IsExpired(now):
+		return "expired"
+	case i.IsExhausted():
+		return "exhausted"
+	default:
+		return "active"
+	}
+}
+
+// InvitationRedemption records a registration made with an invitation
+type InvitationRedemption struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	InvitationID uuid.UUID `json:"invitation_id" gorm:"type:uuid;not null"`
+	EventID      uuid.UUID `json:"event_id" gorm:"type:uuid;not null"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Email        string    `json:"email" gorm:"not null"`
+	RedeemedAt   time.Time `json:"redeemed_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (InvitationRedemption) TableName() string {
+	return "event_invitation_redemptions"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (r *InvitationRedemption) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// invitationPayloadSize is the invitation ID, the event ID and the expiry as unix seconds
+const invitationPayloadSize = 16 + 16 + 8
+
+// SignInvitationToken builds the token handed out for an invitation. The token carries the
+// invitation and event IDs and the expiry, signed with HMAC-SHA256, so forged or tampered
+// tokens are rejected before touching the database.
+func SignInvitationToken(secret []byte, invitation *Invitation) string {
+	payload := make([]byte, invitationPayloadSize)
+	copy(payload[0:16], invitation.ID[:])
+	copy(payload[16:32], invitation.EventID[:])
+	binary.BigEndian.PutUint64(payload[32:], uint64(invitation.ExpiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signInvitationPayload(secret, payload))
+}
+
+// ParseInvitationToken verifies the token signature and returns the invitation ID,
+// the event ID and the expiry it carries
+func ParseInvitationToken(secret []byte, token string) (uuid.UUID, uuid.UUID, time.Time, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return uuid.Nil, uuid.Nil, time.Time{}, ErrInvalidInvitationToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != invitationPayloadSize {
+		return uuid.Nil, uuid.Nil, time.Time{}, ErrInvalidInvitationToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signInvitationPayload(secret, payload)) {
+		return uuid.Nil, uuid.Nil, time.Time{}, ErrInvalidInvitationToken
+	}
+
+	var invitationID, eventID uuid.UUID
+	copy(invitationID[:], payload[0:16])
+	copy(eventID[:], payload[16:32])
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0).UTC()
+
+	return invitationID, eventID, expiresAt, nil
+}
+
+func signInvitationPayload(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("telescopio-invitation:"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,33 @@
+package event
+
+// ResultsVisibility controls who, besides the people managing an event, can see its
+// results once the event reaches the result stage
+type ResultsVisibility string
+
+const (
+	// ResultsVisibilityManagers keeps the results to the event creator, co-organizers,
+	// organizers and admins
+	ResultsVisibilityManagers ResultsVisibility = "managers"
+	// ResultsVisibilityMembers also shows the results to participants, reviewers and observers
+	ResultsVisibilityMembers ResultsVisibility = "members"
+	// ResultsVisibilityPublic shows the results to every signed-in user
+	ResultsVisibilityPublic ResultsVisibility = "public"
+)
+
+// IsValid checks if the results visibility is one of the supported values
+func (v ResultsVisibility) IsValid() bool {
+	switch v {
+	case ResultsVisibilityManagers, ResultsVisibilityMembers, ResultsVisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault returns the visibility, or members for events stored before the setting existed
+func (v ResultsVisibility) OrDefault() ResultsVisibility {
+	if v == "" {
+		return ResultsVisibilityMembers
+	}
+	return v
+}
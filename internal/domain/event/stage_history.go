@@ -0,0 +1,172 @@
+package event
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransitionDirection tells forward stage progressions apart from rollbacks
+type TransitionDirection string
+
+const (
+	DirectionForward TransitionDirection = "forward"
+	DirectionReverse TransitionDirection = "reverse"
+)
+
+// TransitionTrigger records who initiated a stage change
+type TransitionTrigger string
+
+const (
+	TriggerManual    TransitionTrigger = "manual"
+	TriggerScheduler TransitionTrigger = "scheduler"
+)
+
+// DataPolicy defines what happens to voting data when a stage is rolled back
+type DataPolicy string
+
+const (
+	// DataPolicyKeep leaves the records untouched
+	DataPolicyKeep DataPolicy = "keep"
+	// DataPolicyInvalidate deletes the records permanently
+	DataPolicyInvalidate DataPolicy = "invalidate"
+	// DataPolicyArchive snapshots the records into event_archived_records before deleting them
+	DataPolicyArchive DataPolicy = "archive"
+)
+
+// IsValid checks if the policy is one of the supported values
+func (p DataPolicy) IsValid() bool {
+	switch p {
+	case DataPolicyKeep, DataPolicyInvalidate, DataPolicyArchive:
+		return true
+	default:
+		return false
+	}
+}
+
+// RollbackPolicy selects a DataPolicy for each kind of voting record affected by a rollback
+type RollbackPolicy struct {
+	Assignments DataPolicy `json:"assignments"`
+	Votes       DataPolicy `json:"votes"`
+	Drafts      DataPolicy `json:"drafts"`
+}
+
+// DefaultRollbackPolicy returns the policy applied when the caller does not choose one.
+// Reopening participation discards the assignments (proposals may change), so the
+// submitted votes are archived and drafts dropped. Reopening voting keeps everything so
+// reviewers can keep amending their rankings.
+func DefaultRollbackPolicy(from, to Stage) RollbackPolicy {
+	if from == StageVoting && to == StageParticipation {
+		return RollbackPolicy{
+			Assignments: DataPolicyArchive,
+			Votes:       DataPolicyArchive,
+			Drafts:      DataPolicyInvalidate,
+		}
+	}
+
+	return RollbackPolicy{
+		Assignments: DataPolicyKeep,
+		Votes:       DataPolicyKeep,
+		Drafts:      DataPolicyKeep,
+	}
+}
+
+// Validate checks the policy values and that votes and drafts never outlive their assignments
+func (p RollbackPolicy) Validate() error {
+	for name, policy := range map[string]DataPolicy{
+		"assignments": p.Assignments,
+		"votes":       p.Votes,
+		"drafts":      p.Drafts,
+	} {
+		if !policy.IsValid() {
+			return fmt.Errorf("invalid %s policy %q: must be keep, invalidate or archive", name, policy)
+		}
+	}
+
+	if p.Assignments != DataPolicyKeep && (p.Votes == DataPolicyKeep || p.Drafts == DataPolicyKeep) {
+		return errors.New("votes and drafts cannot be kept when assignments are invalidated or archived")
+	}
+
+	return nil
+}
+
+func (p RollbackPolicy) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RollbackPolicy: %w", err)
+	}
+	return string(b), nil
+}
+
+func (p *RollbackPolicy) Scan(value interface{}) error {
+	return scanJSONB(value, p, "RollbackPolicy")
+}
+
+// RollbackSummary counts the records affected by a rollback
+type RollbackSummary struct {
+	Assignments         int64 `json:"assignments"`
+	Votes               int64 `json:"votes"`
+	VoteRevisions       int64 `json:"vote_revisions"`
+	Drafts              int64 `json:"drafts"`
+	VotingResults       int64 `json:"voting_results"`
+	ReopenedAssignments int64 `json:"reopened_assignments"`
+}
+
+func (s RollbackSummary) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RollbackSummary: %w", err)
+	}
+	return string(b), nil
+}
+
+func (s *RollbackSummary) Scan(value interface{}) error {
+	return scanJSONB(value, s, "RollbackSummary")
+}
+
+func scanJSONB(value interface{}, dest interface{}, name string) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to scan %s: unsupported type %T", name, value)
+	}
+	return json.Unmarshal(bytes, dest)
+}
+
+// StageHistoryEntry is one row of an event's stage history log.
+// Reverse transitions always carry an actor, a justification and the applied policy.
+type StageHistoryEntry struct {
+	ID            uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID       uuid.UUID           `json:"event_id" gorm:"type:uuid;not null"`
+	FromStage     Stage               `json:"from_stage" gorm:"type:event_stage;not null"`
+	ToStage       Stage               `json:"to_stage" gorm:"type:event_stage;not null"`
+	Direction     TransitionDirection `json:"direction" gorm:"not null"`
+	Trigger       TransitionTrigger   `json:"trigger" gorm:"column:triggered_by;not null"`
+	ActorID       *uuid.UUID          `json:"actor_id,omitempty" gorm:"type:uuid"`
+	Justification string              `json:"justification,omitempty" gorm:"default:''"`
+	Policy        *RollbackPolicy     `json:"policy,omitempty" gorm:"type:jsonb"`
+	Summary       *RollbackSummary    `json:"summary,omitempty" gorm:"type:jsonb"`
+	CreatedAt     time.Time           `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (StageHistoryEntry) TableName() string {
+	return "event_stage_history"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (h *StageHistoryEntry) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
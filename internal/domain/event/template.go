@@ -0,0 +1,133 @@
+package event
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
+	"github.com/gravadigital/telescopio-api/internal/domain/vote"
+)
+
+// TemplateSettings holds the per-event policies copied when an event is cloned or
+// instantiated from a template
+type TemplateSettings struct {
+	VotingConfiguration  *vote.VotingSettings `json:"voting_configuration,omitempty"`
+	Visibility           Visibility           `json:"visibility,omitempty"`
+	ResultsVisibility    ResultsVisibility    `json:"results_visibility,omitempty"`
+	SubmissionForm       *submission.Form     `json:"submission_form,omitempty"`
+	RequireVerifiedEmail bool                 `json:"require_verified_email,omitempty"`
+}
+
+func (s TemplateSettings) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TemplateSettings: %w", err)
+	}
+	return string(b), nil
+}
+
+func (s *TemplateSettings) Scan(value interface{}) error {
+	if value == nil {
+		*s = TemplateSettings{}
+		return nil
+	}
+	return scanJSONB(value, s, "TemplateSettings")
+}
+
+// EventTemplate is a named, reusable blueprint for creating events.
+// Cloning an event builds an unsaved template from it and instantiates it right away.
+type EventTemplate struct {
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	OwnerID         uuid.UUID        `json:"owner_id" gorm:"type:uuid;not null"`
+	OrganizationID  uuid.UUID        `json:"organization_id" gorm:"type:uuid;not null"`
+	Name            string           `json:"name" gorm:"not null"`
+	Description     string           `json:"description" gorm:"not null"`
+	Organizer       string           `json:"organizer" gorm:"default:''"`
+	MaxParticipants *int             `json:"max_participants,omitempty" gorm:"default:null"`
+	Settings        TemplateSettings `json:"settings" gorm:"type:jsonb;not null;default:'{}'"`
+	SourceEventID   *uuid.UUID       `json:"source_event_id,omitempty" gorm:"type:uuid"`
+	CreatedAt       time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (EventTemplate) TableName() string {
+	return "event_templates"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (t *EventTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewTemplateFromEvent snapshots an event and its voting configuration (which may be nil)
+func NewTemplateFromEvent(name string, ownerID uuid.UUID, evt *Event, config *vote.VotingConfiguration) *EventTemplate {
+	template := &EventTemplate{
+		ID:              uuid.New(),
+		OwnerID:         ownerID,
+		OrganizationID:  evt.OrganizationID,
+		Name:            name,
+		Description:     evt.Description,
+		Organizer:       evt.Organizer,
+		MaxParticipants: evt.MaxParticipants,
+		SourceEventID:   &evt.ID,
+		Settings: TemplateSettings{
+			Visibility:           evt.Visibility,
+			ResultsVisibility:    evt.ResultsVisibility,
+			SubmissionForm:       evt.SubmissionForm,
+			RequireVerifiedEmail: evt.RequireVerifiedEmail,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	if config != nil {
+		settings := config.Settings()
+		template.Settings.VotingConfiguration = &settings
+	}
+
+	return template
+}
+
+// IsOwner checks if the given user ID owns this template
+func (t *EventTemplate) IsOwner(userID uuid.UUID) bool {
+	return t.OwnerID == userID
+}
+
+// Instantiate creates a new event in the creation stage from the template, in the
+// organization of the template
+func (t *EventTemplate) Instantiate(name string, authorID uuid.UUID, startDate, endDate time.Time) *Event {
+	evt := NewEvent(name, t.Description, authorID, startDate, endDate, t.Organizer)
+	evt.OrganizationID = t.OrganizationID
+	if t.MaxParticipants != nil {
+		maxParticipants := *t.MaxParticipants
+		evt.MaxParticipants = &maxParticipants
+	}
+	evt.SubmissionForm = t.Settings.SubmissionForm
+	evt.RequireVerifiedEmail = t.Settings.RequireVerifiedEmail
+	if t.Settings.Visibility.IsValid() {
+		evt.Visibility = t.Settings.Visibility
+	}
+	if t.Settings.ResultsVisibility.IsValid() {
+		evt.ResultsVisibility = t.Settings.ResultsVisibility
+	}
+	return evt
+}
+
+// Validate checks if the template data is valid
+func (t *EventTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if t.OwnerID == uuid.Nil {
+		return fmt.Errorf("template owner is required")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistStatus tracks a waitlist entry from joining the queue until it leaves it
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistPromoted  WaitlistStatus = "promoted"
+	WaitlistWithdrawn WaitlistStatus = "withdrawn"
+)
+
+// WaitlistEntry is a registrant queued because the event was full.
+// Waiting entries are served in Position order; PromotedBy is nil for automatic promotions.
+type WaitlistEntry struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID      uuid.UUID      `json:"event_id" gorm:"type:uuid;not null"`
+	UserID       uuid.UUID      `json:"user_id" gorm:"type:uuid;not null"`
+	Position     int            `json:"position" gorm:"not null"`
+	Status       WaitlistStatus `json:"status" gorm:"not null;default:'waiting'"`
+	InvitationID *uuid.UUID     `json:"invitation_id,omitempty" gorm:"type:uuid"`
+	PromotedAt   *time.Time     `json:"promoted_at,omitempty"`
+	PromotedBy   *uuid.UUID     `json:"promoted_by,omitempty" gorm:"type:uuid"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (WaitlistEntry) TableName() string {
+	return "event_waitlist_entries"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (w *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsWaiting reports whether the entry is still in the queue
+func (w *WaitlistEntry) IsWaiting() bool {
+	return w.Status == WaitlistWaiting
+}
+
+// MoveWaitlistEntry returns the queue with the entry moved to the given 1-based position,
+// clamped to the queue, and every entry's Position renumbered to its place. The bool is
+// false when the entry is not in the queue. queue must be in queue order.
+func MoveWaitlistEntry(queue []*WaitlistEntry, entryID uuid.UUID, position int) ([]*WaitlistEntry, bool) {
+	index := -1
+	for i, entry := range queue {
+		if entry.ID == entryID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return queue, false
+	}
+
+	if position < 1 {
+		position = 1
+	}
+	if position > len(queue) {
+		position = len(queue)
+	}
+
+	moved := queue[index]
+	reordered := make([]*WaitlistEntry, 0, len(queue))
+	for i, entry := range queue {
+		if i == index {
+			continue
+		}
+		if len(reordered) == position-1 {
+			reordered = append(reordered, moved)
+		}
+		reordered = append(reordered, entry)
+	}
+	if len(reordered) < len(queue) {
+		reordered = append(reordered, moved)
+	}
+
+	for i, entry := range reordered {
+		entry.Position = i + 1
+	}
+	return reordered, true
+}
@@ -0,0 +1,81 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testQueue returns waiting entries named by their user in queue order
+func testQueue(names ...string) ([]*WaitlistEntry, map[uuid.UUID]string) {
+	queue := make([]*WaitlistEntry, len(names))
+	byID := make(map[uuid.UUID]string, len(names))
+	for i, name := range names {
+		queue[i] = &WaitlistEntry{ID: uuid.New(), Position: i + 1, Status: WaitlistWaiting}
+		byID[queue[i].ID] = name
+	}
+	return queue, byID
+}
+
+func TestMoveWaitlistEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		move     int // index of the entry to move
+		position int
+		want     []string
+	}{
+		{"to the front", 2, 1, []string{"c", "a", "b", "d"}},
+		{"to the back", 0, 4, []string{"b", "c", "d", "a"}},
+		{"one place down", 1, 3, []string{"a", "c", "b", "d"}},
+		{"one place up", 2, 2, []string{"a", "c", "b", "d"}},
+		{"to its own place", 1, 2, []string{"a", "b", "c", "d"}},
+		{"past the end", 0, 10, []string{"b", "c", "d", "a"}},
+		{"before the start", 3, 0, []string{"d", "a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue, names := testQueue("a", "b", "c", "d")
+
+			got, ok := MoveWaitlistEntry(queue, queue[tt.move].ID, tt.position)
+			if !ok {
+				t.Fatal("entry not found")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("queue has %d entries, want %d", len(got), len(tt.want))
+			}
+			for i, entry := range got {
+				if names[entry.ID] != tt.want[i] {
+					t.Errorf("position %d = %s, want %s", i+1, names[entry.ID], tt.want[i])
+				}
+				if entry.Position != i+1 {
+					t.Errorf("entry %s has Position %d, want %d", names[entry.ID], entry.Position, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveWaitlistEntryRenumbersGaps(t *testing.T) {
+	// Promotions and withdrawals leave gaps in the stored positions
+	queue, names := testQueue("a", "b", "c")
+	queue[0].Position, queue[1].Position, queue[2].Position = 2, 5, 9
+
+	got, _ := MoveWaitlistEntry(queue, queue[2].ID, 2)
+	for i, want := range []string{"a", "c", "b"} {
+		if names[got[i].ID] != want || got[i].Position != i+1 {
+			t.Errorf("position %d = %s (Position %d), want %s", i+1, names[got[i].ID], got[i].Position, want)
+		}
+	}
+}
+
+func TestMoveWaitlistEntryNotQueued(t *testing.T) {
+	queue, _ := testQueue("a", "b")
+
+	if _, ok := MoveWaitlistEntry(queue, uuid.New(), 1); ok {
+		t.Error("moved an entry that is not in the queue")
+	}
+	if _, ok := MoveWaitlistEntry(nil, uuid.New(), 1); ok {
+		t.Error("moved an entry of an empty queue")
+	}
+}
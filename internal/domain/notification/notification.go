@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kind identifies what a notification is about
+type Kind string
+
+const (
+	KindWaitlistPromoted Kind = "waitlist_promoted"
+)
+
+// Notification is an in-app message for a user
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	EventID   *uuid.UUID `json:"event_id,omitempty" gorm:"type:uuid"`
+	Kind      Kind       `json:"kind" gorm:"not null"`
+	Title     string     `json:"title" gorm:"not null"`
+	Message   string     `json:"message" gorm:"not null"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (Notification) TableName() string {
+	return "user_notifications"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewNotification creates an unread notification, optionally tied to an event
+func NewNotification(userID uuid.UUID, eventID *uuid.UUID, kind Kind, title, message string) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		EventID:   eventID,
+		Kind:      kind,
+		Title:     title,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsRead reports whether the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
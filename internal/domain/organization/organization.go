@@ -0,0 +1,124 @@
+package organization
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+)
+
+// DefaultSlug is the slug of the organization created by the migration. It owns every
+// event and user of single-tenant deployments and serves requests naming no organization.
+const DefaultSlug = "default"
+
+// MemberRole is the role of a user within an organization, on top of their global role
+type MemberRole string
+
+const (
+	// RoleOrgAdmin administers the organization: its users, events and branding
+	RoleOrgAdmin MemberRole = "org_admin"
+	// RoleMember is any other user of the organization
+	RoleMember MemberRole = "member"
+)
+
+// MemberRoles lists every role a member can have
+var MemberRoles = []MemberRole{RoleOrgAdmin, RoleMember}
+
+// IsValid checks if the member role is known
+func (r MemberRole) IsValid() bool {
+	return slices.Contains(MemberRoles, r)
+}
+
+// String returns the string representation of the member role
+func (r MemberRole) String() string {
+	return string(r)
+}
+
+// slugPattern matches slugs usable as a DNS label
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Organization is an institution hosting its own calls on a shared deployment. Its events
+// and users are invisible to other organizations. The slug doubles as its subdomain.
+type Organization struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Slug         string    `json:"slug" gorm:"not null;uniqueIndex"`
+	Name         string    `json:"name" gorm:"not null"`
+	LogoURL      string    `json:"logo_url" gorm:"not null;default:''"`
+	PrimaryColor string    `json:"primary_color" gorm:"not null;default:''"` // #rrggbb
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewOrganization creates an organization without branding
+func NewOrganization(slug, name string) *Organization {
+	return &Organization{
+		ID:        uuid.New(),
+		Slug:      strings.ToLower(strings.TrimSpace(slug)),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsValidSlug reports whether the slug can name an organization and its subdomain
+func IsValidSlug(slug string) bool {
+	return slugPattern.MatchString(slug)
+}
+
+// colorPattern matches the #rrggbb colors accepted as primary color
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validate checks if the organization data is valid
+func (o *Organization) Validate() error {
+	if !IsValidSlug(o.Slug) {
+		return fmt.Errorf("slug must be 1 to 63 lowercase letters, digits or dashes, not starting or ending with a dash")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("organization name is required")
+	}
+	if o.PrimaryColor != "" && !colorPattern.MatchString(o.PrimaryColor) {
+		return fmt.Errorf("primary color must be written as #rrggbb")
+	}
+	if o.LogoURL != "" && !strings.HasPrefix(o.LogoURL, "https://") {
+		return fmt.Errorf("logo URL must use https")
+	}
+	return nil
+}
+
+// Member is the membership of a user in an organization. Users join the organization they
+// sign in to; the organization is carried by their access tokens.
+type Member struct {
+	OrganizationID uuid.UUID         `json:"organization_id" gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID         `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role           MemberRole        `json:"role" gorm:"not null;default:'member'"`
+	User           *participant.User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt      time.Time         `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (Member) TableName() string {
+	return "organization_members"
+}
+
+// IsAdmin reports whether the member administers the organization
+func (m *Member) IsAdmin() bool {
+	return m != nil && m.Role == RoleOrgAdmin
+}
@@ -0,0 +1,101 @@
+package participant
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditAction names a change an admin made to a user account
+type AuditAction string
+
+const (
+	AuditRoleChanged AuditAction = "role_changed"
+	AuditDeactivated AuditAction = "deactivated"
+	AuditReactivated AuditAction = "reactivated"
+	// AuditUnlocked is recorded when an admin lifts a lockout after failed sign-ins
+	AuditUnlocked AuditAction = "unlocked"
+	// AuditTwoFactorReset is recorded when an admin removes the second factor of a user who
+	// lost their authenticator and recovery codes
+	AuditTwoFactorReset AuditAction = "two_factor_reset"
+	// AuditMerged is recorded for both accounts of a merge
+	AuditMerged AuditAction = "merged"
+	// AuditMemberRoleChanged is recorded when an admin changes the role of a user within the
+	// organization
+	AuditMemberRoleChanged AuditAction = "member_role_changed"
+	// AuditMemberRemoved is recorded when an admin removes a user from the organization
+	AuditMemberRemoved AuditAction = "member_removed"
+)
+
+// AuditDetails holds the action specific data of an audit entry, such as the old and new role
+type AuditDetails map[string]interface{}
+
+func (d AuditDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AuditDetails: %w", err)
+	}
+	return string(b), nil
+}
+
+func (d *AuditDetails) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to scan AuditDetails: unsupported type %T", value)
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+// AuditEntry is one row of the audit log of admin changes to user accounts. The target's
+// email is copied so entries stay readable once the account is merged away. Entries are
+// kept in the organization of the admin who made the change.
+type AuditEntry struct {
+	ID             uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	OrganizationID *uuid.UUID   `json:"organization_id,omitempty" gorm:"type:uuid"`
+	ActorID        *uuid.UUID   `json:"actor_id,omitempty" gorm:"type:uuid"`
+	TargetUserID   uuid.UUID    `json:"target_user_id" gorm:"type:uuid;not null"`
+	TargetEmail    string       `json:"target_email" gorm:"not null"`
+	Action         AuditAction  `json:"action" gorm:"not null"`
+	Details        AuditDetails `json:"details" gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (AuditEntry) TableName() string {
+	return "user_audit_log"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (e *AuditEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewAuditEntry creates an audit entry for a change made by actor, an admin of the
+// organization, to target
+func NewAuditEntry(organizationID, actorID uuid.UUID, target *User, action AuditAction, details AuditDetails) *AuditEntry {
+	return &AuditEntry{
+		ID:             uuid.New(),
+		OrganizationID: &organizationID,
+		ActorID:        &actorID,
+		TargetUserID:   target.ID,
+		TargetEmail:    target.Email,
+		Action:         action,
+		Details:        details,
+		CreatedAt:      time.Now(),
+	}
+}
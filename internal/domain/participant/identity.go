@@ -0,0 +1,33 @@
+package participant
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Identity links a user to an account at an OpenID Connect provider. The subject is the
+// provider's stable user identifier; the email is informational, as it may change.
+type Identity struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	Provider    string     `json:"provider" gorm:"not null"`
+	Subject     string     `json:"subject" gorm:"not null"`
+	Email       string     `json:"email" gorm:"not null;default:''"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// TableName overrides the table name used by GORM
+func (Identity) TableName() string {
+	return "user_identities"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (i *Identity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -30,15 +30,19 @@ func (r Role) IsValid() bool {
 
 // User represents a system user (admin or participant)
 type User struct {
-	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	Name         string    `json:"name" gorm:"not null"`
-	LastName     string    `json:"lastname" gorm:"column:lastname"`
-	Email        string    `json:"email" gorm:"uniqueIndex;not null"`
-	PasswordHash *string   `json:"-" gorm:"column:password_hash"`
-	GoogleID     *string   `json:"google_id,omitempty" gorm:"column:google_id;uniqueIndex"`
-	Role         Role      `json:"role" gorm:"type:varchar(20);not null;default:'participant'"`
-	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name             string     `json:"name" gorm:"not null"`
+	LastName         string     `json:"lastname" gorm:"column:lastname"`
+	Email            string     `json:"email" gorm:"uniqueIndex;not null"`
+	PasswordHash     *string    `json:"-" gorm:"column:password_hash"`
+	GoogleID         *string    `json:"google_id,omitempty" gorm:"column:google_id;uniqueIndex"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at,omitempty"` // set once the user followed a mailed link
+	Role             Role       `json:"role" gorm:"type:varchar(20);not null;default:'participant'"`
+	TokenVersion     int        `json:"-" gorm:"not null;default:0"`                      // bumped to revoke every token issued so far
+	IsServiceAccount bool       `json:"is_service_account" gorm:"not null;default:false"` // used by scripts through API keys; can't sign in
+	DeactivatedAt    *time.Time `json:"deactivated_at,omitempty"`                         // set by an admin; blocks login, tokens and API keys
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName overrides the table name used by GORM
@@ -125,6 +129,16 @@ func (u *User) UpdateRole(newRole Role) error {
 	return nil
 }
 
+// IsEmailVerified reports whether the user proved they own their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsDeactivated reports whether an admin deactivated the account
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
 // IsAdmin checks if the user has admin role
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
@@ -165,59 +179,8 @@ func (u *User) GetName() string {
 	return u.Name
 }
 
-// TODO: Permission management methods by role
-// These methods should be implemented to handle role-based permissions
-
-// CanCreateEvent checks if user can create events
-func (u *User) CanCreateEvent() bool {
-	return u.IsAdmin() || u.IsOrganizer()
-}
-
-// CanManageParticipants checks if user can manage event participants
-func (u *User) CanManageParticipants() bool {
-	return u.IsAdmin() || u.IsOrganizer()
-}
-
-// CanVote checks if user can participate in voting
-func (u *User) CanVote() bool {
-	return u.IsParticipant() || u.IsAdmin() || u.IsOrganizer()
-}
-
-// CanViewResults checks if user can view voting results
-// TODO: Implement result viewing permission logic
-func (u *User) CanViewResults() bool {
-	// TODO: Define which roles can view results
-	return true // Placeholder: everyone can view results
-}
-
-// CanModifyVotingConfiguration checks if user can modify voting settings
-func (u *User) CanModifyVotingConfiguration() bool {
-	return u.IsAdmin() || u.IsOrganizer()
-}
-
-// HasPermission checks if user has a specific permission
-// TODO: Implement a comprehensive permission system
-func (u *User) HasPermission(permission string) bool {
-	// TODO: Implement permission checking logic
-	// This could integrate with a more sophisticated RBAC system
-	switch permission {
-	case "create_event":
-		return u.CanCreateEvent()
-	case "manage_participants":
-		return u.CanManageParticipants()
-	case "vote":
-		return u.CanVote()
-	case "view_results":
-		return u.CanViewResults()
-	case "modify_voting_config":
-		return u.CanModifyVotingConfiguration()
-	default:
-		return false // TODO: Define default permission behavior
-	}
-}
-
 // UserWithEventRole represents a user with their role in a specific event
 type UserWithEventRole struct {
 	User
-	EventRole string `json:"event_role"` // Role from event_participants table (creator/participant)
+	EventRole string `json:"event_role"` // Role from event_participants table (creator, participant, co_organizer, reviewer, observer)
 }
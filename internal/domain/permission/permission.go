@@ -0,0 +1,192 @@
+package permission
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+)
+
+// Permission names an action guarded by the access policy. Whether a user holds it depends
+// on their global role, their role in the organization and, for event scoped permissions,
+// on their role in the event.
+type Permission string
+
+const (
+	// EventCreate allows creating events; every signed-in user holds it
+	EventCreate Permission = "event.create"
+	// UsersManage allows administering the user accounts, service accounts, audit log and
+	// members of the organization
+	UsersManage Permission = "users.manage"
+	// OrganizationManage allows changing the name and branding of the organization
+	OrganizationManage Permission = "organization.manage"
+	// PlatformManage allows creating and listing organizations. Reserved to admins (global
+	// role); org admins don't hold it.
+	PlatformManage Permission = "platform.manage"
+
+	// EventLifecycle allows moving an event between stages, archiving and deleting it.
+	// Reserved to the event creator.
+	EventLifecycle Permission = "event.lifecycle"
+	// EventManage allows changing the settings of an event and running it: invitations,
+	// members, waitlist, voting configuration, exports and reports
+	EventManage Permission = "event.manage"
+	// CoOrganizerGrant allows granting and revoking co-organizer rights in an event
+	CoOrganizerGrant Permission = "event.co_organizer.grant"
+	// EventHistoryView allows reading the stage history of an event
+	EventHistoryView Permission = "event.history.view"
+	// MemberAct allows acting for a member of an event: uploading their proposal, voting,
+	// or withdrawing them. Members hold it for themselves.
+	MemberAct Permission = "event.member.act"
+	// ProposalManage allows changing a proposal, its co-authors, answers and files.
+	// Authors hold it for their own proposals.
+	ProposalManage Permission = "proposal.manage"
+	// ResultsView allows reading the results of an event, see ResultsVisibility
+	ResultsView Permission = "results.view"
+	// ProgressView allows following the voting progress of an event
+	ProgressView Permission = "progress.view"
+)
+
+// All lists every permission
+var All = []Permission{
+	EventCreate,
+	UsersManage,
+	OrganizationManage,
+	PlatformManage,
+	EventLifecycle,
+	EventManage,
+	CoOrganizerGrant,
+	EventHistoryView,
+	MemberAct,
+	ProposalManage,
+	ResultsView,
+	ProgressView,
+}
+
+// String returns the string representation of the permission
+func (p Permission) String() string {
+	return string(p)
+}
+
+// EventScoped reports whether the permission is checked against a specific event
+func (p Permission) EventScoped() bool {
+	switch p {
+	case EventCreate, UsersManage, OrganizationManage, PlatformManage:
+		return false
+	default:
+		return true
+	}
+}
+
+// Subject is the user asking for a permission, with their role in the organization of the
+// request. Targets always belong to that organization.
+type Subject struct {
+	UserID           uuid.UUID
+	Role             participant.Role
+	OrganizationRole organization.MemberRole
+}
+
+// IsAdmin reports whether the subject administers the organization: admins (global role)
+// and org admins
+func (s Subject) IsAdmin() bool {
+	return s.Role == participant.RoleAdmin || s.OrganizationRole == organization.RoleOrgAdmin
+}
+
+// Target is what a permission is asked for. Event is required for event scoped
+// permissions; EventRole is empty when the subject is not a member of the event.
+// Owners are the users the action is about, such as the member in the URL or the
+// authors of a proposal; they hold MemberAct and ProposalManage for themselves.
+type Target struct {
+	Event     *event.Event
+	EventRole event.EventParticipantRole
+	Owners    []uuid.UUID
+}
+
+// Allowed reports whether the subject holds the permission on the target.
+//
+// Admins and org admins hold every permission but PlatformManage, held by admins only.
+// Organizers manage every event but only act
+// for members and change proposals of the events they created or co-organize. Results are
+// always visible to the people managing the event; anyone else only sees them once the
+// event reaches the result stage, as allowed by the event's results visibility.
+func Allowed(p Permission, s Subject, t Target) bool {
+	if s.UserID == uuid.Nil {
+		return false
+	}
+	if p == PlatformManage {
+		return s.Role == participant.RoleAdmin
+	}
+	if s.IsAdmin() {
+		return true
+	}
+
+	switch p {
+	case EventCreate:
+		return true
+	case UsersManage, OrganizationManage:
+		return false
+	}
+
+	if t.Event == nil {
+		return false
+	}
+
+	switch p {
+	case EventLifecycle:
+		return isCreator(s, t)
+	case EventManage:
+		return s.Role == participant.RoleOrganizer || isManager(s, t)
+	case CoOrganizerGrant:
+		return s.Role == participant.RoleOrganizer || isCreator(s, t)
+	case EventHistoryView:
+		return Allowed(EventManage, s, t) || t.EventRole == event.RoleObserver
+	case MemberAct, ProposalManage:
+		return isManager(s, t) || isOwner(s, t)
+	case ResultsView:
+		return Allowed(EventManage, s, t) || resultsVisible(t)
+	case ProgressView:
+		return Allowed(EventManage, s, t) || t.EventRole == event.RoleObserver || resultsVisible(t)
+	default:
+		return false
+	}
+}
+
+// isCreator reports whether the subject created the event
+func isCreator(s Subject, t Target) bool {
+	return t.Event.IsAuthor(s.UserID) || t.EventRole == event.RoleCreator
+}
+
+// isManager reports whether the subject created or co-organizes the event
+func isManager(s Subject, t Target) bool {
+	return isCreator(s, t) || t.EventRole.CanManage()
+}
+
+func isOwner(s Subject, t Target) bool {
+	for _, id := range t.Owners {
+		if id == s.UserID {
+			return true
+		}
+	}
+	return false
+}
+
+// resultsVisible reports whether the results of the event are visible to a subject that
+// doesn't manage it
+func resultsVisible(t Target) bool {
+	if t.Event.Stage != event.StageResult {
+		return false
+	}
+
+	switch t.Event.ResultsVisibility.OrDefault() {
+	case event.ResultsVisibilityPublic:
+		return true
+	case event.ResultsVisibilityMembers:
+		switch t.EventRole {
+		case event.RoleParticipant, event.RoleReviewer, event.RoleObserver:
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,233 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+)
+
+// relation is how the subject relates to the event a permission is checked against
+type relation string
+
+const (
+	relCreator     relation = "creator"
+	relCoOrganizer relation = "co_organizer"
+	relParticipant relation = "participant"
+	relReviewer    relation = "reviewer"
+	relObserver    relation = "observer"
+	relOutsider    relation = "outsider"
+	// relOwner is a participant the request is about, such as the member in the URL
+	relOwner relation = "owner"
+)
+
+var allRelations = []relation{relCreator, relCoOrganizer, relParticipant, relReviewer, relObserver, relOutsider, relOwner}
+
+var globalRoles = []participant.Role{participant.RoleAdmin, participant.RoleOrganizer, participant.RoleParticipant}
+
+// fixture builds the subject and target for a global role and a relation to an event in
+// the given stage and with the given results visibility
+func fixture(role participant.Role, rel relation, stage event.Stage, visibility event.ResultsVisibility) (Subject, Target) {
+	subject := Subject{UserID: uuid.New(), Role: role}
+	evt := &event.Event{ID: uuid.New(), AuthorID: uuid.New(), Stage: stage, ResultsVisibility: visibility}
+	target := Target{Event: evt, Owners: []uuid.UUID{uuid.New()}}
+
+	switch rel {
+	case relCreator:
+		evt.AuthorID = subject.UserID
+		target.EventRole = event.RoleCreator
+	case relCoOrganizer:
+		target.EventRole = event.RoleCoOrganizer
+	case relParticipant:
+		target.EventRole = event.RoleParticipant
+	case relReviewer:
+		target.EventRole = event.RoleReviewer
+	case relObserver:
+		target.EventRole = event.RoleObserver
+	case relOwner:
+		target.EventRole = event.RoleParticipant
+		target.Owners = []uuid.UUID{subject.UserID}
+	}
+
+	return subject, target
+}
+
+func contains(relations []relation, rel relation) bool {
+	for _, r := range relations {
+		if r == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// TestAllowedMatrix checks every permission for every global role and event relation, on
+// an event in the voting stage whose results are visible to its members
+func TestAllowedMatrix(t *testing.T) {
+	managers := []relation{relCreator, relCoOrganizer}
+
+	matrix := map[Permission]map[participant.Role][]relation{
+		EventCreate: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: allRelations,
+		},
+		UsersManage: {
+			participant.RoleAdmin: allRelations,
+		},
+		OrganizationManage: {
+			participant.RoleAdmin: allRelations,
+		},
+		PlatformManage: {
+			participant.RoleAdmin: allRelations,
+		},
+		EventLifecycle: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   {relCreator},
+			participant.RoleParticipant: {relCreator},
+		},
+		EventManage: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: managers,
+		},
+		CoOrganizerGrant: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: {relCreator},
+		},
+		EventHistoryView: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: {relCreator, relCoOrganizer, relObserver},
+		},
+		MemberAct: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   {relCreator, relCoOrganizer, relOwner},
+			participant.RoleParticipant: {relCreator, relCoOrganizer, relOwner},
+		},
+		ProposalManage: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   {relCreator, relCoOrganizer, relOwner},
+			participant.RoleParticipant: {relCreator, relCoOrganizer, relOwner},
+		},
+		ResultsView: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: managers,
+		},
+		ProgressView: {
+			participant.RoleAdmin:       allRelations,
+			participant.RoleOrganizer:   allRelations,
+			participant.RoleParticipant: {relCreator, relCoOrganizer, relObserver},
+		},
+	}
+
+	for _, p := range All {
+		granted, ok := matrix[p]
+		if !ok {
+			t.Errorf("permission %s is missing from the matrix", p)
+			continue
+		}
+
+		for _, role := range globalRoles {
+			for _, rel := range allRelations {
+				subject, target := fixture(role, rel, event.StageVoting, event.ResultsVisibilityMembers)
+				want := contains(granted[role], rel)
+				if got := Allowed(p, subject, target); got != want {
+					t.Errorf("Allowed(%s) for %s who is %s = %v, want %v", p, role, rel, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestResultsPolicy checks who outside the event management sees results and progress,
+// for every stage and results visibility
+func TestResultsPolicy(t *testing.T) {
+	members := []relation{relParticipant, relReviewer, relObserver, relOwner}
+	stages := []event.Stage{event.StageCreation, event.StageParticipation, event.StageVoting, event.StageResult}
+
+	tests := []struct {
+		visibility event.ResultsVisibility
+		// results lists the relations that see the results in the result stage
+		results []relation
+	}{
+		{event.ResultsVisibilityManagers, nil},
+		{event.ResultsVisibilityMembers, members},
+		{event.ResultsVisibilityPublic, append([]relation{relOutsider}, members...)},
+		// Events stored before the setting existed behave as members
+		{"", members},
+	}
+
+	for _, tt := range tests {
+		for _, stage := range stages {
+			for _, rel := range allRelations {
+				subject, target := fixture(participant.RoleParticipant, rel, stage, tt.visibility)
+				isManager := rel == relCreator || rel == relCoOrganizer
+
+				wantResults := isManager || (stage == event.StageResult && contains(tt.results, rel))
+				if got := Allowed(ResultsView, subject, target); got != wantResults {
+					t.Errorf("results visibility %q, stage %s: ResultsView for %s = %v, want %v",
+						tt.visibility, stage, rel, got, wantResults)
+				}
+
+				wantProgress := wantResults || rel == relObserver
+				if got := Allowed(ProgressView, subject, target); got != wantProgress {
+					t.Errorf("results visibility %q, stage %s: ProgressView for %s = %v, want %v",
+						tt.visibility, stage, rel, got, wantProgress)
+				}
+			}
+		}
+	}
+}
+
+func TestAllowedWithoutSubjectOrEvent(t *testing.T) {
+	for _, p := range All {
+		if Allowed(p, Subject{Role: participant.RoleAdmin}, Target{}) {
+			t.Errorf("Allowed(%s) without a user = true, want false", p)
+		}
+
+		for _, role := range globalRoles {
+			subject := Subject{UserID: uuid.New(), Role: role}
+			want := role == participant.RoleAdmin || p == EventCreate
+			if got := Allowed(p, subject, Target{}); got != want {
+				t.Errorf("Allowed(%s) for %s without an event = %v, want %v", p, role, got, want)
+			}
+		}
+	}
+}
+
+// TestOrgAdmin checks that org admins hold every permission but PlatformManage, like
+// admins, whatever their global role and relation to the event
+func TestOrgAdmin(t *testing.T) {
+	for _, p := range All {
+		for _, role := range globalRoles {
+			for _, rel := range allRelations {
+				subject, target := fixture(role, rel, event.StageCreation, event.ResultsVisibilityManagers)
+				subject.OrganizationRole = organization.RoleOrgAdmin
+				want := p != PlatformManage || role == participant.RoleAdmin
+				if got := Allowed(p, subject, target); got != want {
+					t.Errorf("Allowed(%s) for org admin %s/%s = %v, want %v", p, role, rel, got, want)
+				}
+
+				subject.OrganizationRole = organization.RoleMember
+				if Allowed(p, subject, target) != Allowed(p, Subject{UserID: subject.UserID, Role: role}, target) {
+					t.Errorf("Allowed(%s) for member %s/%s differs from a subject without organization role", p, role, rel)
+				}
+			}
+		}
+	}
+}
+
+func TestEventScoped(t *testing.T) {
+	for _, p := range All {
+		want := p != EventCreate && p != UsersManage && p != OrganizationManage && p != PlatformManage
+		if got := p.EventScoped(); got != want {
+			t.Errorf("%s.EventScoped() = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Scope is a permission granted to an API key. Sessions of signed-in users are not limited by scopes.
+type Scope string
+
+const (
+	ScopeEventsRead        Scope = "events:read"
+	ScopeEventsWrite       Scope = "events:write"
+	ScopeParticipantsRead  Scope = "participants:read"
+	ScopeParticipantsWrite Scope = "participants:write"
+	ScopeResultsRead       Scope = "results:read"
+)
+
+// Scopes lists every scope an API key can be granted
+var Scopes = []Scope{
+	ScopeEventsRead,
+	ScopeEventsWrite,
+	ScopeParticipantsRead,
+	ScopeParticipantsWrite,
+	ScopeResultsRead,
+}
+
+// IsValid checks if the scope is known
+func (s Scope) IsValid() bool {
+	return slices.Contains(Scopes, s)
+}
+
+// APIKeyPrefix starts every API key, so keys are told apart from JWTs and found by secret scanners
+const APIKeyPrefix = "tsk_"
+
+// apiKeyDisplayLength is how much of the key is kept in clear to recognize it in listings
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// APIKey is a long-lived credential for scripts, acting as its owner within its scopes and
+// the organization it was created in. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null"`
+	UserID         uuid.UUID      `json:"user_id" gorm:"type:uuid;not null"` // owner: a user or a service account
+	Name           string         `json:"name" gorm:"not null"`
+	Prefix         string         `json:"prefix" gorm:"not null"` // first characters of the key, for display
+	KeyHash        string         `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes         pq.StringArray `json:"scopes" gorm:"type:text[];not null"`
+	CreatedBy      *uuid.UUID     `json:"created_by,omitempty" gorm:"type:uuid"`
+	ExpiresAt      *time.Time     `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time     `json:"revoked_at,omitempty"`
+	LastUsedAt     *time.Time     `json:"last_used_at,omitempty"`
+	LastUsedIP     string         `json:"last_used_ip,omitempty" gorm:"column:last_used_ip;not null;default:''"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewAPIKey creates an API key and returns it together with the plain key, which is shown
+// to its creator once and never stored
+func NewAPIKey(organizationID, userID, createdBy uuid.UUID, name string, scopes []Scope, expiresAt *time.Time) (*APIKey, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	plain := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	scopeNames := make(pq.StringArray, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(scopeNames, string(scope)) {
+			scopeNames = append(scopeNames, string(scope))
+		}
+	}
+
+	return &APIKey{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Name:           name,
+		Prefix:         plain[:apiKeyDisplayLength],
+		KeyHash:        HashToken(plain),
+		Scopes:         scopeNames,
+		CreatedBy:      &createdBy,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      time.Now(),
+	}, plain, nil
+}
+
+// IsAPIKey reports whether a bearer credential is an API key rather than a JWT
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// HasScope reports whether the key was granted the scope
+func (k *APIKey) HasScope(scope Scope) bool {
+	return slices.Contains(k.Scopes, string(scope))
+}
+
+// IsRevoked reports whether the key was revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired reports whether the key expired at the given time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Status returns "active", "expired" or "revoked"
+func (k *APIKey) Status(now time.Time) string {
+	switch {
+	case k.IsRevoked():
+		return "revoked"
+	case k.IsExpired(now):
+		return "expired"
+	default:
+		return "active"
+	}
+}
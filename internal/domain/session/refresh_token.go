@@ -0,0 +1,87 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a long-lived, single-use credential exchanged for a new access token.
+// Only the SHA-256 hash of the token is stored. Every refresh replaces the token with a
+// new one of the same family; presenting a token that was already used revokes the family.
+type RefreshToken struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"` // where the login started; refreshes keep it
+	FamilyID       uuid.UUID  `json:"family_id" gorm:"type:uuid;not null"`       // shared by all tokens of one login
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt         *time.Time `json:"used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	UserAgent      string     `json:"user_agent" gorm:"not null;default:''"`
+	IPAddress      string     `json:"ip_address" gorm:"not null;default:''"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewRefreshToken creates a refresh token of the given family and returns it together with
+// the plain token, which is handed to the client once and never stored
+func NewRefreshToken(userID, organizationID, familyID uuid.UUID, ttl time.Duration, userAgent, ipAddress string) (*RefreshToken, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	plain := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	return &RefreshToken{
+		ID:             uuid.New(),
+		UserID:         userID,
+		OrganizationID: organizationID,
+		FamilyID:       familyID,
+		TokenHash:      HashToken(plain),
+		ExpiresAt:      now.Add(ttl),
+		UserAgent:      userAgent,
+		IPAddress:      ipAddress,
+		CreatedAt:      now,
+	}, plain, nil
+}
+
+// HashToken returns the stored form of a plain refresh token
+func HashToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsed reports whether the token was already exchanged
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsRevoked reports whether the token was revoked by a logout or by reuse detection
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired reports whether the token expired at the given time
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,113 @@
+package session
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PurposeTwoFactorChallenge is the purpose of the token handed out after a correct password
+// when the account needs a second factor; it is redeemed with a TOTP or recovery code
+const PurposeTwoFactorChallenge Purpose = "two_factor_challenge"
+
+// RecoveryCodeCount is how many recovery codes are issued at a time
+const RecoveryCodeCount = 10
+
+// recoveryCodeAlphabet leaves out characters that are easily confused: 0/O, 1/I/L
+const recoveryCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// TwoFactor is the TOTP enrolment of a user. It is pending until the user proves their
+// authenticator works by entering a code, and only then required at sign-in.
+type TwoFactor struct {
+	UserID       uuid.UUID  `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Secret       string     `json:"-" gorm:"not null"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep int64      `json:"-" gorm:"not null;default:0"` // last accepted time step, so codes are single-use
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (TwoFactor) TableName() string {
+	return "user_two_factor"
+}
+
+// IsEnabled reports whether the enrolment was confirmed
+func (t *TwoFactor) IsEnabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the authenticator is
+// lost. Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	CodeHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (RecoveryCode) TableName() string {
+	return "two_factor_recovery_codes"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewRecoveryCodes creates a set of recovery codes and returns them together with the
+// plain codes, which are shown to the user once and never stored
+func NewRecoveryCodes(userID uuid.UUID) ([]*RecoveryCode, []string, error) {
+	codes := make([]*RecoveryCode, 0, RecoveryCodeCount)
+	plain := make([]string, 0, RecoveryCodeCount)
+
+	now := time.Now()
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, &RecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(code),
+			CreatedAt: now,
+		})
+		plain = append(plain, code)
+	}
+
+	return codes, plain, nil
+}
+
+// HashRecoveryCode hashes a recovery code as typed by the user, ignoring case, spaces
+// and dashes
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return HashToken(normalized)
+}
+
+// randomRecoveryCode returns a code of ten characters written as XXXXX-XXXXX
+func randomRecoveryCode() (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+
+	code := make([]byte, 0, 11)
+	for i, b := range random {
+		if i == 5 {
+			code = append(code, '-')
+		}
+		code = append(code, recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+	return string(code), nil
+}
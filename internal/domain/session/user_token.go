@@ -0,0 +1,63 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Purpose is what a user token can be used for
+type Purpose string
+
+const (
+	PurposePasswordReset     Purpose = "password_reset"
+	PurposeEmailVerification Purpose = "email_verification"
+)
+
+// UserToken is a single-use, expiring token mailed to a user to prove they own their
+// email address. Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	Purpose   Purpose    `json:"purpose" gorm:"not null"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the table name used by GORM
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// BeforeCreate sets a UUID before creating the record
+func (t *UserToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewUserToken creates a user token and returns it together with the plain token to mail
+func NewUserToken(userID uuid.UUID, purpose Purpose, ttl time.Duration) (*UserToken, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate user token: %w", err)
+	}
+	plain := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	return &UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: HashToken(plain),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, plain, nil
+}
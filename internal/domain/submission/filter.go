@@ -0,0 +1,178 @@
+package submission
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter selects submissions by their answers: field key → expression.
+// All conditions must match. How an expression is read depends on the field type:
+//   - text, long_text, url: case-insensitive substring
+//   - select: exact option
+//   - boolean: "true" or "false"
+//   - number and date: exact value or an inclusive range "from..to" (either side optional)
+//   - multi_select, keywords: comma-separated values, all of which must be present
+type Filter map[string]string
+
+// Validate checks that every filtered field exists and the expressions can be parsed
+func (flt Filter) Validate(form *Form) error {
+	for key, expr := range flt {
+		field, ok := form.Field(key)
+		if !ok {
+			return fmt.Errorf("unknown field %q", key)
+		}
+
+		switch field.Type {
+		case FieldNumber:
+			if _, _, err := parseNumberRange(expr); err != nil {
+				return fmt.Errorf("field %q: %w", key, err)
+			}
+		case FieldBoolean:
+			if _, err := strconv.ParseBool(expr); err != nil {
+				return fmt.Errorf("field %q: expected true or false", key)
+			}
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the answers satisfy every condition of the filter.
+// Call Validate first; conditions on unknown fields never match.
+func (flt Filter) Matches(form *Form, answers Answers) bool {
+	for key, expr := range flt {
+		field, ok := form.Field(key)
+		if !ok {
+			return false
+		}
+		value, answered := answers[key]
+		if !answered || !field.matches(value, expr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (field *Field) matches(value interface{}, expr string) bool {
+	switch field.Type {
+	case FieldNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		from, to, err := parseNumberRange(expr)
+		if err != nil {
+			return false
+		}
+		return (from == nil || n >= *from) && (to == nil || n <= *to)
+
+	case FieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		// ISO dates compare correctly as strings
+		if from, to, isRange := strings.Cut(expr, ".."); isRange {
+			return (from == "" || s >= from) && (to == "" || s <= to)
+		}
+		return s == expr
+
+	case FieldBoolean:
+		b, ok := value.(bool)
+		want, err := strconv.ParseBool(expr)
+		return ok && err == nil && b == want
+
+	case FieldSelect:
+		s, ok := value.(string)
+		return ok && s == expr
+
+	case FieldMultiSelect, FieldKeywords:
+		items, ok := toStrings(value)
+		if !ok {
+			return false
+		}
+		for _, wanted := range strings.Split(expr, ",") {
+			wanted = strings.TrimSpace(wanted)
+			if wanted == "" {
+				continue
+			}
+			found := false
+			for _, item := range items {
+				if strings.EqualFold(item, wanted) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+
+	default:
+		s, ok := value.(string)
+		return ok && strings.Contains(strings.ToLower(s), strings.ToLower(expr))
+	}
+}
+
+// parseNumberRange reads "n", "from..to", "from.." or "..to"
+func parseNumberRange(expr string) (*float64, *float64, error) {
+	parse := func(s string) (*float64, error) {
+		if s == "" {
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return &n, nil
+	}
+
+	fromStr, toStr, isRange := strings.Cut(expr, "..")
+	if !isRange {
+		toStr = fromStr
+	}
+
+	from, err := parse(strings.TrimSpace(fromStr))
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := parse(strings.TrimSpace(toStr))
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// FormatAnswer renders an answer as plain text for exports; lists are joined with "; "
+func FormatAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	if items, ok := toStrings(value); ok {
+		return strings.Join(items, "; ")
+	}
+	return fmt.Sprint(value)
+}
+
+// SpreadsheetSafe prefixes a value that spreadsheets would read as a formula (starting
+// with =, +, -, @, a tab or a carriage return) with an apostrophe, so exported answers
+// are shown as text rather than evaluated
+func SpreadsheetSafe(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,204 @@
+package submission
+
+import (
+	"strings"
+	"testing"
+)
+
+// testAnswers are normalized answers to testForm
+func testAnswers() Answers {
+	return Answers{
+		"title":       "Deep Field Survey",
+		"abstract":    "Imaging of faint galaxies",
+		"hours":       12.5,
+		"remote":      true,
+		"start":       "2026-03-15",
+		"website":     "https://example.org/survey",
+		"band":        "C",
+		"instruments": []string{"camera", "spectrograph"},
+		"keywords":    []interface{}{"galaxies", "Deep"},
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr string
+	}{
+		{"empty", Filter{}, ""},
+		{"every field type", Filter{"title": "x", "hours": "1..2", "remote": "false", "start": "2026-01-01..", "band": "L", "instruments": "camera", "keywords": "a,b"}, ""},
+		{"unknown field", Filter{"missing": "x"}, `unknown field "missing"`},
+		{"number not a number", Filter{"hours": "many"}, `field "hours"`},
+		{"number range side not a number", Filter{"hours": "1..x"}, `field "hours"`},
+		{"boolean not a boolean", Filter{"remote": "maybe"}, "expected true or false"},
+	}
+
+	form := testForm()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate(form)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"no conditions", Filter{}, true},
+
+		// text, long_text and url: case-insensitive substring
+		{"text substring", Filter{"title": "field"}, true},
+		{"text case-insensitive", Filter{"title": "DEEP"}, true},
+		{"text no match", Filter{"title": "nebula"}, false},
+		{"long text substring", Filter{"abstract": "faint"}, true},
+		{"url substring", Filter{"website": "example.org"}, true},
+		{"url no match", Filter{"website": "example.com"}, false},
+
+		// select: exact option
+		{"select exact", Filter{"band": "C"}, true},
+		{"select other option", Filter{"band": "L"}, false},
+		{"select is not a substring match", Filter{"band": ""}, false},
+
+		// boolean
+		{"boolean true", Filter{"remote": "true"}, true},
+		{"boolean false", Filter{"remote": "false"}, false},
+		{"boolean unparsable", Filter{"remote": "yes"}, false},
+
+		// number: exact value or inclusive range
+		{"number exact", Filter{"hours": "12.5"}, true},
+		{"number exact other", Filter{"hours": "12"}, false},
+		{"number range inside", Filter{"hours": "10..20"}, true},
+		{"number range inclusive low", Filter{"hours": "12.5..20"}, true},
+		{"number range inclusive high", Filter{"hours": "1..12.5"}, true},
+		{"number range outside", Filter{"hours": "13..20"}, false},
+		{"number open high", Filter{"hours": "10.."}, true},
+		{"number open low", Filter{"hours": "..10"}, false},
+		{"number with spaces", Filter{"hours": " 10 .. 20 "}, true},
+		{"number unparsable", Filter{"hours": "abc"}, false},
+
+		// date: exact value or inclusive range
+		{"date exact", Filter{"start": "2026-03-15"}, true},
+		{"date exact other", Filter{"start": "2026-03-16"}, false},
+		{"date range inside", Filter{"start": "2026-03-01..2026-03-31"}, true},
+		{"date range inclusive", Filter{"start": "2026-03-15..2026-03-15"}, true},
+		{"date range outside", Filter{"start": "2026-04-01..2026-04-30"}, false},
+		{"date open high", Filter{"start": "2026-01-01.."}, true},
+		{"date open low", Filter{"start": "..2026-03-14"}, false},
+
+		// multi_select and keywords: every listed value present, case-insensitive
+		{"multi select one value", Filter{"instruments": "camera"}, true},
+		{"multi select all values", Filter{"instruments": "camera, spectrograph"}, true},
+		{"multi select missing value", Filter{"instruments": "camera,radio"}, false},
+		{"multi select case-insensitive", Filter{"instruments": "CAMERA"}, true},
+		{"multi select empty items ignored", Filter{"instruments": "camera,,"}, true},
+		{"keywords decoded from JSON", Filter{"keywords": "deep,galaxies"}, true},
+		{"keywords missing", Filter{"keywords": "stars"}, false},
+
+		// every condition must hold
+		{"all conditions match", Filter{"band": "C", "remote": "true", "hours": "..20"}, true},
+		{"one condition fails", Filter{"band": "C", "remote": "false"}, false},
+
+		{"unknown field", Filter{"missing": "x"}, false},
+	}
+
+	form := testForm()
+	answers := testAnswers()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(form, answers); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatchesUnansweredAndMistypedAnswers(t *testing.T) {
+	form := testForm()
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		answers Answers
+	}{
+		{"unanswered text", Filter{"abstract": ""}, Answers{}},
+		{"unanswered boolean", Filter{"remote": "false"}, Answers{}},
+		{"number stored as string", Filter{"hours": "12"}, Answers{"hours": "12"}},
+		{"date stored as number", Filter{"start": "2026-01-01"}, Answers{"start": 2026.0}},
+		{"boolean stored as string", Filter{"remote": "true"}, Answers{"remote": "true"}},
+		{"select stored as list", Filter{"band": "C"}, Answers{"band": []string{"C"}}},
+		{"list stored as string", Filter{"instruments": "camera"}, Answers{"instruments": "camera"}},
+		{"text stored as number", Filter{"title": "1"}, Answers{"title": 1.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.filter.Matches(form, tt.answers) {
+				t.Errorf("Matches(%v) on %v = true, want false", tt.filter, tt.answers)
+			}
+		})
+	}
+}
+
+func TestFormatAnswer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"string", "Orion", "Orion"},
+		{"boolean", true, "true"},
+		{"integer number", 12.0, "12"},
+		{"fractional number", 0.25, "0.25"},
+		{"large number", 1e21, "1000000000000000000000"},
+		{"string list", []string{"a", "b"}, "a; b"},
+		{"decoded list", []interface{}{"a", "b"}, "a; b"},
+		{"other", map[string]int{"x": 1}, "map[x:1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAnswer(tt.value); got != tt.want {
+				t.Errorf("FormatAnswer(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpreadsheetSafe(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Orion", "Orion"},
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+		{" =1", " =1"},
+		{"'quoted", "'quoted"},
+	}
+
+	for _, tt := range tests {
+		if got := SpreadsheetSafe(tt.value); got != tt.want {
+			t.Errorf("SpreadsheetSafe(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package submission
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldType is the kind of value a form field accepts
+type FieldType string
+
+const (
+	FieldText        FieldType = "text"
+	FieldLongText    FieldType = "long_text"
+	FieldNumber      FieldType = "number"
+	FieldBoolean     FieldType = "boolean"
+	FieldDate        FieldType = "date" // YYYY-MM-DD
+	FieldURL         FieldType = "url"
+	FieldSelect      FieldType = "select"
+	FieldMultiSelect FieldType = "multi_select"
+	FieldKeywords    FieldType = "keywords" // free-form list of short strings
+)
+
+// IsValid checks if the field type is supported
+func (t FieldType) IsValid() bool {
+	switch t {
+	case FieldText, FieldLongText, FieldNumber, FieldBoolean, FieldDate, FieldURL,
+		FieldSelect, FieldMultiSelect, FieldKeywords:
+		return true
+	}
+	return false
+}
+
+// IsList reports whether answers to the field are lists of strings
+func (t FieldType) IsList() bool {
+	return t == FieldMultiSelect || t == FieldKeywords
+}
+
+// Keys of the standard proposal fields
+const (
+	FieldKeyTitle              = "title"
+	FieldKeyAbstract           = "abstract"
+	FieldKeyKeywords           = "keywords"
+	FieldKeyRequestedResources = "requested_resources"
+)
+
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Field describes one question of a submission form and its validation rules
+type Field struct {
+	Key         string    `json:"key"`
+	Label       string    `json:"label"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Required    bool      `json:"required"`
+
+	// Text rules (text, long_text, url) and per-item rules (keywords)
+	MinLength *int   `json:"min_length,omitempty"`
+	MaxLength *int   `json:"max_length,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	// Number rules
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// Choices for select and multi_select
+	Options []string `json:"options,omitempty"`
+
+	// List rules (multi_select, keywords)
+	MaxItems *int `json:"max_items,omitempty"`
+}
+
+// Form is the submission form schema of an event
+type Form struct {
+	Fields []Field `json:"fields"`
+}
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+// DefaultForm returns the standard proposal fields: title, abstract, keywords and
+// requested resources. Organizers can start from it and append custom fields.
+func DefaultForm() *Form {
+	return &Form{Fields: []Field{
+		{Key: FieldKeyTitle, Label: "Title", Type: FieldText, Required: true, MaxLength: intPtr(200)},
+		{Key: FieldKeyAbstract, Label: "Abstract", Type: FieldLongText, Required: true, MaxLength: intPtr(5000)},
+		{Key: FieldKeyKeywords, Label: "Keywords", Type: FieldKeywords, MaxItems: intPtr(10), MaxLength: intPtr(50)},
+		{Key: FieldKeyRequestedResources, Label: "Requested resources (hours)", Type: FieldNumber, Min: floatPtr(0)},
+	}}
+}
+
+// Field returns the field with the given key
+func (f *Form) Field(key string) (*Field, bool) {
+	for i := range f.Fields {
+		if f.Fields[i].Key == key {
+			return &f.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate checks that the schema itself is well formed
+func (f *Form) Validate() error {
+	if len(f.Fields) == 0 {
+		return errors.New("the form must have at least one field")
+	}
+	if len(f.Fields) > 50 {
+		return errors.New("the form cannot have more than 50 fields")
+	}
+
+	seen := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if !fieldKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("field key %q must be lowercase letters, digits and underscores, starting with a letter", field.Key)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("duplicate field key %q", field.Key)
+		}
+		seen[field.Key] = true
+
+		if strings.TrimSpace(field.Label) == "" {
+			return fmt.Errorf("field %q needs a label", field.Key)
+		}
+		if !field.Type.IsValid() {
+			return fmt.Errorf("field %q has unsupported type %q", field.Key, field.Type)
+		}
+		if (field.Type == FieldSelect || field.Type == FieldMultiSelect) && len(field.Options) == 0 {
+			return fmt.Errorf("field %q needs at least one option", field.Key)
+		}
+		if field.MinLength != nil && field.MaxLength != nil && *field.MinLength > *field.MaxLength {
+			return fmt.Errorf("field %q has min_length greater than max_length", field.Key)
+		}
+		if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+			return fmt.Errorf("field %q has min greater than max", field.Key)
+		}
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return fmt.Errorf("field %q has an invalid pattern: %w", field.Key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BreakingChanges lists the changes from f to next that would strand answers already
+// given: answered fields that are removed or change type, and options removed while
+// answers still use them. Other changes apply when the answers are next edited.
+func (f *Form) BreakingChanges(next *Form, answers []Answers) []string {
+	var changes []string
+	for _, field := range f.Fields {
+		used := usedValues(field.Key, answers)
+		if len(used) == 0 {
+			continue
+		}
+
+		updated, ok := next.Field(field.Key)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("field %q is removed but has answers", field.Key))
+			continue
+		}
+		if updated.Type != field.Type {
+			changes = append(changes, fmt.Sprintf("field %q changes type from %s to %s but has answers", field.Key, field.Type, updated.Type))
+			continue
+		}
+
+		if field.Type == FieldSelect || field.Type == FieldMultiSelect {
+			for _, option := range field.Options {
+				if used[option] && !contains(updated.Options, option) {
+					changes = append(changes, fmt.Sprintf("option %q of field %q is removed but is used by answers", option, field.Key))
+				}
+			}
+		}
+	}
+	return changes
+}
+
+// usedValues returns the answers given to a field, each list item counted on its own
+func usedValues(key string, answers []Answers) map[string]bool {
+	used := make(map[string]bool)
+	for _, a := range answers {
+		value, ok := a[key]
+		if !ok || isEmpty(value) {
+			continue
+		}
+		if items, ok := toStrings(value); ok {
+			for _, item := range items {
+				used[item] = true
+			}
+			continue
+		}
+		used[FormatAnswer(value)] = true
+	}
+	return used
+}
+
+// Value implements driver.Valuer for JSONB storage
+func (f Form) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal submission form: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner for JSONB storage
+func (f *Form) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// Answers holds the answers of a submission keyed by field key
+type Answers map[string]interface{}
+
+// Value implements driver.Valuer for JSONB storage
+func (a Answers) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal submission answers: %w", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner for JSONB storage
+func (a *Answers) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	return scanJSON(value, a)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("failed to scan JSONB value: unexpected type %T", value)
+	}
+}
+
+// ValidationError lists the fields whose answers are invalid
+type ValidationError struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + ": " + e.Fields[key]
+	}
+	return "invalid submission answers: " + strings.Join(parts, "; ")
+}
+
+// ValidateAnswers checks the answers against the form and returns them normalized:
+// unknown keys are rejected, empty optional answers are dropped, numbers become float64
+// and list answers become []string
+func (f *Form) ValidateAnswers(answers Answers) (Answers, error) {
+	problems := make(map[string]string)
+	normalized := make(Answers, len(answers))
+
+	for key := range answers {
+		if _, ok := f.Field(key); !ok {
+			problems[key] = "unknown field"
+		}
+	}
+
+	for _, field := range f.Fields {
+		raw, present := answers[field.Key]
+		if !present || isEmpty(raw) {
+			if field.Required {
+				problems[field.Key] = "is required"
+			}
+			continue
+		}
+
+		value, problem := field.normalize(raw)
+		if problem != "" {
+			problems[field.Key] = problem
+			continue
+		}
+		normalized[field.Key] = value
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Fields: problems}
+	}
+	return normalized, nil
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+// normalize validates a single non-empty answer and returns its stored form
+func (field *Field) normalize(raw interface{}) (interface{}, string) {
+	switch field.Type {
+	case FieldText, FieldLongText, FieldURL, FieldDate, FieldSelect:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, "must be a string"
+		}
+		s = strings.TrimSpace(s)
+		if problem := field.checkString(s); problem != "" {
+			return nil, problem
+		}
+		return s, ""
+
+	case FieldNumber:
+		n, ok := raw.(float64)
+		if !ok {
+			return nil, "must be a number"
+		}
+		if field.Min != nil && n < *field.Min {
+			return nil, fmt.Sprintf("must be at least %g", *field.Min)
+		}
+		if field.Max != nil && n > *field.Max {
+			return nil, fmt.Sprintf("must be at most %g", *field.Max)
+		}
+		return n, ""
+
+	case FieldBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, "must be true or false"
+		}
+		return b, ""
+
+	case FieldMultiSelect, FieldKeywords:
+		items, ok := toStrings(raw)
+		if !ok {
+			return nil, "must be a list of strings"
+		}
+		if field.MaxItems != nil && len(items) > *field.MaxItems {
+			return nil, fmt.Sprintf("must have at most %d items", *field.MaxItems)
+		}
+		for _, item := range items {
+			if problem := field.checkString(item); problem != "" {
+				return nil, fmt.Sprintf("item %q %s", item, problem)
+			}
+		}
+		return items, ""
+	}
+
+	return nil, "has an unsupported type"
+}
+
+func (field *Field) checkString(s string) string {
+	length := utf8.RuneCountInString(s)
+	if field.Type != FieldSelect && field.Type != FieldMultiSelect {
+		if field.MinLength != nil && length < *field.MinLength {
+			return fmt.Sprintf("must be at least %d characters", *field.MinLength)
+		}
+		if field.MaxLength != nil && length > *field.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", *field.MaxLength)
+		}
+	}
+
+	switch field.Type {
+	case FieldDate:
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return "must be a date formatted as YYYY-MM-DD"
+		}
+	case FieldURL:
+		if u, err := url.ParseRequestURI(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return "must be an http(s) URL"
+		}
+	case FieldSelect, FieldMultiSelect:
+		if !contains(field.Options, s) {
+			return "is not one of the allowed options"
+		}
+	}
+
+	if field.Pattern != "" {
+		if re, err := regexp.Compile(field.Pattern); err == nil && !re.MatchString(s) {
+			return "does not match the required format"
+		}
+	}
+
+	return ""
+}
+
+// toStrings converts a decoded JSON list to trimmed, de-duplicated strings
+func toStrings(raw interface{}) ([]string, bool) {
+	var values []interface{}
+	switch v := raw.(type) {
+	case []interface{}:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	default:
+		return nil, false
+	}
+
+	items := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		items = append(items, s)
+	}
+	return items, true
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+package submission
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testForm has one field of every type
+func testForm() *Form {
+	return &Form{Fields: []Field{
+		{Key: "title", Label: "Title", Type: FieldText, Required: true, MinLength: intPtr(3), MaxLength: intPtr(20)},
+		{Key: "abstract", Label: "Abstract", Type: FieldLongText, MaxLength: intPtr(50)},
+		{Key: "hours", Label: "Hours", Type: FieldNumber, Min: floatPtr(0), Max: floatPtr(100)},
+		{Key: "remote", Label: "Remote", Type: FieldBoolean},
+		{Key: "start", Label: "Start", Type: FieldDate},
+		{Key: "website", Label: "Website", Type: FieldURL},
+		{Key: "band", Label: "Band", Type: FieldSelect, Options: []string{"L", "C", "X"}},
+		{Key: "instruments", Label: "Instruments", Type: FieldMultiSelect, Options: []string{"camera", "spectrograph", "radio"}, MaxItems: intPtr(2)},
+		{Key: "keywords", Label: "Keywords", Type: FieldKeywords, MaxItems: intPtr(3), MaxLength: intPtr(10)},
+		{Key: "code", Label: "Code", Type: FieldText, Pattern: `^[A-Z]{2}-\d+$`},
+	}}
+}
+
+func TestFieldTypeIsValid(t *testing.T) {
+	valid := []FieldType{FieldText, FieldLongText, FieldNumber, FieldBoolean, FieldDate, FieldURL, FieldSelect, FieldMultiSelect, FieldKeywords}
+	for _, fieldType := range valid {
+		if !fieldType.IsValid() {
+			t.Errorf("%q.IsValid() = false, want true", fieldType)
+		}
+	}
+
+	for _, fieldType := range []FieldType{"", "file", "TEXT"} {
+		if fieldType.IsValid() {
+			t.Errorf("%q.IsValid() = true, want false", fieldType)
+		}
+	}
+}
+
+func TestFormValidate(t *testing.T) {
+	tooMany := &Form{}
+	for i := 0; i < 51; i++ {
+		tooMany.Fields = append(tooMany.Fields, Field{Key: "f" + strings.Repeat("x", i), Label: "F", Type: FieldText})
+	}
+
+	tests := []struct {
+		name    string
+		form    *Form
+		wantErr string
+	}{
+		{"default form", DefaultForm(), ""},
+		{"every field type", testForm(), ""},
+		{"no fields", &Form{}, "at least one field"},
+		{"too many fields", tooMany, "more than 50 fields"},
+		{"uppercase key", &Form{Fields: []Field{{Key: "Title", Label: "T", Type: FieldText}}}, "lowercase"},
+		{"key starting with a digit", &Form{Fields: []Field{{Key: "1st", Label: "T", Type: FieldText}}}, "lowercase"},
+		{"duplicate key", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldText}, {Key: "a", Label: "B", Type: FieldText}}}, "duplicate field key"},
+		{"blank label", &Form{Fields: []Field{{Key: "a", Label: "  ", Type: FieldText}}}, "needs a label"},
+		{"unknown type", &Form{Fields: []Field{{Key: "a", Label: "A", Type: "file"}}}, "unsupported type"},
+		{"select without options", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldSelect}}}, "at least one option"},
+		{"multi_select without options", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldMultiSelect}}}, "at least one option"},
+		{"min_length above max_length", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldText, MinLength: intPtr(5), MaxLength: intPtr(2)}}}, "min_length greater than max_length"},
+		{"min above max", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldNumber, Min: floatPtr(5), Max: floatPtr(1)}}}, "min greater than max"},
+		{"invalid pattern", &Form{Fields: []Field{{Key: "a", Label: "A", Type: FieldText, Pattern: "("}}}, "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.form.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers Answers
+		want    Answers // normalized answers when valid
+		invalid string  // key of the field reported invalid
+	}{
+		{"text trimmed", Answers{"title": "  Orion  "}, Answers{"title": "Orion"}, ""},
+		{"text too short", Answers{"title": "ab"}, nil, "title"},
+		{"text too long", Answers{"title": strings.Repeat("a", 21)}, nil, "title"},
+		{"text length counts runes", Answers{"title": strings.Repeat("é", 20)}, Answers{"title": strings.Repeat("é", 20)}, ""},
+		{"text not a string", Answers{"title": 42.0}, nil, "title"},
+		{"required missing", Answers{}, nil, "title"},
+		{"required blank", Answers{"title": "   "}, nil, "title"},
+		{"unknown field", Answers{"title": "Orion", "extra": "x"}, nil, "extra"},
+		{"optional empty dropped", Answers{"title": "Orion", "abstract": "", "keywords": []interface{}{}, "hours": nil}, Answers{"title": "Orion"}, ""},
+
+		{"long text", Answers{"title": "Orion", "abstract": "A study of the nebula"}, Answers{"title": "Orion", "abstract": "A study of the nebula"}, ""},
+		{"long text too long", Answers{"title": "Orion", "abstract": strings.Repeat("a", 51)}, nil, "abstract"},
+
+		{"number", Answers{"title": "Orion", "hours": 12.5}, Answers{"title": "Orion", "hours": 12.5}, ""},
+		{"number at bounds", Answers{"title": "Orion", "hours": 100.0}, Answers{"title": "Orion", "hours": 100.0}, ""},
+		{"number below min", Answers{"title": "Orion", "hours": -1.0}, nil, "hours"},
+		{"number above max", Answers{"title": "Orion", "hours": 100.5}, nil, "hours"},
+		{"number as string", Answers{"title": "Orion", "hours": "12"}, nil, "hours"},
+
+		{"boolean", Answers{"title": "Orion", "remote": false}, Answers{"title": "Orion", "remote": false}, ""},
+		{"boolean as string", Answers{"title": "Orion", "remote": "true"}, nil, "remote"},
+
+		{"date", Answers{"title": "Orion", "start": "2026-03-01"}, Answers{"title": "Orion", "start": "2026-03-01"}, ""},
+		{"date wrong format", Answers{"title": "Orion", "start": "01/03/2026"}, nil, "start"},
+		{"date out of range", Answers{"title": "Orion", "start": "2026-02-30"}, nil, "start"},
+
+		{"url", Answers{"title": "Orion", "website": "https://example.org/p"}, Answers{"title": "Orion", "website": "https://example.org/p"}, ""},
+		{"url other scheme", Answers{"title": "Orion", "website": "ftp://example.org"}, nil, "website"},
+		{"url relative", Answers{"title": "Orion", "website": "example.org"}, nil, "website"},
+
+		{"select", Answers{"title": "Orion", "band": "C"}, Answers{"title": "Orion", "band": "C"}, ""},
+		{"select unknown option", Answers{"title": "Orion", "band": "K"}, nil, "band"},
+		{"select is case sensitive", Answers{"title": "Orion", "band": "c"}, nil, "band"},
+
+		{"multi select", Answers{"title": "Orion", "instruments": []interface{}{"camera", "radio"}}, Answers{"title": "Orion", "instruments": []string{"camera", "radio"}}, ""},
+		{"multi select de-duplicated", Answers{"title": "Orion", "instruments": []interface{}{"camera", " camera ", "radio"}}, Answers{"title": "Orion", "instruments": []string{"camera", "radio"}}, ""},
+		{"multi select unknown option", Answers{"title": "Orion", "instruments": []interface{}{"laser"}}, nil, "instruments"},
+		{"multi select too many", Answers{"title": "Orion", "instruments": []interface{}{"camera", "radio", "spectrograph"}}, nil, "instruments"},
+		{"multi select not a list", Answers{"title": "Orion", "instruments": "camera"}, nil, "instruments"},
+
+		{"keywords", Answers{"title": "Orion", "keywords": []interface{}{"nebula", "stars"}}, Answers{"title": "Orion", "keywords": []string{"nebula", "stars"}}, ""},
+		{"keywords item too long", Answers{"title": "Orion", "keywords": []interface{}{"spectroscopy"}}, nil, "keywords"},
+		{"keywords too many", Answers{"title": "Orion", "keywords": []interface{}{"a", "b", "c", "d"}}, nil, "keywords"},
+		{"keywords non-string item", Answers{"title": "Orion", "keywords": []interface{}{"a", 1.0}}, nil, "keywords"},
+
+		{"pattern", Answers{"title": "Orion", "code": "AB-12"}, Answers{"title": "Orion", "code": "AB-12"}, ""},
+		{"pattern mismatch", Answers{"title": "Orion", "code": "ab12"}, nil, "code"},
+	}
+
+	form := testForm()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := form.ValidateAnswers(tt.answers)
+			if tt.invalid == "" {
+				if err != nil {
+					t.Fatalf("ValidateAnswers() = %v, want nil", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ValidateAnswers() = %#v, want %#v", got, tt.want)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateAnswers() = %v, want a *ValidationError", err)
+			}
+			if _, ok := validationErr.Fields[tt.invalid]; !ok {
+				t.Errorf("ValidateAnswers() reported %v, want field %q", validationErr.Fields, tt.invalid)
+			}
+		})
+	}
+}
+
+func TestValidationErrorListsFieldsInOrder(t *testing.T) {
+	err := &ValidationError{Fields: map[string]string{"title": "is required", "band": "is not one of the allowed options"}}
+
+	want := "invalid submission answers: band: is not one of the allowed options; title: is required"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFormJSONRoundTrip(t *testing.T) {
+	form := testForm()
+
+	value, err := form.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var scanned Form
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !reflect.DeepEqual(&scanned, form) {
+		t.Errorf("Scan(Value()) = %#v, want %#v", scanned, form)
+	}
+
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Scan of an int returned no error")
+	}
+}
+
+func TestAnswersNullRoundTrip(t *testing.T) {
+	var answers Answers
+	if value, err := answers.Value(); value != nil || err != nil {
+		t.Errorf("nil Answers Value() = %v, %v; want nil, nil", value, err)
+	}
+
+	answers = Answers{"title": "x"}
+	if err := answers.Scan(nil); err != nil || answers != nil {
+		t.Errorf("Scan(nil) = %v, answers %v; want nil answers", err, answers)
+	}
+}
+
+func TestBreakingChanges(t *testing.T) {
+	current := testForm()
+	answers := []Answers{
+		{"title": "Orion", "band": "C", "instruments": []interface{}{"camera"}},
+		{"title": "Vega", "hours": 3.0, "instruments": []string{"radio"}, "abstract": ""},
+	}
+
+	edit := func(change func(f *Form)) *Form {
+		f := testForm()
+		change(f)
+		return f
+	}
+	remove := func(key string) func(f *Form) {
+		return func(f *Form) {
+			for i, field := range f.Fields {
+				if field.Key == key {
+					f.Fields = append(f.Fields[:i], f.Fields[i+1:]...)
+					return
+				}
+			}
+		}
+	}
+	field := func(f *Form, key string) *Field {
+		found, _ := f.Field(key)
+		return found
+	}
+
+	tests := []struct {
+		name string
+		next *Form
+		want []string
+	}{
+		{"unchanged", testForm(), nil},
+		{"answered field removed", edit(remove("hours")), []string{`field "hours" is removed`}},
+		{"unanswered field removed", edit(remove("website")), nil},
+		{"field answered only with empty values removed", edit(remove("abstract")), nil},
+		{"answered field retyped", edit(func(f *Form) { field(f, "title").Type = FieldLongText }), []string{`field "title" changes type from text to long_text`}},
+		{"unanswered field retyped", edit(func(f *Form) { field(f, "start").Type = FieldText }), nil},
+		{"used select option removed", edit(func(f *Form) { field(f, "band").Options = []string{"L", "X"} }), []string{`option "C" of field "band"`}},
+		{"unused select option removed", edit(func(f *Form) { field(f, "band").Options = []string{"C"} }), nil},
+		{"used multi select option removed", edit(func(f *Form) { field(f, "instruments").Options = []string{"camera", "spectrograph"} }), []string{`option "radio" of field "instruments"`}},
+		{"options added", edit(func(f *Form) { field(f, "band").Options = append(field(f, "band").Options, "K") }), nil},
+		{"rules tightened", edit(func(f *Form) { field(f, "title").MaxLength = intPtr(4); field(f, "hours").Max = floatPtr(1) }), nil},
+		{"field added", edit(func(f *Form) { f.Fields = append(f.Fields, Field{Key: "notes", Label: "Notes", Type: FieldLongText}) }), nil},
+		{"several changes", edit(func(f *Form) { remove("hours")(f); field(f, "title").Type = FieldURL }), []string{`field "title"`, `field "hours"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := current.BreakingChanges(tt.next, answers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("BreakingChanges() = %q, want %d changes", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("change %d = %q, want it to mention %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakingChangesWithoutAnswers(t *testing.T) {
+	if got := testForm().BreakingChanges(&Form{Fields: []Field{{Key: "other", Label: "Other", Type: FieldBoolean}}}, nil); len(got) != 0 {
+		t.Errorf("BreakingChanges() without answers = %q, want none", got)
+	}
+}
@@ -0,0 +1,293 @@
+package vote
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoAssignments is returned for reliability reports of events without assignments
+var ErrNoAssignments = errors.New("no assignments found for this event")
+
+// ReliabilityReport summarizes how consistent the evaluators were with each other
+// and how much the incentive system reshaped the final ranking
+type ReliabilityReport struct {
+	EventID uuid.UUID `json:"event_id"`
+
+	// Krippendorff's alpha (interval metric) over normalized Borda points of
+	// attachments evaluated by at least two reviewers. 1 = perfect agreement,
+	// 0 = agreement expected by chance, negative = systematic disagreement.
+	KrippendorffAlpha *float64 `json:"krippendorff_alpha"`
+	PairableUnits     int      `json:"pairable_units"`  // attachments with >= 2 evaluations
+	PairableValues    int      `json:"pairable_values"` // votes on those attachments
+
+	// Kendall's tau-b between the global ranking G and the adjusted ranking G'
+	GlobalAdjustedKendallTau *float64 `json:"global_adjusted_kendall_tau"`
+
+	Connectivity AssignmentConnectivity `json:"connectivity"`
+
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// AssignmentConnectivity describes the bipartite reviewer–attachment co-assignment graph.
+// Rankings from separate components cannot be compared on a common scale.
+type AssignmentConnectivity struct {
+	IsConnected           bool     `json:"is_connected"`
+	Components            int      `json:"components"`
+	LargestComponentSize  int      `json:"largest_component_size"`
+	Reviewers             int      `json:"reviewers"`
+	Attachments           int      `json:"attachments"`
+	UnassignedAttachments []string `json:"unassigned_attachments"`
+}
+
+// CalculateReliabilityReport computes inter-rater agreement, ranking stability and
+// assignment connectivity for an event. results may be nil when no ranking exists yet.
+func (vs *VotingService) CalculateReliabilityReport(eventID uuid.UUID, results *VotingResults) (*ReliabilityReport, error) {
+	votes, err := vs.voteRepo.GetByEventID(eventID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes: %w", err)
+	}
+
+	assignments, err := vs.voteRepo.GetAssignmentsByEventID(eventID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	attachments, err := vs.attachmentRepo.GetByEventID(eventID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	if len(assignments) == 0 {
+		return nil, ErrNoAssignments
+	}
+
+	attachmentIDs := make([]uuid.UUID, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentIDs = append(attachmentIDs, attachment.GetID())
+	}
+
+	report := &ReliabilityReport{
+		EventID:      eventID,
+		Connectivity: checkAssignmentConnectivity(assignments, attachmentIDs),
+		CalculatedAt: time.Now(),
+	}
+
+	alpha, units, values := krippendorffAlpha(normalizedBallots(votes))
+	report.KrippendorffAlpha = alpha
+	report.PairableUnits = units
+	report.PairableValues = values
+
+	if results != nil && len(results.AdjustedRanking) > 1 {
+		globalRanks := make(map[uuid.UUID]int, len(results.AdjustedRanking))
+		adjustedRanks := make(map[uuid.UUID]int, len(results.AdjustedRanking))
+		for _, result := range results.AdjustedRanking {
+			globalRanks[result.AttachmentID] = result.GlobalRank
+			adjustedRanks[result.AttachmentID] = result.AdjustedRank
+		}
+		report.GlobalAdjustedKendallTau = kendallTauB(globalRanks, adjustedRanks)
+	}
+
+	return report, nil
+}
+
+// normalizedBallots converts each voter's rank positions into Borda points scaled to [0, 1]
+// so that ballots of different sizes can be compared: (m - R) / (m - 1)
+func normalizedBallots(votes []*Vote) map[uuid.UUID]map[uuid.UUID]float64 {
+	byVoter := make(map[uuid.UUID][]*Vote)
+	for _, v := range votes {
+		byVoter[v.VoterID] = append(byVoter[v.VoterID], v)
+	}
+
+	// unit (attachment) -> coder (voter) -> value
+	units := make(map[uuid.UUID]map[uuid.UUID]float64)
+	for voterID, ballot := range byVoter {
+		m := len(ballot)
+		if m < 2 {
+			continue
+		}
+		for _, v := range ballot {
+			if units[v.AttachmentID] == nil {
+				units[v.AttachmentID] = make(map[uuid.UUID]float64)
+			}
+			units[v.AttachmentID][voterID] = float64(m-v.RankPosition) / float64(m-1)
+		}
+	}
+
+	return units
+}
+
+// krippendorffAlpha computes alpha with the interval distance metric.
+// Returns nil when there is not enough overlap (or no variance) to estimate agreement.
+func krippendorffAlpha(units map[uuid.UUID]map[uuid.UUID]float64) (*float64, int, int) {
+	var pairable [][]float64
+	n := 0
+	for _, coders := range units {
+		if len(coders) < 2 {
+			continue
+		}
+		values := make([]float64, 0, len(coders))
+		for _, value := range coders {
+			values = append(values, value)
+		}
+		pairable = append(pairable, values)
+		n += len(values)
+	}
+
+	if len(pairable) == 0 || n < 2 {
+		return nil, len(pairable), n
+	}
+
+	// Observed disagreement: within-unit squared differences weighted by 1/(m_u - 1)
+	var observed float64
+	var all []float64
+	for _, values := range pairable {
+		var sum float64
+		for i := range values {
+			for j := range values {
+				if i != j {
+					d := values[i] - values[j]
+					sum += d * d
+				}
+			}
+		}
+		observed += sum / float64(len(values)-1)
+		all = append(all, values...)
+	}
+	observed /= float64(n)
+
+	// Expected disagreement: squared differences across all pairable values
+	var expected float64
+	for i := range all {
+		for j := range all {
+			if i != j {
+				d := all[i] - all[j]
+				expected += d * d
+			}
+		}
+	}
+	expected /= float64(n) * float64(n-1)
+
+	if expected == 0 {
+		return nil, len(pairable), n
+	}
+
+	alpha := 1 - observed/expected
+	return &alpha, len(pairable), n
+}
+
+// kendallTauB computes Kendall's tau-b between two rankings over their common keys
+func kendallTauB(a, b map[uuid.UUID]int) *float64 {
+	keys := make([]uuid.UUID, 0, len(a))
+	for id := range a {
+		if _, ok := b[id]; ok {
+			keys = append(keys, id)
+		}
+	}
+	if len(keys) < 2 {
+		return nil
+	}
+
+	var concordant, discordant, tiesA, tiesB float64
+	for i := 0; i < len(keys); i++ {
+		for j := i + 1; j < len(keys); j++ {
+			da := a[keys[i]] - a[keys[j]]
+			db := b[keys[i]] - b[keys[j]]
+			switch {
+			case da == 0 && db == 0:
+				// tied in both rankings, excluded from both denominators
+			case da == 0:
+				tiesA++
+			case db == 0:
+				tiesB++
+			case (da > 0) == (db > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	denominator := math.Sqrt((concordant + discordant + tiesA) * (concordant + discordant + tiesB))
+	if denominator == 0 {
+		return nil
+	}
+
+	tau := (concordant - discordant) / denominator
+	return &tau
+}
+
+// checkAssignmentConnectivity runs a union-find over the bipartite graph whose nodes are
+// reviewers and attachments and whose edges are assignments
+func checkAssignmentConnectivity(assignments []*Assignment, attachmentIDs []uuid.UUID) AssignmentConnectivity {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(x string) string {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	union := func(x, y string) {
+		rx, ry := find(x), find(y)
+		if rx != ry {
+			parent[rx] = ry
+		}
+	}
+	add := func(x string) {
+		if _, ok := parent[x]; !ok {
+			parent[x] = x
+		}
+	}
+
+	for _, id := range attachmentIDs {
+		add("a:" + id.String())
+	}
+
+	assigned := make(map[string]bool)
+	reviewers := 0
+	for _, assignment := range assignments {
+		reviewer := "r:" + assignment.ParticipantID.String()
+		if _, ok := parent[reviewer]; !ok {
+			reviewers++
+		}
+		add(reviewer)
+		for _, attachmentID := range assignment.AttachmentIDs {
+			node := "a:" + attachmentID
+			add(node)
+			union(reviewer, node)
+			assigned[attachmentID] = true
+		}
+	}
+
+	sizes := make(map[string]int)
+	for node := range parent {
+		sizes[find(node)]++
+	}
+
+	largest := 0
+	for _, size := range sizes {
+		largest = max(largest, size)
+	}
+
+	unassigned := []string{}
+	for _, id := range attachmentIDs {
+		if !assigned[id.String()] {
+			unassigned = append(unassigned, id.String())
+		}
+	}
+	sort.Strings(unassigned)
+
+	return AssignmentConnectivity{
+		IsConnected:           len(sizes) <= 1,
+		Components:            len(sizes),
+		LargestComponentSize:  largest,
+		Reviewers:             reviewers,
+		Attachments:           len(parent) - reviewers,
+		UnassignedAttachments: unassigned,
+	}
+}
@@ -0,0 +1,342 @@
+package vote
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/common"
+)
+
+const tolerance = 1e-9
+
+// units builds krippendorffAlpha input from a units x coders table; NaN marks a missing value
+func units(table [][]float64) map[uuid.UUID]map[uuid.UUID]float64 {
+	coders := make([]uuid.UUID, len(table[0]))
+	for i := range coders {
+		coders[i] = uuid.New()
+	}
+
+	result := make(map[uuid.UUID]map[uuid.UUID]float64)
+	for _, row := range table {
+		unit := make(map[uuid.UUID]float64)
+		for i, value := range row {
+			if !math.IsNaN(value) {
+				unit[coders[i]] = value
+			}
+		}
+		result[uuid.New()] = unit
+	}
+	return result
+}
+
+func TestKrippendorffAlpha(t *testing.T) {
+	missing := math.NaN()
+
+	tests := []struct {
+		name       string
+		table      [][]float64
+		want       *float64
+		wantUnits  int
+		wantValues int
+	}{
+		{
+			// Krippendorff (2011), "Computing Krippendorff's Alpha-Reliability": 4 coders,
+			// 12 units with missing values, interval alpha = 0.849
+			name: "published example",
+			table: [][]float64{
+				{1, 1, missing, 1},
+				{2, 2, 3, 2},
+				{3, 3, 3, 3},
+				{3, 3, 3, 3},
+				{2, 2, 2, 2},
+				{1, 2, 3, 4},
+				{4, 4, 4, 4},
+				{1, 1, 2, 1},
+				{2, 2, 2, 2},
+				{missing, 5, 5, 5},
+				{missing, missing, 1, 1},
+				{missing, missing, 3, missing},
+			},
+			want:       floatPtr(0.8491071428571428),
+			wantUnits:  11,
+			wantValues: 40,
+		},
+		{
+			name:       "perfect agreement",
+			table:      [][]float64{{1, 1}, {0, 0}},
+			want:       floatPtr(1),
+			wantUnits:  2,
+			wantValues: 4,
+		},
+		{
+			name:       "systematic disagreement",
+			table:      [][]float64{{1, 0}, {0, 1}},
+			want:       floatPtr(-0.5),
+			wantUnits:  2,
+			wantValues: 4,
+		},
+		{
+			name:       "tied normalized points",
+			table:      [][]float64{{1, 1, missing}, {0, 0.5, missing}, {0.5, 0.5, 0}},
+			want:       floatPtr(4.0 / 7.0),
+			wantUnits:  3,
+			wantValues: 7,
+		},
+		{
+			name:       "no variance",
+			table:      [][]float64{{0.5, 0.5}, {0.5, 0.5}},
+			want:       nil,
+			wantUnits:  2,
+			wantValues: 4,
+		},
+		{
+			name:       "single rater per unit",
+			table:      [][]float64{{1, missing}, {missing, 0}},
+			want:       nil,
+			wantUnits:  0,
+			wantValues: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pairableUnits, pairableValues := krippendorffAlpha(units(tt.table))
+
+			if pairableUnits != tt.wantUnits || pairableValues != tt.wantValues {
+				t.Errorf("pairable units, values = %d, %d; want %d, %d", pairableUnits, pairableValues, tt.wantUnits, tt.wantValues)
+			}
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("alpha = %v, want nil", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("alpha = nil, want %v", *tt.want)
+			case tt.want != nil && math.Abs(*got-*tt.want) > tolerance:
+				t.Errorf("alpha = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizedBallots(t *testing.T) {
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	voter, lone := uuid.New(), uuid.New()
+
+	ballots := normalizedBallots([]*Vote{
+		{VoterID: voter, AttachmentID: first, RankPosition: 1},
+		{VoterID: voter, AttachmentID: second, RankPosition: 2},
+		{VoterID: voter, AttachmentID: third, RankPosition: 3},
+		// A single ranked attachment carries no ordering and is left out
+		{VoterID: lone, AttachmentID: first, RankPosition: 1},
+	})
+
+	want := map[uuid.UUID]float64{first: 1, second: 0.5, third: 0}
+	for attachmentID, points := range want {
+		coders := ballots[attachmentID]
+		if len(coders) != 1 || coders[voter] != points {
+			t.Errorf("points of attachment ranked %v = %v, want only the full ballot's %v", points, coders, points)
+		}
+	}
+}
+
+func TestKrippendorffAlphaSingleRater(t *testing.T) {
+	voter := uuid.New()
+	var votes []*Vote
+	for rank := 1; rank <= 4; rank++ {
+		votes = append(votes, &Vote{VoterID: voter, AttachmentID: uuid.New(), RankPosition: rank})
+	}
+
+	alpha, pairableUnits, pairableValues := krippendorffAlpha(normalizedBallots(votes))
+	if alpha != nil || pairableUnits != 0 || pairableValues != 0 {
+		t.Errorf("krippendorffAlpha with one rater = %v, %d, %d; want nil, 0, 0", alpha, pairableUnits, pairableValues)
+	}
+}
+
+// ranking maps the attachments to the given ranks, in order
+func ranking(ids []uuid.UUID, ranks ...int) map[uuid.UUID]int {
+	result := make(map[uuid.UUID]int, len(ranks))
+	for i, rank := range ranks {
+		result[ids[i]] = rank
+	}
+	return result
+}
+
+func TestKendallTauB(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name string
+		a, b map[uuid.UUID]int
+		want *float64
+	}{
+		{"identical", ranking(ids, 1, 2, 3, 4), ranking(ids, 1, 2, 3, 4), floatPtr(1)},
+		{"reversed", ranking(ids, 1, 2, 3, 4), ranking(ids, 4, 3, 2, 1), floatPtr(-1)},
+		{"one swap", ranking(ids, 1, 2, 3, 4), ranking(ids, 2, 1, 3, 4), floatPtr(4.0 / 6.0)},
+		// 5 concordant pairs, 1 pair tied in a only: 5 / sqrt(5 * 6)
+		{"ties in one ranking", ranking(ids, 1, 2, 2, 3), ranking(ids, 1, 2, 3, 4), floatPtr(5 / math.Sqrt(30))},
+		// The pair tied in both rankings counts in neither denominator
+		{"ties in both rankings", ranking(ids, 1, 1, 2), ranking(ids, 1, 1, 2), floatPtr(1)},
+		{"only the common attachments", ranking(ids, 1, 2, 3), ranking(ids[1:], 1, 2, 3, 4), floatPtr(1)},
+		{"all tied", ranking(ids, 1, 1, 1), ranking(ids, 1, 2, 3), nil},
+		{"single attachment", ranking(ids, 1), ranking(ids, 1), nil},
+		{"nothing in common", ranking(ids, 1, 2), ranking(ids[2:], 1, 2), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kendallTauB(tt.a, tt.b)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("tau = %v, want nil", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("tau = nil, want %v", *tt.want)
+			case tt.want != nil && math.Abs(*got-*tt.want) > tolerance:
+				t.Errorf("tau = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func assignment(attachments ...uuid.UUID) *Assignment {
+	ids := make([]string, len(attachments))
+	for i, id := range attachments {
+		ids[i] = id.String()
+	}
+	return &Assignment{ID: uuid.New(), ParticipantID: uuid.New(), AttachmentIDs: ids}
+}
+
+func TestCheckAssignmentConnectivity(t *testing.T) {
+	a := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name        string
+		assignments []*Assignment
+		attachments []uuid.UUID
+		want        AssignmentConnectivity
+	}{
+		{
+			name:        "chained through shared attachments",
+			assignments: []*Assignment{assignment(a[0], a[1]), assignment(a[1], a[2]), assignment(a[2], a[3])},
+			attachments: a[:4],
+			want:        AssignmentConnectivity{IsConnected: true, Components: 1, LargestComponentSize: 7, Reviewers: 3, Attachments: 4},
+		},
+		{
+			name:        "two separate groups",
+			assignments: []*Assignment{assignment(a[0], a[1]), assignment(a[1], a[0]), assignment(a[2], a[3])},
+			attachments: a[:4],
+			want:        AssignmentConnectivity{IsConnected: false, Components: 2, LargestComponentSize: 4, Reviewers: 3, Attachments: 4},
+		},
+		{
+			name:        "unassigned attachment",
+			assignments: []*Assignment{assignment(a[0], a[1]), assignment(a[1], a[2])},
+			attachments: a[:4],
+			want: AssignmentConnectivity{IsConnected: false, Components: 2, LargestComponentSize: 5, Reviewers: 2, Attachments: 4,
+				UnassignedAttachments: []string{a[3].String()}},
+		},
+		{
+			name:        "single reviewer",
+			assignments: []*Assignment{assignment(a[0], a[1], a[2])},
+			attachments: a[:3],
+			want:        AssignmentConnectivity{IsConnected: true, Components: 1, LargestComponentSize: 4, Reviewers: 1, Attachments: 3},
+		},
+		{
+			name:        "assigned attachment no longer in the event",
+			assignments: []*Assignment{assignment(a[0], a[4])},
+			attachments: a[:1],
+			want:        AssignmentConnectivity{IsConnected: true, Components: 1, LargestComponentSize: 3, Reviewers: 1, Attachments: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkAssignmentConnectivity(tt.assignments, tt.attachments)
+
+			if got.IsConnected != tt.want.IsConnected || got.Components != tt.want.Components ||
+				got.LargestComponentSize != tt.want.LargestComponentSize ||
+				got.Reviewers != tt.want.Reviewers || got.Attachments != tt.want.Attachments {
+				t.Errorf("connectivity = %+v, want %+v", got, tt.want)
+			}
+
+			if len(got.UnassignedAttachments) != len(tt.want.UnassignedAttachments) {
+				t.Fatalf("unassigned = %v, want %v", got.UnassignedAttachments, tt.want.UnassignedAttachments)
+			}
+			for i := range got.UnassignedAttachments {
+				if got.UnassignedAttachments[i] != tt.want.UnassignedAttachments[i] {
+					t.Errorf("unassigned = %v, want %v", got.UnassignedAttachments, tt.want.UnassignedAttachments)
+				}
+			}
+		})
+	}
+}
+
+// fakeVoteRepository serves a fixed set of votes and assignments; other methods panic
+type fakeVoteRepository struct {
+	VoteRepository
+	votes       []*Vote
+	assignments []*Assignment
+}
+
+func (f *fakeVoteRepository) GetByEventID(eventID string) ([]*Vote, error) {
+	return f.votes, nil
+}
+
+func (f *fakeVoteRepository) GetAssignmentsByEventID(eventID string) ([]*Assignment, error) {
+	return f.assignments, nil
+}
+
+type fakeAttachmentRepository struct {
+	AttachmentRepository
+}
+
+func (f *fakeAttachmentRepository) GetByEventID(eventID string) ([]common.AttachmentInterface, error) {
+	return nil, nil
+}
+
+func TestCalculateReliabilityReportWithoutAssignments(t *testing.T) {
+	service := NewVotingService(&fakeVoteRepository{}, &fakeAttachmentRepository{}, nil)
+
+	if _, err := service.CalculateReliabilityReport(uuid.New(), nil); !errors.Is(err, ErrNoAssignments) {
+		t.Errorf("CalculateReliabilityReport = %v, want ErrNoAssignments", err)
+	}
+}
+
+func TestCalculateReliabilityReport(t *testing.T) {
+	a := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	first, second := assignment(a...), assignment(a...)
+	repo := &fakeVoteRepository{assignments: []*Assignment{first, second}}
+	for i, id := range a {
+		// Both reviewers rank the attachments in the same order
+		repo.votes = append(repo.votes,
+			&Vote{VoterID: first.ParticipantID, AttachmentID: id, RankPosition: i + 1},
+			&Vote{VoterID: second.ParticipantID, AttachmentID: id, RankPosition: i + 1})
+	}
+	results := &VotingResults{AdjustedRanking: []AttachmentResult{
+		{AttachmentID: a[0], GlobalRank: 1, AdjustedRank: 2},
+		{AttachmentID: a[1], GlobalRank: 2, AdjustedRank: 1},
+		{AttachmentID: a[2], GlobalRank: 3, AdjustedRank: 3},
+	}}
+
+	report, err := NewVotingService(repo, &fakeAttachmentRepository{}, nil).CalculateReliabilityReport(uuid.New(), results)
+	if err != nil {
+		t.Fatalf("CalculateReliabilityReport: %v", err)
+	}
+
+	if report.KrippendorffAlpha == nil || math.Abs(*report.KrippendorffAlpha-1) > tolerance {
+		t.Errorf("alpha = %v, want 1", report.KrippendorffAlpha)
+	}
+	if report.PairableUnits != 3 || report.PairableValues != 6 {
+		t.Errorf("pairable units, values = %d, %d; want 3, 6", report.PairableUnits, report.PairableValues)
+	}
+	if report.GlobalAdjustedKendallTau == nil || math.Abs(*report.GlobalAdjustedKendallTau-1.0/3.0) > tolerance {
+		t.Errorf("tau = %v, want 1/3", report.GlobalAdjustedKendallTau)
+	}
+	if !report.Connectivity.IsConnected || report.Connectivity.Reviewers != 2 {
+		t.Errorf("connectivity = %+v, want two connected reviewers", report.Connectivity)
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -66,30 +67,70 @@ type VotingResults struct {
 
 // VotingConfiguration represents the mathematical parameters for the voting system
 type VotingConfiguration struct {
-	ID                      uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	EventID                 uuid.UUID `json:"event_id" gorm:"type:uuid;not null;uniqueIndex"`
-	AttachmentsPerEvaluator int       `json:"attachments_per_evaluator"` // m parameter
-	QualityGoodThreshold    float64   `json:"quality_good_threshold"`    // Q_good
-	QualityBadThreshold     float64   `json:"quality_bad_threshold"`     // Q_bad
-	AdjustmentMagnitude     int       `json:"adjustment_magnitude"`      // n parameter
-	MinEvaluationsPerFile   int       `json:"min_evaluations_per_file"`
-	CreatedAt               time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt               time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                      uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID                 uuid.UUID         `json:"event_id" gorm:"type:uuid;not null;uniqueIndex"`
+	AttachmentsPerEvaluator int               `json:"attachments_per_evaluator"` // m parameter
+	QualityGoodThreshold    float64           `json:"quality_good_threshold"`    // Q_good
+	QualityBadThreshold     float64           `json:"quality_bad_threshold"`     // Q_bad
+	AdjustmentMagnitude     int               `json:"adjustment_magnitude"`      // n parameter
+	MinEvaluationsPerFile   int               `json:"min_evaluations_per_file"`
+	AmendmentDeadline       *time.Time        `json:"amendment_deadline"` // optional cutoff for resubmitting rankings
+	TeamQualityPolicy       TeamQualityPolicy `json:"team_quality_policy" gorm:"not null;default:'average'"`
+	CreatedAt               time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt               time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 
 	// Relations - loaded through repositories when needed to avoid circular imports
 }
 
+// TeamQualityPolicy decides how the evaluator qualities Q_i of a proposal's authors are
+// combined into the single quality used for the incentive adjustment of team proposals
+type TeamQualityPolicy string
+
+const (
+	// TeamQualityAverage uses the mean Q_i of the authors who evaluated
+	TeamQualityAverage TeamQualityPolicy = "average"
+	// TeamQualityMinimum uses the lowest Q_i among the authors who evaluated
+	TeamQualityMinimum TeamQualityPolicy = "minimum"
+)
+
+// IsValid checks if the policy is supported
+func (p TeamQualityPolicy) IsValid() bool {
+	return p == TeamQualityAverage || p == TeamQualityMinimum
+}
+
+// Combine applies the policy to the qualities of a team's members
+func (p TeamQualityPolicy) Combine(qualities []float64) float64 {
+	if len(qualities) == 0 {
+		return 0
+	}
+
+	if p == TeamQualityMinimum {
+		lowest := qualities[0]
+		for _, q := range qualities[1:] {
+			lowest = math.Min(lowest, q)
+		}
+		return lowest
+	}
+
+	var sum float64
+	for _, q := range qualities {
+		sum += q
+	}
+	return sum / float64(len(qualities))
+}
+
 // AttachmentResult represents the MBC score and ranking for an attachment
 type AttachmentResult struct {
-	AttachmentID    uuid.UUID `json:"attachment_id"`
-	Filename        string    `json:"filename"`
-	ParticipantID   uuid.UUID `json:"participant_id"`
-	ParticipantName string    `json:"participant_name,omitempty"`
-	MBCScore        float64   `json:"mbc_score"`
-	GlobalRank      int       `json:"global_rank"`
-	AdjustedRank    int       `json:"adjusted_rank"`
-	VoteCount       int       `json:"vote_count"`
-	AverageRank     float64   `json:"average_rank"`
+	AttachmentID    uuid.UUID   `json:"attachment_id"`
+	Filename        string      `json:"filename"`
+	ParticipantID   uuid.UUID   `json:"participant_id"`
+	CoAuthorIDs     []uuid.UUID `json:"co_author_ids,omitempty"`
+	ParticipantName string      `json:"participant_name,omitempty"`
+	MBCScore        float64     `json:"mbc_score"`
+	GlobalRank      int         `json:"global_rank"`
+	AdjustedRank    int         `json:"adjusted_rank"`
+	VoteCount       int         `json:"vote_count"`
+	AverageRank     float64     `json:"average_rank"`
 }
 
 // AttachmentResultSlice is a custom type for JSONB serialization
@@ -296,6 +337,52 @@ func NewVotingConfiguration(eventID uuid.UUID, m int) *VotingConfiguration {
 		QualityBadThreshold:     0.3,
 		AdjustmentMagnitude:     3,
 		MinEvaluationsPerFile:   3,
+		TeamQualityPolicy:       TeamQualityAverage,
+		CreatedAt:               time.Now(),
+	}
+}
+
+// VotingSettings are the event-independent parameters of a voting configuration,
+// used to copy a configuration between events (cloning and templates)
+type VotingSettings struct {
+	AttachmentsPerEvaluator int               `json:"attachments_per_evaluator"`
+	QualityGoodThreshold    float64           `json:"quality_good_threshold"`
+	QualityBadThreshold     float64           `json:"quality_bad_threshold"`
+	AdjustmentMagnitude     int               `json:"adjustment_magnitude"`
+	MinEvaluationsPerFile   int               `json:"min_evaluations_per_file"`
+	TeamQualityPolicy       TeamQualityPolicy `json:"team_quality_policy,omitempty"`
+}
+
+// Settings returns the reusable parameters of the configuration.
+// The amendment deadline is an absolute date and is not carried over.
+func (vc *VotingConfiguration) Settings() VotingSettings {
+	return VotingSettings{
+		AttachmentsPerEvaluator: vc.AttachmentsPerEvaluator,
+		QualityGoodThreshold:    vc.QualityGoodThreshold,
+		QualityBadThreshold:     vc.QualityBadThreshold,
+		AdjustmentMagnitude:     vc.AdjustmentMagnitude,
+		MinEvaluationsPerFile:   vc.MinEvaluationsPerFile,
+		TeamQualityPolicy:       vc.TeamQualityPolicy,
+	}
+}
+
+// NewVotingConfigurationFromSettings creates a configuration for an event from copied settings
+func NewVotingConfigurationFromSettings(eventID uuid.UUID, settings VotingSettings) *VotingConfiguration {
+	// Templates saved before team proposals existed carry no policy
+	policy := settings.TeamQualityPolicy
+	if policy == "" {
+		policy = TeamQualityAverage
+	}
+
+	return &VotingConfiguration{
+		ID:                      uuid.New(),
+		EventID:                 eventID,
+		AttachmentsPerEvaluator: settings.AttachmentsPerEvaluator,
+		QualityGoodThreshold:    settings.QualityGoodThreshold,
+		QualityBadThreshold:     settings.QualityBadThreshold,
+		AdjustmentMagnitude:     settings.AdjustmentMagnitude,
+		MinEvaluationsPerFile:   settings.MinEvaluationsPerFile,
+		TeamQualityPolicy:       policy,
 		CreatedAt:               time.Now(),
 	}
 }
@@ -320,9 +407,25 @@ func (vc *VotingConfiguration) Validate() error {
 	if vc.MinEvaluationsPerFile <= 0 {
 		return fmt.Errorf("min_evaluations_per_file must be positive")
 	}
+	if !vc.TeamQualityPolicy.IsValid() {
+		return fmt.Errorf("team_quality_policy must be %q or %q", TeamQualityAverage, TeamQualityMinimum)
+	}
 	return nil
 }
 
+// AllowsAmendment reports whether completed rankings can still be resubmitted at the given time.
+// Without an explicit deadline amendments stay open until the voting stage closes. Events
+// without a voting configuration don't allow amendments.
+func (vc *VotingConfiguration) AllowsAmendment(now time.Time) bool {
+	if vc == nil {
+		return false
+	}
+	if vc.AmendmentDeadline == nil {
+		return true
+	}
+	return now.Before(*vc.AmendmentDeadline)
+}
+
 // IsOptimal checks if the configuration follows mathematical recommendations
 func (vc *VotingConfiguration) IsOptimal(totalAttachments int) bool {
 	// TODO: Implement optimality checks based on mathematical constraints
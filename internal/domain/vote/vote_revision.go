@@ -0,0 +1,52 @@
+package vote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VoteRevision is an immutable snapshot of a ranking submission.
+// Every submission (the first one and each amendment) appends a revision; the votes
+// table only holds the rankings of the latest revision.
+type VoteRevision struct {
+	ID           uuid.UUID     `json:"id"            gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID      uuid.UUID     `json:"event_id"      gorm:"type:uuid;not null"`
+	AssignmentID uuid.UUID     `json:"assignment_id" gorm:"type:uuid;not null"`
+	VoterID      uuid.UUID     `json:"voter_id"      gorm:"type:uuid;not null"`
+	Revision     int           `json:"revision"      gorm:"not null"`
+	Rankings     DraftRankings `json:"rankings"      gorm:"type:jsonb;not null;default:'[]'"`
+	SubmittedAt  time.Time     `json:"submitted_at"  gorm:"autoCreateTime"`
+}
+
+func (VoteRevision) TableName() string {
+	return "vote_revisions"
+}
+
+func (v *VoteRevision) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// NewVoteRevision builds a revision snapshot from the votes of a single submission
+func NewVoteRevision(assignment *Assignment, votes []*Vote) *VoteRevision {
+	rankings := make(DraftRankings, 0, len(votes))
+	for _, v := range votes {
+		rankings = append(rankings, DraftRanking{
+			AttachmentID: v.AttachmentID,
+			Rank:         v.RankPosition,
+		})
+	}
+
+	return &VoteRevision{
+		ID:           uuid.New(),
+		EventID:      assignment.EventID,
+		AssignmentID: assignment.ID,
+		VoterID:      assignment.ParticipantID,
+		Rankings:     rankings,
+		SubmittedAt:  time.Now(),
+	}
+}
@@ -27,19 +27,18 @@ func NewVotingService(voteRepo VoteRepository, attachmentRepo AttachmentReposito
 }
 
 // GenerateAssignments implements the assignment algorithm A: P → 2^F
+// participants are the evaluators: members who submitted a file are never assigned their
+// own file, reviewer-only members can be assigned any file
 func (vs *VotingService) GenerateAssignments(eventID uuid.UUID, participants []uuid.UUID, attachments []uuid.UUID, config *VotingConfiguration) ([]*Assignment, error) {
 	n := len(participants)
 	k := len(attachments)
 	m := config.AttachmentsPerEvaluator
 
-	// When there are conflict of interest constraints (participants can't evaluate own files),
-	// the maximum possible m is k-1, not k
-	// We assume each participant has submitted a file, so there's always conflict of interest
-	maxPossibleM := k
-	if n > 0 && k > 0 {
-		// Each participant will be excluded from evaluating their own file
-		maxPossibleM = k - 1
-	}
+	// Conflict of interest (evaluators can't evaluate their own files) caps m at the
+	// smallest number of files any evaluator is allowed to see: k-1 for submitters,
+	// k when every evaluator is reviewer-only
+	conflicts := vs.conflictMatrix(participants, attachments)
+	maxPossibleM := maxEligibleAttachments(conflicts, k)
 
 	if m > maxPossibleM {
 		return nil, fmt.Errorf("attachments per evaluator (m=%d) cannot exceed %d (conflict of interest prevents evaluating own files)", m, maxPossibleM)
@@ -85,7 +84,7 @@ func (vs *VotingService) GenerateAssignments(eventID uuid.UUID, participants []u
 			participantIdx := participantIndices[evalCount]
 
 			// Check if participant can evaluate this attachment (conflict of interest)
-			if !vs.hasConflictOfInterest(participants[participantIdx], attachments[attachmentIdx]) {
+			if !conflicts[participantIdx][attachmentIdx] {
 				assignmentMatrix[participantIdx][attachmentIdx] = true
 				evaluationsPerAttachment[attachmentIdx]++
 			}
@@ -108,7 +107,7 @@ func (vs *VotingService) GenerateAssignments(eventID uuid.UUID, participants []u
 			}
 
 			if !assignmentMatrix[participantIdx][attachmentIdx] &&
-				!vs.hasConflictOfInterest(participants[participantIdx], attachments[attachmentIdx]) {
+				!conflicts[participantIdx][attachmentIdx] {
 				assignmentMatrix[participantIdx][attachmentIdx] = true
 				evaluationsPerAttachment[attachmentIdx]++
 				currentAssignments++
@@ -139,6 +138,8 @@ func (vs *VotingService) CalculateModifiedBordaCount(eventID uuid.UUID, config *
 		return nil, fmt.Errorf("invalid configuration: attachments_per_evaluator must be at least 2, got %d", config.AttachmentsPerEvaluator)
 	}
 
+	// Only the latest revision of each ranking is stored as votes; amended
+	// versions are kept separately in the revision history
 	votes, err := vs.voteRepo.GetByEventID(eventID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get votes: %w", err)
@@ -200,6 +201,7 @@ func (vs *VotingService) CalculateModifiedBordaCount(eventID uuid.UUID, config *
 			AttachmentID:    attachment.GetID(),
 			Filename:        attachment.GetOriginalName(),
 			ParticipantID:   attachment.GetParticipantID(),
+			CoAuthorIDs:     attachment.GetCoAuthorIDs(),
 			ParticipantName: "", // Will be populated later
 			MBCScore:        mbcScore,
 			VoteCount:       voteCount,
@@ -367,13 +369,14 @@ func (vs *VotingService) calculateRelativeRanks(assignedAttachments []uuid.UUID,
 }
 
 // applyIncentiveSystem implements the adjustment Δ(owner(f_j))
+// For team proposals the qualities of the authors who evaluated are combined with the
+// configured TeamQualityPolicy; authors without a quality score are left out
 func (vs *VotingService) applyIncentiveSystem(results []AttachmentResult, qualities map[string]float64, config *VotingConfiguration) []AttachmentResult {
 	adjustedResults := make([]AttachmentResult, len(results))
 	copy(adjustedResults, results)
 
 	for i := range adjustedResults {
-		participantID := adjustedResults[i].ParticipantID.String()
-		quality, exists := qualities[participantID]
+		quality, exists := teamQuality(adjustedResults[i], qualities, config.TeamQualityPolicy)
 
 		if !exists {
 			adjustedResults[i].AdjustedRank = adjustedResults[i].GlobalRank
@@ -412,18 +415,84 @@ func (vs *VotingService) applyIncentiveSystem(results []AttachmentResult, qualit
 	return adjustedResults
 }
 
-// hasConflictOfInterest checks if a participant has a conflict with an attachment
-func (vs *VotingService) hasConflictOfInterest(participantID, attachmentID uuid.UUID) bool {
-	attachment, err := vs.attachmentRepo.GetByID(attachmentID.String())
-	if err != nil {
-		return true // Err on the side of caution
+// teamQuality combines the qualities of a proposal's authors. Returns false when none of
+// them has a quality score.
+func teamQuality(result AttachmentResult, qualities map[string]float64, policy TeamQualityPolicy) (float64, bool) {
+	authorIDs := append([]uuid.UUID{result.ParticipantID}, result.CoAuthorIDs...)
+
+	var teamQualities []float64
+	for _, authorID := range authorIDs {
+		if quality, exists := qualities[authorID.String()]; exists {
+			teamQualities = append(teamQualities, quality)
+		}
+	}
+
+	if len(teamQualities) == 0 {
+		return 0, false
 	}
 
-	return attachment.GetParticipantID() == participantID
+	return policy.Combine(teamQualities), true
 }
 
-// ValidateVotingConfiguration ensures the mathematical parameters are valid
-func (vs *VotingService) ValidateVotingConfiguration(config *VotingConfiguration, totalAttachments, totalParticipants int) error {
+// conflictMatrix marks, for each evaluator, the attachments they cannot evaluate: the ones
+// they authored or co-authored. Each attachment is loaded once; attachments that cannot be
+// loaded conflict with everyone.
+func (vs *VotingService) conflictMatrix(evaluators, attachments []uuid.UUID) [][]bool {
+	authors := make([]map[uuid.UUID]bool, len(attachments))
+	for j, attachmentID := range attachments {
+		attachment, err := vs.attachmentRepo.GetByID(attachmentID.String())
+		if err != nil {
+			continue // Err on the side of caution
+		}
+		authors[j] = map[uuid.UUID]bool{attachment.GetParticipantID(): true}
+		for _, coAuthorID := range attachment.GetCoAuthorIDs() {
+			authors[j][coAuthorID] = true
+		}
+	}
+
+	conflicts := make([][]bool, len(evaluators))
+	for i, evaluatorID := range evaluators {
+		conflicts[i] = make([]bool, len(attachments))
+		for j, team := range authors {
+			conflicts[i][j] = team == nil || team[evaluatorID]
+		}
+	}
+
+	return conflicts
+}
+
+// maxEligibleAttachments returns the smallest number of attachments any evaluator can be
+// assigned, or k when there are no evaluators
+func maxEligibleAttachments(conflicts [][]bool, k int) int {
+	maxPossibleM := k
+	for _, row := range conflicts {
+		eligible := 0
+		for _, conflict := range row {
+			if !conflict {
+				eligible++
+			}
+		}
+		if eligible < maxPossibleM {
+			maxPossibleM = eligible
+		}
+	}
+	return maxPossibleM
+}
+
+// ValidateVotingConfigurationForEvaluators ensures the mathematical parameters are valid
+// for the given evaluators. Reviewer-only evaluators have no conflict of interest, so m
+// may reach k when nobody evaluating has submitted a file.
+func (vs *VotingService) ValidateVotingConfigurationForEvaluators(config *VotingConfiguration, evaluators, attachments []uuid.UUID) error {
+	return vs.validateVotingConfiguration(config, len(attachments), len(evaluators), vs.MaxAttachmentsPerEvaluator(evaluators, attachments))
+}
+
+// MaxAttachmentsPerEvaluator returns the largest m every evaluator can be assigned
+// without evaluating their own files
+func (vs *VotingService) MaxAttachmentsPerEvaluator(evaluators, attachments []uuid.UUID) int {
+	return maxEligibleAttachments(vs.conflictMatrix(evaluators, attachments), len(attachments))
+}
+
+func (vs *VotingService) validateVotingConfiguration(config *VotingConfiguration, totalAttachments, totalParticipants, maxPossibleM int) error {
 	m := config.AttachmentsPerEvaluator
 	k := totalAttachments
 	n := totalParticipants
@@ -432,15 +501,6 @@ func (vs *VotingService) ValidateVotingConfiguration(config *VotingConfiguration
 		return errors.New("attachments per evaluator must be positive")
 	}
 
-	// When there are conflict of interest constraints (participants can't evaluate own files),
-	// the maximum possible m is k-1, not k
-	// We assume each participant has submitted a file, so there's always conflict of interest
-	maxPossibleM := k
-	if n > 0 && k > 0 {
-		// Each participant will be excluded from evaluating their own file
-		maxPossibleM = k - 1
-	}
-
 	if m > maxPossibleM {
 		return fmt.Errorf("attachments per evaluator cannot exceed %d (conflict of interest prevents evaluating own files)", maxPossibleM)
 	}
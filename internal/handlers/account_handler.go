@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// AccountHandler serves the password reset and email verification flows
+type AccountHandler struct {
+	accounts *AccountService
+	userRepo postgres.UserRepository
+	log      *log.Logger
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(accounts *AccountService, userRepo postgres.UserRepository) *AccountHandler {
+	return &AccountHandler{
+		accounts: accounts,
+		userRepo: userRepo,
+		log:      logger.Handler("account"),
+	}
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ForgotPassword handles POST /api/v1/auth/password/forgot
+// Always answers the same way, whether or not an account exists for the email
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Failures are only logged, the response must not reveal whether the account exists
+	h.accounts.RequestPasswordReset(req.Email)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If an account exists for this email, a password reset link has been sent",
+		"code":    "PASSWORD_RESET_REQUESTED",
+	})
+}
+
+// ResetPassword handles POST /api/v1/auth/password/reset
+// Sets a new password and signs the user out of every session
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.accounts.ResetPassword(req.Token, req.Password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAccountToken) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired password reset token",
+				"code":  "INVALID_TOKEN",
+			})
+			return
+		}
+		h.log.Error("failed to reset password", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reset password",
+			"code":  "PASSWORD_RESET_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("password reset completed", "user_id", user.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password reset successfully; please sign in with your new password",
+		"code":    "PASSWORD_RESET",
+	})
+}
+
+// SendEmailVerification handles POST /api/v1/auth/email/verification
+// Mails a new verification link to the authenticated user
+func (h *AccountHandler) SendEmailVerification(c *gin.Context) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(userID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	if err := h.accounts.SendEmailVerification(user); err != nil {
+		if errors.Is(err, ErrEmailAlreadyVerified) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Email is already verified",
+				"code":  "EMAIL_ALREADY_VERIFIED",
+			})
+			return
+		}
+		if errors.Is(err, ErrVerificationRecentlySent) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "A verification email was sent a few minutes ago; please check your inbox",
+				"code":  "VERIFICATION_RECENTLY_SENT",
+			})
+			return
+		}
+		h.log.Error("failed to send verification email", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to send verification email",
+			"code":  "EMAIL_DELIVERY_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Verification email sent",
+		"code":    "VERIFICATION_EMAIL_SENT",
+	})
+}
+
+// VerifyEmail handles POST /api/v1/auth/email/verify
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.accounts.VerifyEmail(req.Token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAccountToken) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid or expired verification token",
+				"code":  "INVALID_TOKEN",
+			})
+			return
+		}
+		h.log.Error("failed to verify email", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to verify email",
+			"code":  "EMAIL_VERIFICATION_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":                user.ID.String(),
+			"email":             user.Email,
+			"email_verified":    true,
+			"email_verified_at": user.EmailVerifiedAt,
+		},
+		"message": "Email verified successfully",
+		"code":    "EMAIL_VERIFIED",
+	})
+}
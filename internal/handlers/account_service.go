@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/mail"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+	mailSendTimeout      = 15 * time.Second
+
+	// verificationResendInterval is the least time between two verification emails to a user
+	verificationResendInterval = 10 * time.Minute
+)
+
+// ErrInvalidAccountToken is returned for unknown, expired or already used reset and verification tokens
+var ErrInvalidAccountToken = errors.New("invalid or expired token")
+
+// ErrEmailAlreadyVerified is returned when verification is requested for a verified address
+var ErrEmailAlreadyVerified = errors.New("email is already verified")
+
+// ErrVerificationRecentlySent is returned when the last verification email went out less
+// than verificationResendInterval ago; its link still works
+var ErrVerificationRecentlySent = errors.New("a verification email was sent recently")
+
+// AccountService mails password reset and email verification links and redeems them
+type AccountService struct {
+	container *postgres.Container
+	mailer    mail.Mailer
+	config    *config.Config
+	log       *log.Logger
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(container *postgres.Container, mailer mail.Mailer, cfg *config.Config) *AccountService {
+	return &AccountService{
+		container: container,
+		mailer:    mailer,
+		config:    cfg,
+		log:       logger.Service("account"),
+	}
+}
+
+// RequestPasswordReset mails a reset link when an account exists for the email. Unknown
+// emails are ignored silently so the endpoint can't be used to probe for accounts. The
+// lookup and the mail run in the background, so the caller returns just as fast for
+// unknown emails as for existing accounts.
+func (s *AccountService) RequestPasswordReset(email string) {
+	go func() {
+		if err := s.sendPasswordReset(email); err != nil {
+			s.log.Error("failed to send password reset email", "error", err)
+		}
+	}()
+}
+
+func (s *AccountService) sendPasswordReset(email string) error {
+	user, err := s.container.Users().GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if err.Error() == "user not found" {
+			s.log.Debug("password reset requested for unknown email")
+			return nil
+		}
+		return err
+	}
+
+	// Service accounts have no password to reset
+	if user.IsServiceAccount {
+		s.log.Debug("password reset requested for service account", "user_id", user.ID)
+		return nil
+	}
+
+	plain, err := s.issueToken(user, session.PurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.send(mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Telescopio password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password of your Telescopio account. "+
+			"To choose a new password, open this link within the next hour:\n\n%s\n\n"+
+			"If you didn't ask for this, you can ignore this email; your password stays the same.\n",
+			user.Name, s.link("/reset-password", plain)),
+	})
+}
+
+// ResetPassword sets a new password with a reset token. Every session of the user is
+// revoked, and the email counts as verified since the user received the link.
+func (s *AccountService) ResetPassword(plain, newPassword string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	token, err := tx.UserTokens().Consume(session.HashToken(plain), session.PurposePasswordReset, now)
+	if err != nil {
+		if err.Error() == "user token not found" {
+			return nil, ErrInvalidAccountToken
+		}
+		return nil, err
+	}
+
+	user, err := tx.Users().GetByID(token.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
+		return nil, err
+	}
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := tx.Users().Update(user); err != nil {
+		return nil, err
+	}
+
+	// Old passwords must not keep sessions alive
+	if err := tx.Users().IncrementTokenVersion(user.ID.String()); err != nil {
+		return nil, err
+	}
+	if err := tx.RefreshTokens().RevokeAllForUser(user.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("password reset", "user_id", user.ID)
+	return user, nil
+}
+
+// SendEmailVerification mails a verification link to the user. Anyone who knows an address
+// can trigger it by registering for an event with it, so a new link is mailed at most once
+// every verificationResendInterval.
+func (s *AccountService) SendEmailVerification(user *participant.User) error {
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	pending, err := s.container.UserTokens().GetLatestPending(user.ID, session.PurposeEmailVerification, time.Now())
+	if err != nil && err.Error() != "user token not found" {
+		return err
+	}
+	if pending != nil && time.Since(pending.CreatedAt) < verificationResendInterval {
+		s.log.Debug("verification email sent recently, not sending another", "user_id", user.ID)
+		return ErrVerificationRecentlySent
+	}
+
+	plain, err := s.issueToken(user, session.PurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.send(mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email for Telescopio",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm that this is your email address by opening this link within the next two days:\n\n%s\n\n"+
+			"If you didn't create a Telescopio account or register for an event, you can ignore this email.\n",
+			user.Name, s.link("/verify-email", plain)),
+	})
+}
+
+// VerifyEmail marks the email of the token's user as verified. The token is only used up
+// when the email is marked verified too.
+func (s *AccountService) VerifyEmail(plain string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	token, err := tx.UserTokens().Consume(session.HashToken(plain), session.PurposeEmailVerification, now)
+	if err != nil {
+		if err.Error() == "user token not found" {
+			return nil, ErrInvalidAccountToken
+		}
+		return nil, err
+	}
+
+	if err := tx.Users().MarkEmailVerified(token.UserID.String(), now); err != nil {
+		return nil, err
+	}
+
+	user, err := tx.Users().GetByID(token.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("email verified", "user_id", token.UserID)
+	return user, nil
+}
+
+// issueToken replaces the user's pending tokens for the purpose with a new one
+func (s *AccountService) issueToken(user *participant.User, purpose session.Purpose, ttl time.Duration) (string, error) {
+	token, plain, err := session.NewUserToken(user.ID, purpose, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.UserTokens().InvalidateForUser(user.ID, purpose); err != nil {
+		return "", err
+	}
+	if err := tx.UserTokens().Create(token); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return plain, nil
+}
+
+func (s *AccountService) send(msg mail.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	return s.mailer.Send(ctx, msg)
+}
+
+// link builds a frontend link carrying the token
+func (s *AccountService) link(path, token string) string {
+	return strings.TrimRight(s.config.Server.FrontendURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
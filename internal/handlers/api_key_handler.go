@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// serviceAccountEmailDomain is a reserved domain (RFC 2606), so service accounts can't
+// receive mail and can't be taken over through a password reset
+const serviceAccountEmailDomain = "service-accounts.telescopio.invalid"
+
+// APIKeyHandler manages API keys and the service accounts that own keys for scripts
+type APIKeyHandler struct {
+	container *postgres.Container
+	log       *log.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(container *postgres.Container) *APIKeyHandler {
+	return &APIKeyHandler{
+		container: container,
+		log:       logger.Handler("api_key"),
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays *int     `json:"expires_in_days" binding:"omitempty,min=1,max=3650"` // Optional: never expires when omitted
+	OwnerID       string   `json:"owner_id"`                                           // Optional: a service account (admins only); defaults to the caller
+}
+
+type CreateServiceAccountRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+	Role string `json:"role"` // Optional: participant (default) or organizer
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+// The plain key is only returned here; it is stored hashed and cannot be retrieved later
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	callerID, orgID, isAdmin, ok := h.caller(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	scopes := make([]session.Scope, 0, len(req.Scopes))
+	for _, name := range req.Scopes {
+		scope := session.Scope(name)
+		if !scope.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":        "Invalid scope: " + name,
+				"code":         "INVALID_SCOPE",
+				"valid_scopes": session.Scopes,
+			})
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+
+	ownerID := callerID
+	if req.OwnerID != "" {
+		owner, ok := h.loadServiceAccount(c, req.OwnerID, isAdmin)
+		if !ok {
+			return
+		}
+		ownerID = owner.ID
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		expiry := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &expiry
+	}
+
+	key, plain, err := session.NewAPIKey(orgID, ownerID, callerID, strings.TrimSpace(req.Name), scopes, expiresAt)
+	if err != nil {
+		h.log.Error("failed to generate API key", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create API key",
+			"code":  "API_KEY_CREATION_ERROR",
+		})
+		return
+	}
+
+	if err := h.container.APIKeys().Create(key); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create API key",
+			"code":  "API_KEY_CREATION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("API key created", "api_key_id", key.ID, "owner_id", ownerID, "created_by", callerID, "scopes", key.Scopes)
+
+	response := apiKeyResponse(key, time.Now())
+	response["key"] = plain
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    response,
+		"message": "API key created successfully; store the key now, it is not shown again",
+		"code":    "API_KEY_CREATED",
+	})
+}
+
+// ListAPIKeys handles GET /api/v1/api-keys
+// Lists the caller's keys, or with ?owner_id= those of a service account (admins only)
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	callerID, orgID, isAdmin, ok := h.caller(c)
+	if !ok {
+		return
+	}
+
+	ownerID := callerID
+	if ownerParam := c.Query("owner_id"); ownerParam != "" {
+		owner, ok := h.loadServiceAccount(c, ownerParam, isAdmin)
+		if !ok {
+			return
+		}
+		ownerID = owner.ID
+	}
+
+	keys, err := h.container.APIKeys().GetByUser(orgID, ownerID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve API keys",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	now := time.Now()
+	data := make([]gin.H, 0, len(keys))
+	for _, key := range keys {
+		data = append(data, apiKeyResponse(key, now))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "API keys retrieved successfully",
+		"code":    "API_KEYS_RETRIEVED",
+	})
+}
+
+// RevokeAPIKey handles DELETE /api/v1/api-keys/{key_id}
+// Only the owner of the key or an admin can revoke it
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	callerID, _, isAdmin, ok := h.caller(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.container.APIKeys().GetByID(c.Param("key_id"))
+	if err != nil {
+		if err.Error() == "invalid API key ID format" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid key_id format",
+				"code":  "INVALID_API_KEY_ID",
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "API key not found",
+			"code":  "API_KEY_NOT_FOUND",
+		})
+		return
+	}
+
+	// Keys of others are reported as missing, so key IDs can't be probed
+	if key.UserID != callerID && !isAdmin {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "API key not found",
+			"code":  "API_KEY_NOT_FOUND",
+		})
+		return
+	}
+
+	if key.IsRevoked() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "API key is already revoked",
+			"code":  "API_KEY_ALREADY_REVOKED",
+		})
+		return
+	}
+
+	now := time.Now()
+	if err := h.container.APIKeys().Revoke(key.ID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke API key",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+	key.RevokedAt = &now
+
+	h.log.Info("API key revoked", "api_key_id", key.ID, "revoked_by", callerID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    apiKeyResponse(key, now),
+		"message": "API key revoked successfully",
+		"code":    "API_KEY_REVOKED",
+	})
+}
+
+// CreateServiceAccount handles POST /api/v1/service-accounts
+// Service accounts own API keys for scripts and can't sign in; only admins manage them
+func (h *APIKeyHandler) CreateServiceAccount(c *gin.Context) {
+	callerID, orgID, _, ok := h.caller(c)
+	if !ok {
+		return
+	}
+
+	var req CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := participant.RoleParticipant
+	if req.Role != "" {
+		role = participant.Role(req.Role)
+		if role != participant.RoleParticipant && role != participant.RoleOrganizer {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       "Invalid role for a service account",
+				"code":        "INVALID_ROLE",
+				"valid_roles": []string{"participant", "organizer"},
+			})
+			return
+		}
+	}
+
+	account := &participant.User{
+		ID:               uuid.New(),
+		Name:             strings.TrimSpace(req.Name),
+		Role:             role,
+		IsServiceAccount: true,
+	}
+	account.Email = "svc-" + account.ID.String() + "@" + serviceAccountEmailDomain
+
+	if err := h.createServiceAccount(account, orgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create service account",
+			"code":  "CREATION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("service account created", "service_account_id", account.ID, "organization_id", orgID, "role", role, "created_by", callerID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    serviceAccountResponse(account),
+		"message": "Service account created successfully",
+		"code":    "SERVICE_ACCOUNT_CREATED",
+	})
+}
+
+// createServiceAccount saves the account as a member of the organization
+func (h *APIKeyHandler) createServiceAccount(account *participant.User, organizationID uuid.UUID) error {
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.Users().Create(account); err != nil {
+		return err
+	}
+
+	member := &organization.Member{
+		OrganizationID: organizationID,
+		UserID:         account.ID,
+		Role:           organization.RoleMember,
+		CreatedAt:      time.Now(),
+	}
+	if err := tx.Organizations().AddMember(member); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListServiceAccounts handles GET /api/v1/service-accounts
+func (h *APIKeyHandler) ListServiceAccounts(c *gin.Context) {
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	accounts, err := h.container.Users().GetServiceAccounts(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve service accounts",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	data := make([]gin.H, 0, len(accounts))
+	for _, account := range accounts {
+		data = append(data, serviceAccountResponse(account))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Service accounts retrieved successfully",
+		"code":    "SERVICE_ACCOUNTS_RETRIEVED",
+	})
+}
+
+// caller returns the signed-in user, the organization of the request and whether the user
+// administers it
+func (h *APIKeyHandler) caller(c *gin.Context) (uuid.UUID, uuid.UUID, bool, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return uuid.Nil, uuid.Nil, false, false
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false, false
+	}
+
+	return userID, orgID, auth.IsAdmin(c), true
+}
+
+// loadServiceAccount resolves the service account whose keys an admin manages
+func (h *APIKeyHandler) loadServiceAccount(c *gin.Context, ownerID string, isAdmin bool) (*participant.User, bool) {
+	if !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only admins can manage the keys of service accounts",
+			"code":  "FORBIDDEN",
+		})
+		return nil, false
+	}
+
+	if _, err := uuid.Parse(ownerID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid owner_id format",
+			"code":  "INVALID_OWNER_ID",
+		})
+		return nil, false
+	}
+
+	owner, err := h.container.Users().GetByIDInOrganization(tenant.Scope(c), ownerID)
+	if err != nil || !owner.IsServiceAccount {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Service account not found",
+			"code":  "SERVICE_ACCOUNT_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	return owner, true
+}
+
+func apiKeyResponse(key *session.APIKey, now time.Time) gin.H {
+	return gin.H{
+		"id":           key.ID.String(),
+		"owner_id":     key.UserID.String(),
+		"name":         key.Name,
+		"prefix":       key.Prefix,
+		"scopes":       key.Scopes,
+		"status":       key.Status(now),
+		"expires_at":   key.ExpiresAt,
+		"revoked_at":   key.RevokedAt,
+		"last_used_at": key.LastUsedAt,
+		"last_used_ip": key.LastUsedIP,
+		"created_at":   key.CreatedAt,
+	}
+}
+
+func serviceAccountResponse(account *participant.User) gin.H {
+	return gin.H{
+		"id":         account.ID.String(),
+		"name":       account.Name,
+		"role":       account.Role.String(),
+		"created_at": account.CreatedAt,
+	}
+}
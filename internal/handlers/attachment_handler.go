@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,7 +19,11 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/config"
 	"github.com/gravadigital/telescopio-api/internal/domain/attachment"
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/permission"
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
 	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
 	"github.com/gravadigital/telescopio-api/internal/storage"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
@@ -25,16 +33,18 @@ type AttachmentHandler struct {
 	eventRepo      postgres.EventRepository
 	userRepo       postgres.UserRepository
 	fileStorage    storage.FileStorage
+	cleaner        *storage.CleanupWorker
 	config         *config.Config
 	log            *log.Logger
 }
 
-func NewAttachmentHandler(attachmentRepo postgres.AttachmentRepository, eventRepo postgres.EventRepository, userRepo postgres.UserRepository, fileStorage storage.FileStorage, cfg *config.Config) *AttachmentHandler {
+func NewAttachmentHandler(attachmentRepo postgres.AttachmentRepository, eventRepo postgres.EventRepository, userRepo postgres.UserRepository, fileStorage storage.FileStorage, cleaner *storage.CleanupWorker, cfg *config.Config) *AttachmentHandler {
 	return &AttachmentHandler{
 		attachmentRepo: attachmentRepo,
 		eventRepo:      eventRepo,
 		userRepo:       userRepo,
 		fileStorage:    fileStorage,
+		cleaner:        cleaner,
 		config:         cfg,
 		log:            logger.Handler("attachment"),
 	}
@@ -79,7 +89,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 	}
 
 	// Check if event exists and validate its state
-	eventEntity, err := h.eventRepo.GetByID(eventID)
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -101,7 +111,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 	}
 
 	// Check if participant exists
-	participant, err := h.userRepo.GetByID(participantID)
+	participant, err := h.userRepo.GetByIDInOrganization(tenant.Scope(c), participantID)
 	if err != nil {
 		h.log.Error("participant not found", "participant_id", participantID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -124,7 +134,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 	}
 
 	// Check if participant is registered for this event
-	participantEvents, err := h.eventRepo.GetByParticipant(participantID)
+	participantEvents, err := h.eventRepo.GetByParticipant(eventEntity.OrganizationID, participantID)
 	isParticipant := false
 	if err == nil {
 		for _, evt := range participantEvents {
@@ -144,84 +154,60 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 		return
 	}
 
-	// Check if participant already has an attachment for this event
-	existingAttachments, err := h.attachmentRepo.GetByParticipantID(participantID)
-	if err == nil {
-		for _, att := range existingAttachments {
-			if att.EventID == eventUUID {
-				h.log.Warn("duplicate attachment attempt", "event_id", eventID, "participant_id", participantID, "existing_attachment", att.ID)
-				c.JSON(http.StatusConflict, gin.H{
-					"error":                  "Participant already has an attachment for this event",
-					"code":                   "DUPLICATE_ATTACHMENT",
-					"existing_attachment_id": att.ID.String(),
-				})
-				return
-			}
-		}
+	// Only regular participants submit; co-organizers, reviewers and observers don't
+	if role, err := h.eventRepo.GetParticipantRole(eventID, participantID); err == nil && !role.CanSubmit() {
+		h.log.Warn("upload attempt by non-submitting member", "event_id", eventID, "participant_id", participantID, "event_role", *role)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":      "Only participants can submit attachments to this event",
+			"code":       "ROLE_CANNOT_SUBMIT",
+			"event_role": role.String(),
+		})
+		return
 	}
 
-	// Get the file from the form
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		h.log.Warn("no file provided in request", "error", err)
+	// A proposal has a main document and optional supplementary files, each in a named slot.
+	// Uploading to a slot of an existing proposal adds a new version and keeps the previous ones.
+	slot := strings.TrimSpace(c.DefaultPostForm("slot", attachment.MainSlot))
+	if !attachment.IsValidSlot(slot) {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "No file provided",
-			"code":    "NO_FILE",
-			"details": err.Error(),
+			"error": "slot must be lowercase letters, digits, '-' or '_' (up to 50 characters)",
+			"code":  "INVALID_SLOT",
 		})
 		return
 	}
-	defer file.Close()
-
-	h.log.Debug("file received", "filename", header.Filename, "size", header.Size, "content_type", header.Header.Get("Content-Type"))
 
-	// Validate file size using configuration
-	if header.Size > h.config.Upload.MaxFileSize {
-		h.log.Warn("file size exceeds limit", "filename", header.Filename, "size", header.Size, "max_size", h.config.Upload.MaxFileSize)
+	existing, err := h.attachmentRepo.GetByEventAndParticipant(eventID, participantID)
+	if err != nil && err.Error() != "attachment not found" {
+		h.log.Error("failed to look up existing attachment", "event_id", eventID, "participant_id", participantID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachments",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+	if existing == nil && slot != attachment.MainSlot {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":         "File size exceeds limit",
-			"code":          "FILE_TOO_LARGE",
-			"max_size":      fmt.Sprintf("%d bytes", h.config.Upload.MaxFileSize),
-			"received_size": header.Size,
+			"error": "Upload the main document before supplementary files",
+			"code":  "MAIN_FILE_REQUIRED",
 		})
 		return
 	}
 
-	// Enhanced file type validation
-	contentType := header.Header.Get("Content-Type")
-	allowedTypes := map[string]string{
-		"image/jpeg":         "JPEG Image",
-		"image/png":          "PNG Image",
-		"image/gif":          "GIF Image",
-		"application/pdf":    "PDF Document",
-		"text/plain":         "Text Document",
-		"application/msword": "Word Document",
-		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "Word Document (DOCX)",
+	upload, ok := h.receiveFile(c, slot)
+	if !ok {
+		return
 	}
+	defer upload.file.Close()
+	cleanFilename := upload.name
 
-	if _, isAllowed := allowedTypes[contentType]; !isAllowed {
-		h.log.Warn("file type not allowed", "filename", header.Filename, "content_type", contentType)
-		allowedList := make([]string, 0, len(allowedTypes))
-		for _, desc := range allowedTypes {
-			allowedList = append(allowedList, desc)
-		}
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":         "File type not allowed",
-			"code":          "INVALID_FILE_TYPE",
-			"received_type": contentType,
-			"allowed_types": allowedList,
-		})
+	if existing != nil {
+		h.uploadFileVersion(c, eventEntity, existing, slot, upload)
 		return
 	}
 
-	// Security: Validate filename to prevent path traversal
-	cleanFilename := filepath.Base(header.Filename)
-	if cleanFilename != header.Filename || strings.Contains(cleanFilename, "..") {
-		h.log.Warn("suspicious filename detected", "original", header.Filename, "cleaned", cleanFilename)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid filename",
-			"code":  "INVALID_FILENAME",
-		})
+	// Answers to the event's submission form arrive as a JSON object in the "answers" field
+	answers, ok := h.bindSubmissionAnswers(c, eventEntity, c.PostForm("answers"))
+	if !ok {
 		return
 	}
 
@@ -231,7 +217,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 
 	// Use FileStorage interface to save the file
 	ctx := context.Background()
-	storageKey, err := h.fileStorage.Put(ctx, secureFilename, file, header.Size, contentType)
+	storageKey, err := h.fileStorage.Put(ctx, secureFilename, upload.file, upload.size, upload.contentType)
 	if err != nil {
 		h.log.Error("failed to store file", "key", secureFilename, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -248,9 +234,11 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 		secureFilename,
 		cleanFilename,
 		storageKey, // Use storage key instead of file path
-		contentType,
-		header.Size,
+		upload.contentType,
+		upload.size,
 	)
+	newAttachment.Answers = answers
+	newAttachment.ExtractedText = upload.extractedText
 
 	if err := h.attachmentRepo.Create(newAttachment); err != nil {
 		h.log.Error("failed to save attachment metadata", "attachment_id", newAttachment.ID, "error", err)
@@ -270,7 +258,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 		"event_id", eventID,
 		"participant_id", participantID,
 		"filename", cleanFilename,
-		"size", header.Size)
+		"size", upload.size)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"data": gin.H{
@@ -280,6 +268,9 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 			"mime_type":   newAttachment.MimeType,
 			"participant": participant.Name,
 			"uploaded_at": newAttachment.UploadedAt,
+			"answers":     newAttachment.Answers,
+			"slot":        attachment.MainSlot,
+			"version":     1,
 		},
 		"message": "File uploaded successfully",
 		"code":    "UPLOAD_SUCCESS",
@@ -292,7 +283,7 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 
 	h.log.Debug("retrieving attachment", "attachment_id", attachmentID)
 
-	attachment, err := h.attachmentRepo.GetByID(attachmentID)
+	attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID)
 	if err != nil {
 		h.log.Error("attachment not found", "attachment_id", attachmentID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -302,6 +293,25 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 		return
 	}
 
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), attachment.EventID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	files, err := h.visibleFiles(attachment, eventEntity)
+	if err != nil {
+		h.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachment files",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": gin.H{
 			"id":             attachment.ID.String(),
@@ -312,11 +322,15 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 			"uploaded_at":    attachment.UploadedAt,
 			"event_id":       attachment.EventID.String(),
 			"participant_id": attachment.ParticipantID.String(),
+			"co_author_ids":  attachment.GetCoAuthorIDs(),
+			"answers":        attachment.Answers,
+			"files":          files,
 		},
 	})
 }
 
 // GetEventAttachments handles GET /api/events/{event_id}/attachments
+// Filter by submission form answers with field[<key>]=<expression>
 func (h *AttachmentHandler) GetEventAttachments(c *gin.Context) {
 	eventID := c.Param("event_id")
 
@@ -332,6 +346,27 @@ func (h *AttachmentHandler) GetEventAttachments(c *gin.Context) {
 		return
 	}
 
+	if filter := submission.Filter(c.QueryMap("field")); len(filter) > 0 {
+		eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Event not found",
+				"code":  "EVENT_NOT_FOUND",
+			})
+			return
+		}
+
+		attachments, err = filterByAnswers(attachments, eventEntity.SubmissionForm, filter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid answer filter",
+				"code":    "INVALID_FILTER",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
 	// Transform to response format
 	attachmentData := make([]gin.H, len(attachments))
 	for i, att := range attachments {
@@ -340,6 +375,8 @@ func (h *AttachmentHandler) GetEventAttachments(c *gin.Context) {
 			"id":             att.ID.String(),
 			"event_id":       att.EventID.String(),
 			"participant_id": att.ParticipantID.String(),
+			"co_author_ids":  att.GetCoAuthorIDs(),
+			"answers":        att.Answers,
 			"original_name":  att.OriginalName,
 			"file_size":      att.FileSize,
 			"mime_type":      att.MimeType,
@@ -355,26 +392,50 @@ func (h *AttachmentHandler) GetEventAttachments(c *gin.Context) {
 }
 
 // DownloadAttachment handles GET /api/attachments/{attachment_id}/download
+// Serves the main document as reviewers see it. Allowed for the authors, the members of
+// the event, the event owner and co-organizers, and admins.
 func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
 	attachmentID := c.Param("attachment_id")
 
 	h.log.Debug("downloading attachment", "attachment_id", attachmentID)
 
-	attachment, err := h.attachmentRepo.GetByID(attachmentID)
+	attachment, eventEntity, ok := h.loadProposal(c, attachmentID)
+	if !ok {
+		return
+	}
+
+	if !h.canManageProposal(c, attachment, eventEntity) && !h.isEventMember(c, eventEntity) {
+		h.log.Warn("unauthorized attachment download attempt", "attachment_id", attachmentID, "event_id", eventEntity.ID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to download this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	// The main document, as reviewers got it once voting opened
+	files, err := h.visibleFiles(attachment, eventEntity)
 	if err != nil {
-		h.log.Error("attachment not found for download", "attachment_id", attachmentID, "error", err)
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Attachment not found",
-			"code":  "ATTACHMENT_NOT_FOUND",
+		h.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachment files",
+			"code":  "RETRIEVAL_ERROR",
 		})
 		return
 	}
+	mainFile := attachment.MainFile()
+	for _, f := range files {
+		if f.IsMain() {
+			mainFile = f
+			break
+		}
+	}
 
 	// Get file from storage
 	ctx := context.Background()
-	fileReader, err := h.fileStorage.Get(ctx, attachment.FilePath)
+	fileReader, err := h.fileStorage.Get(ctx, mainFile.FilePath)
 	if err != nil {
-		h.log.Error("file not found in storage", "attachment_id", attachmentID, "storage_key", attachment.FilePath, "error", err)
+		h.log.Error("file not found in storage", "attachment_id", attachmentID, "storage_key", mainFile.FilePath, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "File not found in storage",
 			"code":  "FILE_NOT_FOUND",
@@ -384,11 +445,11 @@ func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
 	defer fileReader.Close()
 
 	// Set appropriate headers for file download
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", attachment.OriginalName))
-	c.Header("Content-Type", attachment.MimeType)
-	c.Header("Content-Length", fmt.Sprintf("%d", attachment.FileSize))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", mainFile.OriginalName))
+	c.Header("Content-Type", mainFile.MimeType)
+	c.Header("Content-Length", fmt.Sprintf("%d", mainFile.FileSize))
 
-	h.log.Info("serving file download", "attachment_id", attachmentID, "filename", attachment.OriginalName)
+	h.log.Info("serving file download", "attachment_id", attachmentID, "filename", mainFile.OriginalName, "version", mainFile.Version)
 	
 	// Stream the file to the response
 	if _, err := io.Copy(c.Writer, fileReader); err != nil {
@@ -404,7 +465,7 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 	h.log.Debug("deleting attachment", "attachment_id", attachmentID)
 
 	// Get attachment details first
-	attachment, err := h.attachmentRepo.GetByID(attachmentID)
+	attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID)
 	if err != nil {
 		h.log.Error("attachment not found for deletion", "attachment_id", attachmentID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -414,15 +475,17 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 		return
 	}
 
-	// TODO: Add authorization check - only allow deletion by attachment owner or admin
-	// participantID := c.GetString("user_id") // From JWT middleware
-	// if attachment.ParticipantID.String() != participantID {
-	//     c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to delete this attachment"})
-	//     return
-	// }
-
-	// Check event stage - only allow deletion during participation stage
-	eventEntity, err := h.eventRepo.GetByID(attachment.EventID.String())
+	// Check event state - archived events are read-only and attachments can only be
+	// deleted during the participation stage
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), attachment.EventID.String())
+	if err == nil && eventEntity.IsArchived() {
+		h.log.Warn("deletion attempt on archived event", "attachment_id", attachmentID, "event_id", eventEntity.ID)
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return
+	}
 	if err == nil && eventEntity.Stage != event.StageParticipation {
 		h.log.Warn("deletion attempt outside participation stage", "attachment_id", attachmentID, "event_stage", eventEntity.Stage)
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -432,6 +495,25 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 		return
 	}
 
+	// Only the attachment owner, the event owner or co-organizers, or an admin can delete an attachment
+	userID, authErr := auth.GetUserIDFromContext(c)
+	if authErr != nil {
+		h.log.Warn("user not authenticated", "error", authErr)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+	if !auth.Authorize(c, h.eventRepo, permission.ProposalManage, eventEntity, attachment.ParticipantID) {
+		h.log.Warn("unauthorized attachment deletion attempt", "attachment_id", attachmentID, "user_id", userID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to delete this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
 	// Prevent event creator from deleting attachments as participant
 	if err == nil {
 		participant, err := h.userRepo.GetByID(attachment.ParticipantID.String())
@@ -448,6 +530,15 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 		}
 	}
 
+	// Every stored version goes, not only the current main document
+	storageKeys := []string{attachment.FilePath}
+	if files, err := h.attachmentRepo.GetFiles(attachmentID, true); err == nil && len(files) > 0 {
+		storageKeys = make([]string, len(files))
+		for i, f := range files {
+			storageKeys[i] = f.FilePath
+		}
+	}
+
 	// Delete from database
 	if err := h.attachmentRepo.Delete(attachmentID); err != nil {
 		h.log.Error("failed to delete attachment from database", "attachment_id", attachmentID, "error", err)
@@ -458,11 +549,10 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 		return
 	}
 
-	// Delete file from storage
-	ctx := context.Background()
-	if err := h.fileStorage.Delete(ctx, attachment.FilePath); err != nil {
-		h.log.Warn("failed to delete file from storage", "attachment_id", attachmentID, "storage_key", attachment.FilePath, "error", err)
-		// Don't fail the request if file deletion fails, as DB record is already deleted
+	// Remove the file from storage in the background (retried until it succeeds)
+	if err := h.cleaner.Enqueue(&attachment.EventID, storageKeys...); err != nil {
+		h.log.Warn("failed to schedule file removal", "attachment_id", attachmentID, "storage_keys", storageKeys, "error", err)
+		// Don't fail the request if scheduling fails, as DB record is already deleted
 	}
 
 	h.log.Info("attachment deleted successfully", "attachment_id", attachmentID, "filename", attachment.OriginalName)
@@ -472,3 +562,898 @@ func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 		"code":    "DELETE_SUCCESS",
 	})
 }
+
+type SetCoAuthorsRequest struct {
+	CoAuthorIDs []string `json:"co_author_ids" binding:"max=20,dive,uuid"`
+}
+
+// SetCoAuthors handles PUT /api/attachments/{attachment_id}/co-authors
+// Replaces the co-authors of a proposal. Co-authors must be registered participants of the
+// event. Allowed for the primary author, the event owner and co-organizers, and admins,
+// during the participation stage only.
+func (h *AttachmentHandler) SetCoAuthors(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+
+	h.log.Debug("setting attachment co-authors", "attachment_id", attachmentID)
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	var req SetCoAuthorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for co-authors", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Attachment not found",
+			"code":  "ATTACHMENT_NOT_FOUND",
+		})
+		return
+	}
+
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), attachment.EventID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+	eventID := eventEntity.ID.String()
+
+	if !auth.Authorize(c, h.eventRepo, permission.ProposalManage, eventEntity, attachment.ParticipantID) {
+		h.log.Warn("unauthorized co-author change attempt", "attachment_id", attachmentID, "user_id", userID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to change the co-authors of this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if eventEntity.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return
+	}
+	if eventEntity.Stage != event.StageParticipation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Co-authors can only be changed during the participation stage",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": eventEntity.Stage.String(),
+		})
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.CoAuthorIDs))
+	coAuthorIDs := make([]uuid.UUID, 0, len(req.CoAuthorIDs))
+	for _, raw := range req.CoAuthorIDs {
+		coAuthorID := uuid.MustParse(raw) // validated by the binding
+		if seen[coAuthorID] {
+			continue
+		}
+		seen[coAuthorID] = true
+
+		if coAuthorID == attachment.ParticipantID {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "The primary author cannot also be a co-author",
+				"code":  "INVALID_CO_AUTHOR",
+			})
+			return
+		}
+
+		role, err := h.eventRepo.GetParticipantRole(eventID, coAuthorID.String())
+		if err != nil || !role.CanSubmit() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":        "Co-authors must be registered participants of the event",
+				"code":         "CO_AUTHOR_NOT_PARTICIPANT",
+				"co_author_id": coAuthorID.String(),
+			})
+			return
+		}
+
+		coAuthorIDs = append(coAuthorIDs, coAuthorID)
+	}
+
+	if err := h.attachmentRepo.SetCoAuthors(attachmentID, coAuthorIDs); err != nil {
+		h.log.Error("failed to set co-authors", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update co-authors",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("attachment co-authors updated", "attachment_id", attachmentID, "co_authors", len(coAuthorIDs), "updated_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":             attachment.ID.String(),
+			"event_id":       eventID,
+			"participant_id": attachment.ParticipantID.String(),
+			"co_author_ids":  coAuthorIDs,
+		},
+		"message": "Co-authors updated successfully",
+		"code":    "CO_AUTHORS_UPDATED",
+	})
+}
+
+// bindSubmissionAnswers parses and validates answers (a JSON object) against the event's
+// submission form, writing the error response on failure. Events without a form take no answers.
+func (h *AttachmentHandler) bindSubmissionAnswers(c *gin.Context, evt *event.Event, raw string) (submission.Answers, bool) {
+	if evt.SubmissionForm == nil {
+		if strings.TrimSpace(raw) != "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "This event has no submission form",
+				"code":  "NO_SUBMISSION_FORM",
+			})
+			return nil, false
+		}
+		return nil, true
+	}
+
+	answers := submission.Answers{}
+	if strings.TrimSpace(raw) != "" {
+		if err := json.Unmarshal([]byte(raw), &answers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "answers must be a JSON object",
+				"code":    "INVALID_ANSWERS",
+				"details": err.Error(),
+			})
+			return nil, false
+		}
+	}
+
+	normalized, err := evt.SubmissionForm.ValidateAnswers(answers)
+	if err != nil {
+		var validationErr *submission.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":  "Submission form answers are invalid",
+				"code":   "INVALID_ANSWERS",
+				"fields": validationErr.Fields,
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Submission form answers are invalid",
+			"code":    "INVALID_ANSWERS",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+
+	return normalized, true
+}
+
+// UpdateAnswers handles PUT /api/attachments/{attachment_id}/answers
+// Replaces the submission form answers of a proposal. Allowed for the primary author,
+// the event owner and co-organizers, and admins, during the participation stage only.
+func (h *AttachmentHandler) UpdateAnswers(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+
+	h.log.Debug("updating attachment answers", "attachment_id", attachmentID)
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request payload",
+			"code":  "INVALID_PAYLOAD",
+		})
+		return
+	}
+
+	attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Attachment not found",
+			"code":  "ATTACHMENT_NOT_FOUND",
+		})
+		return
+	}
+
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), attachment.EventID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if !auth.Authorize(c, h.eventRepo, permission.ProposalManage, eventEntity, attachment.ParticipantID) {
+		h.log.Warn("unauthorized answers update attempt", "attachment_id", attachmentID, "user_id", userID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to change the answers of this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if eventEntity.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return
+	}
+	if eventEntity.Stage != event.StageParticipation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Answers can only be changed during the participation stage",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": eventEntity.Stage.String(),
+		})
+		return
+	}
+	if eventEntity.SubmissionForm == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "This event has no submission form",
+			"code":  "NO_SUBMISSION_FORM",
+		})
+		return
+	}
+
+	answers, ok := h.bindSubmissionAnswers(c, eventEntity, string(body))
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentRepo.UpdatePartial(attachmentID, map[string]interface{}{"answers": answers}); err != nil {
+		h.log.Error("failed to update attachment answers", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update answers",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("attachment answers updated", "attachment_id", attachmentID, "updated_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"id":       attachment.ID.String(),
+			"event_id": eventEntity.ID.String(),
+			"answers":  answers,
+		},
+		"message": "Answers updated successfully",
+		"code":    "ANSWERS_UPDATED",
+	})
+}
+
+// filterByAnswers keeps the attachments whose answers match the filter
+func filterByAnswers(attachments []*attachment.Attachment, form *submission.Form, filter submission.Filter) ([]*attachment.Attachment, error) {
+	if form == nil {
+		return nil, errors.New("this event has no submission form")
+	}
+	if err := filter.Validate(form); err != nil {
+		return nil, err
+	}
+
+	matching := make([]*attachment.Attachment, 0, len(attachments))
+	for _, att := range attachments {
+		if filter.Matches(form, att.Answers) {
+			matching = append(matching, att)
+		}
+	}
+	return matching, nil
+}
+
+// uploadedFile is a proposal file received in the "file" form field
+type uploadedFile struct {
+	file          multipart.File
+	name          string
+	size          int64
+	contentType   string
+	extractedText string // text of the main document, indexed for proposal search
+}
+
+// receiveFile reads and validates the uploaded file for the given slot, writing the error
+// response on failure. The caller closes the file.
+func (h *AttachmentHandler) receiveFile(c *gin.Context, slot string) (*uploadedFile, bool) {
+	// Get the file from the form
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		h.log.Warn("no file provided in request", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "No file provided",
+			"code":    "NO_FILE",
+			"details": err.Error(),
+		})
+		return nil, false
+	}
+	// Closed by the caller once the upload succeeds
+	accepted := false
+	defer func() {
+		if !accepted {
+			file.Close()
+		}
+	}()
+
+	h.log.Debug("file received", "filename", header.Filename, "size", header.Size, "content_type", header.Header.Get("Content-Type"))
+
+	// Validate file size using configuration
+	if header.Size > h.config.Upload.MaxFileSize {
+		h.log.Warn("file size exceeds limit", "filename", header.Filename, "size", header.Size, "max_size", h.config.Upload.MaxFileSize)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "File size exceeds limit",
+			"code":          "FILE_TOO_LARGE",
+			"max_size":      fmt.Sprintf("%d bytes", h.config.Upload.MaxFileSize),
+			"received_size": header.Size,
+		})
+		return nil, false
+	}
+
+	// Enhanced file type validation
+	contentType := header.Header.Get("Content-Type")
+	allowedTypes := map[string]string{
+		"image/jpeg":         "JPEG Image",
+		"image/png":          "PNG Image",
+		"image/gif":          "GIF Image",
+		"application/pdf":    "PDF Document",
+		"text/plain":         "Text Document",
+		"application/msword": "Word Document",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "Word Document (DOCX)",
+	}
+
+	if _, isAllowed := allowedTypes[contentType]; !isAllowed {
+		h.log.Warn("file type not allowed", "filename", header.Filename, "content_type", contentType)
+		allowedList := make([]string, 0, len(allowedTypes))
+		for _, desc := range allowedTypes {
+			allowedList = append(allowedList, desc)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "File type not allowed",
+			"code":          "INVALID_FILE_TYPE",
+			"received_type": contentType,
+			"allowed_types": allowedList,
+		})
+		return nil, false
+	}
+
+	// Security: Validate filename to prevent path traversal
+	cleanFilename := filepath.Base(header.Filename)
+	if cleanFilename != header.Filename || strings.Contains(cleanFilename, "..") {
+		h.log.Warn("suspicious filename detected", "original", header.Filename, "cleaned", cleanFilename)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid filename",
+			"code":  "INVALID_FILENAME",
+		})
+		return nil, false
+	}
+
+	// The text of the main document is indexed for proposal search
+	var extractedText string
+	if slot == attachment.MainSlot {
+		extractedText, err = storage.ExtractText(file, header.Size, contentType)
+		if err != nil {
+			h.log.Warn("failed to extract document text", "filename", cleanFilename, "content_type", contentType, "error", err)
+		}
+	}
+
+	accepted = true
+	return &uploadedFile{
+		file:          file,
+		name:          cleanFilename,
+		size:          header.Size,
+		contentType:   contentType,
+		extractedText: extractedText,
+	}, true
+}
+
+// uploadFileVersion stores a new version of one of the files of an existing proposal:
+// the main document or a supplementary file in the given slot. Answers sent along replace
+// the stored ones.
+func (h *AttachmentHandler) uploadFileVersion(c *gin.Context, eventEntity *event.Event, existing *attachment.Attachment, slot string, upload *uploadedFile) {
+	attachmentID := existing.ID.String()
+
+	current, err := h.attachmentRepo.GetFiles(attachmentID, false)
+	if err != nil {
+		h.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachment files",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	var previous *attachment.File
+	for _, f := range current {
+		if f.Slot == slot {
+			previous = f
+			break
+		}
+	}
+	if previous == nil && len(current) >= attachment.MaxSlotsPerProposal {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":     "This proposal already has the maximum number of files",
+			"code":      "TOO_MANY_FILES",
+			"max_files": attachment.MaxSlotsPerProposal,
+		})
+		return
+	}
+
+	var answers submission.Answers
+	if raw := c.PostForm("answers"); strings.TrimSpace(raw) != "" {
+		var ok bool
+		if answers, ok = h.bindSubmissionAnswers(c, eventEntity, raw); !ok {
+			return
+		}
+	}
+
+	uploaderID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		uploaderID = existing.ParticipantID
+	}
+
+	ext := filepath.Ext(upload.name)
+	secureFilename := fmt.Sprintf("%s_%s_%s_%d%s", existing.EventID, existing.ParticipantID, slot, time.Now().UnixNano(), ext)
+
+	ctx := context.Background()
+	storageKey, err := h.fileStorage.Put(ctx, secureFilename, upload.file, upload.size, upload.contentType)
+	if err != nil {
+		h.log.Error("failed to store file", "key", secureFilename, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save file to storage",
+			"code":  "FILE_SAVE_ERROR",
+		})
+		return
+	}
+
+	version := &attachment.File{
+		AttachmentID: existing.ID,
+		Slot:         slot,
+		Filename:     secureFilename,
+		OriginalName: upload.name,
+		FilePath:     storageKey,
+		FileSize:     upload.size,
+		MimeType:     upload.contentType,
+		UploadedBy:   uploaderID,
+	}
+	if err := h.attachmentRepo.AddFileVersion(version); err != nil {
+		h.log.Error("failed to save file version", "attachment_id", attachmentID, "slot", slot, "error", err)
+		if delErr := h.fileStorage.Delete(ctx, storageKey); delErr != nil {
+			h.log.Error("failed to cleanup file after db error", "key", storageKey, "error", delErr)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save attachment metadata",
+			"code":  "DB_SAVE_ERROR",
+		})
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if answers != nil {
+		updates["answers"] = answers
+	}
+	if version.IsMain() {
+		updates["extracted_text"] = upload.extractedText
+	}
+	if len(updates) > 0 {
+		if err := h.attachmentRepo.UpdatePartial(attachmentID, updates); err != nil {
+			h.log.Error("failed to update attachment", "attachment_id", attachmentID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to update attachment",
+				"code":  "DB_UPDATE_ERROR",
+			})
+			return
+		}
+	}
+
+	h.log.Info("attachment file version uploaded",
+		"attachment_id", attachmentID,
+		"slot", slot,
+		"version", version.Version,
+		"filename", upload.name,
+		"size", upload.size,
+		"uploaded_by", uploaderID)
+
+	data := gin.H{
+		"id":          attachmentID,
+		"file_id":     version.ID.String(),
+		"slot":        slot,
+		"version":     version.Version,
+		"filename":    version.OriginalName,
+		"size":        version.FileSize,
+		"mime_type":   version.MimeType,
+		"uploaded_at": version.UploadedAt,
+	}
+	if previous != nil {
+		data["replaced_file_id"] = previous.ID.String()
+	}
+	if answers != nil {
+		data["answers"] = answers
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "File version uploaded successfully",
+		"code":    "FILE_VERSION_UPLOADED",
+	})
+}
+
+// visibleFiles returns the files of a proposal as reviewers see them: once voting has
+// opened, the versions that were current at that moment; before that, the current ones
+func (h *AttachmentHandler) visibleFiles(att *attachment.Attachment, evt *event.Event) ([]*attachment.File, error) {
+	if evt.Stage == event.StageVoting || evt.Stage == event.StageResult {
+		return h.attachmentRepo.GetReviewFiles(att.ID.String())
+	}
+	return h.attachmentRepo.GetFiles(att.ID.String(), false)
+}
+
+// canManageProposal reports whether the user may see the file history of a proposal and
+// change its files: its authors, the event owner and co-organizers, and admins
+func (h *AttachmentHandler) canManageProposal(c *gin.Context, att *attachment.Attachment, evt *event.Event) bool {
+	return auth.Authorize(c, h.eventRepo, permission.ProposalManage, evt, att.AuthorIDs()...)
+}
+
+// isEventMember reports whether the user has a role in the event, and so may read the
+// proposal files reviewers see
+func (h *AttachmentHandler) isEventMember(c *gin.Context, evt *event.Event) bool {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		return false
+	}
+	_, err = h.eventRepo.GetParticipantRole(evt.ID.String(), userID.String())
+	return err == nil
+}
+
+// loadProposal loads an attachment and its event, writing the error response on failure
+func (h *AttachmentHandler) loadProposal(c *gin.Context, attachmentID string) (*attachment.Attachment, *event.Event, bool) {
+	att, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Attachment not found",
+			"code":  "ATTACHMENT_NOT_FOUND",
+		})
+		return nil, nil, false
+	}
+
+	eventEntity, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), att.EventID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return nil, nil, false
+	}
+
+	return att, eventEntity, true
+}
+
+// GetAttachmentFiles handles GET /api/attachments/{attachment_id}/files
+// Lists the files of a proposal, main document first. Once voting has opened, everyone gets
+// the versions that were current at that moment. Authors and event managers can pass
+// history=true to get every version, including replaced and removed ones.
+func (h *AttachmentHandler) GetAttachmentFiles(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+
+	att, eventEntity, ok := h.loadProposal(c, attachmentID)
+	if !ok {
+		return
+	}
+
+	history := c.Query("history") == "true"
+	if history && !h.canManageProposal(c, att, eventEntity) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the authors and event managers can see the file history",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	var files []*attachment.File
+	var err error
+	if history {
+		files, err = h.attachmentRepo.GetFiles(attachmentID, true)
+	} else {
+		files, err = h.visibleFiles(att, eventEntity)
+	}
+	if err != nil {
+		h.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachment files",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  files,
+		"count": len(files),
+	})
+}
+
+// DownloadAttachmentFile handles GET /api/attachments/{attachment_id}/files/{file_id}/download
+// Event members can download the versions listed by GetAttachmentFiles; authors and event
+// managers can download any version.
+func (h *AttachmentHandler) DownloadAttachmentFile(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+	fileID := c.Param("file_id")
+
+	att, eventEntity, ok := h.loadProposal(c, attachmentID)
+	if !ok {
+		return
+	}
+
+	file, err := h.attachmentRepo.GetFile(fileID)
+	if err != nil || file.AttachmentID != att.ID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "File not found",
+			"code":  "FILE_NOT_FOUND",
+		})
+		return
+	}
+
+	if !h.canManageProposal(c, att, eventEntity) {
+		if !h.isEventMember(c, eventEntity) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Not authorized to download this attachment",
+				"code":  "FORBIDDEN",
+			})
+			return
+		}
+
+		visible, err := h.visibleFiles(att, eventEntity)
+		if err != nil {
+			h.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to retrieve attachment files",
+				"code":  "RETRIEVAL_ERROR",
+			})
+			return
+		}
+
+		allowed := false
+		for _, f := range visible {
+			if f.ID == file.ID {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This version of the file is not available",
+				"code":  "FORBIDDEN",
+			})
+			return
+		}
+	}
+
+	fileReader, err := h.fileStorage.Get(context.Background(), file.FilePath)
+	if err != nil {
+		h.log.Error("file not found in storage", "file_id", fileID, "storage_key", file.FilePath, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "File not found in storage",
+			"code":  "FILE_NOT_FOUND",
+		})
+		return
+	}
+	defer fileReader.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.OriginalName))
+	c.Header("Content-Type", file.MimeType)
+	c.Header("Content-Length", fmt.Sprintf("%d", file.FileSize))
+
+	h.log.Info("serving file download", "attachment_id", attachmentID, "file_id", fileID, "slot", file.Slot, "version", file.Version)
+
+	if _, err := io.Copy(c.Writer, fileReader); err != nil {
+		h.log.Error("failed to stream file", "file_id", fileID, "error", err)
+	}
+}
+
+// UploadAttachmentFile handles POST /api/attachments/{attachment_id}/files
+// Adds a new version of the main document or of the file in "slot" during the participation
+// stage. Allowed for the authors, co-authors included, the event owner and co-organizers, and admins.
+func (h *AttachmentHandler) UploadAttachmentFile(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+
+	att, eventEntity, ok := h.loadProposal(c, attachmentID)
+	if !ok {
+		return
+	}
+
+	if !h.canManageProposal(c, att, eventEntity) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to change the files of this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if eventEntity.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return
+	}
+	if eventEntity.Stage != event.StageParticipation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "File uploads are only allowed during the participation stage",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": eventEntity.Stage.String(),
+		})
+		return
+	}
+
+	slot := strings.TrimSpace(c.DefaultPostForm("slot", attachment.MainSlot))
+	if !attachment.IsValidSlot(slot) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "slot must be lowercase letters, digits, '-' or '_' (up to 50 characters)",
+			"code":  "INVALID_SLOT",
+		})
+		return
+	}
+
+	upload, ok := h.receiveFile(c, slot)
+	if !ok {
+		return
+	}
+	defer upload.file.Close()
+
+	h.uploadFileVersion(c, eventEntity, att, slot, upload)
+}
+
+// RemoveAttachmentFile handles DELETE /api/attachments/{attachment_id}/files/{file_id}
+// Withdraws a supplementary file during the participation stage. Its versions stay in the
+// history; the main document can only be replaced, not removed.
+func (h *AttachmentHandler) RemoveAttachmentFile(c *gin.Context) {
+	attachmentID := c.Param("attachment_id")
+	fileID := c.Param("file_id")
+
+	att, eventEntity, ok := h.loadProposal(c, attachmentID)
+	if !ok {
+		return
+	}
+
+	if !h.canManageProposal(c, att, eventEntity) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Not authorized to change the files of this attachment",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if eventEntity.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return
+	}
+	if eventEntity.Stage != event.StageParticipation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Files can only be removed during the participation stage",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": eventEntity.Stage.String(),
+		})
+		return
+	}
+
+	file, err := h.attachmentRepo.GetFile(fileID)
+	if err != nil || file.AttachmentID != att.ID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "File not found",
+			"code":  "FILE_NOT_FOUND",
+		})
+		return
+	}
+	if file.IsMain() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "The main document can be replaced but not removed",
+			"code":  "MAIN_FILE_REQUIRED",
+		})
+		return
+	}
+	if !file.IsCurrent() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Only the current version of a file can be removed",
+			"code":  "FILE_NOT_CURRENT",
+		})
+		return
+	}
+
+	if err := h.attachmentRepo.RemoveFile(fileID); err != nil {
+		h.log.Error("failed to remove attachment file", "file_id", fileID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to remove file",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("attachment file removed", "attachment_id", attachmentID, "file_id", fileID, "slot", file.Slot)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "File removed successfully",
+		"code":    "FILE_REMOVED",
+	})
+}
+
+// SearchEventAttachments handles GET /api/events/{event_id}/attachments/search
+// Full-text search over the proposals of an event (form title, abstract and keywords, file
+// name and main document text) with ranking and highlighted fragments. Query parameters:
+// q (web search syntax), sort (relevance or uploaded_at), page and limit.
+func (h *AttachmentHandler) SearchEventAttachments(c *gin.Context) {
+	eventID := c.Param("event_id")
+	query := strings.TrimSpace(c.Query("q"))
+
+	h.log.Debug("searching event attachments", "event_id", eventID, "query", query)
+
+	page := 1
+	limit := 10
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	search := postgres.SearchParams{Query: query, SortBy: postgres.SortByRelevance}
+	if c.Query("sort") == "uploaded_at" {
+		search.SortBy = "uploaded_at"
+	}
+
+	result, err := h.attachmentRepo.Search(tenant.Scope(c), eventID, search, postgres.PaginationParams{Page: page, PageSize: limit})
+	if err != nil {
+		h.log.Error("failed to search attachments", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to search attachments",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	hits, _ := result.Data.([]*postgres.AttachmentSearchHit)
+	data := make([]gin.H, len(hits))
+	for i, hit := range hits {
+		att := hit.Attachment
+		data[i] = gin.H{
+			"id":             att.ID.String(),
+			"participant_id": att.ParticipantID.String(),
+			"original_name":  att.OriginalName,
+			"answers":        att.Answers,
+			"uploaded_at":    att.UploadedAt,
+			"url":            fmt.Sprintf("/api/v1/attachments/%s/download", att.ID.String()),
+		}
+		if query != "" {
+			data[i]["rank"] = hit.Rank
+			data[i]["highlights"] = hit.Highlights
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.PageSize,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+		"query": query,
+	})
+}
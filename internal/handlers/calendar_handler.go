@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/calendar"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// CalendarHandler serves iCalendar feeds of event dates and stage deadlines. Feeds are
+// built on every request, so date changes show up on the client's next refresh.
+type CalendarHandler struct {
+	container *postgres.Container
+	secret    []byte
+	config    *config.Config
+	log       *log.Logger
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(container *postgres.Container, cfg *config.Config) *CalendarHandler {
+	return &CalendarHandler{
+		container: container,
+		secret:    []byte(cfg.Calendar.SigningSecret),
+		config:    cfg,
+		log:       logger.Handler("calendar"),
+	}
+}
+
+// GetEventCalendar handles GET /api/events/{event_id}/calendar.ics
+// Public like the event listing: the event period and its participation and voting
+// deadlines. Archived, link_only and invite_only events are answered like unknown ones;
+// their members get the dates through their private feed.
+func (h *CalendarHandler) GetEventCalendar(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil || evt.IsArchived() || !evt.Visibility.IsListed() {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	cal := &calendar.Calendar{
+		Name:            evt.Name,
+		RefreshInterval: h.refreshInterval(),
+		Entries:         calendar.EntriesForEvent(evt, h.eventURL(c, evt)),
+	}
+
+	h.writeCalendar(c, cal, "event-"+evt.ID.String()+".ics", evt.UpdatedAt)
+}
+
+// GetCalendarFeed handles GET /api/calendar/feed
+// Returns the private feed URL of the authenticated user, creating the feed on first use
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	feed, err := h.container.CalendarFeeds().GetOrCreate(userID.String())
+	if err != nil {
+		h.log.Error("failed to get calendar feed", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get calendar feed",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    h.feedResponse(c, feed),
+		"message": "Calendar feed retrieved successfully",
+		"code":    "CALENDAR_FEED_RETRIEVED",
+	})
+}
+
+// RotateCalendarFeed handles POST /api/calendar/feed/rotate
+// Issues a new feed URL; the previous one stops working immediately
+func (h *CalendarHandler) RotateCalendarFeed(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	feed, err := h.container.CalendarFeeds().Rotate(userID.String())
+	if err != nil {
+		h.log.Error("failed to rotate calendar feed", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to rotate calendar feed",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("calendar feed rotated", "user_id", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    h.feedResponse(c, feed),
+		"message": "Calendar feed rotated successfully",
+		"code":    "CALENDAR_FEED_ROTATED",
+	})
+}
+
+// DeleteCalendarFeed handles DELETE /api/calendar/feed
+func (h *CalendarHandler) DeleteCalendarFeed(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.container.CalendarFeeds().Delete(userID.String()); err != nil {
+		if err.Error() == "calendar feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Calendar feed not found",
+				"code":  "CALENDAR_FEED_NOT_FOUND",
+			})
+			return
+		}
+		h.log.Error("failed to revoke calendar feed", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke calendar feed",
+			"code":  "DB_DELETE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("calendar feed revoked", "user_id", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Calendar feed revoked successfully",
+		"code":    "CALENDAR_FEED_REVOKED",
+	})
+}
+
+// ServeCalendarFeed handles GET /api/calendar/feeds/{token}.ics
+// Authenticated by the token in the URL so calendar clients can subscribe without a JWT.
+// Lists the events the user created or takes part in within the organization of the
+// request, except archived ones.
+func (h *CalendarHandler) ServeCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	feedID, err := calendar.ParseFeedToken(h.secret, token)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Calendar feed not found",
+			"code":  "CALENDAR_FEED_NOT_FOUND",
+		})
+		return
+	}
+
+	feed, err := h.container.CalendarFeeds().GetByID(feedID)
+	if err != nil {
+		if err.Error() != "calendar feed not found" {
+			h.log.Error("failed to retrieve calendar feed", "feed_id", feedID, "error", err)
+		}
+		// Rotated and revoked feeds are indistinguishable from unknown ones
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Calendar feed not found",
+			"code":  "CALENDAR_FEED_NOT_FOUND",
+		})
+		return
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.feedEvents(orgID, feed.UserID.String())
+	if err != nil {
+		h.log.Error("failed to retrieve calendar feed events", "user_id", feed.UserID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve events",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	if err := h.container.CalendarFeeds().Touch(feed.ID, time.Now()); err != nil {
+		h.log.Warn("failed to record calendar feed access", "feed_id", feed.ID, "error", err)
+	}
+
+	cal := &calendar.Calendar{
+		Name:            "Telescopio",
+		RefreshInterval: h.refreshInterval(),
+	}
+	lastModified := feed.CreatedAt
+	for _, evt := range events {
+		cal.Entries = append(cal.Entries, calendar.EntriesForEvent(evt, h.eventURL(c, evt))...)
+		if evt.UpdatedAt.After(lastModified) {
+			lastModified = evt.UpdatedAt
+		}
+	}
+
+	h.writeCalendar(c, cal, "telescopio.ics", lastModified)
+}
+
+// feedEvents returns the non-archived events of the organization a user created or takes
+// part in
+func (h *CalendarHandler) feedEvents(organizationID uuid.UUID, userID string) ([]*event.Event, error) {
+	authored, err := h.container.Events().GetByAuthor(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	participating, err := h.container.Events().GetUserParticipatingEvents(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(authored)+len(participating))
+	events := make([]*event.Event, 0, len(authored)+len(participating))
+	for _, evt := range append(authored, participating...) {
+		if evt.IsArchived() || seen[evt.ID] {
+			continue
+		}
+		seen[evt.ID] = true
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// writeCalendar sends the calendar with an ETag so polling clients get 304 while nothing changed
+func (h *CalendarHandler) writeCalendar(c *gin.Context, cal *calendar.Calendar, filename string, lastModified time.Time) {
+	body := cal.Marshal()
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, calendar.ContentType, body)
+}
+
+// feedResponse describes a feed with its subscription URLs
+func (h *CalendarHandler) feedResponse(c *gin.Context, feed *calendar.Feed) gin.H {
+	feedURL := h.publicBaseURL(c) + "/api/v1/calendar/feeds/" + calendar.SignFeedToken(h.secret, feed) + ".ics"
+
+	webcalURL := feedURL
+	if _, rest, found := strings.Cut(feedURL, "://"); found {
+		webcalURL = "webcal://" + rest
+	}
+
+	return gin.H{
+		"url":              feedURL,
+		"webcal_url":       webcalURL,
+		"created_at":       feed.CreatedAt,
+		"last_accessed_at": feed.LastAccessedAt,
+	}
+}
+
+func (h *CalendarHandler) currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *CalendarHandler) refreshInterval() time.Duration {
+	return time.Duration(h.config.Calendar.RefreshIntervalMinutes) * time.Minute
+}
+
+// publicBaseURL is the configured public API URL, or the host the request was sent to
+func (h *CalendarHandler) publicBaseURL(c *gin.Context) string {
+	if h.config.Server.PublicURL != "" {
+		return strings.TrimRight(h.config.Server.PublicURL, "/")
+	}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// eventURL links calendar entries to the event page of the frontend
+func (h *CalendarHandler) eventURL(c *gin.Context, evt *event.Event) string {
+	baseURL := h.config.Server.FrontendURL
+	if baseURL == "" {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + c.Request.Host
+	}
+	link := evt.ShareableLink
+	if link == "" {
+		link = "/events/" + evt.ID.String()
+	}
+	return baseURL + link
+}
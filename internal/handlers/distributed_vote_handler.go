@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,10 +14,14 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/stage"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
 type DistributedVoteHandler struct {
+	container      *postgres.Container
 	voteRepo       postgres.VoteRepository
 	eventRepo      postgres.EventRepository
 	attachmentRepo postgres.AttachmentRepository
@@ -28,6 +34,7 @@ type DistributedVoteHandler struct {
 }
 
 func NewDistributedVoteHandler(
+	container *postgres.Container,
 	voteRepo postgres.VoteRepository,
 	eventRepo postgres.EventRepository,
 	attachmentRepo postgres.AttachmentRepository,
@@ -37,13 +44,14 @@ func NewDistributedVoteHandler(
 	cfg *config.Config,
 ) *DistributedVoteHandler {
 	// Create adapters to bridge interface differences
-	voteAdapter := NewVoteRepositoryAdapter(voteRepo)
-	attachmentAdapter := NewAttachmentRepositoryAdapter(attachmentRepo)
-	userAdapter := NewUserRepositoryAdapter(userRepo)
+	voteAdapter := postgres.NewVoteRepositoryAdapter(voteRepo)
+	attachmentAdapter := postgres.NewAttachmentRepositoryAdapter(attachmentRepo)
+	userAdapter := postgres.NewUserRepositoryAdapter(userRepo)
 
 	votingService := vote.NewVotingService(voteAdapter, attachmentAdapter, userAdapter)
 
 	return &DistributedVoteHandler{
+		container:      container,
 		voteRepo:       voteRepo,
 		eventRepo:      eventRepo,
 		attachmentRepo: attachmentRepo,
@@ -83,7 +91,7 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		return
 	}
 
-	// Authorization handled by RequireEventOwnerOrOrganizer middleware
+	// Authorization handled by the event.manage permission middleware
 	// User is guaranteed to have permission to configure this event
 
 	var req struct {
@@ -91,7 +99,9 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		QualityGoodThreshold    float64 `json:"quality_good_threshold" binding:"min=0,max=1"`
 		QualityBadThreshold     float64 `json:"quality_bad_threshold" binding:"min=0,max=1"`
 		AdjustmentMagnitude     int     `json:"adjustment_magnitude" binding:"min=1,max=10"`
-		MinEvaluationsPerFile   int     `json:"min_evaluations_per_file" binding:"min=1,max=20"`
+		MinEvaluationsPerFile   int        `json:"min_evaluations_per_file" binding:"min=1,max=20"`
+		AmendmentDeadline       *time.Time `json:"amendment_deadline"`
+		TeamQualityPolicy       string     `json:"team_quality_policy" binding:"omitempty,oneof=average minimum"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -117,7 +127,7 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -127,7 +137,7 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		return
 	}
 
-	// Authorization handled by RequireEventOwnerOrOrganizer middleware
+	// Authorization handled by the event.manage permission middleware
 	// User is guaranteed to have permission to configure this event
 
 	// Only allow configuration during participation or voting stages
@@ -157,8 +167,8 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		return
 	}
 
-	// Get participants and attachments to validate configuration
-	participants, err := h.userRepo.GetEventParticipants(eventID)
+	// Get evaluators (participants and reviewers) and attachments to validate configuration
+	members, err := h.userRepo.GetEventParticipants(eventID)
 	if err != nil {
 		h.log.Error("failed to get participants", "event_id", eventID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -167,11 +177,12 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		})
 		return
 	}
+	participants := stage.EvaluatorIDs(members)
 
 	if len(participants) < 2 {
-		h.log.Warn("insufficient participants for voting", "event_id", eventID, "participant_count", len(participants))
+		h.log.Warn("insufficient evaluators for voting", "event_id", eventID, "evaluator_count", len(participants))
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":         "At least 2 participants are required for distributed voting",
+			"error":         "At least 2 participants or reviewers are required for distributed voting",
 			"code":          "INSUFFICIENT_PARTICIPANTS",
 			"current_count": len(participants),
 		})
@@ -225,8 +236,10 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 	}
 
 	// IMPORTANT: Validate considering conflict of interest (participants can't evaluate their own files)
-	// Maximum evaluable attachments per participant = total_attachments - 1 (excluding their own)
-	maxEvaluablePerParticipant := len(attachments) - 1
+	// Maximum evaluable attachments per participant = total_attachments - 1 (excluding their own),
+	// or total_attachments when every evaluator is a reviewer who did not submit
+	attachmentIDs := stage.AttachmentIDs(attachments)
+	maxEvaluablePerParticipant := h.votingService.MaxAttachmentsPerEvaluator(participants, attachmentIDs)
 	if req.AttachmentsPerEvaluator > maxEvaluablePerParticipant {
 		h.log.Warn("attachments_per_evaluator exceeds maximum evaluable (excluding own submissions)",
 			"event_id", eventID,
@@ -255,6 +268,7 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 		QualityBadThreshold:     req.QualityBadThreshold,
 		AdjustmentMagnitude:     req.AdjustmentMagnitude,
 		MinEvaluationsPerFile:   req.MinEvaluationsPerFile,
+		AmendmentDeadline:       req.AmendmentDeadline,
 		CreatedAt:               time.Now(),
 	}
 
@@ -271,9 +285,13 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 	if config.MinEvaluationsPerFile == 0 {
 		config.MinEvaluationsPerFile = 3
 	}
+	config.TeamQualityPolicy = vote.TeamQualityAverage
+	if req.TeamQualityPolicy != "" {
+		config.TeamQualityPolicy = vote.TeamQualityPolicy(req.TeamQualityPolicy)
+	}
 
 	// Validate configuration with current data
-	if err := h.votingService.ValidateVotingConfiguration(config, len(attachments), len(participants)); err != nil {
+	if err := h.votingService.ValidateVotingConfigurationForEvaluators(config, participants, attachmentIDs); err != nil {
 		h.log.Error("voting configuration validation failed", "event_id", eventID, "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid voting configuration",
@@ -328,6 +346,8 @@ func (h *DistributedVoteHandler) CreateVotingConfiguration(c *gin.Context) {
 			"quality_bad_threshold":     config.QualityBadThreshold,
 			"adjustment_magnitude":      config.AdjustmentMagnitude,
 			"min_evaluations_per_file":  config.MinEvaluationsPerFile,
+			"team_quality_policy":       config.TeamQualityPolicy,
+			"amendment_deadline":        config.AmendmentDeadline,
 			"created_at":                config.CreatedAt,
 		},
 		"message": "Voting configuration created successfully",
@@ -368,11 +388,11 @@ func (h *DistributedVoteHandler) GenerateAssignments(c *gin.Context) {
 		return
 	}
 
-	// Authorization handled by RequireEventOwnerOrOrganizer middleware
+	// Authorization handled by the event.manage permission middleware
 	// User is guaranteed to have permission to generate assignments
 
 	// Check if event exists and is in voting stage
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -415,21 +435,17 @@ func (h *DistributedVoteHandler) GenerateAssignments(c *gin.Context) {
 		return
 	}
 
-	if len(participantUsers) < 2 {
-		h.log.Warn("insufficient participants for assignment generation", "event_id", eventID, "participant_count", len(participantUsers))
+	participants := stage.EvaluatorIDs(participantUsers)
+	if len(participants) < 2 {
+		h.log.Warn("insufficient evaluators for assignment generation", "event_id", eventID, "evaluator_count", len(participants))
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error":         "At least 2 participants are required for distributed voting",
+			"error":         "At least 2 participants or reviewers are required for distributed voting",
 			"code":          "INSUFFICIENT_PARTICIPANTS",
-			"current_count": len(participantUsers),
+			"current_count": len(participants),
 		})
 		return
 	}
 
-	participants := make([]uuid.UUID, len(participantUsers))
-	for i, p := range participantUsers {
-		participants[i] = p.ID
-	}
-
 	attachments, err := h.attachmentRepo.GetByEventID(eventID)
 	if err != nil {
 		h.log.Error("failed to get attachments", "event_id", eventID, "error", err)
@@ -449,10 +465,7 @@ func (h *DistributedVoteHandler) GenerateAssignments(c *gin.Context) {
 		return
 	}
 
-	attachmentIDs := make([]uuid.UUID, len(attachments))
-	for i, a := range attachments {
-		attachmentIDs[i] = a.ID
-	}
+	attachmentIDs := stage.AttachmentIDs(attachments)
 
 	// Get voting configuration
 	config, err := h.configRepo.GetByEventID(eventID)
@@ -467,7 +480,7 @@ func (h *DistributedVoteHandler) GenerateAssignments(c *gin.Context) {
 	}
 
 	// Validate configuration is still valid with current data
-	if err := h.votingService.ValidateVotingConfiguration(config, len(attachments), len(participants)); err != nil {
+	if err := h.votingService.ValidateVotingConfigurationForEvaluators(config, participants, attachmentIDs); err != nil {
 		h.log.Error("voting configuration is no longer valid", "event_id", eventID, "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Voting configuration is no longer valid with current data",
@@ -539,6 +552,7 @@ func (h *DistributedVoteHandler) GenerateAssignments(c *gin.Context) {
 			"id":                        config.ID.String(),
 			"attachments_per_evaluator": config.AttachmentsPerEvaluator,
 			"min_evaluations_per_file":  config.MinEvaluationsPerFile,
+			"team_quality_policy":       config.TeamQualityPolicy,
 		},
 	})
 }
@@ -554,7 +568,7 @@ func (h *DistributedVoteHandler) GetParticipantAssignment(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
 		return
@@ -570,7 +584,7 @@ func (h *DistributedVoteHandler) GetParticipantAssignment(c *gin.Context) {
 	}
 
 	// Check if participant is registered for this event
-	participantEvents, err := h.eventRepo.GetByParticipant(participantID)
+	participantEvents, err := h.eventRepo.GetByParticipant(eventObj.OrganizationID, participantID)
 	isParticipant := false
 	if err == nil {
 		for _, evt := range participantEvents {
@@ -593,10 +607,29 @@ func (h *DistributedVoteHandler) GetParticipantAssignment(c *gin.Context) {
 		return
 	}
 
+	// Submission form answers and files of the assigned proposals, without author information
+	proposals := make([]gin.H, 0, len(assignment.GetAttachmentUUIDs()))
+	for _, attachmentID := range assignment.GetAttachmentUUIDs() {
+		att, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), attachmentID.String())
+		if err != nil {
+			continue
+		}
+		// The file versions that were current when voting opened
+		files, _ := h.attachmentRepo.GetReviewFiles(att.ID.String())
+		proposals = append(proposals, gin.H{
+			"attachment_id": att.ID.String(),
+			"original_name": att.OriginalName,
+			"answers":       att.Answers,
+			"files":         files,
+		})
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"assignment":     assignment,
-		"event_name":     eventObj.Name,
-		"participant_id": participantID,
+		"assignment":      assignment,
+		"proposals":       proposals,
+		"submission_form": eventObj.SubmissionForm,
+		"event_name":      eventObj.Name,
+		"participant_id":  participantID,
 	})
 }
 
@@ -627,7 +660,7 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 	}
 
 	// Check if event exists and is in voting stage
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
 		return
@@ -642,7 +675,7 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 	}
 
 	// Check if participant is registered
-	participantEvents, err := h.eventRepo.GetByParticipant(participantID)
+	participantEvents, err := h.eventRepo.GetByParticipant(eventObj.OrganizationID, participantID)
 	isParticipant := false
 	if err == nil {
 		for _, evt := range participantEvents {
@@ -678,20 +711,6 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 		return
 	}
 
-	// IMPORTANT: Check if assignment is already completed (votes already submitted)
-	if assignment.IsCompleted {
-		h.log.Warn("attempt to submit votes for already completed assignment",
-			"event_id", eventID,
-			"participant_id", participantID,
-			"assignment_id", assignment.ID)
-		c.JSON(http.StatusConflict, gin.H{
-			"error":        "Votes have already been submitted for this assignment",
-			"code":         "VOTES_ALREADY_SUBMITTED",
-			"completed_at": assignment.CompletedAt,
-		})
-		return
-	}
-
 	// Get assigned attachments to validate the vote
 	assignedAttachments := assignment.GetAttachmentUUIDs()
 	assignedMap := make(map[uuid.UUID]bool)
@@ -739,7 +758,7 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 		}
 
 		// Check if attachment exists and belongs to this event
-		attachment, err := h.attachmentRepo.GetByID(ranking.AttachmentID)
+		attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), ranking.AttachmentID)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Attachment not found: " + ranking.AttachmentID,
@@ -765,9 +784,128 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 		votes = append(votes, vote)
 	}
 
+	// Votes and the assignment completion are written atomically: either every vote
+	// is stored and the assignment is marked completed, or nothing is.
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin vote submission transaction", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save votes",
+			"code":  "TRANSACTION_FAILED",
+		})
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	var idempotencyRecord *postgres.IdempotencyKey
+	if key := c.GetHeader(IdempotencyKeyHeader); key != "" {
+		userID, err := auth.GetUserIDFromContext(c)
+		if err != nil {
+			_ = tx.Rollback()
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "code": "UNAUTHORIZED"})
+			return
+		}
+
+		idempotencyRecord = &postgres.IdempotencyKey{
+			Key:         key,
+			UserID:      userID,
+			Scope:       "ranking-votes:" + eventID + ":" + participantID,
+			RequestHash: requestHash(req),
+		}
+
+		existing, err := tx.IdempotencyKeys().Reserve(idempotencyRecord)
+		if err != nil {
+			_ = tx.Rollback()
+			h.log.Error("failed to reserve idempotency key", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to save votes",
+				"code":  "IDEMPOTENCY_FAILED",
+			})
+			return
+		}
+
+		if existing != nil {
+			_ = tx.Rollback()
+			replayIdempotentResponse(c, existing, idempotencyRecord)
+			return
+		}
+	}
+
+	// Lock the assignment so concurrent submissions for it are serialized
+	assignment, err = tx.Votes().LockAssignment(assignment.ID.String())
+	if err != nil {
+		_ = tx.Rollback()
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assignment not found for this participant"})
+		return
+	}
+
+	// A completed assignment can be amended while the amendment window is open.
+	// Amendments must rank every assigned attachment.
+	amended := assignment.IsCompleted
+	if assignment.IsCompleted {
+		votingConfig, err := h.configRepo.GetByEventID(eventID)
+		if err != nil {
+			if !errors.Is(err, postgres.ErrVotingConfigNotFound) {
+				_ = tx.Rollback()
+				h.log.Error("failed to retrieve voting configuration", "event_id", eventID, "error", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to retrieve voting configuration",
+					"code":  "CONFIG_RETRIEVAL_ERROR",
+				})
+				return
+			}
+			// Without a configuration the defaults apply: no amendments
+			votingConfig = nil
+		}
+		if !votingConfig.AllowsAmendment(time.Now()) {
+			_ = tx.Rollback()
+			h.log.Warn("attempt to amend votes after amendment deadline",
+				"event_id", eventID,
+				"participant_id", participantID,
+				"assignment_id", assignment.ID)
+			c.JSON(http.StatusConflict, gin.H{
+				"error":              "Votes have already been submitted and the amendment window is closed",
+				"code":               "VOTES_ALREADY_SUBMITTED",
+				"completed_at":       assignment.CompletedAt,
+				"amendment_deadline": amendmentDeadline(votingConfig),
+			})
+			return
+		}
+
+		if len(votes) != len(assignedAttachments) {
+			_ = tx.Rollback()
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "An amendment must rank every assigned attachment",
+				"code":  "INCOMPLETE_AMENDMENT",
+			})
+			return
+		}
+	}
+
+	// Only the latest version is kept in votes; earlier versions live in vote_revisions
+	if err := tx.Votes().DeleteByAssignmentID(assignment.ID.String()); err != nil {
+		_ = tx.Rollback()
+		h.log.Error("failed to replace previous votes", "assignment_id", assignment.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save votes",
+			"code":  "VOTE_REPLACE_FAILED",
+		})
+		return
+	}
+
 	// Save votes
 	for _, v := range votes {
-		if err := h.voteRepo.Create(v); err != nil {
+		if err := tx.Votes().Create(v); err != nil {
+			_ = tx.Rollback()
+			h.log.Error("failed to save vote, submission rolled back",
+				"event_id", eventID,
+				"participant_id", participantID,
+				"error", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to save vote",
 			})
@@ -775,23 +913,88 @@ func (h *DistributedVoteHandler) SubmitRankingVotes(c *gin.Context) {
 		}
 	}
 
+	revision := vote.NewVoteRevision(assignment, votes)
+	if err := tx.Votes().CreateRevision(revision); err != nil {
+		_ = tx.Rollback()
+		h.log.Error("failed to record vote revision", "assignment_id", assignment.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save votes",
+			"code":  "REVISION_FAILED",
+		})
+		return
+	}
+
+	// Results computed from the replaced votes are stale; they are recalculated on the next read
+	if amended {
+		if err := tx.VotingResults().Delete(eventID); err != nil {
+			_ = tx.Rollback()
+			h.log.Error("failed to invalidate voting results", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to save votes",
+				"code":  "RESULTS_INVALIDATION_FAILED",
+			})
+			return
+		}
+	}
+
 	// Mark assignment as completed if all attachments are ranked
-	if len(votes) == len(assignedAttachments) {
-		assignment.IsCompleted = true
-		now := time.Now()
-		assignment.CompletedAt = &now
-		if err := h.voteRepo.UpdateAssignment(assignment); err != nil {
-			// Log error but don't fail the request - just ignore logging for now
-			// TODO: Improve error handling
+	if len(votes) == len(assignedAttachments) && !assignment.IsCompleted {
+		assignment.MarkCompleted()
+		if err := tx.Votes().UpdateAssignment(assignment); err != nil {
+			_ = tx.Rollback()
+			h.log.Error("failed to mark assignment completed, submission rolled back",
+				"assignment_id", assignment.ID,
+				"error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to save votes",
+				"code":  "ASSIGNMENT_UPDATE_FAILED",
+			})
+			return
 		}
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	response := gin.H{
 		"message":        "Ranking votes submitted successfully",
 		"event_id":       eventID,
 		"participant_id": participantID,
 		"votes_count":    len(votes),
-	})
+		"revision":       revision.Revision,
+		"amended":        amended,
+	}
+
+	if idempotencyRecord != nil {
+		body, err := json.Marshal(response)
+		if err == nil {
+			err = tx.IdempotencyKeys().Complete(idempotencyRecord.ID, http.StatusCreated, body)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			h.log.Error("failed to store idempotent response", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to save votes",
+				"code":  "IDEMPOTENCY_FAILED",
+			})
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit vote submission", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save votes",
+			"code":  "TRANSACTION_FAILED",
+		})
+		return
+	}
+
+	h.log.Info("ranking votes submitted",
+		"event_id", eventID,
+		"participant_id", participantID,
+		"votes_count", len(votes),
+		"revision", revision.Revision,
+		"amended", amended)
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // GetDistributedResults handles GET /api/events/{event_id}/distributed-results
@@ -809,7 +1012,7 @@ func (h *DistributedVoteHandler) GetDistributedResults(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
 		return
@@ -1007,6 +1210,78 @@ func (h *DistributedVoteHandler) GetVotingStatistics(c *gin.Context) {
 	})
 }
 
+// GetReliabilityReport handles GET /api/events/{event_id}/reliability-report
+func (h *DistributedVoteHandler) GetReliabilityReport(c *gin.Context) {
+	eventID := c.Param("event_id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event_id is required"})
+		return
+	}
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event_id format"})
+		return
+	}
+
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	if eventObj.Stage != event.StageVoting && eventObj.Stage != event.StageResult {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":         "Reliability report is only available during voting or results stage",
+			"current_stage": eventObj.Stage.String(),
+		})
+		return
+	}
+
+	config, err := h.configRepo.GetByEventID(eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Voting configuration not found",
+			"details": "Please create a voting configuration before requesting a reliability report",
+		})
+		return
+	}
+
+	// Rankings are optional: without votes the connectivity check is still useful
+	results, err := h.votingService.CalculateModifiedBordaCount(eventUUID, config)
+	if err != nil {
+		h.log.Debug("reliability report without rankings", "event_id", eventID, "reason", err)
+		results = nil
+	}
+
+	report, err := h.votingService.CalculateReliabilityReport(eventUUID, results)
+	if errors.Is(err, vote.ErrNoAssignments) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "No assignments have been generated for this event",
+			"details": "Generate assignments before requesting a reliability report",
+		})
+		return
+	}
+	if err != nil {
+		h.log.Error("failed to calculate reliability report", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to calculate reliability report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !report.Connectivity.IsConnected {
+		h.log.Warn("co-assignment graph is disconnected",
+			"event_id", eventID,
+			"components", report.Connectivity.Components)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
 // Helper function to get participant voting status
 func participantVotingStatus(assignments []*vote.Assignment) map[string]bool {
 	status := make(map[string]bool)
@@ -1050,11 +1325,150 @@ func (h *DistributedVoteHandler) GetVotingConfiguration(c *gin.Context) {
 			"quality_bad_threshold":     config.QualityBadThreshold,
 			"adjustment_magnitude":      config.AdjustmentMagnitude,
 			"min_evaluations_per_file":  config.MinEvaluationsPerFile,
+			"team_quality_policy":       config.TeamQualityPolicy,
+			"amendment_deadline":        config.AmendmentDeadline,
 			"created_at":                config.CreatedAt,
 		},
 	})
 }
 
+// UpdateAmendmentDeadline handles PATCH /api/events/{event_id}/voting-config/amendment-deadline
+// A null deadline keeps amendments open until the voting stage closes.
+func (h *DistributedVoteHandler) UpdateAmendmentDeadline(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	var req struct {
+		AmendmentDeadline *time.Time `json:"amendment_deadline"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if eventObj.Stage != event.StageParticipation && eventObj.Stage != event.StageVoting {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Amendment deadline can only be set during participation or voting stages",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": eventObj.Stage.String(),
+		})
+		return
+	}
+
+	if req.AmendmentDeadline != nil && !req.AmendmentDeadline.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Amendment deadline must be in the future",
+			"code":  "INVALID_AMENDMENT_DEADLINE",
+		})
+		return
+	}
+
+	config, err := h.configRepo.GetByEventID(eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Voting configuration not found for this event",
+			"code":  "CONFIG_NOT_FOUND",
+		})
+		return
+	}
+
+	config.AmendmentDeadline = req.AmendmentDeadline
+	if err := h.configRepo.Update(config); err != nil {
+		h.log.Error("failed to update amendment deadline", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update amendment deadline",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("amendment deadline updated", "event_id", eventID, "amendment_deadline", req.AmendmentDeadline)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":           eventID,
+			"amendment_deadline": config.AmendmentDeadline,
+		},
+		"message": "Amendment deadline updated successfully",
+		"code":    "AMENDMENT_DEADLINE_UPDATED",
+	})
+}
+
+// GetVoteHistory handles GET /api/events/{event_id}/vote-history
+// Returns every submitted ranking version grouped by voter; filter with ?participant_id=
+func (h *DistributedVoteHandler) GetVoteHistory(c *gin.Context) {
+	eventID := c.Param("event_id")
+	participantFilter := c.Query("participant_id")
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	revisions, err := h.voteRepo.GetRevisionsByEventID(eventID)
+	if err != nil {
+		h.log.Error("failed to get vote revisions", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get vote history",
+			"code":  "VOTE_HISTORY_ERROR",
+		})
+		return
+	}
+
+	type voterHistory struct {
+		VoterID         string               `json:"voter_id"`
+		AssignmentID    string               `json:"assignment_id"`
+		CurrentRevision int                  `json:"current_revision"`
+		Amendments      int                  `json:"amendments"`
+		Revisions       []*vote.VoteRevision `json:"revisions"`
+	}
+
+	var history []*voterHistory
+	byVoter := make(map[uuid.UUID]*voterHistory)
+	for _, revision := range revisions {
+		if participantFilter != "" && revision.VoterID.String() != participantFilter {
+			continue
+		}
+
+		entry, exists := byVoter[revision.VoterID]
+		if !exists {
+			entry = &voterHistory{
+				VoterID:      revision.VoterID.String(),
+				AssignmentID: revision.AssignmentID.String(),
+			}
+			byVoter[revision.VoterID] = entry
+			history = append(history, entry)
+		}
+
+		entry.Revisions = append(entry.Revisions, revision)
+		entry.CurrentRevision = max(entry.CurrentRevision, revision.Revision)
+		entry.Amendments = len(entry.Revisions) - 1
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id": eventID,
+			"voters":   history,
+		},
+	})
+}
+
 // UpdateVotingConfiguration handles PUT /api/events/{event_id}/voting-config
 func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 	eventID := c.Param("event_id")
@@ -1070,7 +1484,7 @@ func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 	}
 
 	// Check if event exists and is still configurable
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Event not found",
@@ -1118,6 +1532,7 @@ func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 		QualityBadThreshold     float64 `json:"quality_bad_threshold" binding:"min=0,max=1"`
 		AdjustmentMagnitude     int     `json:"adjustment_magnitude" binding:"min=1,max=10"`
 		MinEvaluationsPerFile   int     `json:"min_evaluations_per_file" binding:"min=1,max=20"`
+		TeamQualityPolicy       string  `json:"team_quality_policy" binding:"omitempty,oneof=average minimum"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -1129,7 +1544,10 @@ func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 		return
 	}
 
-	// Update configuration
+	// Update configuration (the team quality policy is kept unless given)
+	if req.TeamQualityPolicy != "" {
+		config.TeamQualityPolicy = vote.TeamQualityPolicy(req.TeamQualityPolicy)
+	}
 	config.AttachmentsPerEvaluator = req.AttachmentsPerEvaluator
 	config.QualityGoodThreshold = req.QualityGoodThreshold
 	config.QualityBadThreshold = req.QualityBadThreshold
@@ -1137,10 +1555,10 @@ func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 	config.MinEvaluationsPerFile = req.MinEvaluationsPerFile
 
 	// Re-validate configuration
-	participants, _ := h.userRepo.GetEventParticipants(eventID)
+	members, _ := h.userRepo.GetEventParticipants(eventID)
 	attachments, _ := h.attachmentRepo.GetByEventID(eventID)
 
-	if err := h.votingService.ValidateVotingConfiguration(config, len(attachments), len(participants)); err != nil {
+	if err := h.votingService.ValidateVotingConfigurationForEvaluators(config, stage.EvaluatorIDs(members), stage.AttachmentIDs(attachments)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "Invalid voting configuration",
 			"code":    "VALIDATION_FAILED",
@@ -1170,6 +1588,7 @@ func (h *DistributedVoteHandler) UpdateVotingConfiguration(c *gin.Context) {
 			"quality_bad_threshold":     config.QualityBadThreshold,
 			"adjustment_magnitude":      config.AdjustmentMagnitude,
 			"min_evaluations_per_file":  config.MinEvaluationsPerFile,
+			"team_quality_policy":       config.TeamQualityPolicy,
 			"updated_at":                time.Now(),
 		},
 		"message": "Voting configuration updated successfully",
@@ -1192,7 +1611,7 @@ func (h *DistributedVoteHandler) DeleteVotingConfiguration(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Event not found",
@@ -1295,8 +1714,8 @@ func (h *DistributedVoteHandler) PreviewVotingConfiguration(c *gin.Context) {
 		return
 	}
 
-	// Get current participants and attachments
-	participants, err := h.userRepo.GetEventParticipants(eventID)
+	// Get current evaluators and attachments
+	members, err := h.userRepo.GetEventParticipants(eventID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to get participants",
@@ -1304,6 +1723,7 @@ func (h *DistributedVoteHandler) PreviewVotingConfiguration(c *gin.Context) {
 		})
 		return
 	}
+	participants := stage.EvaluatorIDs(members)
 
 	attachments, err := h.attachmentRepo.GetByEventID(eventID)
 	if err != nil {
@@ -1334,7 +1754,7 @@ func (h *DistributedVoteHandler) PreviewVotingConfiguration(c *gin.Context) {
 	}
 
 	// Validate and calculate metrics
-	validationErr := h.votingService.ValidateVotingConfiguration(tempConfig, len(attachments), len(participants))
+	validationErr := h.votingService.ValidateVotingConfigurationForEvaluators(tempConfig, participants, stage.AttachmentIDs(attachments))
 
 	maxPossibleAssignments := req.AttachmentsPerEvaluator * len(participants)
 	minRequiredAssignments := req.MinEvaluationsPerFile * len(attachments)
@@ -1375,3 +1795,11 @@ func (h *DistributedVoteHandler) PreviewVotingConfiguration(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// amendmentDeadline returns the amendment deadline of the configuration, nil when there is none
+func amendmentDeadline(config *vote.VotingConfiguration) *time.Time {
+	if config == nil {
+		return nil
+	}
+	return config.AmendmentDeadline
+}
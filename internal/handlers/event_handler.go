@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -10,24 +12,37 @@ import (
 	"github.com/google/uuid"
 	"github.com/gravadigital/telescopio-api/internal/config"
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
 	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/stage"
+	"github.com/gravadigital/telescopio-api/internal/storage"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
 type EventHandler struct {
+	container      *postgres.Container
 	eventRepo      postgres.EventRepository
 	userRepo       postgres.UserRepository
 	attachmentRepo postgres.AttachmentRepository
+	cleaner        *storage.CleanupWorker
+	invitations    *EventInvitationService
+	accounts       *AccountService
 	config         *config.Config
 	log            *log.Logger
 }
 
-func NewEventHandler(eventRepo postgres.EventRepository, userRepo postgres.UserRepository, attachmentRepo postgres.AttachmentRepository, cfg *config.Config) *EventHandler {
+func NewEventHandler(container *postgres.Container, eventRepo postgres.EventRepository, userRepo postgres.UserRepository, attachmentRepo postgres.AttachmentRepository, cleaner *storage.CleanupWorker, accounts *AccountService, cfg *config.Config) *EventHandler {
 	return &EventHandler{
+		container:      container,
 		eventRepo:      eventRepo,
 		userRepo:       userRepo,
 		attachmentRepo: attachmentRepo,
+		cleaner:        cleaner,
+		invitations:    NewEventInvitationService(container.EventInvitations(), []byte(cfg.Invitations.SigningSecret)),
+		accounts:       accounts,
 		config:         cfg,
 		log:            logger.Handler("event"),
 	}
@@ -41,6 +56,11 @@ type CreateEventRequest struct {
 	Organizer       string `json:"organizer"`
 	AuthorID        string `json:"author_id"`        // Optional: if provided, use this as author_id
 	MaxParticipants *int   `json:"max_participants"` // Optional: if provided, use this limit (default: 20)
+	Visibility      string `json:"visibility"`       // Optional: public (default), link_only or invite_only
+	// Optional: participants must verify their email before registering
+	RequireVerifiedEmail bool `json:"require_verified_email"`
+	// Optional: managers, members (default) or public
+	ResultsVisibility string `json:"results_visibility"`
 }
 
 // CreateEvent handles POST /api/events
@@ -162,8 +182,13 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	h.log.Debug("creating event for authenticated user", "author_id", authorID, "author_name", user.Name)
 
-	// Check for duplicate event names (optional business rule)
-	existingEvents, err := h.eventRepo.GetAll()
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	// Check for duplicate event names within the organization (optional business rule)
+	existingEvents, err := h.eventRepo.GetByOrganization(orgID)
 	if err == nil {
 		for _, existingEvent := range existingEvents {
 			if existingEvent.Name == req.Name {
@@ -178,6 +203,7 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	}
 
 	newEvent := event.NewEvent(req.Name, req.Description, authorID, startDate, endDate, req.Organizer)
+	newEvent.OrganizationID = orgID
 
 	// Set custom max_participants if provided, otherwise use default (20)
 	if req.MaxParticipants != nil {
@@ -201,7 +227,36 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	}
 	newEvent.MaxParticipants = req.MaxParticipants
 	h.log.Debug("using custom max_participants", "value", *req.MaxParticipants)
-}	// Validate the event domain entity
+}
+
+	if req.Visibility != "" {
+		visibility := event.Visibility(req.Visibility)
+		if !visibility.IsValid() {
+			h.log.Warn("invalid visibility value", "value", req.Visibility)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":              "Invalid visibility",
+				"code":               "INVALID_VISIBILITY",
+				"valid_visibilities": []string{"public", "link_only", "invite_only"},
+			})
+			return
+		}
+		newEvent.Visibility = visibility
+	}
+	newEvent.RequireVerifiedEmail = req.RequireVerifiedEmail
+	if req.ResultsVisibility != "" {
+		resultsVisibility := event.ResultsVisibility(req.ResultsVisibility)
+		if !resultsVisibility.IsValid() {
+			h.log.Warn("invalid results visibility value", "value", req.ResultsVisibility)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":                      "Invalid results visibility",
+				"code":                       "INVALID_RESULTS_VISIBILITY",
+				"valid_results_visibilities": []string{"managers", "members", "public"},
+			})
+			return
+		}
+		newEvent.ResultsVisibility = resultsVisibility
+	}
+	// Validate the event domain entity
 	if err := newEvent.Validate(); err != nil {
 		h.log.Error("event validation failed", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -232,17 +287,20 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"event": gin.H{
-			"id":               newEvent.ID.String(),
-			"name":             newEvent.Name,
-			"description":      newEvent.Description,
-			"start_date":       newEvent.StartDate.Format("2006-01-02"),
-			"end_date":         newEvent.EndDate.Format("2006-01-02"),
-			"organizer":        newEvent.Organizer,
-			"shareable_link":   newEvent.ShareableLink,
-			"max_participants": newEvent.MaxParticipants,
-			"stage":            newEvent.Stage.String(),
-			"author_id":        newEvent.AuthorID.String(),
-			"created_at":       newEvent.CreatedAt,
+			"id":                     newEvent.ID.String(),
+			"name":                   newEvent.Name,
+			"description":            newEvent.Description,
+			"start_date":             newEvent.StartDate.Format("2006-01-02"),
+			"end_date":               newEvent.EndDate.Format("2006-01-02"),
+			"organizer":              newEvent.Organizer,
+			"shareable_link":         newEvent.ShareableLink,
+			"max_participants":       newEvent.MaxParticipants,
+			"visibility":             newEvent.Visibility,
+			"require_verified_email": newEvent.RequireVerifiedEmail,
+			"results_visibility":     newEvent.ResultsVisibility,
+			"stage":                  newEvent.Stage.String(),
+			"author_id":              newEvent.AuthorID.String(),
+			"created_at":             newEvent.CreatedAt,
 		},
 		"message": "Event created successfully",
 		"code":    "EVENT_CREATED",
@@ -296,11 +354,32 @@ func (h *EventHandler) UpdateEventStage(c *gin.Context) {
 		return
 	}
 
-	// Authorization is handled by RequireEventOwner middleware
+	// Authorization is handled by the event.lifecycle permission middleware
 	// User is guaranteed to be the event owner or admin at this point
 
+	// The stage changes in one transaction, under the lock the stage scheduler takes
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.AdvisoryLock(stage.LockKey(eventID)); err != nil {
+		h.log.Error("failed to lock event stage", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
 	// Get the event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := tx.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -375,51 +454,60 @@ func (h *EventHandler) UpdateEventStage(c *gin.Context) {
 		estimatedDate = &parsedDate
 	}
 
+	stageService := stage.NewService(tx.Events(), tx.Users(), tx.Attachments(), tx.Votes(), tx.VotingConfigurations(), tx.EventHistory())
+
 	// Additional business rules validation before stage transitions
 	switch newStage {
 	case event.StageVoting:
 		h.log.Debug("moving to voting stage", "event_id", eventID)
 
 		// Validate that there are enough attachments for voting
-		attachments, err := h.attachmentRepo.GetByEventID(eventID)
-		if err != nil {
-			h.log.Error("failed to get attachments", "event_id", eventID, "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to validate attachments",
-				"code":  "ATTACHMENTS_ERROR",
-			})
-			return
-		}
-
-		if len(attachments) == 0 {
+		attachmentCount, err := stageService.ValidateVotingReadiness(eventID)
+		switch {
+		case errors.Is(err, stage.ErrNoAttachments):
 			h.log.Warn("attempting to move to voting stage without attachments", "event_id", eventID)
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Cannot move to voting stage without attachments. Participants must upload their proposals first.",
 				"code":  "NO_ATTACHMENTS",
 			})
 			return
-		}
-
-		// Validate minimum attachments for meaningful voting (at least 2)
-		if len(attachments) < 2 {
-			h.log.Warn("insufficient attachments for voting", "event_id", eventID, "attachment_count", len(attachments))
+		case errors.Is(err, stage.ErrInsufficientAttachments):
+			// Validate minimum attachments for meaningful voting (at least 2)
+			h.log.Warn("insufficient attachments for voting", "event_id", eventID, "attachment_count", attachmentCount)
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":            "At least 2 attachments are required for meaningful voting",
 				"code":             "INSUFFICIENT_ATTACHMENTS",
-				"current_count":    len(attachments),
-				"required_minimum": 2,
+				"current_count":    attachmentCount,
+				"required_minimum": stage.MinAttachmentsForVoting,
+			})
+			return
+		case err != nil:
+			h.log.Error("failed to get attachments", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to validate attachments",
+				"code":  "ATTACHMENTS_ERROR",
 			})
 			return
 		}
 
-		h.log.Info("voting stage validation passed", "event_id", eventID, "attachments", len(attachments))
+		h.log.Info("voting stage validation passed", "event_id", eventID, "attachments", attachmentCount)
+
+		// Reviewers get the proposal files as they are now, whatever happens to them later
+		if err := tx.Attachments().FreezeReviewFiles(eventID); err != nil {
+			h.log.Error("failed to freeze review files", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to update event stage",
+				"code":  "DB_UPDATE_ERROR",
+			})
+			return
+		}
 
 	case event.StageResult:
 		h.log.Debug("moving to results stage", "event_id", eventID)
 	}
 
 	// Update stage with estimated date
-	if err := h.eventRepo.UpdateStageWithEstimatedDate(eventID, newStage, estimatedDate); err != nil {
+	if err := tx.Events().UpdateStageWithEstimatedDate(eventID, newStage, estimatedDate); err != nil {
 		h.log.Error("failed to update event stage", "event_id", eventID, "new_stage", req.Stage, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to update event stage",
@@ -428,8 +516,30 @@ func (h *EventHandler) UpdateEventStage(c *gin.Context) {
 		return
 	}
 
+	var actorID *uuid.UUID
+	if userID, err := auth.GetUserIDFromContext(c); err == nil {
+		actorID = &userID
+	}
+	if err := stageService.RecordTransition(existingEvent.ID, existingEvent.Stage, newStage, event.TriggerManual, actorID); err != nil {
+		h.log.Error("failed to record stage transition in history", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event stage",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit stage update", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
 	// Get updated event
-	updatedEvent, err := h.eventRepo.GetByID(eventID)
+	updatedEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("failed to retrieve updated event", "event_id", eventID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -466,8 +576,224 @@ func (h *EventHandler) UpdateEventStage(c *gin.Context) {
 	})
 }
 
+type RevertStageRequest struct {
+	Stage            string                `json:"stage" binding:"required"`
+	Justification    string                `json:"justification" binding:"required,min=10,max=2000"`
+	EstimatedEndDate string                `json:"estimated_end_date" binding:"required"` // YYYY-MM-DD, deadline of the reopened stage
+	Policy           *event.RollbackPolicy `json:"policy"`                                // Optional: defaults depend on the transition
+}
+
+// RevertEventStage handles POST /api/events/{event_id}/stage/revert
+// Reopens the previous stage (voting -> participation, results -> voting). Requires a
+// justification; the rollback policy decides whether assignments, votes and drafts are
+// kept, invalidated or archived. The rollback is recorded in the event stage history.
+func (h *EventHandler) RevertEventStage(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("reverting event stage", "event_id", eventID)
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		h.log.Warn("invalid event_id format", "event_id", eventID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	var req RevertStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for stage revert", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Authorization is handled by the event.lifecycle permission middleware
+	actorID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		h.log.Warn("user not authenticated", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	targetStage, valid := event.StageFromString(req.Stage)
+	if !valid {
+		h.log.Warn("invalid stage requested", "requested_stage", req.Stage)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Invalid stage",
+			"code":         "INVALID_STAGE",
+			"valid_stages": []string{"participation", "voting"},
+		})
+		return
+	}
+
+	// The reopened stage needs a fresh deadline, otherwise the scheduler would advance it again right away
+	estimatedDate, err := time.Parse("2006-01-02", req.EstimatedEndDate)
+	if err != nil {
+		h.log.Warn("invalid estimated_end_date format", "date", req.EstimatedEndDate, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid estimated_end_date format",
+			"code":    "INVALID_DATE_FORMAT",
+			"details": "Expected format: YYYY-MM-DD",
+		})
+		return
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	if estimatedDate.Before(today) {
+		h.log.Warn("estimated_end_date is in the past", "date", req.EstimatedEndDate)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Estimated end date must be today or in the future",
+			"code":  "INVALID_ESTIMATED_DATE",
+		})
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revert event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Wait for the stage scheduler if it is advancing this event, and keep it out until the rollback commits
+	if err := tx.AdvisoryLock(stage.LockKey(eventID)); err != nil {
+		h.log.Error("failed to lock event stage", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revert event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
+	existingEvent, err := tx.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Error("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if !existingEvent.CanRevertTo(targetStage) {
+		h.log.Warn("invalid stage rollback",
+			"event_id", eventID,
+			"current_stage", existingEvent.Stage.String(),
+			"requested_stage", req.Stage)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":           "Invalid stage rollback",
+			"code":            "INVALID_TRANSITION",
+			"current_stage":   existingEvent.Stage.String(),
+			"requested_stage": req.Stage,
+		})
+		return
+	}
+
+	policy := event.DefaultRollbackPolicy(existingEvent.Stage, targetStage)
+	if req.Policy != nil {
+		policy = *req.Policy
+	}
+
+	stageService := stage.NewService(tx.Events(), tx.Users(), tx.Attachments(), tx.Votes(), tx.VotingConfigurations(), tx.EventHistory())
+	entry, err := stageService.RevertStage(existingEvent, targetStage, policy, strings.TrimSpace(req.Justification), actorID, &estimatedDate)
+	if err != nil {
+		if errors.Is(err, stage.ErrInvalidRollbackPolicy) {
+			h.log.Warn("invalid rollback policy", "event_id", eventID, "error", err)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid rollback policy",
+				"code":    "INVALID_ROLLBACK_POLICY",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		h.log.Error("failed to revert event stage", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revert event stage",
+			"code":  "STAGE_REVERT_ERROR",
+		})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit stage revert", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revert event stage",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event stage reverted successfully",
+		"event_id", eventID,
+		"old_stage", entry.FromStage.String(),
+		"new_stage", entry.ToStage.String(),
+		"actor_id", actorID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    entry,
+		"message": "Event stage reverted successfully",
+		"code":    "STAGE_REVERTED",
+		"transition": gin.H{
+			"from": entry.FromStage.String(),
+			"to":   entry.ToStage.String(),
+		},
+	})
+}
+
+// GetEventHistory handles GET /api/events/{event_id}/history
+// Returns the stage history log of an event, oldest first
+func (h *EventHandler) GetEventHistory(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("getting event stage history", "event_id", eventID)
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		h.log.Warn("invalid event_id format", "event_id", eventID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	entries, err := h.container.EventHistory().GetByEventID(eventID)
+	if err != nil {
+		h.log.Error("failed to get event stage history", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve event history",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id": eventID,
+			"history":  entries,
+			"count":    len(entries),
+		},
+		"message": "Event history retrieved successfully",
+		"code":    "EVENT_HISTORY_RETRIEVED",
+	})
+}
+
 // UpdateEstimatedEndDate handles PATCH /api/v1/events/{event_id}/estimated-end-date
-// Allows the event organizer to edit the estimated end date for an active stage
+// Allows the event organizer to edit the estimated end date for an active stage.
+// Calendar feeds are generated from the stored dates, so subscribers get the new deadline
+// (with a newer LAST-MODIFIED) on their next refresh.
 func (h *EventHandler) UpdateEstimatedEndDate(c *gin.Context) {
 	eventID := c.Param("event_id")
 
@@ -495,7 +821,7 @@ func (h *EventHandler) UpdateEstimatedEndDate(c *gin.Context) {
 	}
 
 	// Get the event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -618,6 +944,7 @@ func (h *EventHandler) UpdateEstimatedEndDate(c *gin.Context) {
 type RegisterParticipantRequest struct {
 	ParticipantName  string `json:"participant_name" binding:"required,min=2,max=100"`
 	ParticipantEmail string `json:"participant_email" binding:"required,email"`
+	InvitationToken  string `json:"invitation_token"` // Required for link_only and invite_only events
 }
 
 // RegisterParticipant handles POST /api/events/{event_id}/register
@@ -659,7 +986,7 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 	}
 
 	// Check if event exists and is in participation stage
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -682,29 +1009,42 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 		return
 	}
 
-	existingUser, err := h.userRepo.GetByEmail(req.ParticipantEmail)
+	// Non-public events need a valid invitation; it is checked before any user is created
+	invitation, err := h.invitations.Verify(eventObj, req.InvitationToken, req.ParticipantEmail)
 	if err != nil {
-		// User doesn't exist, create a new one
-		h.log.Debug("creating new user", "email", req.ParticipantEmail, "name", req.ParticipantName)
+		h.log.Warn("registration rejected by invitation check",
+			"event_id", eventID,
+			"visibility", eventObj.Visibility,
+			"error", err)
+		status, code := invitationErrorStatus(err)
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+			"code":  code,
+		})
+		return
+	}
 
-		newUser := &participant.User{
+	// A new registrant's account is only stored together with the registration, so a
+	// rejected registration leaves no account behind
+	var newUser *participant.User
+	existingUser, err := h.userRepo.GetByEmail(req.ParticipantEmail)
+	switch {
+	case errors.Is(err, postgres.ErrUserNotFound):
+		newUser = &participant.User{
 			ID:    uuid.New(),
 			Name:  req.ParticipantName,
 			Email: req.ParticipantEmail,
 			Role:  participant.RoleParticipant,
 		}
-
-		if err := h.userRepo.Create(newUser); err != nil {
-			h.log.Error("failed to create participant", "email", req.ParticipantEmail, "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to create participant",
-				"code":  "USER_CREATE_ERROR",
-			})
-			return
-		}
 		existingUser = newUser
-		h.log.Info("new user created", "user_id", newUser.ID, "email", newUser.Email)
-	} else {
+	case err != nil:
+		h.log.Error("failed to look up participant", "email", req.ParticipantEmail, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve participant",
+			"code":  "USER_LOOKUP_ERROR",
+		})
+		return
+	default:
 		h.log.Debug("using existing user", "user_id", existingUser.ID, "email", existingUser.Email)
 	}
 
@@ -720,8 +1060,36 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 		return
 	}
 
+	// Events can require a verified address; a verification link is sent right away
+	if eventObj.RequireVerifiedEmail && !existingUser.IsEmailVerified() {
+		h.log.Warn("registration rejected: email not verified",
+			"event_id", eventID,
+			"user_id", existingUser.ID.String())
+		// The link needs an account to verify, so a new registrant's account is created here
+		if newUser != nil {
+			if err := h.createRegistrant(eventObj, newUser); err != nil {
+				h.log.Error("failed to create participant", "email", req.ParticipantEmail, "error", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to create participant",
+					"code":  "USER_CREATE_ERROR",
+				})
+				return
+			}
+			h.log.Info("new user created for email verification", "user_id", newUser.ID, "email", newUser.Email)
+		}
+		// Repeated attempts don't mail the address again until the resend interval passes
+		if err := h.accounts.SendEmailVerification(existingUser); err != nil && !errors.Is(err, ErrVerificationRecentlySent) {
+			h.log.Error("failed to send verification email", "user_id", existingUser.ID, "error", err)
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "This event requires a verified email address; a verification link has been sent",
+			"code":  "EMAIL_NOT_VERIFIED",
+		})
+		return
+	}
+
 	// Check if participant is already registered for this event
-	participantEvents, err := h.eventRepo.GetByParticipant(existingUser.ID.String())
+	participantEvents, err := h.eventRepo.GetByParticipant(eventObj.OrganizationID, existingUser.ID.String())
 	if err == nil && len(participantEvents) > 0 {
 		for _, evt := range participantEvents {
 			if evt.ID == eventUUID {
@@ -737,48 +1105,55 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 		}
 	}
 
-	// Check maximum participants limit
-	currentParticipants, err := h.userRepo.GetEventParticipants(eventID)
+	// Register when a seat is free, otherwise queue on the waitlist. The invitation is
+	// redeemed in the same transaction.
+	waitlistEntry, err := h.registerOrWaitlist(eventObj, existingUser, newUser != nil, invitation)
 	if err != nil {
-		h.log.Error("failed to get current participants count",
-			"event_id", eventID,
-			"error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to verify participant limit",
-			"code":  "PARTICIPANT_CHECK_ERROR",
-		})
-		return
-	}
-
-	// Use max_participants from event configuration (default: 20)
-	maxParticipants := 20 // Default value
-	if eventObj.MaxParticipants != nil && *eventObj.MaxParticipants > 0 {
-		maxParticipants = *eventObj.MaxParticipants
-	}
-
-	if len(currentParticipants) >= maxParticipants {
-		h.log.Warn("maximum participants reached",
-			"event_id", eventID,
-			"current_count", len(currentParticipants),
-			"max_participants", maxParticipants)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":            "Maximum number of participants reached for this event",
-			"code":             "MAX_PARTICIPANTS_REACHED",
-			"current_count":    len(currentParticipants),
-			"max_participants": maxParticipants,
-		})
+		switch {
+		case errors.Is(err, postgres.ErrInvitationUnavailable):
+			h.log.Warn("invitation used up during registration", "event_id", eventID, "invitation_id", invitation.ID)
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Invitation is no longer available",
+				"code":  "INVITATION_UNAVAILABLE",
+			})
+		case errors.Is(err, postgres.ErrAlreadyWaitlisted):
+			h.log.Warn("duplicate waitlist attempt", "event_id", eventID, "user_id", existingUser.ID.String())
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Participant is already on the waitlist for this event",
+				"code":  "ALREADY_WAITLISTED",
+			})
+		default:
+			h.log.Error("failed to register participant",
+				"event_id", eventID,
+				"user_id", existingUser.ID.String(),
+				"error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to register participant",
+				"code":  "REGISTRATION_ERROR",
+			})
+		}
 		return
 	}
 
-	// Add participant to event with 'participant' role
-	if err := h.eventRepo.AddParticipantWithRole(eventID, existingUser.ID.String(), event.RoleParticipant); err != nil {
-		h.log.Error("failed to register participant",
+	if waitlistEntry != nil {
+		h.log.Info("event full, participant added to waitlist",
 			"event_id", eventID,
 			"user_id", existingUser.ID.String(),
-			"error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to register participant",
-			"code":  "REGISTRATION_ERROR",
+			"position", waitlistEntry.Position)
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"data": gin.H{
+				"participant_id":    existingUser.ID.String(),
+				"participant_name":  existingUser.Name,
+				"participant_email": existingUser.Email,
+				"event_id":          eventID,
+				"event_name":        eventObj.Name,
+				"waitlist_entry_id": waitlistEntry.ID.String(),
+				"waitlist_position": waitlistEntry.Position,
+				"max_participants":  eventObj.ParticipantLimit(),
+			},
+			"message": "Event is full. Participant added to the waitlist and will be notified when a seat opens up",
+			"code":    "ADDED_TO_WAITLIST",
 		})
 		return
 	}
@@ -786,7 +1161,9 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 	h.log.Info("participant registered successfully",
 		"event_id", eventID,
 		"user_id", existingUser.ID.String(),
-		"email", existingUser.Email)
+		"email", existingUser.Email,
+		"new_user", newUser != nil,
+		"invited", invitation != nil)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"data": gin.H{
@@ -802,6 +1179,66 @@ func (h *EventHandler) RegisterParticipant(c *gin.Context) {
 	})
 }
 
+// registerOrWaitlist adds a self-registered participant to the event, or to its waitlist
+// when the event is full. A new user is created in the same transaction. Returns the
+// waitlist entry when the participant was queued.
+func (h *EventHandler) registerOrWaitlist(evt *event.Event, user *participant.User, isNew bool, invitation *event.Invitation) (*event.WaitlistEntry, error) {
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := addRegistrant(tx, evt, user, isNew); err != nil {
+		return nil, err
+	}
+
+	entry, err := NewWaitlistServiceForTx(tx).Register(evt, user, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// createRegistrant stores the account of a new registrant who has yet to register, as a
+// member of the organization of the event
+func (h *EventHandler) createRegistrant(evt *event.Event, user *participant.User) error {
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := addRegistrant(tx, evt, user, true); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// addRegistrant creates the account of a new registrant, when isNew, and adds the
+// registrant to the organization of the event, whose lookups only find its members
+func addRegistrant(tx *postgres.TransactionContainer, evt *event.Event, user *participant.User, isNew bool) error {
+	if isNew {
+		if err := tx.Users().Create(user); err != nil {
+			return err
+		}
+	}
+
+	member := &organization.Member{
+		OrganizationID: evt.OrganizationID,
+		UserID:         user.ID,
+		Role:           organization.RoleMember,
+		CreatedAt:      time.Now(),
+	}
+	return tx.Organizations().AddMember(member)
+}
+
 // GetEventParticipants handles GET /api/events/{event_id}/participants
 func (h *EventHandler) GetEventParticipants(c *gin.Context) {
 	eventID := c.Param("event_id")
@@ -829,7 +1266,7 @@ func (h *EventHandler) GetEventParticipants(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -859,7 +1296,7 @@ func (h *EventHandler) GetEventParticipants(c *gin.Context) {
 			"id":         p.ID.String(),
 			"name":       p.Name,
 			"email":      p.Email,
-			"role":       p.EventRole, // Use event-specific role (creator/participant)
+			"role":       p.EventRole, // Use event-specific role (participant, co_organizer, reviewer, observer)
 			"created_at": p.CreatedAt,
 		}
 	}
@@ -880,9 +1317,18 @@ func (h *EventHandler) GetEventParticipants(c *gin.Context) {
 }
 
 // GetAllEvents handles GET /api/events
+// Lists public events with database-level pagination. Optional filters: q (full-text search
+// over name and description, web search syntax), stage, from and to (YYYY-MM-DD, events
+// overlapping the range) and sort (relevance, created_at, start_date) with order (asc, desc).
+// Only events of the organization of the request are listed.
 func (h *EventHandler) GetAllEvents(c *gin.Context) {
 	h.log.Debug("retrieving all events")
 
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
 	// Add pagination support
 	page := 1
 	limit := 10
@@ -901,27 +1347,12 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 
 	// Add filtering support
 	stage := c.Query("stage")
+	query := strings.TrimSpace(c.Query("q"))
+	from := c.Query("from")
+	to := c.Query("to")
 
-	events, err := h.eventRepo.GetAll()
-	if err != nil {
-		h.log.Error("failed to retrieve events", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve events",
-			"code":  "RETRIEVAL_ERROR",
-		})
-		return
-	}
-
-	// Filter by stage if specified
-	var filteredEvents []*event.Event
 	if stage != "" {
-		if stageEnum, valid := event.StageFromString(stage); valid {
-			for _, evt := range events {
-				if evt.Stage == stageEnum {
-					filteredEvents = append(filteredEvents, evt)
-				}
-			}
-		} else {
+		if _, valid := event.StageFromString(stage); !valid {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":        "Invalid stage filter",
 				"code":         "INVALID_STAGE_FILTER",
@@ -929,27 +1360,67 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 			})
 			return
 		}
-	} else {
-		filteredEvents = events
 	}
 
-	// Apply pagination
-	total := len(filteredEvents)
-	start := (page - 1) * limit
-	end := start + limit
-
-	if start >= total {
-		filteredEvents = []*event.Event{}
-	} else {
-		if end > total {
-			end = total
+	for name, value := range map[string]string{"from": from, "to": to} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid " + name + " date",
+				"code":    "INVALID_DATE_FORMAT",
+				"details": "Expected format: YYYY-MM-DD",
+			})
+			return
 		}
-		filteredEvents = filteredEvents[start:end]
+	}
+	if from != "" && to != "" && from > to {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from must not be after to",
+			"code":  "INVALID_DATE_RANGE",
+		})
+		return
 	}
 
+	sortBy := c.Query("sort")
+	switch sortBy {
+	case "", postgres.SortByRelevance, postgres.SortByCreatedAt, postgres.SortByStartDate:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      "Invalid sort",
+			"code":       "INVALID_SORT",
+			"valid_sort": []string{postgres.SortByRelevance, postgres.SortByCreatedAt, postgres.SortByStartDate},
+		})
+		return
+	}
+
+	result, err := h.eventRepo.GetAllPaginated(
+		orgID,
+		postgres.PaginationParams{Page: page, PageSize: limit},
+		postgres.SearchParams{
+			Query:    query,
+			Filters:  map[string]string{"stage": stage, "from": from, "to": to},
+			SortBy:   sortBy,
+			SortDesc: c.Query("order") != "asc",
+		},
+	)
+	if err != nil {
+		h.log.Error("failed to retrieve events", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve events",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	hits, _ := result.Data.([]*postgres.EventSearchHit)
+
 	// Transform events data
-	eventData := make([]gin.H, len(filteredEvents))
-	for i, evt := range filteredEvents {
+	eventData := make([]gin.H, len(hits))
+	for i, hit := range hits {
+		evt := hit.Event
+
 		// Get participant IDs for this event
 		participantIDs := []string{}
 		participants, err := h.userRepo.GetEventParticipants(evt.ID.String())
@@ -974,20 +1445,27 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 			"created_at":                       evt.CreatedAt,
 			"updated_at":                       evt.UpdatedAt,
 		}
+		if query != "" {
+			eventData[i]["rank"] = hit.Rank
+			eventData[i]["highlights"] = hit.Highlights
+		}
 	}
 
-	h.log.Debug("events retrieved successfully", "total", total, "page", page, "limit", limit)
+	h.log.Debug("events retrieved successfully", "total", result.Total, "page", page, "limit", limit)
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": eventData,
 		"pagination": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": (total + limit - 1) / limit,
+			"page":        result.Page,
+			"limit":       result.PageSize,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
 		},
 		"filters": gin.H{
 			"stage": stage,
+			"q":     query,
+			"from":  from,
+			"to":    to,
 		},
 	})
 }
@@ -1019,7 +1497,7 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 	}
 
 	// Get the event
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -1073,6 +1551,9 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 			"author_id":                        eventObj.AuthorID.String(),
 			"organizer":                        organizer,
 			"max_participants":                 eventObj.MaxParticipants,
+			"visibility":                       eventObj.Visibility,
+			"require_verified_email":           eventObj.RequireVerifiedEmail,
+			"results_visibility":               eventObj.ResultsVisibility.OrDefault(),
 			"participation_estimated_end_date": formatDatePtr(eventObj.ParticipationEstimatedEndDate),
 			"voting_estimated_end_date":        formatDatePtr(eventObj.VotingEstimatedEndDate),
 			"participant_ids":                  participantIDs,
@@ -1120,7 +1601,7 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	}
 
 	// Get existing event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -1204,6 +1685,8 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 }
 
 // DeleteEvent handles DELETE /api/events/{event_id}
+// Permanently deletes an event with its assignments, votes, drafts and results. Stored files
+// are removed in the background. Events past the creation stage must be archived first.
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	eventID := c.Param("event_id")
 
@@ -1220,7 +1703,8 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	}
 
 	// Validate UUID format
-	if _, err := uuid.Parse(eventID); err != nil {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
 		h.log.Warn("invalid event_id format", "event_id", eventID, "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Invalid event_id format",
@@ -1229,8 +1713,20 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
+	// Authorization is handled by the event.lifecycle permission middleware
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	// Get existing event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := tx.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.log.Error("event not found", "event_id", eventID, "error", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -1240,26 +1736,233 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
-	// Only allow deletion in creation stage
-	if existingEvent.Stage != event.StageCreation {
-		h.log.Warn("deletion attempt outside creation stage", "event_id", eventID, "current_stage", existingEvent.Stage)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":         "Event can only be deleted during creation stage",
-			"code":          "INVALID_DELETE_STAGE",
+	// Events that already started must be archived before they can be deleted
+	if !existingEvent.CanHardDelete() {
+		h.log.Warn("deletion attempt on active event", "event_id", eventID, "current_stage", existingEvent.Stage)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "Only events in creation stage or archived events can be deleted. Archive the event first",
+			"code":          "EVENT_NOT_ARCHIVED",
 			"current_stage": existingEvent.Stage.String(),
 		})
 		return
 	}
 
-	h.log.Warn("event delete feature not implemented", "event_id", eventID)
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":   "Event delete feature is not yet implemented",
-		"code":    "NOT_IMPLEMENTED",
-		"details": "EventRepository.Delete method needs to be added",
+	// Every stored version of every proposal file
+	storageKeys, err := tx.Attachments().GetFileStorageKeys(eventID)
+	if err != nil {
+		h.log.Error("failed to get attachments for deletion", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event",
+			"code":  "ATTACHMENTS_ERROR",
+		})
+		return
+	}
+
+	// Files are queued in the same transaction, so they are only removed if the delete commits
+	if err := tx.StorageCleanup().Enqueue(&eventUUID, storageKeys); err != nil {
+		h.log.Error("failed to schedule file removal", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event",
+			"code":  "DB_DELETE_ERROR",
+		})
+		return
+	}
+
+	if err := tx.Events().Delete(eventID); err != nil {
+		h.log.Error("failed to delete event", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event",
+			"code":  "DB_DELETE_ERROR",
+		})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit event deletion", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
+	h.cleaner.Notify()
+
+	h.log.Info("event deleted", "event_id", eventID, "name", existingEvent.Name, "files_scheduled", len(storageKeys))
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":                   eventID,
+			"files_scheduled_for_removal": len(storageKeys),
+		},
+		"message": "Event deleted successfully",
+		"code":    "EVENT_DELETED",
+	})
+}
+
+// ArchiveEvent handles POST /api/events/{event_id}/archive
+// Archived events are read-only and hidden from listings
+func (h *EventHandler) ArchiveEvent(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("archiving event", "event_id", eventID)
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		h.log.Warn("invalid event_id format", "event_id", eventID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		h.log.Warn("user not authenticated", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	existingEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Error("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if existingEvent.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Event is already archived",
+			"code":  "EVENT_ALREADY_ARCHIVED",
+		})
+		return
+	}
+
+	if err := h.eventRepo.Archive(eventID, userID.String()); err != nil {
+		h.log.Error("failed to archive event", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to archive event",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event archived", "event_id", eventID, "user_id", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":    eventID,
+			"archived_by": userID.String(),
+		},
+		"message": "Event archived successfully",
+		"code":    "EVENT_ARCHIVED",
+	})
+}
+
+// UnarchiveEvent handles POST /api/events/{event_id}/unarchive
+func (h *EventHandler) UnarchiveEvent(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("unarchiving event", "event_id", eventID)
+
+	if _, err := uuid.Parse(eventID); err != nil {
+		h.log.Warn("invalid event_id format", "event_id", eventID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return
+	}
+
+	existingEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Error("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if !existingEvent.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Event is not archived",
+			"code":  "EVENT_NOT_ARCHIVED",
+		})
+		return
+	}
+
+	if err := h.eventRepo.Unarchive(eventID); err != nil {
+		h.log.Error("failed to unarchive event", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to unarchive event",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event unarchived", "event_id", eventID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id": eventID,
+		},
+		"message": "Event unarchived successfully",
+		"code":    "EVENT_UNARCHIVED",
+	})
+}
+
+// GetArchivedEvents handles GET /api/events/archived
+// Lists the caller's archived events; admins see every archived event of the organization
+func (h *EventHandler) GetArchivedEvents(c *gin.Context) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		h.log.Warn("user not authenticated", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	authorID := userID.String()
+	if auth.IsAdmin(c) {
+		authorID = ""
+	}
+
+	events, err := h.eventRepo.GetArchived(orgID, authorID)
+	if err != nil {
+		h.log.Error("failed to retrieve archived events", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve archived events",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"events": events,
+			"count":  len(events),
+		},
+		"message": "Archived events retrieved successfully",
+		"code":    "ARCHIVED_EVENTS_RETRIEVED",
 	})
 }
 
 // RemoveParticipant handles DELETE /api/events/{event_id}/participants/{participant_id}
+// Also used by participants to withdraw. Freed seats go to the next registrants on the waitlist.
 func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 	eventID := c.Param("event_id")
 	participantID := c.Param("participant_id")
@@ -1294,7 +1997,7 @@ func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 	}
 
 	// Get existing event
-	existingEvent, err := h.eventRepo.GetByID(eventID)
+	existingEvent, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Event not found",
@@ -1303,6 +2006,15 @@ func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 		return
 	}
 
+	// The creator is not a regular participant and cannot be removed
+	if existingEvent.AuthorID.String() == participantID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Event creator cannot be removed from the event",
+			"code":  "CREATOR_CANNOT_BE_REMOVED",
+		})
+		return
+	}
+
 	// Only allow removal during participation stage
 	if existingEvent.Stage != event.StageParticipation {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -1313,7 +2025,7 @@ func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 		return
 	}
 
-	participantEvents, err := h.eventRepo.GetByParticipant(participantID)
+	participantEvents, err := h.eventRepo.GetByParticipant(existingEvent.OrganizationID, participantID)
 	isRegistered := false
 	if err == nil {
 		for _, evt := range participantEvents {
@@ -1332,8 +2044,23 @@ func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 		return
 	}
 
-	// Remove participant from event
-	if err := h.eventRepo.RemoveParticipant(eventID, participantID); err != nil {
+	// Remove participant from event and promote the next registrants on the waitlist
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to remove participant",
+			"code":  "REMOVAL_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	promoted, err := NewWaitlistServiceForTx(tx).RemoveParticipant(existingEvent, participantID)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
 		h.log.Error("failed to remove participant", "event_id", eventID, "participant_id", participantID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to remove participant",
@@ -1342,9 +2069,22 @@ func (h *EventHandler) RemoveParticipant(c *gin.Context) {
 		return
 	}
 
-	h.log.Info("participant removed successfully", "event_id", eventID, "participant_id", participantID)
+	promotedIDs := make([]string, len(promoted))
+	for i, entry := range promoted {
+		promotedIDs[i] = entry.UserID.String()
+	}
+
+	h.log.Info("participant removed successfully",
+		"event_id", eventID,
+		"participant_id", participantID,
+		"promoted_from_waitlist", len(promoted))
 
 	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":                 eventID,
+			"participant_id":           participantID,
+			"promoted_participant_ids": promotedIDs,
+		},
 		"message": "Participant removed successfully",
 		"code":    "PARTICIPANT_REMOVED",
 	})
@@ -1367,7 +2107,7 @@ func (h *EventHandler) GetShareableEventInfo(c *gin.Context) {
 	}
 
 	// Get the event
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Event not found",
@@ -0,0 +1,542 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// maxInvitationTTL caps how long an invitation can stay valid
+const maxInvitationTTL = 365 * 24 * time.Hour
+
+// EventInvitationHandler manages event visibility and the invitations used to register
+// for link-only and invite-only events
+type EventInvitationHandler struct {
+	container   *postgres.Container
+	invitations *EventInvitationService
+	config      *config.Config
+	log         *log.Logger
+}
+
+// NewEventInvitationHandler creates a new event invitation handler
+func NewEventInvitationHandler(container *postgres.Container, cfg *config.Config) *EventInvitationHandler {
+	return &EventInvitationHandler{
+		container:   container,
+		invitations: NewEventInvitationService(container.EventInvitations(), []byte(cfg.Invitations.SigningSecret)),
+		config:      cfg,
+		log:         logger.Handler("event_invitation"),
+	}
+}
+
+type UpdateVisibilityRequest struct {
+	Visibility string `json:"visibility" binding:"required"`
+}
+
+// UpdateEventVisibility handles PATCH /api/events/{event_id}/visibility
+// Invitations already issued stay valid when the visibility changes
+func (h *EventInvitationHandler) UpdateEventVisibility(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("updating event visibility", "event_id", eventID)
+
+	var req UpdateVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for visibility update", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	visibility := event.Visibility(req.Visibility)
+	if !visibility.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":              "Invalid visibility",
+			"code":               "INVALID_VISIBILITY",
+			"valid_visibilities": []string{"public", "link_only", "invite_only"},
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	if err := h.container.Events().UpdateVisibility(eventID, visibility); err != nil {
+		h.log.Error("failed to update event visibility", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event visibility",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event visibility updated",
+		"event_id", eventID,
+		"from", evt.Visibility,
+		"to", visibility)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":            eventID,
+			"previous_visibility": evt.Visibility,
+			"visibility":          visibility,
+		},
+		"message": "Event visibility updated successfully",
+		"code":    "VISIBILITY_UPDATED",
+	})
+}
+
+type UpdateRegistrationRequirementsRequest struct {
+	RequireVerifiedEmail *bool `json:"require_verified_email" binding:"required"`
+}
+
+// UpdateRegistrationRequirements handles PATCH /api/events/{event_id}/registration-requirements
+// Participants already registered are not affected
+func (h *EventInvitationHandler) UpdateRegistrationRequirements(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	var req UpdateRegistrationRequirementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for registration requirements update", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if _, ok := h.loadEvent(c, eventID); !ok {
+		return
+	}
+
+	if err := h.container.Events().UpdateRequireVerifiedEmail(eventID, *req.RequireVerifiedEmail); err != nil {
+		h.log.Error("failed to update registration requirements", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update registration requirements",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event registration requirements updated",
+		"event_id", eventID,
+		"require_verified_email", *req.RequireVerifiedEmail)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":               eventID,
+			"require_verified_email": *req.RequireVerifiedEmail,
+		},
+		"message": "Registration requirements updated successfully",
+		"code":    "REGISTRATION_REQUIREMENTS_UPDATED",
+	})
+}
+
+type UpdateResultsVisibilityRequest struct {
+	ResultsVisibility string `json:"results_visibility" binding:"required"`
+}
+
+// UpdateResultsVisibility handles PATCH /api/events/{event_id}/results-visibility
+// Event managers always see the results; this decides who else can once the event reaches the result stage
+func (h *EventInvitationHandler) UpdateResultsVisibility(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	var req UpdateResultsVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for results visibility update", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	visibility := event.ResultsVisibility(req.ResultsVisibility)
+	if !visibility.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":                      "Invalid results visibility",
+			"code":                       "INVALID_RESULTS_VISIBILITY",
+			"valid_results_visibilities": []string{"managers", "members", "public"},
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	if err := h.container.Events().UpdateResultsVisibility(eventID, visibility); err != nil {
+		h.log.Error("failed to update event results visibility", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update results visibility",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event results visibility updated",
+		"event_id", eventID,
+		"from", evt.ResultsVisibility,
+		"to", visibility)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":                    eventID,
+			"previous_results_visibility": evt.ResultsVisibility.OrDefault(),
+			"results_visibility":          visibility,
+		},
+		"message": "Results visibility updated successfully",
+		"code":    "RESULTS_VISIBILITY_UPDATED",
+	})
+}
+
+type IssueInvitationRequest struct {
+	Email          string `json:"email" binding:"omitempty,email"` // Optional: only this email can redeem it
+	SingleUse      bool   `json:"single_use"`                      // Shorthand for max_uses = 1
+	MaxUses        *int   `json:"max_uses"`                        // Optional: unlimited until expiry when omitted
+	ExpiresInHours *int   `json:"expires_in_hours"`                // Optional: defaults to INVITATION_DEFAULT_TTL_HOURS
+}
+
+// IssueInvitation handles POST /api/events/{event_id}/invitations
+// The signed token is only returned here; it is not stored and cannot be retrieved later
+func (h *EventInvitationHandler) IssueInvitation(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("issuing event invitation", "event_id", eventID)
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req IssueInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for invitation", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	maxUses := req.MaxUses
+	if req.SingleUse {
+		if maxUses != nil && *maxUses != 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "single_use cannot be combined with max_uses greater than 1",
+				"code":  "INVALID_MAX_USES",
+			})
+			return
+		}
+		one := 1
+		maxUses = &one
+	}
+	if maxUses != nil && *maxUses < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "max_uses must be at least 1",
+			"code":  "INVALID_MAX_USES",
+		})
+		return
+	}
+
+	ttl := time.Duration(h.config.Invitations.DefaultTTLHours) * time.Hour
+	if req.ExpiresInHours != nil {
+		ttl = time.Duration(*req.ExpiresInHours) * time.Hour
+	}
+	if ttl <= 0 || ttl > maxInvitationTTL {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "expires_in_hours must be between 1 and 8760",
+			"code":  "INVALID_EXPIRY",
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	if evt.Stage == event.StageVoting || evt.Stage == event.StageResult {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Invitations can only be issued before voting starts",
+			"code":          "INVALID_INVITATION_STAGE",
+			"current_stage": evt.Stage.String(),
+		})
+		return
+	}
+
+	if evt.Visibility == event.VisibilityInviteOnly && req.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invite-only events require invitations bound to an email",
+			"code":  "INVITATION_EMAIL_REQUIRED",
+		})
+		return
+	}
+
+	invitation := event.NewInvitation(evt.ID, userID, req.Email, maxUses, time.Now().Add(ttl))
+	token, err := h.invitations.Issue(invitation)
+	if err != nil {
+		h.log.Error("failed to issue invitation", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to issue invitation",
+			"code":  "INVITATION_CREATE_ERROR",
+		})
+		return
+	}
+
+	response := invitationResponse(invitation)
+	response["token"] = token
+	response["invitation_url"] = h.invitationURL(c, evt, token)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    response,
+		"message": "Invitation issued successfully",
+		"code":    "INVITATION_ISSUED",
+	})
+}
+
+// ListInvitations handles GET /api/events/{event_id}/invitations
+func (h *EventInvitationHandler) ListInvitations(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("listing event invitations", "event_id", eventID)
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.container.EventInvitations().GetByEventID(eventID)
+	if err != nil {
+		h.log.Error("failed to list invitations", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve invitations",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	data := make([]gin.H, len(invitations))
+	totalRedemptions := 0
+	for i, invitation := range invitations {
+		data[i] = invitationResponse(invitation)
+		totalRedemptions += invitation.UseCount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"summary": gin.H{
+			"event_id":          eventID,
+			"visibility":        evt.Visibility,
+			"total_invitations": len(invitations),
+			"total_redemptions": totalRedemptions,
+		},
+	})
+}
+
+// GetInvitation handles GET /api/events/{event_id}/invitations/{invitation_id}
+// Includes every redemption of the invitation
+func (h *EventInvitationHandler) GetInvitation(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	invitation, ok := h.loadInvitation(c, eventID)
+	if !ok {
+		return
+	}
+
+	redemptions, err := h.container.EventInvitations().GetRedemptions(invitation.ID.String())
+	if err != nil {
+		h.log.Error("failed to retrieve redemptions", "invitation_id", invitation.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve invitation redemptions",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	response := invitationResponse(invitation)
+	response["redemptions"] = redemptions
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+// RevokeInvitation handles DELETE /api/events/{event_id}/invitations/{invitation_id}
+// Revoked invitations are kept for auditing but can no longer be redeemed
+func (h *EventInvitationHandler) RevokeInvitation(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	invitation, ok := h.loadInvitation(c, eventID)
+	if !ok {
+		return
+	}
+
+	if invitation.IsRevoked() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Invitation is already revoked",
+			"code":  "INVITATION_ALREADY_REVOKED",
+		})
+		return
+	}
+
+	if err := h.container.EventInvitations().Revoke(invitation.ID.String(), userID); err != nil {
+		h.log.Error("failed to revoke invitation", "invitation_id", invitation.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke invitation",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("invitation revoked", "event_id", eventID, "invitation_id", invitation.ID, "revoked_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"invitation_id": invitation.ID.String(),
+			"event_id":      eventID,
+			"revoked_by":    userID.String(),
+		},
+		"message": "Invitation revoked successfully",
+		"code":    "INVITATION_REVOKED",
+	})
+}
+
+func (h *EventInvitationHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		h.log.Warn("user not authenticated", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// loadEvent fetches the event from the route, writing the error response on failure
+func (h *EventInvitationHandler) loadEvent(c *gin.Context, eventID string) (*event.Event, bool) {
+	if _, err := uuid.Parse(eventID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return nil, false
+	}
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Warn("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	return evt, true
+}
+
+// loadInvitation fetches the invitation from the route and checks it belongs to the event
+func (h *EventInvitationHandler) loadInvitation(c *gin.Context, eventID string) (*event.Invitation, bool) {
+	invitationID := c.Param("invitation_id")
+	if _, err := uuid.Parse(invitationID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid invitation_id format",
+			"code":  "INVALID_INVITATION_ID",
+		})
+		return nil, false
+	}
+
+	invitation, err := h.container.EventInvitations().GetByID(invitationID)
+	if err != nil || invitation.EventID.String() != eventID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Invitation not found",
+			"code":  "INVITATION_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	return invitation, true
+}
+
+// invitationURL builds the frontend registration link carrying the token
+func (h *EventInvitationHandler) invitationURL(c *gin.Context, evt *event.Event, token string) string {
+	baseURL := h.config.Server.FrontendURL
+	if baseURL == "" {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + c.Request.Host
+	}
+	link := evt.ShareableLink
+	if link == "" {
+		link = "/events/" + evt.ID.String()
+	}
+	return baseURL + link + "?invitation=" + url.QueryEscape(token)
+}
+
+// invitationResponse is the invitation payload shared by the invitation endpoints
+func invitationResponse(invitation *event.Invitation) gin.H {
+	return gin.H{
+		"id":               invitation.ID.String(),
+		"event_id":         invitation.EventID.String(),
+		"email":            invitation.Email,
+		"max_uses":         invitation.MaxUses,
+		"single_use":       invitation.IsSingleUse(),
+		"use_count":        invitation.UseCount,
+		"status":           invitation.Status(time.Now()),
+		"expires_at":       invitation.ExpiresAt,
+		"issued_by":        invitation.IssuedBy.String(),
+		"revoked_at":       invitation.RevokedAt,
+		"last_redeemed_at": invitation.LastRedeemedAt,
+		"created_at":       invitation.CreatedAt,
+	}
+}
+
+// invitationErrorStatus maps EventInvitationService errors to an HTTP status and error code
+func invitationErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrInvitationRequired):
+		return http.StatusForbidden, "INVITATION_REQUIRED"
+	case errors.Is(err, ErrInvitationRevoked):
+		return http.StatusForbidden, "INVITATION_REVOKED"
+	case errors.Is(err, ErrInvitationExpired):
+		return http.StatusForbidden, "INVITATION_EXPIRED"
+	case errors.Is(err, ErrInvitationExhausted):
+		return http.StatusForbidden, "INVITATION_EXHAUSTED"
+	case errors.Is(err, ErrInvitationEmailMismatch):
+		return http.StatusForbidden, "INVITATION_EMAIL_MISMATCH"
+	case errors.Is(err, ErrInvitationNotPersonal):
+		return http.StatusForbidden, "INVITATION_EMAIL_REQUIRED"
+	default:
+		return http.StatusForbidden, "INVALID_INVITATION"
+	}
+}
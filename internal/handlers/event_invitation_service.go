@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+var (
+	ErrInvitationRequired      = errors.New("an invitation is required to register for this event")
+	ErrInvalidInvitation       = errors.New("invalid invitation")
+	ErrInvitationRevoked       = errors.New("invitation has been revoked")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationExhausted     = errors.New("invitation has already been used")
+	ErrInvitationEmailMismatch = errors.New("invitation was issued for a different email")
+	ErrInvitationNotPersonal   = errors.New("this event only accepts invitations issued for the registrant's email")
+)
+
+// EventInvitationService issues signed invitation tokens and checks them at registration
+type EventInvitationService struct {
+	invitationRepo postgres.EventInvitationRepository
+	secret         []byte
+	log            *log.Logger
+}
+
+// NewEventInvitationService creates an invitation service that signs tokens with secret
+func NewEventInvitationService(invitationRepo postgres.EventInvitationRepository, secret []byte) *EventInvitationService {
+	return &EventInvitationService{
+		invitationRepo: invitationRepo,
+		secret:         secret,
+		log:            logger.Service("event_invitation"),
+	}
+}
+
+// Issue stores a new invitation for the event and returns it together with its token
+func (s *EventInvitationService) Issue(invitation *event.Invitation) (string, error) {
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return "", err
+	}
+	return s.Token(invitation), nil
+}
+
+// Token returns the signed token of an invitation
+func (s *EventInvitationService) Token(invitation *event.Invitation) string {
+	return event.SignInvitationToken(s.secret, invitation)
+}
+
+// Verify checks that a token grants registration to evt for the given email.
+// It returns a nil invitation when the event is public and no token was given.
+// Redeeming the invitation is left to the caller so it can share the registration transaction.
+func (s *EventInvitationService) Verify(evt *event.Event, token, email string) (*event.Invitation, error) {
+	if token == "" {
+		if evt.Visibility.RequiresInvitation() {
+			return nil, ErrInvitationRequired
+		}
+		return nil, nil
+	}
+
+	invitationID, eventID, _, err := event.ParseInvitationToken(s.secret, token)
+	if err != nil || eventID != evt.ID {
+		s.log.Warn("rejected invitation token", "event_id", evt.ID, "error", err)
+		return nil, ErrInvalidInvitation
+	}
+
+	invitation, err := s.invitationRepo.GetByID(invitationID.String())
+	if err != nil {
+		s.log.Warn("invitation from token not found", "event_id", evt.ID, "invitation_id", invitationID, "error", err)
+		return nil, ErrInvalidInvitation
+	}
+
+	if invitation.EventID != evt.ID {
+		return nil, ErrInvalidInvitation
+	}
+
+	switch {
+	case invitation.IsRevoked():
+		return nil, ErrInvitationRevoked
+	case invitation.IsExpired(time.Now()):
+		return nil, ErrInvitationExpired
+	case invitation.IsExhausted():
+		return nil, ErrInvitationExhausted
+	}
+
+	if evt.Visibility == event.VisibilityInviteOnly && invitation.Email == nil {
+		return nil, ErrInvitationNotPersonal
+	}
+
+	if !invitation.MatchesEmail(email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	return invitation, nil
+}
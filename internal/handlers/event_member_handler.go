@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/permission"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// EventMemberHandler manages the per-event roles of members: regular participants,
+// co-organizers, reviewers and observers
+type EventMemberHandler struct {
+	container *postgres.Container
+	log       *log.Logger
+}
+
+// NewEventMemberHandler creates a new event member handler
+func NewEventMemberHandler(container *postgres.Container) *EventMemberHandler {
+	return &EventMemberHandler{
+		container: container,
+		log:       logger.Handler("event_member"),
+	}
+}
+
+type SetMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// SetMemberRole handles PUT /api/events/{event_id}/members/{participant_id}/role
+// Users who are not members yet are added with the role. Only the event owner, organizers
+// and admins can grant or revoke co-organizer rights. Once voting has started, roles that
+// take part in the evaluation can no longer be changed.
+func (h *EventMemberHandler) SetMemberRole(c *gin.Context) {
+	eventID := c.Param("event_id")
+	memberID := c.Param("participant_id")
+
+	h.log.Debug("setting event member role", "event_id", eventID, "participant_id", memberID)
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	var req SetMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for member role", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := event.EventParticipantRole(req.Role)
+	if !role.IsAssignable() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Invalid event role",
+			"code":          "INVALID_ROLE",
+			"allowed_roles": event.AssignableRoles,
+		})
+		return
+	}
+
+	memberUUID, err := uuid.Parse(memberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid participant_id format",
+			"code":  "INVALID_PARTICIPANT_ID",
+		})
+		return
+	}
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if evt.AuthorID.String() == memberID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "The event creator's role cannot be changed",
+			"code":  "CREATOR_ROLE_FIXED",
+		})
+		return
+	}
+
+	// Only members of the organization of the event can take a role in it
+	if _, err := h.container.Organizations().GetMember(evt.OrganizationID, memberUUID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var previous event.EventParticipantRole
+	if current, err := h.container.Events().GetParticipantRole(eventID, memberID); err == nil {
+		previous = *current
+	}
+
+	if previous == role {
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"event_id":       eventID,
+				"participant_id": memberID,
+				"role":           role,
+			},
+			"message": "Member already has this role",
+			"code":    "MEMBER_ROLE_UNCHANGED",
+		})
+		return
+	}
+
+	if (role == event.RoleCoOrganizer || previous == event.RoleCoOrganizer) &&
+		!auth.Authorize(c, h.container.Events(), permission.CoOrganizerGrant, evt) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the event creator, organizers, or admins can grant or revoke co-organizer rights",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if (evt.Stage == event.StageVoting || evt.Stage == event.StageResult) && (role.CanReview() || previous.CanReview()) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "Evaluator roles cannot change once voting has started",
+			"code":          "EVALUATION_ROLES_LOCKED",
+			"current_stage": evt.Stage.String(),
+		})
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update member role",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Moving a participant to a role that doesn't take a seat frees one for the waitlist
+	promoted, err := NewWaitlistServiceForTx(tx).SetRole(evt, memberID, role)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		h.log.Error("failed to update member role", "event_id", eventID, "participant_id", memberID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update member role",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	promotedIDs := make([]string, len(promoted))
+	for i, entry := range promoted {
+		promotedIDs[i] = entry.UserID.String()
+	}
+
+	h.log.Info("event member role updated",
+		"event_id", eventID,
+		"participant_id", memberID,
+		"role", role,
+		"previous_role", previous,
+		"updated_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":                 eventID,
+			"participant_id":           memberID,
+			"role":                     role,
+			"previous_role":            previous,
+			"promoted_participant_ids": promotedIDs,
+		},
+		"message": "Member role updated successfully",
+		"code":    "MEMBER_ROLE_UPDATED",
+	})
+}
@@ -0,0 +1,525 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+type EventTemplateHandler struct {
+	container *postgres.Container
+	config    *config.Config
+	log       *log.Logger
+}
+
+func NewEventTemplateHandler(container *postgres.Container, cfg *config.Config) *EventTemplateHandler {
+	return &EventTemplateHandler{
+		container: container,
+		config:    cfg,
+		log:       logger.Handler("event_template"),
+	}
+}
+
+type CloneEventRequest struct {
+	Name        string `json:"name" binding:"required,min=3,max=200"`
+	Description string `json:"description" binding:"omitempty,min=10,max=2000"` // Optional: defaults to the source description
+	StartDate   string `json:"start_date" binding:"required"`
+	EndDate     string `json:"end_date" binding:"required"`
+	// Optional: copy every member with their role; the source creator becomes a co-organizer
+	CopyParticipants bool `json:"copy_participants"`
+}
+
+type CreateTemplateRequest struct {
+	Name string `json:"name" binding:"required,min=3,max=200"`
+}
+
+type InstantiateTemplateRequest struct {
+	Name        string `json:"name" binding:"required,min=3,max=200"`
+	Description string `json:"description" binding:"omitempty,min=10,max=2000"` // Optional: defaults to the template description
+	StartDate   string `json:"start_date" binding:"required"`
+	EndDate     string `json:"end_date" binding:"required"`
+}
+
+// CloneEvent handles POST /api/events/{event_id}/clone
+// Creates a new event in the creation stage with the description, participant limit and
+// voting configuration of the source event. With copy_participants, every member is copied
+// with their role: participants, co-organizers, reviewers and observers.
+func (h *EventTemplateHandler) CloneEvent(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("cloning event", "event_id", eventID)
+
+	var req CloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for event clone", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, ok := h.parseEventDates(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	sourceEvent, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Error("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clone event",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	service := NewEventTemplateService(tx.Events(), tx.Users(), tx.VotingConfigurations())
+	snapshot := service.SnapshotEvent(sourceEvent.Name, userID, sourceEvent)
+
+	params := InstantiateParams{
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		AuthorID:    userID,
+	}
+
+	if req.CopyParticipants {
+		params.Members, err = service.Members(eventID, userID)
+		if err != nil {
+			h.log.Error("failed to get participants to copy", "event_id", eventID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to retrieve participants",
+				"code":  "RETRIEVAL_ERROR",
+			})
+			return
+		}
+	}
+
+	newEvent, ok := h.instantiate(c, service, snapshot, params)
+	if !ok {
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit event clone", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to clone event",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
+	copiedRoles := make(map[event.EventParticipantRole]int)
+	for _, member := range params.Members {
+		copiedRoles[member.Role]++
+	}
+
+	h.log.Info("event cloned successfully", "source_event_id", eventID, "event_id", newEvent.ID, "author_id", userID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event": eventResponse(newEvent),
+		"copied": gin.H{
+			"source_event_id":      eventID,
+			"voting_configuration": snapshot.Settings.VotingConfiguration != nil,
+			"participants":         copiedRoles[event.RoleParticipant],
+			"members":              copiedRoles,
+		},
+		"message": "Event cloned successfully",
+		"code":    "EVENT_CLONED",
+	})
+}
+
+// CreateTemplateFromEvent handles POST /api/events/{event_id}/templates
+// Saves the reusable settings of an event as a named template owned by the caller
+func (h *EventTemplateHandler) CreateTemplateFromEvent(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("creating template from event", "event_id", eventID)
+
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for template creation", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sourceEvent, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		h.log.Error("event not found", "event_id", eventID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	service := NewEventTemplateService(h.container.Events(), h.container.Users(), h.container.VotingConfigurations())
+	template := service.SnapshotEvent(req.Name, userID, sourceEvent)
+
+	if err := h.container.EventTemplates().Create(template); err != nil {
+		if errors.Is(err, postgres.ErrTemplateNameTaken) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "A template with this name already exists",
+				"code":  "DUPLICATE_TEMPLATE_NAME",
+			})
+			return
+		}
+		h.log.Error("failed to create template", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create template",
+			"code":  "DB_CREATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("template created from event", "template_id", template.ID, "event_id", eventID, "owner_id", userID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    template,
+		"message": "Template created successfully",
+		"code":    "TEMPLATE_CREATED",
+	})
+}
+
+// ListTemplates handles GET /api/event-templates
+// Returns the templates owned by the authenticated user in the organization
+func (h *EventTemplateHandler) ListTemplates(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.container.EventTemplates().GetByOwner(orgID, userID.String())
+	if err != nil {
+		h.log.Error("failed to list templates", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve templates",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"templates": templates,
+			"count":     len(templates),
+		},
+		"message": "Templates retrieved successfully",
+		"code":    "TEMPLATES_RETRIEVED",
+	})
+}
+
+// GetTemplate handles GET /api/event-templates/{template_id}
+func (h *EventTemplateHandler) GetTemplate(c *gin.Context) {
+	template, ok := h.loadOwnedTemplate(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    template,
+		"message": "Template retrieved successfully",
+		"code":    "TEMPLATE_RETRIEVED",
+	})
+}
+
+// InstantiateTemplate handles POST /api/event-templates/{template_id}/events
+// Creates a new event in the creation stage from a saved template
+func (h *EventTemplateHandler) InstantiateTemplate(c *gin.Context) {
+	var req InstantiateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for template instantiation", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, ok := h.loadOwnedTemplate(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, ok := h.parseEventDates(c, req.StartDate, req.EndDate)
+	if !ok {
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create event from template",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	service := NewEventTemplateService(tx.Events(), tx.Users(), tx.VotingConfigurations())
+	newEvent, ok := h.instantiate(c, service, template, InstantiateParams{
+		Name:        req.Name,
+		Description: req.Description,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		AuthorID:    userID,
+	})
+	if !ok {
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.log.Error("failed to commit template instantiation", "template_id", template.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create event from template",
+			"code":  "TRANSACTION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("event created from template", "template_id", template.ID, "event_id", newEvent.ID, "author_id", userID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event":       eventResponse(newEvent),
+		"template_id": template.ID.String(),
+		"message":     "Event created from template successfully",
+		"code":        "EVENT_CREATED",
+	})
+}
+
+// DeleteTemplate handles DELETE /api/event-templates/{template_id}
+func (h *EventTemplateHandler) DeleteTemplate(c *gin.Context) {
+	template, ok := h.loadOwnedTemplate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.container.EventTemplates().Delete(template.ID.String()); err != nil {
+		h.log.Error("failed to delete template", "template_id", template.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete template",
+			"code":  "DB_DELETE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("template deleted", "template_id", template.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Template deleted successfully",
+		"code":    "TEMPLATE_DELETED",
+	})
+}
+
+// instantiate runs the template service and maps its errors to responses
+func (h *EventTemplateHandler) instantiate(c *gin.Context, service *EventTemplateService, template *event.EventTemplate, params InstantiateParams) (*event.Event, bool) {
+	newEvent, err := service.Instantiate(template, params)
+	if err == nil {
+		return newEvent, true
+	}
+
+	if errors.Is(err, ErrDuplicateEventName) {
+		h.log.Warn("duplicate event name", "event_name", params.Name)
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "An event with this name already exists",
+			"code":  "DUPLICATE_EVENT_NAME",
+		})
+		return nil, false
+	}
+
+	h.log.Error("failed to create event from template", "template_id", template.ID, "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "Failed to create event",
+		"code":    "DB_CREATE_ERROR",
+		"details": err.Error(),
+	})
+	return nil, false
+}
+
+// loadOwnedTemplate fetches the template in the URL; only its owner or an admin may use it
+func (h *EventTemplateHandler) loadOwnedTemplate(c *gin.Context) (*event.EventTemplate, bool) {
+	templateID := c.Param("template_id")
+
+	if _, err := uuid.Parse(templateID); err != nil {
+		h.log.Warn("invalid template_id format", "template_id", templateID, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid template_id format",
+			"code":  "INVALID_TEMPLATE_ID",
+		})
+		return nil, false
+	}
+
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	template, err := h.container.EventTemplates().GetByID(templateID)
+	if err == nil && template.OrganizationID != tenant.Scope(c) {
+		err = errors.New("template of another organization")
+	}
+	if err != nil {
+		h.log.Warn("template not found", "template_id", templateID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Template not found",
+			"code":  "TEMPLATE_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	if !template.IsOwner(userID) && !auth.IsAdmin(c) {
+		h.log.Warn("template access denied", "template_id", templateID, "user_id", userID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "You do not have access to this template",
+			"code":  "FORBIDDEN",
+		})
+		return nil, false
+	}
+
+	return template, true
+}
+
+func (h *EventTemplateHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		h.log.Warn("user not authenticated", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// parseEventDates applies the same date rules as CreateEvent
+func (h *EventTemplateHandler) parseEventDates(c *gin.Context, start, end string) (time.Time, time.Time, bool) {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid start_date format",
+			"code":    "INVALID_START_DATE",
+			"details": "Expected format: YYYY-MM-DD",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid end_date format",
+			"code":    "INVALID_END_DATE",
+			"details": "Expected format: YYYY-MM-DD",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if startDate.Before(today) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Start date cannot be in the past",
+			"code":  "PAST_START_DATE",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "End date must be after start date",
+			"code":  "INVALID_DATE_RANGE",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	duration := endDate.Sub(startDate)
+	if duration < 24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Event duration must be at least 1 day",
+			"code":  "DURATION_TOO_SHORT",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if duration > 365*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Event duration cannot exceed 1 year",
+			"code":  "DURATION_TOO_LONG",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	return startDate, endDate, true
+}
+
+// eventResponse matches the event payload returned by CreateEvent
+func eventResponse(evt *event.Event) gin.H {
+	return gin.H{
+		"id":                     evt.ID.String(),
+		"name":                   evt.Name,
+		"description":            evt.Description,
+		"start_date":             evt.StartDate.Format("2006-01-02"),
+		"end_date":               evt.EndDate.Format("2006-01-02"),
+		"organizer":              evt.Organizer,
+		"shareable_link":         evt.ShareableLink,
+		"max_participants":       evt.MaxParticipants,
+		"visibility":             evt.Visibility,
+		"results_visibility":     evt.ResultsVisibility,
+		"require_verified_email": evt.RequireVerifiedEmail,
+		"stage":                  evt.Stage.String(),
+		"author_id":              evt.AuthorID.String(),
+		"created_at":             evt.CreatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/vote"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// ErrDuplicateEventName mirrors the unique-name rule enforced by CreateEvent
+var ErrDuplicateEventName = errors.New("an event with this name already exists")
+
+// InstantiateParams are the values that differ between events created from the same template
+type InstantiateParams struct {
+	Name        string
+	Description string // Optional: overrides the template description
+	StartDate   time.Time
+	EndDate     time.Time
+	AuthorID    uuid.UUID
+	Members     []*event.EventParticipant // Members of the new event besides its creator, with their roles
+}
+
+// EventTemplateService creates events from templates and snapshots events into templates.
+// Cloning an event is a snapshot followed by an instantiation.
+type EventTemplateService struct {
+	eventRepo  postgres.EventRepository
+	userRepo   postgres.UserRepository
+	configRepo postgres.VotingConfigurationRepository
+	log        *log.Logger
+}
+
+// NewEventTemplateService creates an event template service over the given repositories.
+// Pass repositories from a TransactionContainer so a new event is created atomically.
+func NewEventTemplateService(
+	eventRepo postgres.EventRepository,
+	userRepo postgres.UserRepository,
+	configRepo postgres.VotingConfigurationRepository,
+) *EventTemplateService {
+	return &EventTemplateService{
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		configRepo: configRepo,
+		log:        logger.Service("event_template"),
+	}
+}
+
+// SnapshotEvent builds an unsaved template from an event and its voting configuration
+func (s *EventTemplateService) SnapshotEvent(name string, ownerID uuid.UUID, evt *event.Event) *event.EventTemplate {
+	var config *vote.VotingConfiguration
+	if existing, err := s.configRepo.GetByEventID(evt.ID.String()); err == nil {
+		config = existing
+	} else {
+		s.log.Debug("no voting configuration to copy", "event_id", evt.ID)
+	}
+
+	return event.NewTemplateFromEvent(name, ownerID, evt, config)
+}
+
+// Members returns the members of an event to copy into a clone created by the given
+// user, with their roles. The cloning user becomes the creator of the copy and is left
+// out; the source creator, when someone else, becomes a co-organizer so they keep
+// managing the event.
+func (s *EventTemplateService) Members(eventID string, cloner uuid.UUID) ([]*event.EventParticipant, error) {
+	members, err := s.userRepo.GetEventParticipants(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	copied := make([]*event.EventParticipant, 0, len(members))
+	for _, m := range members {
+		role := event.EventParticipantRole(m.EventRole)
+		if m.ID == cloner || !role.IsValid() {
+			continue
+		}
+		if role == event.RoleCreator {
+			role = event.RoleCoOrganizer
+		}
+		copied = append(copied, &event.EventParticipant{UserID: m.ID, Role: role})
+	}
+
+	return copied, nil
+}
+
+// Instantiate creates a new event in the creation stage from a template, registers the
+// author as creator, copies the voting configuration and adds the given members
+func (s *EventTemplateService) Instantiate(template *event.EventTemplate, params InstantiateParams) (*event.Event, error) {
+	if err := s.ensureUniqueName(template.OrganizationID, params.Name); err != nil {
+		return nil, err
+	}
+
+	newEvent := template.Instantiate(params.Name, params.AuthorID, params.StartDate, params.EndDate)
+	if params.Description != "" {
+		newEvent.Description = params.Description
+	}
+
+	if err := newEvent.Validate(); err != nil {
+		return nil, fmt.Errorf("event validation failed: %w", err)
+	}
+
+	if err := s.eventRepo.Create(newEvent); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	eventID := newEvent.ID.String()
+	if err := s.eventRepo.AddParticipantWithRole(eventID, params.AuthorID.String(), event.RoleCreator); err != nil {
+		return nil, fmt.Errorf("failed to add creator: %w", err)
+	}
+
+	if settings := template.Settings.VotingConfiguration; settings != nil {
+		config := vote.NewVotingConfigurationFromSettings(newEvent.ID, *settings)
+		if err := s.configRepo.Create(config); err != nil {
+			return nil, fmt.Errorf("failed to copy voting configuration: %w", err)
+		}
+	}
+
+	for _, member := range params.Members {
+		if err := s.eventRepo.AddParticipantWithRole(eventID, member.UserID.String(), member.Role); err != nil {
+			return nil, fmt.Errorf("failed to copy member %s: %w", member.UserID, err)
+		}
+	}
+
+	s.log.Info("event created from template",
+		"event_id", newEvent.ID,
+		"template_id", template.ID,
+		"source_event_id", template.SourceEventID,
+		"members", len(params.Members),
+		"voting_configuration", template.Settings.VotingConfiguration != nil)
+
+	return newEvent, nil
+}
+
+func (s *EventTemplateService) ensureUniqueName(organizationID uuid.UUID, name string) error {
+	existingEvents, err := s.eventRepo.GetByOrganization(organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to check event names: %w", err)
+	}
+
+	for _, existingEvent := range existingEvents {
+		if existingEvent.Name == name {
+			return ErrDuplicateEventName
+		}
+	}
+
+	return nil
+}
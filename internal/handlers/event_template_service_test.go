@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/vote"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+type fakeTemplateUsers struct {
+	postgres.UserRepository
+	members []*participant.UserWithEventRole
+}
+
+func (f *fakeTemplateUsers) GetEventParticipants(eventID string) ([]*participant.UserWithEventRole, error) {
+	return f.members, nil
+}
+
+func TestCloneMembersKeepTheirRoles(t *testing.T) {
+	creator, cloner := uuid.New(), uuid.New()
+	roles := map[uuid.UUID]event.EventParticipantRole{
+		creator:    event.RoleCreator,
+		cloner:     event.RoleCoOrganizer,
+		uuid.New(): event.RoleParticipant,
+		uuid.New(): event.RoleParticipant,
+		uuid.New(): event.RoleCoOrganizer,
+		uuid.New(): event.RoleReviewer,
+		uuid.New(): event.RoleObserver,
+	}
+
+	users := &fakeTemplateUsers{}
+	for id, role := range roles {
+		users.members = append(users.members, &participant.UserWithEventRole{
+			User:      participant.User{ID: id},
+			EventRole: role.String(),
+		})
+	}
+
+	service := NewEventTemplateService(nil, users, nil)
+	members, err := service.Members(uuid.NewString(), cloner)
+	if err != nil {
+		t.Fatalf("Members: %v", err)
+	}
+
+	if len(members) != len(roles)-1 {
+		t.Fatalf("copied %d members, want every member but the cloning user (%d)", len(members), len(roles)-1)
+	}
+	for _, m := range members {
+		want := roles[m.UserID]
+		switch m.UserID {
+		case cloner:
+			t.Error("the cloning user was copied; they become the creator of the copy")
+		case creator:
+			want = event.RoleCoOrganizer
+		}
+		if m.Role != want {
+			t.Errorf("member %s copied as %s, want %s", m.UserID, m.Role, want)
+		}
+	}
+}
+
+type fakeTemplateEvents struct {
+	postgres.EventRepository
+	created []*event.Event
+	members map[uuid.UUID]event.EventParticipantRole
+}
+
+func (f *fakeTemplateEvents) GetByOrganization(organizationID uuid.UUID) ([]*event.Event, error) {
+	return f.created, nil
+}
+
+func (f *fakeTemplateEvents) Create(evt *event.Event) error {
+	f.created = append(f.created, evt)
+	return nil
+}
+
+func (f *fakeTemplateEvents) AddParticipantWithRole(eventID, userID string, role event.EventParticipantRole) error {
+	f.members[uuid.MustParse(userID)] = role
+	return nil
+}
+
+type fakeTemplateConfigs struct {
+	postgres.VotingConfigurationRepository
+	source  *vote.VotingConfiguration
+	created []*vote.VotingConfiguration
+}
+
+func (f *fakeTemplateConfigs) GetByEventID(eventID string) (*vote.VotingConfiguration, error) {
+	if f.source == nil || f.source.EventID.String() != eventID {
+		return nil, postgres.ErrVotingConfigNotFound
+	}
+	return f.source, nil
+}
+
+func (f *fakeTemplateConfigs) Create(config *vote.VotingConfiguration) error {
+	f.created = append(f.created, config)
+	return nil
+}
+
+func TestCloneArchivedEvent(t *testing.T) {
+	owner, cloner := uuid.New(), uuid.New()
+	archivedAt := time.Now().Add(-30 * 24 * time.Hour)
+	limit := 12
+
+	source := event.NewEvent("Observing Run 2025B", "Proposals for the second semester", owner,
+		time.Now().AddDate(-1, 0, 0), time.Now().AddDate(0, -6, 0), "Grava")
+	source.OrganizationID = uuid.New()
+	source.Stage = event.StageResult
+	source.ArchivedAt = &archivedAt
+	source.MaxParticipants = &limit
+	source.Visibility = event.VisibilityInviteOnly
+	source.RequireVerifiedEmail = true
+
+	events := &fakeTemplateEvents{members: make(map[uuid.UUID]event.EventParticipantRole)}
+	configs := &fakeTemplateConfigs{source: vote.NewVotingConfiguration(source.ID, 3)}
+	service := NewEventTemplateService(events, nil, configs)
+
+	snapshot := service.SnapshotEvent(source.Name, cloner, source)
+	clone, err := service.Instantiate(snapshot, InstantiateParams{
+		Name:      "Observing Run 2026B",
+		StartDate: time.Now().AddDate(0, 1, 0),
+		EndDate:   time.Now().AddDate(0, 7, 0),
+		AuthorID:  cloner,
+	})
+	if err != nil {
+		t.Fatalf("Instantiate: %v", err)
+	}
+
+	if clone.IsArchived() {
+		t.Error("the clone of an archived event is archived")
+	}
+	if clone.Stage != event.StageCreation {
+		t.Errorf("clone is in stage %s, want %s", clone.Stage, event.StageCreation)
+	}
+	if clone.ID == source.ID || clone.OrganizationID != source.OrganizationID {
+		t.Errorf("clone has ID %s in organization %s, want a new event in %s", clone.ID, clone.OrganizationID, source.OrganizationID)
+	}
+	if clone.ParticipantLimit() != limit || clone.Visibility != event.VisibilityInviteOnly {
+		t.Errorf("clone has limit %d and visibility %s, want %d and %s", clone.ParticipantLimit(), clone.Visibility, limit, event.VisibilityInviteOnly)
+	}
+	if !clone.RequireVerifiedEmail {
+		t.Error("clone does not require a verified email like its source")
+	}
+	if events.members[cloner] != event.RoleCreator {
+		t.Errorf("cloning user has role %q in the clone, want creator", events.members[cloner])
+	}
+	if len(configs.created) != 1 || configs.created[0].EventID != clone.ID {
+		t.Error("voting configuration of the archived event not copied to the clone")
+	}
+}
+
+func TestTemplateKeepsRegistrationRequirements(t *testing.T) {
+	for _, required := range []bool{true, false} {
+		source := event.NewEvent("Observing Run 2026A", "Proposals for the first semester", uuid.New(), time.Now(), time.Now().AddDate(0, 6, 0), "Grava")
+		source.RequireVerifiedEmail = required
+
+		configs := &fakeTemplateConfigs{}
+		service := NewEventTemplateService(&fakeTemplateEvents{members: make(map[uuid.UUID]event.EventParticipantRole)}, nil, configs)
+		template := service.SnapshotEvent("Semester call", uuid.New(), source)
+		if template.Settings.RequireVerifiedEmail != required {
+			t.Errorf("template saved require_verified_email = %t, want %t", template.Settings.RequireVerifiedEmail, required)
+		}
+
+		// Templates are stored as JSON and read back before being instantiated
+		raw, err := template.Settings.Value()
+		if err != nil {
+			t.Fatalf("Value: %v", err)
+		}
+		var stored event.TemplateSettings
+		if err := stored.Scan([]byte(raw.(string))); err != nil {
+			t.Fatalf("Scan: %v", err)
+		}
+		template.Settings = stored
+
+		instance, err := service.Instantiate(template, InstantiateParams{
+			Name:      "Observing Run 2026B",
+			StartDate: time.Now(),
+			EndDate:   time.Now().AddDate(0, 6, 0),
+			AuthorID:  uuid.New(),
+		})
+		if err != nil {
+			t.Fatalf("Instantiate: %v", err)
+		}
+		if instance.RequireVerifiedEmail != required {
+			t.Errorf("instance require_verified_email = %t, want %t", instance.RequireVerifiedEmail, required)
+		}
+	}
+}
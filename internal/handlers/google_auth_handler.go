@@ -3,30 +3,38 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/gin-gonic/gin"
 	"github.com/gravadigital/telescopio-api/internal/config"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
 	"github.com/gravadigital/telescopio-api/internal/logger"
-	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/oidc"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
 
 // GoogleAuthHandler handles Google OAuth authentication endpoints.
 type GoogleAuthHandler struct {
-	userRepo postgres.UserRepository
-	cfg      *config.Config
-	log      *log.Logger
+	userRepo  postgres.UserRepository
+	sessions  *SessionService
+	twoFactor *TwoFactorService
+	google    *oidc.Provider // nil when no Google client ID is configured
+	cfg       *config.Config
+	log       *log.Logger
 }
 
 // NewGoogleAuthHandler creates a new GoogleAuthHandler.
-func NewGoogleAuthHandler(userRepo postgres.UserRepository, cfg *config.Config) *GoogleAuthHandler {
+func NewGoogleAuthHandler(userRepo postgres.UserRepository, sessions *SessionService, twoFactor *TwoFactorService, providers *oidc.Registry, cfg *config.Config) *GoogleAuthHandler {
+	google, _ := providers.Get(oidc.GoogleProviderName)
 	return &GoogleAuthHandler{
-		userRepo: userRepo,
-		cfg:      cfg,
-		log:      logger.Handler("google_auth"),
+		userRepo:  userRepo,
+		sessions:  sessions,
+		twoFactor: twoFactor,
+		google:    google,
+		cfg:       cfg,
+		log:       logger.Handler("google_auth"),
 	}
 }
 
@@ -56,8 +64,15 @@ func (h *GoogleAuthHandler) VerifyGoogleToken(c *gin.Context) {
 		return
 	}
 
-	profile, err := verifyGoogleToken(req.Token, h.cfg.Google.ClientID)
+	profile, err := verifyGoogleToken(c.Request.Context(), h.google, req.Token, "")
 	if err != nil {
+		if errors.Is(err, ErrGoogleNotConfigured) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Google sign-in is not configured",
+				"code":  "GOOGLE_NOT_CONFIGURED",
+			})
+			return
+		}
 		if errors.Is(err, ErrInvalidGoogleToken) {
 			h.log.Warn("invalid google token received", "error", err)
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -85,8 +100,35 @@ func (h *GoogleAuthHandler) VerifyGoogleToken(c *gin.Context) {
 	}
 
 	if resolution.Status == "existing_user" {
-		token, err := auth.GenerateToken(resolution.User.ID, resolution.User.Email, resolution.User.Role)
+		// Enrolled accounts, and roles that must enrol, finish signing in with a code
+		challenge, err := h.twoFactor.Challenge(resolution.User)
 		if err != nil {
+			h.log.Error("failed to start two-factor challenge", "error", err, "user_id", resolution.User.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to generate token",
+				"code":  "INTERNAL_ERROR",
+			})
+			return
+		}
+		if challenge != nil {
+			respondSignInChallenge(c, challenge)
+			return
+		}
+
+		orgID, ok := requestOrganization(c)
+		if !ok {
+			return
+		}
+
+		tokens, err := h.sessions.Start(resolution.User, orgID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			if errors.Is(err, ErrAccountDeactivated) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "This account has been deactivated",
+					"code":  "ACCOUNT_DEACTIVATED",
+				})
+				return
+			}
 			h.log.Error("failed to generate JWT", "error", err, "user_id", resolution.User.ID)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to generate token",
@@ -97,8 +139,11 @@ func (h *GoogleAuthHandler) VerifyGoogleToken(c *gin.Context) {
 
 		h.log.Info("existing google user authenticated", "user_id", resolution.User.ID, "email", resolution.User.Email)
 		c.JSON(http.StatusOK, gin.H{
-			"status": "existing_user",
-			"token":  token,
+			"status":        "existing_user",
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
+			"token_type":    "Bearer",
+			"expires_in":    tokens.ExpiresIn,
 			"user": gin.H{
 				"id":       resolution.User.ID,
 				"email":    resolution.User.Email,
@@ -135,8 +180,15 @@ func (h *GoogleAuthHandler) RegisterGoogleUser(c *gin.Context) {
 	}
 
 	// Re-validate the Google token to prevent account creation with intercepted tokens
-	profile, err := verifyGoogleToken(req.Token, h.cfg.Google.ClientID)
+	profile, err := verifyGoogleToken(c.Request.Context(), h.google, req.Token, "")
 	if err != nil {
+		if errors.Is(err, ErrGoogleNotConfigured) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Google sign-in is not configured",
+				"code":  "GOOGLE_NOT_CONFIGURED",
+			})
+			return
+		}
 		if errors.Is(err, ErrInvalidGoogleToken) {
 			h.log.Warn("invalid google token in registration", "error", err)
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -180,6 +232,10 @@ func (h *GoogleAuthHandler) RegisterGoogleUser(c *gin.Context) {
 		GoogleID: &googleID,
 		Role:     participant.RoleParticipant,
 	}
+	if profile.EmailVerified {
+		now := time.Now()
+		newUser.EmailVerifiedAt = &now
+	}
 
 	if err := h.userRepo.Create(newUser); err != nil {
 		h.log.Error("failed to create google user", "error", err, "email", profile.Email)
@@ -190,7 +246,12 @@ func (h *GoogleAuthHandler) RegisterGoogleUser(c *gin.Context) {
 		return
 	}
 
-	token, err := auth.GenerateToken(newUser.ID, newUser.Email, newUser.Role)
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessions.Start(newUser, orgID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		h.log.Error("failed to generate JWT after registration", "error", err, "user_id", newUser.ID)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -202,7 +263,10 @@ func (h *GoogleAuthHandler) RegisterGoogleUser(c *gin.Context) {
 
 	h.log.Info("google user registered successfully", "user_id", newUser.ID, "email", newUser.Email, "username", newUser.Name)
 	c.JSON(http.StatusCreated, gin.H{
-		"token": token,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
 		"user": gin.H{
 			"id":       newUser.ID,
 			"email":    newUser.Email,
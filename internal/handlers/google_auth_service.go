@@ -1,13 +1,13 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/oidc"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
@@ -17,11 +17,15 @@ var ErrInvalidGoogleToken = errors.New("invalid or expired Google token")
 // ErrGoogleAPIUnavailable is returned when the Google API cannot be reached.
 var ErrGoogleAPIUnavailable = errors.New("Google API unavailable")
 
+// ErrGoogleNotConfigured is returned when no Google client ID is configured.
+var ErrGoogleNotConfigured = errors.New("Google sign-in is not configured")
+
 // GoogleProfile holds the user profile extracted from a validated Google token.
 type GoogleProfile struct {
-	GoogleID string
-	Email    string
-	Name     string
+	GoogleID      string
+	Email         string
+	Name          string
+	EmailVerified bool
 }
 
 // UserResolution holds the result of resolving a Google profile against the local user store.
@@ -30,53 +34,28 @@ type UserResolution struct {
 	User   *participant.User
 }
 
-// googleTokenInfoResponse maps the relevant fields from the Google tokeninfo endpoint.
-type googleTokenInfoResponse struct {
-	Sub              string `json:"sub"`
-	Email            string `json:"email"`
-	Name             string `json:"name"`
-	Aud              string `json:"aud"`
-	EmailVerified    string `json:"email_verified"`
-	ErrorDescription string `json:"error_description"`
-}
-
-// verifyGoogleToken calls the Google tokeninfo endpoint to validate an id_token.
-// It verifies that the token's aud matches clientID.
-// Returns ErrInvalidGoogleToken for bad/expired tokens, ErrGoogleAPIUnavailable for network errors.
-func verifyGoogleToken(token, clientID string) (*GoogleProfile, error) {
-	resp, err := http.Get("https://oauth2.googleapis.com/tokeninfo?id_token=" + token)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrGoogleAPIUnavailable, err)
+// verifyGoogleToken validates a Google id_token locally against Google's cached signing keys,
+// checking issuer, audience (the configured client ID) and expiry. A nonce is checked when given;
+// clients that request one use the generic OIDC endpoints with the "google" provider.
+// Returns ErrInvalidGoogleToken for bad/expired tokens, ErrGoogleAPIUnavailable when the keys can't be fetched.
+func verifyGoogleToken(ctx context.Context, provider *oidc.Provider, token, nonce string) (*GoogleProfile, error) {
+	if provider == nil {
+		return nil, ErrGoogleNotConfigured
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	identity, err := provider.Verify(ctx, token, nonce)
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to read response body: %v", ErrGoogleAPIUnavailable, err)
-	}
-
-	var info googleTokenInfoResponse
-	if err := json.Unmarshal(body, &info); err != nil {
-		return nil, fmt.Errorf("%w: failed to parse response: %v", ErrGoogleAPIUnavailable, err)
-	}
-
-	if info.ErrorDescription != "" || resp.StatusCode != http.StatusOK {
-		return nil, ErrInvalidGoogleToken
-	}
-
-	if clientID != "" && info.Aud != clientID {
-		return nil, ErrInvalidGoogleToken
+		if errors.Is(err, oidc.ErrProviderUnavailable) {
+			return nil, fmt.Errorf("%w: %v", ErrGoogleAPIUnavailable, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGoogleToken, err)
 	}
 
-	if info.Sub == "" || info.Email == "" {
+	if identity.Email == "" {
 		return nil, ErrInvalidGoogleToken
 	}
 
-	return &GoogleProfile{
-		GoogleID: info.Sub,
-		Email:    info.Email,
-		Name:     info.Name,
-	}, nil
+	return googleProfile(identity), nil
 }
 
 // resolveUser looks up a user by google_id then by email.
@@ -105,6 +84,10 @@ func resolveUser(profile *GoogleProfile, userRepo postgres.UserRepository) (*Use
 	if user.GoogleID == nil {
 		googleID := profile.GoogleID
 		user.GoogleID = &googleID
+		if profile.EmailVerified && !user.IsEmailVerified() {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
 		if err := userRepo.Update(user); err != nil {
 			return nil, fmt.Errorf("failed to link google_id to existing user: %w", err)
 		}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// IdempotencyKeyHeader lets clients safely retry non-idempotent requests
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// requestHash fingerprints a bound request payload so a key cannot be reused for a different body
+func requestHash(payload interface{}) string {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// replayIdempotentResponse answers a retried request from the stored record
+func replayIdempotentResponse(c *gin.Context, existing, attempted *postgres.IdempotencyKey) {
+	if existing.Scope != attempted.Scope || existing.RequestHash != attempted.RequestHash {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": "Idempotency-Key was already used with a different request",
+			"code":  "IDEMPOTENCY_KEY_REUSED",
+		})
+		return
+	}
+
+	if !existing.IsCompleted() || existing.ResponseBody == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A request with this Idempotency-Key is still being processed",
+			"code":  "IDEMPOTENCY_KEY_IN_PROGRESS",
+		})
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(*existing.ResponseStatus, "application/json; charset=utf-8", []byte(*existing.ResponseBody))
+}
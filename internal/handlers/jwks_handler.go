@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+)
+
+// jwksMaxAge is how long, in seconds, verifiers may cache the published keys. It is short
+// enough that a key added before a rotation is picked up well before it signs tokens.
+const jwksMaxAge = "300"
+
+// JWKSHandler publishes the public keys that verify access tokens
+type JWKSHandler struct {
+	keys *auth.KeySet
+}
+
+// NewJWKSHandler creates a new JWKS handler
+func NewJWKSHandler(keys *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json
+// Returns the RSA and Ed25519 keys of the key set, including retired keys still accepted.
+// HMAC secrets are never published.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age="+jwksMaxAge)
+	c.JSON(http.StatusOK, gin.H{
+		"keys": h.keys.PublicKeys(),
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// NotificationHandler exposes the in-app notifications of the authenticated user
+type NotificationHandler struct {
+	notificationRepo postgres.NotificationRepository
+	log              *log.Logger
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationRepo postgres.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: notificationRepo,
+		log:              logger.Handler("notification"),
+	}
+}
+
+// GetNotifications handles GET /api/notifications
+// Pass ?unread=true to only return unread notifications
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	notifications, err := h.notificationRepo.GetByUser(userID.String(), c.Query("unread") == "true")
+	if err != nil {
+		h.log.Error("failed to retrieve notifications", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve notifications",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	unread := 0
+	for _, n := range notifications {
+		if !n.IsRead() {
+			unread++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         notifications,
+		"unread_count": unread,
+	})
+}
+
+// MarkNotificationRead handles POST /api/notifications/{notification_id}/read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	notificationID := c.Param("notification_id")
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	if err := h.notificationRepo.MarkRead(notificationID, userID.String()); err != nil {
+		h.log.Warn("failed to mark notification as read", "notification_id", notificationID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Notification not found",
+			"code":  "NOTIFICATION_NOT_FOUND",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read",
+		"code":    "NOTIFICATION_READ",
+	})
+}
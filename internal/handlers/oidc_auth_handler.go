@@ -0,0 +1,427 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/oidc"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// OIDCAuthHandler signs users in with ID tokens of the configured OpenID Connect providers.
+// The frontend runs the authorization flow with the provider and hands the ID token over;
+// it is verified locally against the provider's signing keys.
+type OIDCAuthHandler struct {
+	container   *postgres.Container
+	providers   *oidc.Registry
+	sessions    *SessionService
+	twoFactor   *TwoFactorService
+	nonceSecret []byte
+	nonceTTL    time.Duration
+	log         *log.Logger
+}
+
+// NewOIDCAuthHandler creates a new OIDC auth handler
+func NewOIDCAuthHandler(container *postgres.Container, providers *oidc.Registry, sessions *SessionService, twoFactor *TwoFactorService, cfg *config.Config) *OIDCAuthHandler {
+	return &OIDCAuthHandler{
+		container:   container,
+		providers:   providers,
+		sessions:    sessions,
+		twoFactor:   twoFactor,
+		nonceSecret: []byte(cfg.OIDC.NonceSecret),
+		nonceTTL:    time.Duration(cfg.OIDC.NonceTTLMinutes) * time.Minute,
+		log:         logger.Handler("oidc_auth"),
+	}
+}
+
+type OIDCTokenRequest struct {
+	IDToken string `json:"id_token" binding:"required"`
+	Nonce   string `json:"nonce"` // Required unless the provider is configured without nonces
+}
+
+type RegisterOIDCUserRequest struct {
+	IDToken  string `json:"id_token" binding:"required"`
+	Nonce    string `json:"nonce"`
+	Username string `json:"username" binding:"required,min=2,max=100,excludesall=\t\n\r"`
+}
+
+// ListProviders handles GET /api/v1/auth/oidc/providers
+// Lists the providers users can sign in with, and what the frontend needs to start a sign-in
+func (h *OIDCAuthHandler) ListProviders(c *gin.Context) {
+	providers := make([]gin.H, 0)
+	for _, p := range h.providers.List() {
+		providers = append(providers, gin.H{
+			"name":           p.Name(),
+			"display_name":   p.DisplayName(),
+			"issuer":         p.Issuer(),
+			"client_id":      p.ClientID(),
+			"requires_nonce": p.RequiresNonce(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    providers,
+		"message": "Sign-in providers retrieved successfully",
+		"code":    "OIDC_PROVIDERS_RETRIEVED",
+	})
+}
+
+// IssueNonce handles POST /api/v1/auth/oidc/{provider}/nonce
+// The nonce goes into the provider's authorization request and comes back inside the ID token
+func (h *OIDCAuthHandler) IssueNonce(c *gin.Context) {
+	if _, ok := h.provider(c); !ok {
+		return
+	}
+
+	nonce, expiresAt, err := oidc.NewNonce(h.nonceSecret, h.nonceTTL)
+	if err != nil {
+		h.log.Error("failed to issue nonce", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to issue nonce",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"nonce":      nonce,
+			"expires_at": expiresAt,
+		},
+		"message": "Nonce issued successfully",
+		"code":    "OIDC_NONCE_ISSUED",
+	})
+}
+
+// VerifyIDToken handles POST /api/v1/auth/oidc/{provider}/verify
+// Signs in the user of the identity, or reports a new user who has to pick a username
+func (h *OIDCAuthHandler) VerifyIDToken(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	var req OIDCTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	identity, ok := h.verify(c, provider, req.IDToken, req.Nonce)
+	if !ok {
+		return
+	}
+
+	resolution, err := resolveIdentity(identity, h.container)
+	if err != nil {
+		if errors.Is(err, ErrIdentityEmailUnverified) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "An account with this email already exists; sign in to it and link this provider",
+				"code":  "OIDC_EMAIL_NOT_VERIFIED",
+			})
+			return
+		}
+		h.log.Error("failed to resolve identity", "provider", identity.Provider, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve user",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	if resolution.Status == "existing_user" {
+		// Enrolled accounts, and roles that must enrol, finish signing in with a code
+		challenge, err := h.twoFactor.Challenge(resolution.User)
+		if err != nil {
+			h.log.Error("failed to start two-factor challenge", "error", err, "user_id", resolution.User.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to generate token",
+				"code":  "INTERNAL_ERROR",
+			})
+			return
+		}
+		if challenge != nil {
+			respondSignInChallenge(c, challenge)
+			return
+		}
+
+		orgID, ok := requestOrganization(c)
+		if !ok {
+			return
+		}
+
+		tokens, err := h.sessions.Start(resolution.User, orgID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			if errors.Is(err, ErrAccountDeactivated) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "This account has been deactivated",
+					"code":  "ACCOUNT_DEACTIVATED",
+				})
+				return
+			}
+			h.log.Error("failed to generate JWT", "error", err, "user_id", resolution.User.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to generate token",
+				"code":  "INTERNAL_ERROR",
+			})
+			return
+		}
+
+		h.log.Info("oidc user authenticated", "provider", identity.Provider, "user_id", resolution.User.ID)
+		c.JSON(http.StatusOK, gin.H{
+			"status":        "existing_user",
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
+			"token_type":    "Bearer",
+			"expires_in":    tokens.ExpiresIn,
+			"user": gin.H{
+				"id":       resolution.User.ID,
+				"email":    resolution.User.Email,
+				"username": resolution.User.Name,
+			},
+		})
+		return
+	}
+
+	h.log.Info("new oidc user detected", "provider", identity.Provider)
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "new_user",
+		"provider": identity.Provider,
+		"profile": gin.H{
+			"email":          identity.Email,
+			"email_verified": identity.EmailVerified,
+			"suggested_name": identity.Name,
+		},
+	})
+}
+
+// RegisterOIDCUser handles POST /api/v1/auth/oidc/{provider}/register
+// Creates an account without password for a new identity, with the chosen username
+func (h *OIDCAuthHandler) RegisterOIDCUser(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	var req RegisterOIDCUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Re-validate the ID token to prevent account creation with intercepted tokens
+	identity, ok := h.verify(c, provider, req.IDToken, req.Nonce)
+	if !ok {
+		return
+	}
+
+	if identity.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "The provider did not share an email address",
+			"code":  "OIDC_EMAIL_MISSING",
+		})
+		return
+	}
+
+	// The identity must still be unknown; existing users sign in through verify
+	resolution, err := resolveIdentity(identity, h.container)
+	if err != nil && !errors.Is(err, ErrIdentityEmailUnverified) {
+		h.log.Error("failed to resolve identity", "provider", identity.Provider, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve user",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+	if err != nil || resolution.Status == "existing_user" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "An account for this identity or email already exists",
+			"code":  "USER_ALREADY_EXISTS",
+		})
+		return
+	}
+
+	usernameExists, err := h.container.Users().UsernameExists(req.Username)
+	if err != nil {
+		h.log.Error("failed to check username existence", "error", err, "username", req.Username)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to validate username",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+	if usernameExists {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Username is already in use",
+			"code":  "USERNAME_ALREADY_EXISTS",
+		})
+		return
+	}
+
+	newUser, err := createIdentityUser(h.container, identity, req.Username)
+	if err != nil {
+		h.log.Error("failed to create oidc user", "provider", identity.Provider, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create user",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessions.Start(newUser, orgID, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		h.log.Error("failed to generate JWT after registration", "error", err, "user_id", newUser.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate token",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("oidc user registered successfully", "provider", identity.Provider, "user_id", newUser.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
+		"user": gin.H{
+			"id":       newUser.ID,
+			"email":    newUser.Email,
+			"username": newUser.Name,
+		},
+	})
+}
+
+// LinkIdentity handles POST /api/v1/auth/oidc/{provider}/link
+// Links the identity to the authenticated user, e.g. when its email differs from the account's
+func (h *OIDCAuthHandler) LinkIdentity(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	var req OIDCTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	identity, ok := h.verify(c, provider, req.IDToken, req.Nonce)
+	if !ok {
+		return
+	}
+
+	user, err := h.container.Users().GetByID(userID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	if err := linkIdentity(h.container, user, identity); err != nil {
+		if errors.Is(err, postgres.ErrIdentityAlreadyLinked) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "This provider account or another account at the provider is already linked",
+				"code":  "IDENTITY_ALREADY_LINKED",
+			})
+			return
+		}
+		h.log.Error("failed to link identity", "provider", identity.Provider, "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to link identity",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("identity linked", "provider", identity.Provider, "user_id", userID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"provider": identity.Provider,
+			"email":    identity.Email,
+		},
+		"message": "Identity linked successfully",
+		"code":    "IDENTITY_LINKED",
+	})
+}
+
+// provider resolves the provider named in the URL
+func (h *OIDCAuthHandler) provider(c *gin.Context) (*oidc.Provider, bool) {
+	provider, ok := h.providers.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Sign-in provider not found",
+			"code":  "OIDC_PROVIDER_NOT_FOUND",
+		})
+		return nil, false
+	}
+	return provider, true
+}
+
+// verify checks the nonce was issued here and verifies the ID token with it
+func (h *OIDCAuthHandler) verify(c *gin.Context, provider *oidc.Provider, idToken, nonce string) (*oidc.Identity, bool) {
+	if nonce != "" {
+		if err := oidc.CheckNonce(h.nonceSecret, nonce, time.Now()); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid or expired nonce",
+				"code":  "INVALID_NONCE",
+			})
+			return nil, false
+		}
+	}
+
+	identity, err := provider.Verify(c.Request.Context(), idToken, nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrProviderUnavailable) {
+			h.log.Error("identity provider unavailable", "provider", provider.Name(), "error", err)
+			c.JSON(http.StatusBadGateway, gin.H{
+				"error": "Failed to contact the identity provider",
+				"code":  "OIDC_PROVIDER_UNAVAILABLE",
+			})
+			return nil, false
+		}
+		h.log.Warn("invalid id token received", "provider", provider.Name(), "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired ID token",
+			"code":  "INVALID_ID_TOKEN",
+		})
+		return nil, false
+	}
+
+	return identity, true
+}
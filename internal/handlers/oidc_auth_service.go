@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/oidc"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// ErrIdentityEmailUnverified is returned when an account with the identity's email exists,
+// but the provider doesn't vouch for the email. Linking it would let anyone who can set
+// that email at the provider take over the account.
+var ErrIdentityEmailUnverified = errors.New("an account with this email exists, but the provider has not verified the email")
+
+// resolveIdentity looks up the user of a verified OIDC identity: by the linked identity
+// first, then by email. Like resolveUser for Google, an account found by email is linked
+// automatically, but only when the provider verified the email.
+// Google identities are resolved through resolveUser, so they keep using users.google_id.
+func resolveIdentity(identity *oidc.Identity, container *postgres.Container) (*UserResolution, error) {
+	if identity.Provider == oidc.GoogleProviderName {
+		return resolveUser(googleProfile(identity), container.Users())
+	}
+
+	// 1. Search by linked identity
+	linked, err := container.Identities().GetByProviderSubject(identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := container.Users().GetByID(linked.UserID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user of identity: %w", err)
+		}
+		_ = container.Identities().Touch(linked.ID, time.Now())
+		return &UserResolution{Status: "existing_user", User: user}, nil
+	}
+	if err.Error() != "identity not found" {
+		return nil, fmt.Errorf("failed to look up identity: %w", err)
+	}
+
+	// 2. Search by email
+	if identity.Email == "" {
+		return &UserResolution{Status: "new_user"}, nil
+	}
+	user, err := container.Users().GetByEmail(identity.Email)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return &UserResolution{Status: "new_user"}, nil
+		}
+		return nil, fmt.Errorf("failed to look up user by email: %w", err)
+	}
+
+	if !identity.EmailVerified {
+		return nil, ErrIdentityEmailUnverified
+	}
+
+	// Found by verified email — link automatically
+	if err := linkIdentity(container, user, identity); err != nil {
+		return nil, fmt.Errorf("failed to link identity to existing user: %w", err)
+	}
+
+	return &UserResolution{Status: "existing_user", User: user}, nil
+}
+
+// linkIdentity links a verified identity to the user. Returns postgres.ErrIdentityAlreadyLinked
+// when the provider account belongs to another user or the user has another account at the provider.
+func linkIdentity(container *postgres.Container, user *participant.User, identity *oidc.Identity) error {
+	now := time.Now()
+
+	if identity.Provider == oidc.GoogleProviderName {
+		if user.GoogleID != nil {
+			if *user.GoogleID == identity.Subject {
+				return nil
+			}
+			return postgres.ErrIdentityAlreadyLinked
+		}
+		if _, err := container.Users().GetByGoogleID(identity.Subject); err == nil {
+			return postgres.ErrIdentityAlreadyLinked
+		}
+
+		googleID := identity.Subject
+		user.GoogleID = &googleID
+		if identity.EmailVerified && identity.Email == user.Email && !user.IsEmailVerified() {
+			user.EmailVerifiedAt = &now
+		}
+		return container.Users().Update(user)
+	}
+
+	if err := container.Identities().Create(&participant.Identity{
+		UserID:      user.ID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	}); err != nil {
+		return err
+	}
+
+	if identity.EmailVerified && identity.Email == user.Email && !user.IsEmailVerified() {
+		if err := container.Users().MarkEmailVerified(user.ID.String(), now); err != nil {
+			return err
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	return nil
+}
+
+// createIdentityUser creates a user without password for a verified identity and links it
+func createIdentityUser(container *postgres.Container, identity *oidc.Identity, username string) (*participant.User, error) {
+	tx, err := container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	user := &participant.User{
+		Name:  username,
+		Email: identity.Email,
+		Role:  participant.RoleParticipant,
+	}
+	if identity.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+	if identity.Provider == oidc.GoogleProviderName {
+		googleID := identity.Subject
+		user.GoogleID = &googleID
+	}
+
+	if err := tx.Users().Create(user); err != nil {
+		return nil, err
+	}
+
+	if identity.Provider != oidc.GoogleProviderName {
+		if err := tx.Identities().Create(&participant.Identity{
+			UserID:      user.ID,
+			Provider:    identity.Provider,
+			Subject:     identity.Subject,
+			Email:       identity.Email,
+			LastLoginAt: &now,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func googleProfile(identity *oidc.Identity) *GoogleProfile {
+	return &GoogleProfile{
+		GoogleID:      identity.Subject,
+		Email:         identity.Email,
+		Name:          identity.Name,
+		EmailVerified: identity.EmailVerified,
+	}
+}
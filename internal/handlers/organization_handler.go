@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// OrganizationHandler serves the branding and members of the organization of the request,
+// and the platform endpoints managing every organization
+type OrganizationHandler struct {
+	container     *postgres.Container
+	organizations *OrganizationService
+	log           *log.Logger
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(container *postgres.Container, organizations *OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		container:     container,
+		organizations: organizations,
+		log:           logger.Handler("organization"),
+	}
+}
+
+type UpdateOrganizationRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=200"`
+	LogoURL      *string `json:"logo_url" binding:"omitempty,max=500"` // Empty string removes the logo
+	PrimaryColor *string `json:"primary_color"`                        // #rrggbb; empty string removes it
+}
+
+type ChangeMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type CreateOrganizationRequest struct {
+	Slug        string `json:"slug" binding:"required"`
+	Name        string `json:"name" binding:"required,min=1,max=200"`
+	AdminUserID string `json:"admin_user_id"` // Optional: existing user made the first org admin
+}
+
+// GetOrganization handles GET /api/v1/organization
+// Public: the name and branding of the organization the request is served for
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	org, err := tenant.Organization(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Organization not resolved",
+			"code":  "ORGANIZATION_NOT_RESOLVED",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    org,
+		"message": "Organization retrieved successfully",
+		"code":    "ORGANIZATION_RETRIEVED",
+	})
+}
+
+// UpdateOrganization handles PATCH /api/v1/organization
+// Changes the name and branding; the slug, which names the subdomain, can't change
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	current, err := tenant.Organization(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Organization not resolved",
+			"code":  "ORGANIZATION_NOT_RESOLVED",
+		})
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// The resolved organization is shared with the rest of the request
+	org := *current
+	if req.Name != nil {
+		org.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.LogoURL != nil {
+		org.LogoURL = strings.TrimSpace(*req.LogoURL)
+	}
+	if req.PrimaryColor != nil {
+		org.PrimaryColor = strings.TrimSpace(*req.PrimaryColor)
+	}
+
+	if err := org.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid organization",
+			"code":    "INVALID_ORGANIZATION",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.container.Organizations().Update(&org); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update organization",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    org,
+		"message": "Organization updated successfully",
+		"code":    "ORGANIZATION_UPDATED",
+	})
+}
+
+// ListMembers handles GET /api/v1/organization/members
+// Query params: page and limit
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	page, limit := paginationFromQuery(c, 20)
+
+	result, err := h.container.Organizations().GetMembers(orgID, postgres.PaginationParams{Page: page, PageSize: limit})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve members",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	members, _ := result.Data.([]*organization.Member)
+	data := make([]gin.H, len(members))
+	for i, member := range members {
+		data[i] = memberResponse(member)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Members retrieved successfully",
+		"code":    "MEMBERS_RETRIEVED",
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.PageSize,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// ChangeMemberRole handles PATCH /api/v1/organization/members/{user_id}
+// Revokes the member's access tokens
+func (h *OrganizationHandler) ChangeMemberRole(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req ChangeMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := organization.MemberRole(req.Role)
+	if !role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid role",
+			"code":        "INVALID_ROLE",
+			"valid_roles": organization.MemberRoles,
+		})
+		return
+	}
+
+	member, err := h.organizations.ChangeMemberRole(actor, uuid.MustParse(userID), role)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    memberResponse(member),
+		"message": "Member role changed successfully",
+		"code":    "MEMBER_ROLE_CHANGED",
+	})
+}
+
+// RemoveMember handles DELETE /api/v1/organization/members/{user_id}
+// Revokes the member's access tokens; their account, events and registrations stay
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizations.RemoveMember(actor, uuid.MustParse(userID)); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed successfully",
+		"code":    "MEMBER_REMOVED",
+	})
+}
+
+// CreateOrganization handles POST /api/v1/platform/organizations
+// The slug names the subdomain of the organization and can't change later
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if req.AdminUserID != "" {
+		if _, err := uuid.Parse(req.AdminUserID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid admin_user_id format",
+				"code":  "INVALID_USER_ID",
+			})
+			return
+		}
+	}
+
+	org := organization.NewOrganization(req.Slug, req.Name)
+	if err := org.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid organization",
+			"code":    "INVALID_ORGANIZATION",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	admin, err := h.organizations.Create(org, req.AdminUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, postgres.ErrOrganizationSlugTaken):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"code":  "ORGANIZATION_SLUG_TAKEN",
+			})
+		case errors.Is(err, ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Admin user not found",
+				"code":  "USER_NOT_FOUND",
+			})
+		default:
+			h.log.Error("failed to create organization", "slug", org.Slug, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to create organization",
+				"code":  "CREATION_ERROR",
+			})
+		}
+		return
+	}
+
+	data := gin.H{"organization": org}
+	if admin != nil {
+		data["admin"] = admin
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    data,
+		"message": "Organization created successfully",
+		"code":    "ORGANIZATION_CREATED",
+	})
+}
+
+// ListOrganizations handles GET /api/v1/platform/organizations
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	orgs, err := h.container.Organizations().GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve organizations",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    orgs,
+		"message": "Organizations retrieved successfully",
+		"code":    "ORGANIZATIONS_RETRIEVED",
+	})
+}
+
+func (h *OrganizationHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Member not found",
+			"code":  "MEMBER_NOT_FOUND",
+		})
+	case errors.Is(err, ErrPlatformAdminOnly):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+			"code":  "PLATFORM_ADMIN_REQUIRED",
+		})
+	case errors.Is(err, ErrLastOrgAdmin):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "LAST_ORG_ADMIN",
+		})
+	case errors.Is(err, ErrMemberUnchanged):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "ROLE_UNCHANGED",
+		})
+	default:
+		h.log.Error("organization member operation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update member",
+			"code":  "DB_UPDATE_ERROR",
+		})
+	}
+}
+
+// requestOrganization returns the ID of the organization the request is served for
+func requestOrganization(c *gin.Context) (uuid.UUID, bool) {
+	orgID, err := tenant.OrganizationID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Organization not resolved",
+			"code":  "ORGANIZATION_NOT_RESOLVED",
+		})
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+func memberResponse(member *organization.Member) gin.H {
+	data := gin.H{
+		"user_id":    member.UserID.String(),
+		"role":       member.Role.String(),
+		"created_at": member.CreatedAt,
+	}
+	if member.User != nil {
+		data["name"] = member.User.Name
+		data["lastname"] = member.User.LastName
+		data["email"] = member.User.Email
+		data["global_role"] = member.User.Role.String()
+	}
+	return data
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+var (
+	ErrMemberNotFound  = errors.New("the user is not a member of the organization")
+	ErrLastOrgAdmin    = errors.New("the organization must keep at least one org admin")
+	ErrMemberUnchanged = errors.New("the member already has this role")
+)
+
+// OrganizationService creates organizations and manages their members. Member changes are
+// written to the user audit log, and revoke the member's access tokens so the new role
+// applies from their next refresh.
+type OrganizationService struct {
+	container *postgres.Container
+	log       *log.Logger
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(container *postgres.Container) *OrganizationService {
+	return &OrganizationService{
+		container: container,
+		log:       logger.Service("organization"),
+	}
+}
+
+// Create creates an organization. The optional admin, an existing user, joins it as its
+// first org admin.
+func (s *OrganizationService) Create(org *organization.Organization, adminID string) (*organization.Member, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.Organizations().Create(org); err != nil {
+		return nil, err
+	}
+
+	var member *organization.Member
+	if adminID != "" {
+		admin, err := tx.Users().GetByID(adminID)
+		if err != nil {
+			if err.Error() == "user not found" || err.Error() == "invalid user ID format" {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+
+		member = &organization.Member{
+			OrganizationID: org.ID,
+			UserID:         admin.ID,
+			Role:           organization.RoleOrgAdmin,
+			CreatedAt:      time.Now(),
+		}
+		if err := tx.Organizations().AddMember(member); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("organization created", "organization_id", org.ID, "slug", org.Slug, "admin_id", adminID)
+	return member, nil
+}
+
+// ChangeMemberRole makes a member an org admin or a plain member
+func (s *OrganizationService) ChangeMemberRole(actor AdminActor, userID uuid.UUID, role organization.MemberRole) (*organization.Member, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	member, user, err := s.loadMember(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == role {
+		return nil, ErrMemberUnchanged
+	}
+	if member.IsAdmin() {
+		if err := s.keepAdmin(tx, actor.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Organizations().SetMemberRole(actor.OrganizationID, userID, role); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Users().IncrementTokenVersion(userID.String()); err != nil {
+		return nil, err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditMemberRoleChanged, participant.AuditDetails{
+		"from": member.Role,
+		"to":   role,
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	member.Role = role
+	member.User = user
+	s.log.Info("organization member role changed", "organization_id", actor.OrganizationID, "user_id", userID, "role", role, "actor_id", actor.UserID)
+	return member, nil
+}
+
+// RemoveMember removes a user from the organization. Their account, events and
+// registrations stay; they join again as a plain member if they sign in to it.
+func (s *OrganizationService) RemoveMember(actor AdminActor, userID uuid.UUID) error {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	member, user, err := s.loadMember(tx, actor, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.IsAdmin() {
+		if err := s.keepAdmin(tx, actor.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Organizations().RemoveMember(actor.OrganizationID, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Users().IncrementTokenVersion(userID.String()); err != nil {
+		return err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditMemberRemoved, participant.AuditDetails{
+		"role": member.Role,
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.log.Info("organization member removed", "organization_id", actor.OrganizationID, "user_id", userID, "actor_id", actor.UserID)
+	return nil
+}
+
+// loadMember loads the member an admin changes. Admins (global role) are only changed by
+// other admins.
+func (s *OrganizationService) loadMember(tx *postgres.TransactionContainer, actor AdminActor, userID uuid.UUID) (*organization.Member, *participant.User, error) {
+	member, err := tx.Organizations().GetMember(actor.OrganizationID, userID)
+	if err != nil {
+		if err.Error() == "organization member not found" {
+			return nil, nil, ErrMemberNotFound
+		}
+		return nil, nil, err
+	}
+
+	user, err := tx.Users().GetByID(userID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.Role == participant.RoleAdmin && !actor.Platform {
+		return nil, nil, ErrPlatformAdminOnly
+	}
+
+	return member, user, nil
+}
+
+// keepAdmin refuses to demote or remove the last org admin of the organization
+func (s *OrganizationService) keepAdmin(tx *postgres.TransactionContainer, organizationID uuid.UUID) error {
+	admins, err := tx.Organizations().CountAdmins(organizationID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastOrgAdmin
+	}
+	return nil
+}
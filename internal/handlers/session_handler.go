@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+)
+
+// SessionHandler renews access tokens and ends sessions
+type SessionHandler struct {
+	sessions *SessionService
+	log      *log.Logger
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(sessions *SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessions: sessions,
+		log:      logger.Handler("session"),
+	}
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshSession handles POST /api/v1/auth/refresh
+// Returns a new access token and a new refresh token; the presented refresh token is used up
+func (h *SessionHandler) RefreshSession(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tokens, user, err := h.sessions.Refresh(req.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRefreshTokenReused):
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Refresh token was already used; please sign in again",
+				"code":  "REFRESH_TOKEN_REUSED",
+			})
+		case errors.Is(err, ErrInvalidRefreshToken):
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid or expired refresh token",
+				"code":  "INVALID_REFRESH_TOKEN",
+			})
+		case errors.Is(err, ErrAccountDeactivated):
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This account has been deactivated",
+				"code":  "ACCOUNT_DEACTIVATED",
+			})
+		default:
+			h.log.Error("failed to refresh session", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to refresh session",
+				"code":  "TOKEN_GENERATION_ERROR",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":            "Session refreshed successfully",
+		"token":              tokens.AccessToken,
+		"refresh_token":      tokens.RefreshToken,
+		"token_type":         "Bearer",
+		"expires_in":         tokens.ExpiresIn,
+		"refresh_expires_at": tokens.RefreshExpiresAt,
+		"user": gin.H{
+			"id":    user.ID.String(),
+			"email": user.Email,
+			"role":  user.Role.String(),
+		},
+	})
+}
+
+// Logout handles POST /api/v1/auth/logout
+// Revokes the refresh token and every token rotated from the same login. The current
+// access token stays valid until it expires; use logout-all to revoke access tokens too.
+func (h *SessionHandler) Logout(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.sessions.End(req.RefreshToken); err != nil {
+		h.log.Error("failed to end session", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to log out",
+			"code":  "LOGOUT_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+		"code":    "LOGGED_OUT",
+	})
+}
+
+// LogoutEverywhere handles POST /api/v1/auth/logout-all
+// Revokes every refresh token and every access token of the authenticated user
+func (h *SessionHandler) LogoutEverywhere(c *gin.Context) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	if err := h.sessions.EndAll(userID.String()); err != nil {
+		h.log.Error("failed to end all sessions", "user_id", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to log out",
+			"code":  "LOGOUT_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("all sessions ended", "user_id", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out from all sessions successfully",
+		"code":    "LOGGED_OUT_EVERYWHERE",
+	})
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// ErrInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// ErrRefreshTokenReused is returned when a refresh token is presented a second time.
+// The whole login is revoked, since either the client or an attacker holds a stolen copy.
+var ErrRefreshTokenReused = errors.New("refresh token was already used")
+
+// ErrAccountDeactivated is returned when an admin deactivated the account being signed in
+var ErrAccountDeactivated = errors.New("account is deactivated")
+
+// SessionTokens are the credentials handed out on login and on every refresh
+type SessionTokens struct {
+	AccessToken      string
+	RefreshToken     string
+	ExpiresIn        int // access token lifetime in seconds
+	RefreshExpiresAt time.Time
+}
+
+// SessionService issues access tokens together with rotating refresh tokens and revokes them
+type SessionService struct {
+	container  *postgres.Container
+	refreshTTL time.Duration
+	log        *log.Logger
+}
+
+// NewSessionService creates a new session service
+func NewSessionService(container *postgres.Container, cfg *config.Config) *SessionService {
+	return &SessionService{
+		container:  container,
+		refreshTTL: time.Duration(cfg.Auth.RefreshTokenTTLHours) * time.Hour,
+		log:        logger.Service("session"),
+	}
+}
+
+// Start issues the tokens of a new login in the organization. Users signing in to an
+// organization for the first time join it as members.
+func (s *SessionService) Start(user *participant.User, organizationID uuid.UUID, userAgent, ipAddress string) (*SessionTokens, error) {
+	if user.IsDeactivated() {
+		return nil, ErrAccountDeactivated
+	}
+
+	member := &organization.Member{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           organization.RoleMember,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.container.Organizations().AddMember(member); err != nil {
+		return nil, err
+	}
+
+	// The user may already be an org admin
+	member, err := s.container.Organizations().GetMember(organizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(s.container.RefreshTokens(), user, member, uuid.New(), userAgent, ipAddress)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// The presented token can't be used again.
+func (s *SessionService) Refresh(plain, userAgent, ipAddress string) (*SessionTokens, *participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	token, err := tx.RefreshTokens().GetByHashForUpdate(session.HashToken(plain))
+	if err != nil {
+		if err.Error() == "refresh token not found" {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if token.IsRevoked() || token.IsExpired(now) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	if token.IsUsed() {
+		if err := tx.RefreshTokens().RevokeFamily(token.FamilyID); err != nil {
+			return nil, nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, nil, err
+		}
+		s.log.Warn("refresh token reuse detected, login revoked", "user_id", token.UserID, "family_id", token.FamilyID)
+		return nil, nil, ErrRefreshTokenReused
+	}
+
+	user, err := tx.Users().GetByID(token.UserID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.IsDeactivated() {
+		return nil, nil, ErrAccountDeactivated
+	}
+
+	// Members removed from the organization can't extend their login there
+	member, err := tx.Organizations().GetMember(token.OrganizationID, user.ID)
+	if err != nil {
+		if err.Error() == "organization member not found" {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.RefreshTokens().MarkUsed(token.ID, now); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issue(tx.RefreshTokens(), user, member, token.FamilyID, userAgent, ipAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+// End revokes the login the refresh token belongs to. Unknown tokens are ignored.
+// Access tokens already issued stay valid until they expire.
+func (s *SessionService) End(plain string) error {
+	token, err := s.container.RefreshTokens().GetByHashForUpdate(session.HashToken(plain))
+	if err != nil {
+		if err.Error() == "refresh token not found" {
+			return nil
+		}
+		return err
+	}
+
+	return s.container.RefreshTokens().RevokeFamily(token.FamilyID)
+}
+
+// EndAll revokes every login of the user, including the access tokens already issued
+func (s *SessionService) EndAll(userID string) error {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.Users().IncrementTokenVersion(userID); err != nil {
+		return err
+	}
+
+	if err := tx.RefreshTokens().RevokeAllForUser(userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SessionService) issue(refreshTokens postgres.RefreshTokenRepository, user *participant.User, member *organization.Member, familyID uuid.UUID, userAgent, ipAddress string) (*SessionTokens, error) {
+	accessToken, err := auth.GenerateToken(user.ID, user.Email, user.Role, member, user.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, plain, err := session.NewRefreshToken(user.ID, member.OrganizationID, familyID, s.refreshTTL, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := refreshTokens.Create(refreshToken); err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		AccessToken:      accessToken,
+		RefreshToken:     plain,
+		ExpiresIn:        int(auth.AccessTokenTTL().Seconds()),
+		RefreshExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// SubmissionFormHandler manages the submission form schema of events and exports
+// the answers given by participants
+type SubmissionFormHandler struct {
+	container *postgres.Container
+	log       *log.Logger
+}
+
+// NewSubmissionFormHandler creates a new submission form handler
+func NewSubmissionFormHandler(container *postgres.Container) *SubmissionFormHandler {
+	return &SubmissionFormHandler{
+		container: container,
+		log:       logger.Handler("submission_form"),
+	}
+}
+
+type UpdateSubmissionFormRequest struct {
+	Fields []submission.Field `json:"fields" binding:"required"`
+}
+
+// GetSubmissionForm handles GET /api/events/{event_id}/submission-form
+// Events without a form return null together with the default form as a starting point
+func (h *SubmissionFormHandler) GetSubmissionForm(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":        evt.ID.String(),
+			"submission_form": evt.SubmissionForm,
+			"default_form":    submission.DefaultForm(),
+		},
+		"message": "Submission form retrieved successfully",
+		"code":    "SUBMISSION_FORM_RETRIEVED",
+	})
+}
+
+// UpdateSubmissionForm handles PUT /api/events/{event_id}/submission-form
+// The form can be changed until voting starts. Answered fields can't be removed or change
+// type, nor can options in use be removed. Answers already given are kept as they are;
+// they are validated against the new form the next time the participant edits them.
+func (h *SubmissionFormHandler) UpdateSubmissionForm(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("updating submission form", "event_id", eventID)
+
+	var req UpdateSubmissionFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for submission form", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	form := &submission.Form{Fields: req.Fields}
+	if err := form.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid submission form",
+			"code":    "INVALID_SUBMISSION_FORM",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	evt, ok := h.editableEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	if evt.SubmissionForm != nil {
+		answers, ok := h.givenAnswers(c, eventID)
+		if !ok {
+			return
+		}
+		if changes := evt.SubmissionForm.BreakingChanges(form, answers); len(changes) > 0 {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "The change would discard answers already given",
+				"code":    "SUBMISSION_FORM_IN_USE",
+				"details": changes,
+			})
+			return
+		}
+	}
+
+	if err := h.container.Events().UpdateSubmissionForm(evt.ID.String(), form); err != nil {
+		h.log.Error("failed to update submission form", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update submission form",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	userID, _ := auth.GetUserIDFromContext(c)
+	h.log.Info("submission form updated", "event_id", eventID, "fields", len(form.Fields), "updated_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":        evt.ID.String(),
+			"submission_form": form,
+		},
+		"message": "Submission form updated successfully",
+		"code":    "SUBMISSION_FORM_UPDATED",
+	})
+}
+
+// DeleteSubmissionForm handles DELETE /api/events/{event_id}/submission-form
+// Only forms nobody answered yet can be removed
+func (h *SubmissionFormHandler) DeleteSubmissionForm(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("removing submission form", "event_id", eventID)
+
+	evt, ok := h.editableEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	answers, ok := h.givenAnswers(c, eventID)
+	if !ok {
+		return
+	}
+	if len(answers) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":    "The submission form has answers and can't be removed",
+			"code":     "SUBMISSION_FORM_IN_USE",
+			"answered": len(answers),
+		})
+		return
+	}
+
+	if err := h.container.Events().UpdateSubmissionForm(evt.ID.String(), nil); err != nil {
+		h.log.Error("failed to remove submission form", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to remove submission form",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	userID, _ := auth.GetUserIDFromContext(c)
+	h.log.Info("submission form removed", "event_id", eventID, "removed_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Submission form removed successfully",
+		"code":    "SUBMISSION_FORM_REMOVED",
+	})
+}
+
+// ExportSubmissions handles GET /api/events/{event_id}/submissions/export?format=csv|json
+// Exports one row per proposal with its form answers. Accepts the same field[<key>]
+// filters as the attachment listing.
+func (h *SubmissionFormHandler) ExportSubmissions(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "format must be csv or json",
+			"code":  "INVALID_FORMAT",
+		})
+		return
+	}
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return
+	}
+
+	if evt.SubmissionForm == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "This event has no submission form",
+			"code":  "NO_SUBMISSION_FORM",
+		})
+		return
+	}
+
+	attachments, err := h.container.Attachments().GetByEventID(eventID)
+	if err != nil {
+		h.log.Error("failed to retrieve event attachments", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachments",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	if filter := submission.Filter(c.QueryMap("field")); len(filter) > 0 {
+		attachments, err = filterByAnswers(attachments, evt.SubmissionForm, filter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid answer filter",
+				"code":    "INVALID_FILTER",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	h.log.Info("exporting submissions", "event_id", eventID, "format", format, "count", len(attachments))
+
+	if format == "json" {
+		rows := make([]gin.H, len(attachments))
+		for i, att := range attachments {
+			rows[i] = gin.H{
+				"id":             att.ID.String(),
+				"participant_id": att.ParticipantID.String(),
+				"co_author_ids":  att.GetCoAuthorIDs(),
+				"original_name":  att.OriginalName,
+				"uploaded_at":    att.UploadedAt,
+				"answers":        att.Answers,
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"event_id":        evt.ID.String(),
+				"submission_form": evt.SubmissionForm,
+				"submissions":     rows,
+				"count":           len(rows),
+			},
+			"message": "Submissions exported successfully",
+			"code":    "SUBMISSIONS_EXPORTED",
+		})
+		return
+	}
+
+	header := []string{"id", "participant_id", "co_author_ids", "original_name", "uploaded_at"}
+	for _, field := range evt.SubmissionForm.Fields {
+		header = append(header, field.Key)
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"submissions-%s.csv\"", evt.ID.String()))
+	c.Status(http.StatusOK)
+
+	// Answers and file names come from participants; cells are escaped so opening the
+	// export in a spreadsheet doesn't run formulas
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(spreadsheetRow(header))
+	for _, att := range attachments {
+		coAuthors := make([]string, 0, len(att.CoAuthors))
+		for _, id := range att.GetCoAuthorIDs() {
+			coAuthors = append(coAuthors, id.String())
+		}
+
+		row := []string{
+			att.ID.String(),
+			att.ParticipantID.String(),
+			strings.Join(coAuthors, "; "),
+			att.OriginalName,
+			att.UploadedAt.Format(time.RFC3339),
+		}
+		for _, field := range evt.SubmissionForm.Fields {
+			row = append(row, submission.FormatAnswer(att.Answers[field.Key]))
+		}
+		_ = w.Write(spreadsheetRow(row))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.log.Error("failed to write submissions export", "event_id", eventID, "error", err)
+	}
+}
+
+// editableEvent loads an event whose submission form may still change, writing the error
+// response otherwise
+func (h *SubmissionFormHandler) editableEvent(c *gin.Context, eventID string) (*event.Event, bool) {
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	if evt.IsArchived() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This event is archived and read-only",
+			"code":  "EVENT_ARCHIVED",
+		})
+		return nil, false
+	}
+
+	if evt.Stage != event.StageCreation && evt.Stage != event.StageParticipation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "The submission form can only be changed before voting starts",
+			"code":          "INVALID_EVENT_STAGE",
+			"current_stage": evt.Stage.String(),
+		})
+		return nil, false
+	}
+
+	return evt, true
+}
+
+// givenAnswers returns the non-empty answers of the proposals of the event, writing the
+// error response when they can't be loaded
+func (h *SubmissionFormHandler) givenAnswers(c *gin.Context, eventID string) ([]submission.Answers, bool) {
+	attachments, err := h.container.Attachments().GetByEventID(eventID)
+	if err != nil {
+		h.log.Error("failed to retrieve event attachments", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve attachments",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return nil, false
+	}
+
+	var answers []submission.Answers
+	for _, att := range attachments {
+		if len(att.Answers) > 0 {
+			answers = append(answers, att.Answers)
+		}
+	}
+	return answers, true
+}
+
+// spreadsheetRow escapes the cells of a CSV row that spreadsheets would read as formulas
+func spreadsheetRow(cells []string) []string {
+	for i, cell := range cells {
+		cells[i] = submission.SpreadsheetSafe(cell)
+	}
+	return cells
+}
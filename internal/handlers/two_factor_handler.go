@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/ratelimit"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// TwoFactorHandler manages TOTP enrolment and finishes sign-ins that need a second factor
+type TwoFactorHandler struct {
+	twoFactor *TwoFactorService
+	sessions  *SessionService
+	lockout   *ratelimit.Lockout
+	userRepo  postgres.UserRepository
+	log       *log.Logger
+}
+
+// NewTwoFactorHandler creates a new two-factor handler
+func NewTwoFactorHandler(twoFactor *TwoFactorService, sessions *SessionService, lockout *ratelimit.Lockout, userRepo postgres.UserRepository) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactor: twoFactor,
+		sessions:  sessions,
+		lockout:   lockout,
+		userRepo:  userRepo,
+		log:       logger.Handler("two_factor"),
+	}
+}
+
+type TwoFactorCodeRequest struct {
+	// Code is a six digit code from the authenticator app or, where accepted, a recovery code
+	Code string `json:"code" binding:"required"`
+}
+
+type SignInChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+type CompleteSignInChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// GetTwoFactorStatus handles GET /api/v1/auth/two-factor
+func (h *TwoFactorHandler) GetTwoFactorStatus(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.twoFactor.Status(user)
+	if err != nil {
+		h.log.Error("failed to get two-factor status", "user_id", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get two-factor status",
+			"code":  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    status,
+		"message": "Two-factor status retrieved successfully",
+		"code":    "TWO_FACTOR_STATUS",
+	})
+}
+
+// EnrollTwoFactor handles POST /api/v1/auth/two-factor/enroll
+// Returns a new secret and its otpauth:// URI for the authenticator app; two-factor
+// authentication is enabled once confirmed with a code
+func (h *TwoFactorHandler) EnrollTwoFactor(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	h.enroll(c, user)
+}
+
+// ConfirmTwoFactor handles POST /api/v1/auth/two-factor/confirm
+// Enables two-factor authentication and returns the recovery codes, shown only once
+func (h *TwoFactorHandler) ConfirmTwoFactor(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactor.Confirm(user, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.log.Info("two-factor enabled", "user_id", user.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"recovery_codes": recoveryCodes,
+		},
+		"message": "Two-factor authentication enabled; store the recovery codes somewhere safe",
+		"code":    "TWO_FACTOR_ENABLED",
+	})
+}
+
+// RegenerateRecoveryCodes handles POST /api/v1/auth/two-factor/recovery-codes
+// Replaces the recovery codes; requires a code from the authenticator app
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactor.RegenerateRecoveryCodes(user, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"recovery_codes": recoveryCodes,
+		},
+		"message": "Recovery codes replaced; the previous codes no longer work",
+		"code":    "RECOVERY_CODES_REGENERATED",
+	})
+}
+
+// DisableTwoFactor handles POST /api/v1/auth/two-factor/disable
+// Requires a code or a recovery code; refused for roles that must use two-factor authentication
+func (h *TwoFactorHandler) DisableTwoFactor(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	if err := h.twoFactor.Disable(user, req.Code); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.log.Info("two-factor disabled", "user_id", user.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Two-factor authentication disabled",
+		"code":    "TWO_FACTOR_DISABLED",
+	})
+}
+
+// EnrollForSignIn handles POST /api/v1/auth/two-factor/challenge/enroll
+// Starts the enrolment of a user whose role requires two-factor authentication, during
+// sign-in; the challenge is then completed with a code, which confirms the enrolment
+func (h *TwoFactorHandler) EnrollForSignIn(c *gin.Context) {
+	var req SignInChallengeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	user, err := h.twoFactor.ChallengeUser(req.ChallengeToken)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.enroll(c, user)
+}
+
+// CompleteSignIn handles POST /api/v1/auth/two-factor/challenge
+// Finishes a password sign-in with a code or a recovery code. Wrong codes count as failed
+// sign-ins towards the account lockout.
+func (h *TwoFactorHandler) CompleteSignIn(c *gin.Context) {
+	var req CompleteSignInChallengeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	user, err := h.twoFactor.ChallengeUser(req.ChallengeToken)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if lockedFor := h.lockout.Check(ctx, user.Email); lockedFor > 0 {
+		h.log.Warn("two-factor sign-in refused: account locked", "user_id", user.ID, "locked_for", lockedFor)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       "Too many failed sign-in attempts. Try again later",
+			"code":        "ACCOUNT_LOCKED",
+			"retry_after": ratelimit.SetRetryAfter(c, lockedFor),
+		})
+		return
+	}
+
+	user, recoveryCodes, err := h.twoFactor.CompleteChallenge(req.ChallengeToken, req.Code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) && user != nil {
+			h.log.Warn("two-factor sign-in failed: invalid code", "user_id", user.ID)
+			h.lockout.RecordFailure(ctx, user.Email)
+		}
+		h.respondError(c, err)
+		return
+	}
+
+	if err := h.lockout.Reset(ctx, user.Email); err != nil {
+		h.log.Error("failed to clear failed sign-ins", "user_id", user.ID, "error", err)
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessions.Start(user, orgID, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		if errors.Is(err, ErrAccountDeactivated) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This account has been deactivated",
+				"code":  "ACCOUNT_DEACTIVATED",
+			})
+			return
+		}
+		h.log.Error("failed to generate token", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate authentication token",
+			"code":  "TOKEN_GENERATION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("user authenticated with two-factor", "user_id", user.ID)
+
+	response := gin.H{
+		"message":       "User authenticated successfully",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
+		"user": gin.H{
+			"id":         user.ID.String(),
+			"name":       user.Name,
+			"lastname":   user.LastName,
+			"email":      user.Email,
+			"role":       user.Role.String(),
+			"created_at": user.CreatedAt,
+		},
+	}
+	// Enrolled while signing in: the recovery codes are shown this once
+	if recoveryCodes != nil {
+		response["recovery_codes"] = recoveryCodes
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// respondSignInChallenge answers a sign-in that needs a second factor; the client continues
+// with the challenge token, enrolling first when enrollment is required
+func respondSignInChallenge(c *gin.Context, challenge *SignInChallenge) {
+	status, message := "two_factor_required", "Enter the code from your authenticator app"
+	if challenge.EnrollmentRequired {
+		status, message = "two_factor_enrollment_required", "Your role requires two-factor authentication; set it up to sign in"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":          status,
+		"message":         message,
+		"challenge_token": challenge.Token,
+		"expires_in":      challenge.ExpiresIn,
+	})
+}
+
+func (h *TwoFactorHandler) enroll(c *gin.Context, user *participant.User) {
+	enrolment, err := h.twoFactor.Enroll(user)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    enrolment,
+		"message": "Add the account to your authenticator app, then confirm with a code",
+		"code":    "TWO_FACTOR_ENROLLMENT_STARTED",
+	})
+}
+
+// currentUser loads the authenticated user, answering the request when it can't
+func (h *TwoFactorHandler) currentUser(c *gin.Context) (*participant.User, bool) {
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return nil, false
+	}
+
+	user, err := h.userRepo.GetByID(userID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+			"code":  "USER_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	return user, true
+}
+
+func bindTwoFactorRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+func (h *TwoFactorHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or already used code",
+			"code":  "INVALID_TWO_FACTOR_CODE",
+		})
+	case errors.Is(err, ErrInvalidSignInChallenge):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Sign-in challenge is invalid or expired; please sign in again",
+			"code":  "INVALID_SIGN_IN_CHALLENGE",
+		})
+	case errors.Is(err, ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Two-factor authentication is already enabled",
+			"code":  "TWO_FACTOR_ALREADY_ENABLED",
+		})
+	case errors.Is(err, ErrTwoFactorNotEnrolled):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Two-factor authentication is not set up",
+			"code":  "TWO_FACTOR_NOT_ENROLLED",
+		})
+	case errors.Is(err, ErrTwoFactorMandatory):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Two-factor authentication is mandatory for your role",
+			"code":  "TWO_FACTOR_MANDATORY",
+		})
+	default:
+		h.log.Error("two-factor request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to process two-factor request",
+			"code":  "INTERNAL_ERROR",
+		})
+	}
+}
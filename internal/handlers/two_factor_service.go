@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+	"github.com/gravadigital/telescopio-api/internal/totp"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not set up")
+	ErrTwoFactorMandatory      = errors.New("two-factor authentication is mandatory for this role")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidSignInChallenge  = errors.New("invalid or expired sign-in challenge")
+)
+
+// TwoFactorStatus describes the TOTP enrolment of a user
+type TwoFactorStatus struct {
+	Enabled           bool       `json:"enabled"`
+	Pending           bool       `json:"pending"`  // enrolment started but not confirmed with a code
+	Required          bool       `json:"required"` // the role of the user must use two-factor authentication
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
+
+// TwoFactorEnrolment is what the user needs to add the account to an authenticator app
+type TwoFactorEnrolment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI, usually shown as a QR code
+}
+
+// SignInChallenge is handed out after a correct password when the account needs a second
+// factor. The token is redeemed with a code to finish signing in.
+type SignInChallenge struct {
+	Token              string
+	EnrollmentRequired bool // the role requires two-factor authentication and the user hasn't set it up
+	ExpiresIn          int64
+}
+
+// TwoFactorService manages TOTP enrolments and the second step of password sign-ins
+type TwoFactorService struct {
+	container *postgres.Container
+	config    *config.Config
+	log       *log.Logger
+}
+
+// NewTwoFactorService creates a new two-factor service
+func NewTwoFactorService(container *postgres.Container, cfg *config.Config) *TwoFactorService {
+	return &TwoFactorService{
+		container: container,
+		config:    cfg,
+		log:       logger.Service("two_factor"),
+	}
+}
+
+// Required reports whether the role of the user must use two-factor authentication. The
+// org_admin role stands for the users administering any organization.
+func (s *TwoFactorService) Required(user *participant.User) bool {
+	if slices.Contains(s.config.TwoFactor.RequiredRoles, user.Role.String()) {
+		return true
+	}
+	if !slices.Contains(s.config.TwoFactor.RequiredRoles, organization.RoleOrgAdmin.String()) {
+		return false
+	}
+
+	memberships, err := s.container.Organizations().GetMemberships(user.ID)
+	if err != nil {
+		// Fail closed: an org admin must not skip the second factor
+		s.log.Error("failed to get memberships for two-factor policy", "user_id", user.ID, "error", err)
+		return true
+	}
+
+	return slices.ContainsFunc(memberships, (*organization.Member).IsAdmin)
+}
+
+// Status returns the enrolment of a user
+func (s *TwoFactorService) Status(user *participant.User) (*TwoFactorStatus, error) {
+	status := &TwoFactorStatus{Required: s.Required(user)}
+
+	enrolment, err := s.enrolment(s.container.TwoFactor(), user)
+	if err != nil {
+		if errors.Is(err, ErrTwoFactorNotEnrolled) {
+			return status, nil
+		}
+		return nil, err
+	}
+
+	status.Enabled = enrolment.IsEnabled()
+	status.Pending = !enrolment.IsEnabled()
+	status.EnabledAt = enrolment.EnabledAt
+	if status.Enabled {
+		if status.RecoveryCodesLeft, err = s.container.TwoFactor().CountRecoveryCodes(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// Enroll starts an enrolment with a new secret, replacing a pending one. It takes effect
+// once confirmed with a code from the authenticator app.
+func (s *TwoFactorService) Enroll(user *participant.User) (*TwoFactorEnrolment, error) {
+	enrolment, err := s.enrolment(s.container.TwoFactor(), user)
+	if err != nil && !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		return nil, err
+	}
+	if enrolment.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.container.TwoFactor().SavePending(&session.TwoFactor{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("two-factor enrolment started", "user_id", user.ID)
+	return &TwoFactorEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.config.TwoFactor.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables a pending enrolment with a code from the authenticator app and returns
+// the recovery codes, which are shown once
+func (s *TwoFactorService) Confirm(user *participant.User, code string) ([]string, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	recoveryCodes, err := s.confirm(tx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user, proven with a code from
+// the authenticator app
+func (s *TwoFactorService) RegenerateRecoveryCodes(user *participant.User, code string) ([]string, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	enrolment, err := s.enabledEnrolment(tx.TwoFactor(), user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(tx.TwoFactor(), enrolment, code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.issueRecoveryCodes(tx.TwoFactor(), user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("recovery codes regenerated", "user_id", user.ID)
+	return recoveryCodes, nil
+}
+
+// Disable turns two-factor authentication off, proven with a code or a recovery code.
+// Roles that require it can't turn it off.
+func (s *TwoFactorService) Disable(user *participant.User, code string) error {
+	if s.Required(user) {
+		return ErrTwoFactorMandatory
+	}
+
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	enrolment, err := s.enabledEnrolment(tx.TwoFactor(), user)
+	if err != nil {
+		return err
+	}
+	if err := s.verify(tx.TwoFactor(), enrolment, code, true); err != nil {
+		return err
+	}
+
+	if err := tx.TwoFactor().Delete(user.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.log.Info("two-factor disabled", "user_id", user.ID)
+	return nil
+}
+
+// Challenge returns the challenge that finishes a password sign-in, or nil when the user
+// signs in with the password alone
+func (s *TwoFactorService) Challenge(user *participant.User) (*SignInChallenge, error) {
+	enrolment, err := s.enrolment(s.container.TwoFactor(), user)
+	if err != nil && !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		return nil, err
+	}
+
+	enabled := enrolment.IsEnabled()
+	if !enabled && !s.Required(user) {
+		return nil, nil
+	}
+
+	ttl := time.Duration(s.config.TwoFactor.ChallengeTTLMinutes) * time.Minute
+	token, plain, err := session.NewUserToken(user.ID, session.PurposeTwoFactorChallenge, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.UserTokens().InvalidateForUser(user.ID, session.PurposeTwoFactorChallenge); err != nil {
+		return nil, err
+	}
+	if err := tx.UserTokens().Create(token); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &SignInChallenge{
+		Token:              plain,
+		EnrollmentRequired: !enabled,
+		ExpiresIn:          int64(ttl.Seconds()),
+	}, nil
+}
+
+// ChallengeUser returns the user a pending sign-in challenge belongs to, without using it up
+func (s *TwoFactorService) ChallengeUser(plain string) (*participant.User, error) {
+	token, err := s.container.UserTokens().GetValid(session.HashToken(plain), session.PurposeTwoFactorChallenge, time.Now())
+	if err != nil {
+		if err.Error() == "user token not found" {
+			return nil, ErrInvalidSignInChallenge
+		}
+		return nil, err
+	}
+
+	return s.container.Users().GetByID(token.UserID.String())
+}
+
+// CompleteChallenge redeems a sign-in challenge with a code or a recovery code. When the
+// challenge asked the user to enrol, the code confirms the enrolment and the new recovery
+// codes are returned. A wrong code leaves the challenge usable until it expires.
+func (s *TwoFactorService) CompleteChallenge(plain, code string) (*participant.User, []string, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	token, err := tx.UserTokens().Consume(session.HashToken(plain), session.PurposeTwoFactorChallenge, time.Now())
+	if err != nil {
+		if err.Error() == "user token not found" {
+			return nil, nil, ErrInvalidSignInChallenge
+		}
+		return nil, nil, err
+	}
+
+	user, err := tx.Users().GetByID(token.UserID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enrolment, err := s.enrolment(tx.TwoFactor(), user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var recoveryCodes []string
+	if enrolment.IsEnabled() {
+		err = s.verify(tx.TwoFactor(), enrolment, code, true)
+	} else {
+		recoveryCodes, err = s.confirm(tx, user, code)
+	}
+	if err != nil {
+		return user, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	s.log.Info("sign-in challenge completed", "user_id", user.ID, "enrolled", recoveryCodes != nil)
+	return user, recoveryCodes, nil
+}
+
+// confirm enables the pending enrolment of the user and issues recovery codes
+func (s *TwoFactorService) confirm(tx *postgres.TransactionContainer, user *participant.User, code string) ([]string, error) {
+	enrolment, err := s.enrolment(tx.TwoFactor(), user)
+	if err != nil {
+		return nil, err
+	}
+	if enrolment.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	now := time.Now()
+	step, ok := totp.Verify(enrolment.Secret, code, now, 0)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	if err := tx.TwoFactor().Enable(user.ID, now, step); err != nil {
+		return nil, err
+	}
+
+	return s.issueRecoveryCodes(tx.TwoFactor(), user)
+}
+
+// verify checks a code from the authenticator app, or a recovery code when allowed, and
+// uses it up
+func (s *TwoFactorService) verify(repo postgres.TwoFactorRepository, enrolment *session.TwoFactor, code string, allowRecovery bool) error {
+	now := time.Now()
+	if step, ok := totp.Verify(enrolment.Secret, code, now, enrolment.LastUsedStep); ok {
+		if err := repo.UseStep(enrolment.UserID, step); err != nil {
+			if err.Error() == "two-factor code already used" {
+				return ErrInvalidTwoFactorCode
+			}
+			return err
+		}
+		return nil
+	}
+
+	if !allowRecovery {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if err := repo.ConsumeRecoveryCode(enrolment.UserID, session.HashRecoveryCode(code), now); err != nil {
+		if err.Error() == "recovery code not found" {
+			return ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+	return nil
+}
+
+// issueRecoveryCodes replaces the recovery codes of the user and returns the plain codes
+func (s *TwoFactorService) issueRecoveryCodes(repo postgres.TwoFactorRepository, user *participant.User) ([]string, error) {
+	codes, plain, err := session.NewRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.ReplaceRecoveryCodes(user.ID, codes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
+
+// enrolment returns the enrolment of the user, confirmed or pending
+func (s *TwoFactorService) enrolment(repo postgres.TwoFactorRepository, user *participant.User) (*session.TwoFactor, error) {
+	enrolment, err := repo.Get(user.ID)
+	if err != nil {
+		if err.Error() == "two-factor enrolment not found" {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	return enrolment, nil
+}
+
+// enabledEnrolment returns the confirmed enrolment of the user
+func (s *TwoFactorService) enabledEnrolment(repo postgres.TwoFactorRepository, user *participant.User) (*session.TwoFactor, error) {
+	enrolment, err := s.enrolment(repo, user)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolment.IsEnabled() {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	return enrolment, nil
+}
@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// UserAdminHandler serves the admin endpoints to find, change and merge user accounts
+type UserAdminHandler struct {
+	container *postgres.Container
+	admin     *UserAdminService
+	log       *log.Logger
+}
+
+// NewUserAdminHandler creates a new user admin handler
+func NewUserAdminHandler(container *postgres.Container, admin *UserAdminService) *UserAdminHandler {
+	return &UserAdminHandler{
+		container: container,
+		admin:     admin,
+		log:       logger.Handler("user_admin"),
+	}
+}
+
+type ChangeUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type DeactivateUserRequest struct {
+	Reason string `json:"reason" binding:"max=500"` // Optional: kept in the audit log
+}
+
+type MergeUsersRequest struct {
+	SourceUserID string `json:"source_user_id" binding:"required"` // Account merged into the one in the path, then deleted
+}
+
+// ListUsers handles GET /api/v1/admin/users
+// Query params: q (name, last name or email), role, status (active, deactivated),
+// type (user, service_account), page and limit
+func (h *UserAdminHandler) ListUsers(c *gin.Context) {
+	page, limit := paginationFromQuery(c, 20)
+
+	role := c.Query("role")
+	if role != "" && !participant.Role(role).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid role filter",
+			"code":        "INVALID_ROLE",
+			"valid_roles": []string{"admin", "organizer", "participant"},
+		})
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", postgres.UserStatusActive, postgres.UserStatusDeactivated:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Invalid status filter",
+			"code":         "INVALID_STATUS",
+			"valid_status": []string{postgres.UserStatusActive, postgres.UserStatusDeactivated},
+		})
+		return
+	}
+
+	userType := c.Query("type")
+	switch userType {
+	case "", postgres.UserTypeUser, postgres.UserTypeServiceAccount:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid type filter",
+			"code":        "INVALID_TYPE",
+			"valid_types": []string{postgres.UserTypeUser, postgres.UserTypeServiceAccount},
+		})
+		return
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.container.Users().GetAllPaginated(
+		orgID,
+		postgres.PaginationParams{Page: page, PageSize: limit},
+		postgres.SearchParams{
+			Query: strings.TrimSpace(c.Query("q")),
+			Filters: map[string]string{
+				"role":   role,
+				"status": status,
+				"type":   userType,
+			},
+		},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve users",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	users, _ := result.Data.([]*participant.User)
+	data := make([]gin.H, len(users))
+	for i, user := range users {
+		data[i] = adminUserResponse(user)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Users retrieved successfully",
+		"code":    "USERS_RETRIEVED",
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.PageSize,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetUser handles GET /api/v1/admin/users/{user_id}
+func (h *UserAdminHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.container.Users().GetByIDInOrganization(tenant.Scope(c), userID)
+	if err != nil {
+		h.respondError(c, ErrUserNotFound)
+		return
+	}
+
+	identities, err := h.container.Identities().GetByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve user",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	providers := make([]string, 0, len(identities))
+	for _, identity := range identities {
+		providers = append(providers, identity.Provider)
+	}
+
+	data := adminUserResponse(user)
+	data["identity_providers"] = providers
+
+	// A failing lockout store shouldn't hide the account
+	if failures, lockedUntil, err := h.admin.LockoutStatus(c.Request.Context(), user); err == nil {
+		data["failed_sign_ins"] = failures
+		data["locked_until"] = lockedUntil
+	} else {
+		h.log.Error("failed to get lockout status", "user_id", user.ID, "error", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "User retrieved successfully",
+		"code":    "USER_RETRIEVED",
+	})
+}
+
+// ChangeUserRole handles PATCH /api/v1/admin/users/{user_id}/role
+func (h *UserAdminHandler) ChangeUserRole(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req ChangeUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role := participant.Role(req.Role)
+	if !role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid role",
+			"code":        "INVALID_ROLE",
+			"valid_roles": []string{"admin", "organizer", "participant"},
+		})
+		return
+	}
+
+	user, err := h.admin.ChangeRole(actor, userID, role)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    adminUserResponse(user),
+		"message": "User role changed successfully",
+		"code":    "USER_ROLE_CHANGED",
+	})
+}
+
+// DeactivateUser handles POST /api/v1/admin/users/{user_id}/deactivate
+// Revokes every session, access token and API key use of the account
+func (h *UserAdminHandler) DeactivateUser(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req DeactivateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.admin.Deactivate(actor, userID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    adminUserResponse(user),
+		"message": "User deactivated successfully",
+		"code":    "USER_DEACTIVATED",
+	})
+}
+
+// ReactivateUser handles POST /api/v1/admin/users/{user_id}/reactivate
+func (h *UserAdminHandler) ReactivateUser(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.admin.Reactivate(actor, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    adminUserResponse(user),
+		"message": "User reactivated successfully",
+		"code":    "USER_REACTIVATED",
+	})
+}
+
+// UnlockUser handles POST /api/v1/admin/users/{user_id}/unlock
+// Clears the failed sign-ins of the account so it can sign in again right away
+func (h *UserAdminHandler) UnlockUser(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.admin.Unlock(c.Request.Context(), actor, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    adminUserResponse(user),
+		"message": "User unlocked successfully",
+		"code":    "USER_UNLOCKED",
+	})
+}
+
+// ResetUserTwoFactor handles POST /api/v1/admin/users/{user_id}/two-factor/reset
+// Removes the second factor of a user who lost their authenticator and recovery codes
+func (h *UserAdminHandler) ResetUserTwoFactor(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.admin.ResetTwoFactor(actor, userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    adminUserResponse(user),
+		"message": "Two-factor authentication reset successfully",
+		"code":    "TWO_FACTOR_RESET",
+	})
+}
+
+// MergeUsers handles POST /api/v1/admin/users/{user_id}/merge
+// Merges the source account into the account in the path and deletes the source
+func (h *UserAdminHandler) MergeUsers(c *gin.Context) {
+	actor, ok := adminActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req MergeUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if _, err := uuid.Parse(req.SourceUserID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid source_user_id format",
+			"code":  "INVALID_USER_ID",
+		})
+		return
+	}
+
+	user, moved, err := h.admin.Merge(actor, userID, req.SourceUserID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	data := adminUserResponse(user)
+	data["moved"] = moved
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Users merged successfully",
+		"code":    "USERS_MERGED",
+	})
+}
+
+// GetAuditLog handles GET /api/v1/admin/audit-log and GET /api/v1/admin/users/{user_id}/audit-log
+func (h *UserAdminHandler) GetAuditLog(c *gin.Context) {
+	page, limit := paginationFromQuery(c, 20)
+
+	userID := c.Param("user_id")
+	if userID != "" {
+		if _, ok := userIDParam(c); !ok {
+			return
+		}
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.container.UserAuditLog().GetPaginated(orgID, userID, postgres.PaginationParams{Page: page, PageSize: limit})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve audit log",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    result.Data,
+		"message": "Audit log retrieved successfully",
+		"code":    "AUDIT_LOG_RETRIEVED",
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.PageSize,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// adminActor returns the signed-in admin and the organization they act in
+func adminActor(c *gin.Context) (AdminActor, bool) {
+	actorID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return AdminActor{}, false
+	}
+
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return AdminActor{}, false
+	}
+
+	role, _ := auth.GetUserRoleFromContext(c)
+	return AdminActor{UserID: actorID, OrganizationID: orgID, Platform: role == participant.RoleAdmin}, true
+}
+
+func (h *UserAdminHandler) respondError(c *gin.Context, err error) {
+	var conflict *MergeConflictError
+	switch {
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":            "Both accounts take part in the same events; remove one of them from these events first",
+			"code":             "MERGE_SHARED_EVENTS",
+			"shared_event_ids": conflict.EventIDs,
+		})
+	case errors.Is(err, ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+			"code":  "USER_NOT_FOUND",
+		})
+	case errors.Is(err, ErrSelfAdministration):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+			"code":  "SELF_ADMINISTRATION",
+		})
+	case errors.Is(err, ErrPlatformAdminOnly):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+			"code":  "PLATFORM_ADMIN_REQUIRED",
+		})
+	case errors.Is(err, ErrRoleUnchanged):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "ROLE_UNCHANGED",
+		})
+	case errors.Is(err, ErrServiceAccountAdmin), errors.Is(err, ErrMergeServiceAccount), errors.Is(err, ErrMergeSameUser):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"code":  "INVALID_OPERATION",
+		})
+	case errors.Is(err, ErrAlreadyDeactivated):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "ALREADY_DEACTIVATED",
+		})
+	case errors.Is(err, ErrNotDeactivated):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "NOT_DEACTIVATED",
+		})
+	case errors.Is(err, ErrNotLocked):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "NOT_LOCKED",
+		})
+	case errors.Is(err, ErrTwoFactorNotEnrolled):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"code":  "TWO_FACTOR_NOT_ENROLLED",
+		})
+	default:
+		h.log.Error("user admin operation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update user",
+			"code":  "DB_UPDATE_ERROR",
+		})
+	}
+}
+
+// userIDParam validates the user_id path parameter
+func userIDParam(c *gin.Context) (string, bool) {
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user_id format",
+			"code":  "INVALID_USER_ID",
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// paginationFromQuery reads the page and limit query parameters
+func paginationFromQuery(c *gin.Context, defaultLimit int) (int, int) {
+	page := 1
+	limit := defaultLimit
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	return page, limit
+}
+
+func adminUserResponse(user *participant.User) gin.H {
+	return gin.H{
+		"id":                 user.ID.String(),
+		"name":               user.Name,
+		"lastname":           user.LastName,
+		"email":              user.Email,
+		"role":               user.Role.String(),
+		"email_verified":     user.IsEmailVerified(),
+		"has_password":       user.PasswordHash != nil,
+		"google_linked":      user.GoogleID != nil,
+		"is_service_account": user.IsServiceAccount,
+		"deactivated_at":     user.DeactivatedAt,
+		"created_at":         user.CreatedAt,
+		"updated_at":         user.UpdatedAt,
+	}
+}
@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/ratelimit"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrSelfAdministration  = errors.New("admins can't change their own role, deactivate or merge away their own account")
+	ErrRoleUnchanged       = errors.New("the user already has this role")
+	ErrServiceAccountAdmin = errors.New("service accounts can't be admins")
+	ErrAlreadyDeactivated  = errors.New("the account is already deactivated")
+	ErrNotDeactivated      = errors.New("the account is not deactivated")
+	ErrNotLocked           = errors.New("the account has no failed sign-ins to clear")
+	ErrMergeSameUser       = errors.New("an account can't be merged into itself")
+	ErrMergeServiceAccount = errors.New("service accounts can't be merged")
+	ErrMergeSharedEvents   = errors.New("both accounts take part in the same events")
+	ErrPlatformAdminOnly   = errors.New("only platform admins can grant the admin role or change admins and accounts shared with other organizations")
+)
+
+// AdminActor is the admin carrying out a change and the organization they administer.
+// Platform admins hold the global admin role; org admins only administer their organization.
+type AdminActor struct {
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Platform       bool
+}
+
+// MergeConflictError is returned when the accounts to merge share events: their
+// registrations, proposals and votes in those events can't be combined automatically
+type MergeConflictError struct {
+	EventIDs []uuid.UUID
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s: %d shared events", ErrMergeSharedEvents, len(e.EventIDs))
+}
+
+func (e *MergeConflictError) Unwrap() error {
+	return ErrMergeSharedEvents
+}
+
+// UserAdminService carries out admin changes to user accounts. Every change is written
+// to the user audit log in the same transaction.
+type UserAdminService struct {
+	container *postgres.Container
+	lockout   *ratelimit.Lockout
+	log       *log.Logger
+}
+
+// NewUserAdminService creates a new user admin service
+func NewUserAdminService(container *postgres.Container, lockout *ratelimit.Lockout) *UserAdminService {
+	return &UserAdminService{
+		container: container,
+		lockout:   lockout,
+		log:       logger.Service("user_admin"),
+	}
+}
+
+// ChangeRole changes the global role of a user. Their access tokens are revoked so the
+// new role applies from their next refresh.
+func (s *UserAdminService) ChangeRole(actor AdminActor, userID string, role participant.Role) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := s.loadTarget(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == role {
+		return nil, ErrRoleUnchanged
+	}
+	if user.IsServiceAccount && role == participant.RoleAdmin {
+		return nil, ErrServiceAccountAdmin
+	}
+	if role == participant.RoleAdmin && !actor.Platform {
+		return nil, ErrPlatformAdminOnly
+	}
+
+	if err := tx.Users().SetRole(userID, role); err != nil {
+		return nil, err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditRoleChanged, participant.AuditDetails{
+		"from": user.Role,
+		"to":   role,
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	s.log.Info("user role changed", "user_id", user.ID, "role", role, "actor_id", actor.UserID)
+	return user, nil
+}
+
+// Deactivate blocks an account: its sessions and access tokens are revoked, and it can't
+// sign in or use its API keys until it is reactivated
+func (s *UserAdminService) Deactivate(actor AdminActor, userID, reason string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := s.loadTarget(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeactivated() {
+		return nil, ErrAlreadyDeactivated
+	}
+
+	now := time.Now()
+	if err := tx.Users().Deactivate(userID, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.RefreshTokens().RevokeAllForUser(userID); err != nil {
+		return nil, err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditDeactivated, participant.AuditDetails{
+		"reason": reason,
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	user.DeactivatedAt = &now
+	s.log.Info("user deactivated", "user_id", user.ID, "actor_id", actor.UserID)
+	return user, nil
+}
+
+// Reactivate lets a deactivated account sign in again. Tokens revoked on deactivation
+// stay revoked.
+func (s *UserAdminService) Reactivate(actor AdminActor, userID string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := s.loadTarget(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsDeactivated() {
+		return nil, ErrNotDeactivated
+	}
+
+	if err := tx.Users().Reactivate(userID); err != nil {
+		return nil, err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditReactivated, participant.AuditDetails{
+		"deactivated_at": user.DeactivatedAt,
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	user.DeactivatedAt = nil
+	s.log.Info("user reactivated", "user_id", user.ID, "actor_id", actor.UserID)
+	return user, nil
+}
+
+// Unlock clears the failed sign-ins of an account, lifting a lockout after repeated wrong
+// passwords
+func (s *UserAdminService) Unlock(ctx context.Context, actor AdminActor, userID string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := s.loadTarget(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, lockedFor, err := s.lockout.Status(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if failures == 0 {
+		return nil, ErrNotLocked
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditUnlocked, participant.AuditDetails{
+		"failed_sign_ins":    failures,
+		"locked_for_seconds": int(lockedFor.Seconds()),
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := s.lockout.Reset(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("user unlocked", "user_id", user.ID, "failed_sign_ins", failures, "actor_id", actor.UserID)
+	return user, nil
+}
+
+// ResetTwoFactor removes the second factor of a user who lost their authenticator and
+// recovery codes. Roles that require two-factor authentication enrol again at sign-in.
+func (s *UserAdminService) ResetTwoFactor(actor AdminActor, userID string) (*participant.User, error) {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := s.loadTarget(tx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolment, err := tx.TwoFactor().Get(user.ID)
+	if err != nil {
+		if err.Error() == "two-factor enrolment not found" {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+
+	entry := participant.NewAuditEntry(actor.OrganizationID, actor.UserID, user, participant.AuditTwoFactorReset, participant.AuditDetails{
+		"was_enabled": enrolment.IsEnabled(),
+	})
+	if err := tx.UserAuditLog().Create(entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.TwoFactor().Delete(user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("two-factor reset", "user_id", user.ID, "actor_id", actor.UserID)
+	return user, nil
+}
+
+// LockoutStatus returns the failed sign-ins counted for a user and until when they are
+// locked out, nil when they aren't
+func (s *UserAdminService) LockoutStatus(ctx context.Context, user *participant.User) (int, *time.Time, error) {
+	failures, lockedFor, err := s.lockout.Status(ctx, user.Email)
+	if err != nil || lockedFor == 0 {
+		return failures, nil, err
+	}
+
+	lockedUntil := time.Now().Add(lockedFor)
+	return failures, &lockedUntil, nil
+}
+
+// Merge folds the source account into the target account, typically a password account
+// and a Google account of the same person. The target keeps its email and role and takes
+// over the events, proposals, votes, API keys and sign-in methods of the source, which is
+// deleted. Accounts sharing events are refused with a MergeConflictError.
+func (s *UserAdminService) Merge(actor AdminActor, targetID, sourceID string) (*participant.User, map[string]int64, error) {
+	if targetID == sourceID {
+		return nil, nil, ErrMergeSameUser
+	}
+
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	source, err := s.loadTarget(tx, actor, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Admins may merge other accounts into their own
+	target, err := tx.Users().GetByID(targetID)
+	if err != nil {
+		if err.Error() == "user not found" || err.Error() == "invalid user ID format" {
+			return nil, nil, ErrUserNotFound
+		}
+		return nil, nil, err
+	}
+	if err := s.checkScope(tx, actor, target); err != nil {
+		return nil, nil, err
+	}
+
+	if source.ID == target.ID {
+		return nil, nil, ErrMergeSameUser
+	}
+	if source.IsServiceAccount || target.IsServiceAccount {
+		return nil, nil, ErrMergeServiceAccount
+	}
+
+	shared, err := tx.Users().GetSharedEvents(source.ID, target.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(shared) > 0 {
+		return nil, nil, &MergeConflictError{EventIDs: shared}
+	}
+
+	moved, err := tx.Users().Merge(source, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, entry := range []*participant.AuditEntry{
+		participant.NewAuditEntry(actor.OrganizationID, actor.UserID, source, participant.AuditMerged, participant.AuditDetails{
+			"merged_into":       target.ID,
+			"merged_into_email": target.Email,
+		}),
+		participant.NewAuditEntry(actor.OrganizationID, actor.UserID, target, participant.AuditMerged, participant.AuditDetails{
+			"merged_from":       source.ID,
+			"merged_from_email": source.Email,
+			"moved":             moved,
+		}),
+	} {
+		if err := tx.UserAuditLog().Create(entry); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	merged, err := tx.Users().GetByID(targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	s.log.Info("users merged", "source_id", source.ID, "target_id", target.ID, "actor_id", actor.UserID)
+	return merged, moved, nil
+}
+
+// loadTarget loads the account an admin changes. Admins can't change their own account,
+// which also guarantees an active admin is always left.
+func (s *UserAdminService) loadTarget(tx *postgres.TransactionContainer, actor AdminActor, userID string) (*participant.User, error) {
+	user, err := tx.Users().GetByID(userID)
+	if err != nil {
+		if err.Error() == "user not found" || err.Error() == "invalid user ID format" {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.ID == actor.UserID {
+		return nil, ErrSelfAdministration
+	}
+
+	if err := s.checkScope(tx, actor, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkScope checks the admin may change the account. Users of other organizations are
+// reported as missing. Accounts are shared by every organization they belong to, so org
+// admins can only change those of their organization alone, and never platform admins.
+func (s *UserAdminService) checkScope(tx *postgres.TransactionContainer, actor AdminActor, user *participant.User) error {
+	memberships, err := tx.Organizations().GetMemberships(user.ID)
+	if err != nil {
+		return err
+	}
+
+	member := false
+	for _, membership := range memberships {
+		if membership.OrganizationID == actor.OrganizationID {
+			member = true
+		}
+	}
+	if !member {
+		return ErrUserNotFound
+	}
+
+	if !actor.Platform && (len(memberships) > 1 || user.Role == participant.RoleAdmin) {
+		return ErrPlatformAdminOnly
+	}
+
+	return nil
+}
@@ -8,21 +8,30 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/config"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
 	"github.com/gravadigital/telescopio-api/internal/logger"
-	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/ratelimit"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
 type UserHandler struct {
 	userRepo  postgres.UserRepository
 	eventRepo postgres.EventRepository
+	sessions  *SessionService
+	accounts  *AccountService
+	twoFactor *TwoFactorService
+	lockout   *ratelimit.Lockout
 	config    *config.Config
 	log       *log.Logger
 }
 
-func NewUserHandler(userRepo postgres.UserRepository, eventRepo postgres.EventRepository, cfg *config.Config) *UserHandler {
+func NewUserHandler(userRepo postgres.UserRepository, eventRepo postgres.EventRepository, sessions *SessionService, accounts *AccountService, twoFactor *TwoFactorService, lockout *ratelimit.Lockout, cfg *config.Config) *UserHandler {
 	return &UserHandler{
 		userRepo:  userRepo,
 		eventRepo: eventRepo,
+		sessions:  sessions,
+		accounts:  accounts,
+		twoFactor: twoFactor,
+		lockout:   lockout,
 		config:    cfg,
 		log:       logger.Handler("user"),
 	}
@@ -96,8 +105,18 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	h.log.Info("user created successfully", "id", user.ID, "email", user.Email)
 
-	// Generate JWT token for new user
-	token, err := auth.GenerateToken(user.ID, user.Email, user.Role)
+	// Registration succeeds even when the mail can't be sent; the user can ask for a new link
+	if err := h.accounts.SendEmailVerification(user); err != nil {
+		h.log.Error("failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
+	// Start a session for the new user
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessions.Start(user, orgID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		h.log.Error("failed to generate token for new user", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -108,15 +127,19 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "User created successfully",
-		"token":   token,
+		"message":       "User created successfully",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
 		"user": gin.H{
-			"id":         user.ID.String(),
-			"name":       user.Name,
-			"lastname":   user.LastName,
-			"email":      user.Email,
-			"role":       user.Role.String(),
-			"created_at": user.CreatedAt,
+			"id":             user.ID.String(),
+			"name":           user.Name,
+			"lastname":       user.LastName,
+			"email":          user.Email,
+			"email_verified": user.IsEmailVerified(),
+			"role":           user.Role.String(),
+			"created_at":     user.CreatedAt,
 		},
 	})
 }
@@ -136,10 +159,35 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 		return
 	}
 
-	// Try to find existing user
+	// Locked accounts are refused before the password is checked, so guessing goes no further
+	ctx := c.Request.Context()
+	if lockedFor := h.lockout.Check(ctx, req.Email); lockedFor > 0 {
+		h.log.Warn("authentication refused: account locked", "email", req.Email, "locked_for", lockedFor)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       "Too many failed sign-in attempts. Try again later",
+			"code":        "ACCOUNT_LOCKED",
+			"retry_after": ratelimit.SetRetryAfter(c, lockedFor),
+		})
+		return
+	}
+
+	// Try to find existing user. Failures count for unknown emails too, so the lockout
+	// doesn't reveal which accounts exist.
 	existingUser, err := h.userRepo.GetByEmail(req.Email)
 	if err != nil || existingUser == nil {
 		h.log.Warn("authentication failed: user not found", "email", req.Email)
+		h.lockout.RecordFailure(ctx, req.Email)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid email or password",
+			"code":  "INVALID_CREDENTIALS",
+		})
+		return
+	}
+
+	// Service accounts only authenticate with API keys
+	if existingUser.IsServiceAccount {
+		h.log.Warn("authentication failed: service account", "user_id", existingUser.ID)
+		h.lockout.RecordFailure(ctx, req.Email)
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid email or password",
 			"code":  "INVALID_CREDENTIALS",
@@ -160,6 +208,7 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 	// Verify password
 	if !existingUser.CheckPassword(req.Password) {
 		h.log.Warn("authentication failed: invalid password", "email", req.Email)
+		h.lockout.RecordFailure(ctx, req.Email)
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid email or password",
 			"code":  "INVALID_CREDENTIALS",
@@ -167,10 +216,46 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 		return
 	}
 
+	// Checked after the password, so deactivation isn't revealed to whoever guesses an email
+	if existingUser.IsDeactivated() {
+		h.log.Warn("authentication failed: account deactivated", "user_id", existingUser.ID)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "This account has been deactivated",
+			"code":  "ACCOUNT_DEACTIVATED",
+		})
+		return
+	}
+
+	// Enrolled accounts, and roles that must enrol, finish signing in with a code. Failed
+	// sign-ins are kept until then, so wrong codes add up with wrong passwords.
+	challenge, err := h.twoFactor.Challenge(existingUser)
+	if err != nil {
+		h.log.Error("failed to start two-factor challenge", "user_id", existingUser.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate authentication token",
+			"code":  "TOKEN_GENERATION_ERROR",
+		})
+		return
+	}
+	if challenge != nil {
+		h.log.Info("password accepted, second factor required", "user_id", existingUser.ID, "enrollment_required", challenge.EnrollmentRequired)
+		respondSignInChallenge(c, challenge)
+		return
+	}
+
+	if err := h.lockout.Reset(ctx, req.Email); err != nil {
+		h.log.Error("failed to clear failed sign-ins", "user_id", existingUser.ID, "error", err)
+	}
+
 	h.log.Info("user authenticated successfully", "email", req.Email, "user_id", existingUser.ID)
 
-	// Generate JWT token
-	token, err := auth.GenerateToken(existingUser.ID, existingUser.Email, existingUser.Role)
+	// Start a session: short-lived access token plus refresh token
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessions.Start(existingUser, orgID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		h.log.Error("failed to generate token", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -181,8 +266,11 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "User authenticated successfully",
-		"token":   token,
+		"message":       "User authenticated successfully",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
 		"user": gin.H{
 			"id":         existingUser.ID.String(),
 			"name":       existingUser.Name,
@@ -198,7 +286,7 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 func (h *UserHandler) GetUser(c *gin.Context) {
 	userIDStr := c.Param("user_id")
 
-	user, err := h.userRepo.GetByID(userIDStr)
+	user, err := h.userRepo.GetByIDInOrganization(tenant.Scope(c), userIDStr)
 	if err != nil {
 		h.log.Error("failed to get user", "error", err, "user_id", userIDStr)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -249,8 +337,13 @@ func (h *UserHandler) GetUserEvents(c *gin.Context) {
 		return
 	}
 
-	// Get events from repository
-	events, err := h.eventRepo.GetUserParticipatingEvents(requestedUserID)
+	orgID, ok := requestOrganization(c)
+	if !ok {
+		return
+	}
+
+	// Get the events of the organization from repository
+	events, err := h.eventRepo.GetUserParticipatingEvents(orgID, requestedUserID)
 	if err != nil {
 		h.log.Error("failed to retrieve user events", "user_id", requestedUserID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -59,7 +59,7 @@ func (h *VoteDraftHandler) SaveDraft(c *gin.Context) {
 	}
 
 	// Verify that the authenticated user matches the participant (auth middleware already
-	// enforces this via RequireParticipantOrOwner, but we double-check here for safety)
+	// enforces this via the event.member.act permission, but we double-check here for safety)
 	authenticatedUserID, err := auth.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "code": "UNAUTHORIZED"})
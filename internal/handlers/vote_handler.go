@@ -11,6 +11,7 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
@@ -123,10 +124,12 @@ func (h *VoteHandler) validateEventForResults(eventObj *event.Event) error {
 	return nil
 }
 
-func (h *VoteHandler) checkParticipantRegistration(eventID, participantID string) (bool, error) {
+func (h *VoteHandler) checkParticipantRegistration(eventObj *event.Event, participantID string) (bool, error) {
+	eventID := eventObj.ID.String()
+
 	h.log.Debug("Checking participant registration", "event_id", eventID, "participant_id", participantID)
 
-	participantEvents, err := h.eventRepo.GetByParticipant(participantID)
+	participantEvents, err := h.eventRepo.GetByParticipant(eventObj.OrganizationID, participantID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check participant registration: %w", err)
 	}
@@ -181,7 +184,7 @@ func (h *VoteHandler) SubmitVote(c *gin.Context) {
 		"attachment_id", req.VotedAttachmentID)
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Event not found", err, map[string]interface{}{
 			"event_id": eventID,
@@ -199,7 +202,7 @@ func (h *VoteHandler) SubmitVote(c *gin.Context) {
 	}
 
 	// Check if voter exists
-	voter, err := h.userRepo.GetByID(req.VoterID)
+	voter, err := h.userRepo.GetByIDInOrganization(tenant.Scope(c), req.VoterID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Voter not found", err, map[string]interface{}{
 			"voter_id": req.VoterID,
@@ -208,7 +211,7 @@ func (h *VoteHandler) SubmitVote(c *gin.Context) {
 	}
 
 	// Check if voter is registered for this event
-	isParticipant, err := h.checkParticipantRegistration(eventID, req.VoterID)
+	isParticipant, err := h.checkParticipantRegistration(eventObj, req.VoterID)
 	if err != nil {
 		h.errorResponse(c, http.StatusInternalServerError, "Failed to verify participant registration", err, nil)
 		return
@@ -223,7 +226,7 @@ func (h *VoteHandler) SubmitVote(c *gin.Context) {
 	}
 
 	// Check if attachment exists and belongs to this event
-	attachment, err := h.attachmentRepo.GetByID(req.VotedAttachmentID)
+	attachment, err := h.attachmentRepo.GetByIDInOrganization(tenant.Scope(c), req.VotedAttachmentID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Attachment not found", err, map[string]interface{}{
 			"attachment_id": req.VotedAttachmentID,
@@ -303,7 +306,7 @@ func (h *VoteHandler) GetEventResults(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Event not found", err, map[string]interface{}{
 			"event_id": eventID,
@@ -416,7 +419,7 @@ func (h *VoteHandler) GetVotesByEvent(c *gin.Context) {
 	}
 
 	// Check if event exists
-	_, err := h.eventRepo.GetByID(eventID)
+	_, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Event not found", err, map[string]interface{}{
 			"event_id": eventID,
@@ -454,7 +457,7 @@ func (h *VoteHandler) GetVotesByVoter(c *gin.Context) {
 	}
 
 	// Check if voter exists
-	_, err := h.userRepo.GetByID(voterID)
+	_, err := h.userRepo.GetByIDInOrganization(tenant.Scope(c), voterID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Voter not found", err, map[string]interface{}{
 			"voter_id": voterID,
@@ -498,7 +501,7 @@ func (h *VoteHandler) GetVotingStatus(c *gin.Context) {
 	}
 
 	// Check if event exists
-	eventObj, err := h.eventRepo.GetByID(eventID)
+	eventObj, err := h.eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Event not found", err, map[string]interface{}{
 			"event_id": eventID,
@@ -507,7 +510,7 @@ func (h *VoteHandler) GetVotingStatus(c *gin.Context) {
 	}
 
 	// Check if voter exists
-	_, err = h.userRepo.GetByID(voterID)
+	_, err = h.userRepo.GetByIDInOrganization(tenant.Scope(c), voterID)
 	if err != nil {
 		h.errorResponse(c, http.StatusNotFound, "Voter not found", err, map[string]interface{}{
 			"voter_id": voterID,
@@ -516,7 +519,7 @@ func (h *VoteHandler) GetVotingStatus(c *gin.Context) {
 	}
 
 	// Check if voter is registered for this event
-	isParticipant, err := h.checkParticipantRegistration(eventID, voterID)
+	isParticipant, err := h.checkParticipantRegistration(eventObj, voterID)
 	if err != nil {
 		h.errorResponse(c, http.StatusInternalServerError, "Failed to verify participant registration", err, nil)
 		return
@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/permission"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/middleware/auth"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// WaitlistHandler lets organizers view, reorder and promote the waitlist of a full event
+// and lets waitlisted users leave it
+type WaitlistHandler struct {
+	container *postgres.Container
+	log       *log.Logger
+}
+
+// NewWaitlistHandler creates a new waitlist handler
+func NewWaitlistHandler(container *postgres.Container) *WaitlistHandler {
+	return &WaitlistHandler{
+		container: container,
+		log:       logger.Handler("waitlist"),
+	}
+}
+
+// GetWaitlist handles GET /api/events/{event_id}/waitlist
+// Returns the waiting registrants in queue order
+func (h *WaitlistHandler) GetWaitlist(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	h.log.Debug("retrieving waitlist", "event_id", eventID)
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	entries, err := h.container.EventWaitlist().GetWaiting(eventID)
+	if err != nil {
+		h.log.Error("failed to retrieve waitlist", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve waitlist",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	participants, err := h.container.Users().GetEventParticipants(eventID)
+	if err != nil {
+		h.log.Error("failed to count participants", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve waitlist",
+			"code":  "RETRIEVAL_ERROR",
+		})
+		return
+	}
+
+	data := make([]gin.H, len(entries))
+	for i, entry := range entries {
+		item := gin.H{
+			"id":         entry.ID.String(),
+			"user_id":    entry.UserID.String(),
+			"position":   i + 1,
+			"joined_at":  entry.CreatedAt,
+			"invited":    entry.InvitationID != nil,
+			"user_name":  "",
+			"user_email": "",
+		}
+		if user, err := h.container.Users().GetByID(entry.UserID.String()); err == nil {
+			item["user_name"] = user.Name
+			item["user_email"] = user.Email
+		}
+		data[i] = item
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"summary": gin.H{
+			"event_id":           eventID,
+			"waiting":            len(entries),
+			"participants_count": seatsTaken(participants),
+			"max_participants":   evt.ParticipantLimit(),
+		},
+	})
+}
+
+type MoveWaitlistEntryRequest struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
+
+// MoveWaitlistEntry handles PATCH /api/events/{event_id}/waitlist/{entry_id}
+// Moves an entry to a new 1-based position; positions past the end move it last
+func (h *WaitlistHandler) MoveWaitlistEntry(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	var req MoveWaitlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for waitlist move", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if _, ok := h.loadEvent(c, eventID); !ok {
+		return
+	}
+
+	entry, ok := h.loadWaitingEntry(c, eventID)
+	if !ok {
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reorder waitlist",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = tx.EventWaitlist().LockEvent(eventID)
+	if err == nil {
+		err = tx.EventWaitlist().Move(eventID, entry.ID, req.Position)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		h.log.Error("failed to reorder waitlist", "event_id", eventID, "entry_id", entry.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reorder waitlist",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("waitlist reordered", "event_id", eventID, "entry_id", entry.ID, "position", req.Position)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id": eventID,
+			"entry_id": entry.ID.String(),
+			"position": req.Position,
+		},
+		"message": "Waitlist reordered successfully",
+		"code":    "WAITLIST_REORDERED",
+	})
+}
+
+// PromoteWaitlistEntry handles POST /api/events/{event_id}/waitlist/{entry_id}/promote
+// Registers the entry's user right away, even when the event is full
+func (h *WaitlistHandler) PromoteWaitlistEntry(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	entry, ok := h.loadWaitingEntry(c, eventID)
+	if !ok {
+		return
+	}
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to promote waitlist entry",
+			"code":  "PROMOTION_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = NewWaitlistServiceForTx(tx).Promote(evt, entry, userID)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		if errors.Is(err, ErrWaitlistClosed) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":         err.Error(),
+				"code":          "WAITLIST_CLOSED",
+				"current_stage": evt.Stage.String(),
+			})
+			return
+		}
+		h.log.Error("failed to promote waitlist entry", "event_id", eventID, "entry_id", entry.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to promote waitlist entry",
+			"code":  "PROMOTION_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("waitlist entry promoted manually", "event_id", eventID, "entry_id", entry.ID, "promoted_by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":       eventID,
+			"entry_id":       entry.ID.String(),
+			"participant_id": entry.UserID.String(),
+			"promoted_by":    userID.String(),
+		},
+		"message": "Waitlist entry promoted successfully",
+		"code":    "WAITLIST_ENTRY_PROMOTED",
+	})
+}
+
+type UpdateParticipantLimitRequest struct {
+	MaxParticipants int `json:"max_participants" binding:"required,min=1,max=100"`
+}
+
+// UpdateParticipantLimit handles PATCH /api/events/{event_id}/participant-limit
+// During the participation stage, raising the limit promotes waitlisted registrants into
+// the new seats in queue order
+func (h *WaitlistHandler) UpdateParticipantLimit(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	var req UpdateParticipantLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid request payload for participant limit update", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request payload",
+			"code":    "INVALID_PAYLOAD",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+	previous := evt.ParticipantLimit()
+
+	tx, err := h.container.BeginTransaction()
+	if err != nil {
+		h.log.Error("failed to begin transaction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update participant limit",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	promoted, err := NewWaitlistServiceForTx(tx).SetParticipantLimit(evt, req.MaxParticipants)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		if errors.Is(err, ErrParticipantLimitTooLow) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"code":  "PARTICIPANT_LIMIT_TOO_LOW",
+			})
+			return
+		}
+		h.log.Error("failed to update participant limit", "event_id", eventID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update participant limit",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	promotedIDs := make([]string, len(promoted))
+	for i, entry := range promoted {
+		promotedIDs[i] = entry.UserID.String()
+	}
+
+	h.log.Info("event participant limit updated",
+		"event_id", eventID,
+		"from", previous,
+		"to", req.MaxParticipants,
+		"promoted", len(promoted))
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"event_id":                  eventID,
+			"previous_max_participants": previous,
+			"max_participants":          req.MaxParticipants,
+			"promoted_participant_ids":  promotedIDs,
+		},
+		"message": "Participant limit updated successfully",
+		"code":    "PARTICIPANT_LIMIT_UPDATED",
+	})
+}
+
+// LeaveWaitlist handles DELETE /api/events/{event_id}/waitlist/{entry_id}
+// Allowed for the waitlisted user, the event owner and co-organizers, organizers and admins
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	eventID := c.Param("event_id")
+
+	userID, err := auth.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Authentication required",
+			"code":  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	evt, ok := h.loadEvent(c, eventID)
+	if !ok {
+		return
+	}
+
+	entry, ok := h.loadWaitingEntry(c, eventID)
+	if !ok {
+		return
+	}
+
+	if entry.UserID != userID && !auth.Authorize(c, h.container.Events(), permission.EventManage, evt) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "You can only remove your own waitlist entry",
+			"code":  "FORBIDDEN",
+		})
+		return
+	}
+
+	if err := h.container.EventWaitlist().MarkWithdrawn(entry.ID); err != nil {
+		h.log.Error("failed to withdraw waitlist entry", "event_id", eventID, "entry_id", entry.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to leave waitlist",
+			"code":  "DB_UPDATE_ERROR",
+		})
+		return
+	}
+
+	h.log.Info("waitlist entry withdrawn", "event_id", eventID, "entry_id", entry.ID, "by", userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Waitlist entry removed successfully",
+		"code":    "WAITLIST_ENTRY_REMOVED",
+	})
+}
+
+// loadEvent fetches the event from the route, writing the error response on failure
+func (h *WaitlistHandler) loadEvent(c *gin.Context, eventID string) (*event.Event, bool) {
+	if _, err := uuid.Parse(eventID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event_id format",
+			"code":  "INVALID_EVENT_ID",
+		})
+		return nil, false
+	}
+
+	evt, err := h.container.Events().GetByIDInOrganization(tenant.Scope(c), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event not found",
+			"code":  "EVENT_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	return evt, true
+}
+
+// loadWaitingEntry fetches the waiting entry from the route and checks it belongs to the event
+func (h *WaitlistHandler) loadWaitingEntry(c *gin.Context, eventID string) (*event.WaitlistEntry, bool) {
+	entryID := c.Param("entry_id")
+	if _, err := uuid.Parse(entryID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid entry_id format",
+			"code":  "INVALID_ENTRY_ID",
+		})
+		return nil, false
+	}
+
+	entry, err := h.container.EventWaitlist().GetByID(entryID)
+	if err != nil || entry.EventID.String() != eventID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Waitlist entry not found",
+			"code":  "WAITLIST_ENTRY_NOT_FOUND",
+		})
+		return nil, false
+	}
+
+	if !entry.IsWaiting() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":  "Waitlist entry is no longer waiting",
+			"code":   "WAITLIST_ENTRY_CLOSED",
+			"status": entry.Status,
+		})
+		return nil, false
+	}
+
+	return entry, true
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/notification"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+var (
+	// ErrWaitlistClosed is returned when the waitlist is changed outside the participation stage
+	ErrWaitlistClosed = errors.New("the waitlist can only change during the participation stage")
+	// ErrParticipantLimitTooLow is returned when a new participant limit is below the
+	// participants already registered
+	ErrParticipantLimitTooLow = errors.New("the participant limit cannot be lower than the participants already registered")
+)
+
+// WaitlistService registers participants up to the event limit, queues the rest and
+// promotes queued registrants when seats free up.
+// Build it over repositories from a TransactionContainer: every method locks the event
+// row so concurrent registrations and removals see a consistent participant count.
+type WaitlistService struct {
+	eventRepo        postgres.EventRepository
+	userRepo         postgres.UserRepository
+	waitlistRepo     postgres.EventWaitlistRepository
+	invitationRepo   postgres.EventInvitationRepository
+	notificationRepo postgres.NotificationRepository
+	log              *log.Logger
+}
+
+// NewWaitlistService creates a waitlist service over the given repositories
+func NewWaitlistService(
+	eventRepo postgres.EventRepository,
+	userRepo postgres.UserRepository,
+	waitlistRepo postgres.EventWaitlistRepository,
+	invitationRepo postgres.EventInvitationRepository,
+	notificationRepo postgres.NotificationRepository,
+) *WaitlistService {
+	return &WaitlistService{
+		eventRepo:        eventRepo,
+		userRepo:         userRepo,
+		waitlistRepo:     waitlistRepo,
+		invitationRepo:   invitationRepo,
+		notificationRepo: notificationRepo,
+		log:              logger.Service("waitlist"),
+	}
+}
+
+// NewWaitlistServiceForTx creates a waitlist service bound to a transaction
+func NewWaitlistServiceForTx(tx *postgres.TransactionContainer) *WaitlistService {
+	return NewWaitlistService(tx.Events(), tx.Users(), tx.EventWaitlist(), tx.EventInvitations(), tx.Notifications())
+}
+
+// Register adds the user as a participant when the event has a free seat and queues them
+// otherwise. The invitation, if any, is redeemed either way. Returns the waitlist entry
+// when the user was queued and nil when they were registered.
+func (s *WaitlistService) Register(evt *event.Event, user *participant.User, invitation *event.Invitation) (*event.WaitlistEntry, error) {
+	eventID := evt.ID.String()
+
+	if err := s.waitlistRepo.LockEvent(eventID); err != nil {
+		return nil, err
+	}
+
+	if invitation != nil {
+		if err := s.invitationRepo.Redeem(invitation.ID, user.ID, user.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	seats, err := s.openSeats(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if seats > 0 {
+		if err := s.eventRepo.AddParticipantWithRole(eventID, user.ID.String(), event.RoleParticipant); err != nil {
+			return nil, fmt.Errorf("failed to add participant: %w", err)
+		}
+		return nil, nil
+	}
+
+	entry := &event.WaitlistEntry{
+		EventID: evt.ID,
+		UserID:  user.ID,
+	}
+	if invitation != nil {
+		entry.InvitationID = &invitation.ID
+	}
+
+	if err := s.waitlistRepo.Add(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RemoveParticipant removes a participant and promotes the next registrants in line
+// into the freed seats. Returns the promoted entries.
+func (s *WaitlistService) RemoveParticipant(evt *event.Event, userID string) ([]*event.WaitlistEntry, error) {
+	if err := s.waitlistRepo.LockEvent(evt.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventRepo.RemoveParticipant(evt.ID.String(), userID); err != nil {
+		return nil, err
+	}
+
+	return s.fillOpenSeats(evt)
+}
+
+// SetRole gives a member a role in the event, adding them when they are not a member yet.
+// Moving a participant to a role that doesn't take a seat promotes the next registrant in
+// line into it; giving someone the participant role is an organizer decision and ignores
+// the limit. Returns the promoted entries.
+func (s *WaitlistService) SetRole(evt *event.Event, userID string, role event.EventParticipantRole) ([]*event.WaitlistEntry, error) {
+	if err := s.waitlistRepo.LockEvent(evt.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventRepo.AddParticipantWithRole(evt.ID.String(), userID, role); err != nil {
+		return nil, err
+	}
+
+	return s.fillOpenSeats(evt)
+}
+
+// FillOpenSeats promotes waiting registrants in queue order while the event has free seats.
+// Returns the promoted entries.
+func (s *WaitlistService) FillOpenSeats(evt *event.Event) ([]*event.WaitlistEntry, error) {
+	if err := s.waitlistRepo.LockEvent(evt.ID.String()); err != nil {
+		return nil, err
+	}
+
+	return s.fillOpenSeats(evt)
+}
+
+func (s *WaitlistService) fillOpenSeats(evt *event.Event) ([]*event.WaitlistEntry, error) {
+	if evt.Stage != event.StageParticipation {
+		return nil, nil
+	}
+
+	seats, err := s.openSeats(evt)
+	if err != nil {
+		return nil, err
+	}
+	if seats <= 0 {
+		return nil, nil
+	}
+
+	waiting, err := s.waitlistRepo.GetWaiting(evt.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	promoted := make([]*event.WaitlistEntry, 0, seats)
+	for _, entry := range waiting {
+		if len(promoted) == seats {
+			break
+		}
+		if err := s.promote(evt, entry, nil); err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, entry)
+	}
+
+	return promoted, nil
+}
+
+// SetParticipantLimit changes the participant limit of the event. Raising it promotes
+// waiting registrants into the new seats; lowering it below the participants already
+// registered fails with ErrParticipantLimitTooLow. Returns the promoted entries.
+func (s *WaitlistService) SetParticipantLimit(evt *event.Event, limit int) ([]*event.WaitlistEntry, error) {
+	eventID := evt.ID.String()
+
+	if err := s.waitlistRepo.LockEvent(eventID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.userRepo.GetEventParticipants(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count participants: %w", err)
+	}
+	if limit < seatsTaken(members) {
+		return nil, ErrParticipantLimitTooLow
+	}
+
+	if err := s.eventRepo.UpdateMaxParticipants(eventID, limit); err != nil {
+		return nil, err
+	}
+	evt.MaxParticipants = &limit
+
+	return s.fillOpenSeats(evt)
+}
+
+// Promote registers a waiting entry's user ahead of the queue. Organizers may promote
+// even when the event is full. promotedBy is recorded on the entry.
+func (s *WaitlistService) Promote(evt *event.Event, entry *event.WaitlistEntry, promotedBy uuid.UUID) error {
+	if evt.Stage != event.StageParticipation {
+		return ErrWaitlistClosed
+	}
+
+	if err := s.waitlistRepo.LockEvent(evt.ID.String()); err != nil {
+		return err
+	}
+
+	return s.promote(evt, entry, &promotedBy)
+}
+
+func (s *WaitlistService) promote(evt *event.Event, entry *event.WaitlistEntry, promotedBy *uuid.UUID) error {
+	if err := s.eventRepo.AddParticipantWithRole(evt.ID.String(), entry.UserID.String(), event.RoleParticipant); err != nil {
+		return fmt.Errorf("failed to add promoted participant: %w", err)
+	}
+
+	if err := s.waitlistRepo.MarkPromoted(entry.ID, promotedBy); err != nil {
+		return err
+	}
+	entry.Status = event.WaitlistPromoted
+
+	n := notification.NewNotification(entry.UserID, &evt.ID, notification.KindWaitlistPromoted,
+		"You're in: "+evt.Name,
+		fmt.Sprintf("A seat opened up in %q and you have been moved from the waitlist to the participant list.", evt.Name))
+	if err := s.notificationRepo.Create(n); err != nil {
+		return err
+	}
+
+	s.log.Info("waitlist entry promoted",
+		"event_id", evt.ID,
+		"user_id", entry.UserID,
+		"entry_id", entry.ID,
+		"manual", promotedBy != nil)
+	return nil
+}
+
+// openSeats returns how many participants can still join the event
+func (s *WaitlistService) openSeats(evt *event.Event) (int, error) {
+	members, err := s.userRepo.GetEventParticipants(evt.ID.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count participants: %w", err)
+	}
+	return evt.ParticipantLimit() - seatsTaken(members), nil
+}
+
+// seatsTaken counts the members that count towards the participant limit.
+// Co-organizers, reviewers and observers don't take a seat.
+func seatsTaken(members []*participant.UserWithEventRole) int {
+	taken := 0
+	for _, m := range members {
+		if event.EventParticipantRole(m.EventRole).TakesSeat() {
+			taken++
+		}
+	}
+	return taken
+}
@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/notification"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// The fakes embed the repository interfaces and implement only what the waitlist service
+// calls; anything else panics. They share one waitlistStore.
+
+type waitlistStore struct {
+	members       map[uuid.UUID]event.EventParticipantRole
+	order         []uuid.UUID // members in joining order
+	queue         []*event.WaitlistEntry
+	limit         *int
+	notifications []*notification.Notification
+	locks         int
+}
+
+func newWaitlistStore() *waitlistStore {
+	return &waitlistStore{members: make(map[uuid.UUID]event.EventParticipantRole)}
+}
+
+func (s *waitlistStore) join(userID uuid.UUID, role event.EventParticipantRole) {
+	if _, ok := s.members[userID]; !ok {
+		s.order = append(s.order, userID)
+	}
+	s.members[userID] = role
+}
+
+type fakeWaitlistEvents struct {
+	postgres.EventRepository
+	store *waitlistStore
+}
+
+func (f *fakeWaitlistEvents) AddParticipantWithRole(eventID, userID string, role event.EventParticipantRole) error {
+	f.store.join(uuid.MustParse(userID), role)
+	return nil
+}
+
+func (f *fakeWaitlistEvents) RemoveParticipant(eventID, userID string) error {
+	delete(f.store.members, uuid.MustParse(userID))
+	return nil
+}
+
+func (f *fakeWaitlistEvents) UpdateMaxParticipants(eventID string, maxParticipants int) error {
+	f.store.limit = &maxParticipants
+	return nil
+}
+
+type fakeWaitlistUsers struct {
+	postgres.UserRepository
+	store *waitlistStore
+}
+
+func (f *fakeWaitlistUsers) GetEventParticipants(eventID string) ([]*participant.UserWithEventRole, error) {
+	var members []*participant.UserWithEventRole
+	for _, id := range f.store.order {
+		role, ok := f.store.members[id]
+		if !ok {
+			continue
+		}
+		members = append(members, &participant.UserWithEventRole{
+			User:      participant.User{ID: id},
+			EventRole: string(role),
+		})
+	}
+	return members, nil
+}
+
+type fakeWaitlist struct {
+	postgres.EventWaitlistRepository
+	store *waitlistStore
+}
+
+func (f *fakeWaitlist) LockEvent(eventID string) error {
+	f.store.locks++
+	return nil
+}
+
+func (f *fakeWaitlist) Add(entry *event.WaitlistEntry) error {
+	entry.ID = uuid.New()
+	entry.Position = len(f.store.queue) + 1
+	entry.Status = event.WaitlistWaiting
+	f.store.queue = append(f.store.queue, entry)
+	return nil
+}
+
+func (f *fakeWaitlist) GetWaiting(eventID string) ([]*event.WaitlistEntry, error) {
+	var waiting []*event.WaitlistEntry
+	for _, entry := range f.store.queue {
+		if entry.IsWaiting() {
+			waiting = append(waiting, entry)
+		}
+	}
+	return waiting, nil
+}
+
+func (f *fakeWaitlist) Move(eventID string, entryID uuid.UUID, position int) error {
+	waiting, _ := f.GetWaiting(eventID)
+	moved, ok := event.MoveWaitlistEntry(waiting, entryID, position)
+	if !ok {
+		return errors.New("waitlist entry not found")
+	}
+	f.store.queue = moved
+	return nil
+}
+
+func (f *fakeWaitlist) MarkPromoted(id uuid.UUID, promotedBy *uuid.UUID) error {
+	for _, entry := range f.store.queue {
+		if entry.ID == id {
+			entry.Status = event.WaitlistPromoted
+			entry.PromotedBy = promotedBy
+			return nil
+		}
+	}
+	return errors.New("waitlist entry not found")
+}
+
+type fakeWaitlistNotifications struct {
+	postgres.NotificationRepository
+	store *waitlistStore
+}
+
+func (f *fakeWaitlistNotifications) Create(n *notification.Notification) error {
+	f.store.notifications = append(f.store.notifications, n)
+	return nil
+}
+
+// newTestWaitlist returns a service over a participation-stage event limited to limit
+// participants and already full, with the creator and a co-organizer, who take no seat
+func newTestWaitlist(t *testing.T, limit int) (*WaitlistService, *event.Event, *waitlistStore) {
+	t.Helper()
+
+	store := newWaitlistStore()
+	evt := &event.Event{ID: uuid.New(), Name: "Observing Run 2026B", Stage: event.StageParticipation, MaxParticipants: &limit}
+	store.limit = evt.MaxParticipants
+
+	store.join(uuid.New(), event.RoleCreator)
+	store.join(uuid.New(), event.RoleCoOrganizer)
+	for i := 0; i < limit; i++ {
+		store.join(uuid.New(), event.RoleParticipant)
+	}
+
+	service := NewWaitlistService(
+		&fakeWaitlistEvents{store: store},
+		&fakeWaitlistUsers{store: store},
+		&fakeWaitlist{store: store},
+		nil,
+		&fakeWaitlistNotifications{store: store},
+	)
+	return service, evt, store
+}
+
+// queueUsers registers n more users, which all land on the waitlist, and returns them in order
+func queueUsers(t *testing.T, service *WaitlistService, evt *event.Event, n int) []uuid.UUID {
+	t.Helper()
+
+	users := make([]uuid.UUID, n)
+	for i := range users {
+		users[i] = uuid.New()
+		entry, err := service.Register(evt, &participant.User{ID: users[i]}, nil)
+		if err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+		if entry == nil {
+			t.Fatalf("user %d registered, want them waitlisted", i+1)
+		}
+		if entry.Position != i+1 {
+			t.Errorf("user %d queued at position %d, want %d", i+1, entry.Position, i+1)
+		}
+	}
+	return users
+}
+
+func promotedUsers(promoted []*event.WaitlistEntry) []uuid.UUID {
+	users := make([]uuid.UUID, len(promoted))
+	for i, entry := range promoted {
+		users[i] = entry.UserID
+	}
+	return users
+}
+
+func assertPromoted(t *testing.T, store *waitlistStore, got []*event.WaitlistEntry, want ...uuid.UUID) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("promoted %d registrants, want %d", len(got), len(want))
+	}
+	for i, userID := range promotedUsers(got) {
+		if userID != want[i] {
+			t.Errorf("promotion %d went to %s, want %s", i+1, userID, want[i])
+		}
+		if store.members[userID] != event.RoleParticipant {
+			t.Errorf("promoted user %s has role %q, want participant", userID, store.members[userID])
+		}
+		if got[i].PromotedBy != nil {
+			t.Errorf("automatic promotion %d records promoter %s", i+1, got[i].PromotedBy)
+		}
+	}
+}
+
+func TestWaitlistRegisterQueuesWhenFull(t *testing.T) {
+	service, evt, store := newTestWaitlist(t, 2)
+
+	queued := queueUsers(t, service, evt, 3)
+	for _, userID := range queued {
+		if _, ok := store.members[userID]; ok {
+			t.Errorf("waitlisted user %s became a member", userID)
+		}
+	}
+}
+
+func TestWaitlistPromotesInQueueOrder(t *testing.T) {
+	service, evt, store := newTestWaitlist(t, 2)
+	queued := queueUsers(t, service, evt, 3)
+
+	// The organizer moves the last registrant to the front
+	if err := (&fakeWaitlist{store: store}).Move(evt.ID.String(), store.queue[2].ID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	leaving := store.order[2] // the first participant
+	promoted, err := service.RemoveParticipant(evt, leaving.String())
+	if err != nil {
+		t.Fatalf("RemoveParticipant: %v", err)
+	}
+	assertPromoted(t, store, promoted, queued[2])
+
+	promoted, err = service.RemoveParticipant(evt, store.order[3].String())
+	if err != nil {
+		t.Fatalf("RemoveParticipant: %v", err)
+	}
+	assertPromoted(t, store, promoted, queued[0])
+
+	if len(store.notifications) != 2 {
+		t.Fatalf("sent %d notifications, want one per promotion", len(store.notifications))
+	}
+	for i, userID := range []uuid.UUID{queued[2], queued[0]} {
+		if n := store.notifications[i]; n.UserID != userID || n.Kind != notification.KindWaitlistPromoted {
+			t.Errorf("notification %d = %s to %s, want %s to %s", i+1, n.Kind, n.UserID, notification.KindWaitlistPromoted, userID)
+		}
+	}
+}
+
+func TestWaitlistIgnoresSeatlessRoles(t *testing.T) {
+	service, evt, store := newTestWaitlist(t, 1)
+	queued := queueUsers(t, service, evt, 1)
+
+	// Removing the co-organizer frees no seat
+	for id, role := range store.members {
+		if role == event.RoleCoOrganizer {
+			promoted, err := service.RemoveParticipant(evt, id.String())
+			if err != nil {
+				t.Fatalf("RemoveParticipant: %v", err)
+			}
+			if len(promoted) != 0 {
+				t.Errorf("promoted %d registrants into a co-organizer's place", len(promoted))
+			}
+		}
+	}
+
+	if _, ok := store.members[queued[0]]; ok {
+		t.Error("waitlisted user became a member")
+	}
+}
+
+func TestWaitlistRaisingTheLimitPromotes(t *testing.T) {
+	service, evt, store := newTestWaitlist(t, 2)
+	queued := queueUsers(t, service, evt, 3)
+
+	promoted, err := service.SetParticipantLimit(evt, 4)
+	if err != nil {
+		t.Fatalf("SetParticipantLimit: %v", err)
+	}
+	assertPromoted(t, store, promoted, queued[0], queued[1])
+
+	if store.limit == nil || *store.limit != 4 || evt.ParticipantLimit() != 4 {
+		t.Errorf("stored limit = %v, event limit = %d, want 4", store.limit, evt.ParticipantLimit())
+	}
+
+	// Raising it past the queue promotes everyone left
+	promoted, err = service.SetParticipantLimit(evt, 10)
+	if err != nil {
+		t.Fatalf("SetParticipantLimit: %v", err)
+	}
+	assertPromoted(t, store, promoted, queued[2])
+}
+
+func TestWaitlistLimitChanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		stage        event.Stage
+		limit        int
+		wantErr      error
+		wantPromoted int
+	}{
+		{"raised during participation", event.StageParticipation, 3, nil, 1},
+		{"unchanged", event.StageParticipation, 2, nil, 0},
+		{"raised before participation opens", event.StageCreation, 5, nil, 0},
+		{"lowered below the participants", event.StageParticipation, 1, ErrParticipantLimitTooLow, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, evt, store := newTestWaitlist(t, 2)
+			queueUsers(t, service, evt, 2)
+			evt.Stage = tt.stage
+
+			promoted, err := service.SetParticipantLimit(evt, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetParticipantLimit = %v, want %v", err, tt.wantErr)
+			}
+			if len(promoted) != tt.wantPromoted {
+				t.Errorf("promoted %d registrants, want %d", len(promoted), tt.wantPromoted)
+			}
+			if tt.wantErr != nil && *store.limit != 2 {
+				t.Errorf("limit changed to %d despite the error", *store.limit)
+			}
+			if store.locks == 0 {
+				t.Error("event not locked")
+			}
+		})
+	}
+}
+
+func TestWaitlistRoleChanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		member       int // index in joining order: 0 creator, 1 co-organizer, then participants
+		role         event.EventParticipantRole
+		stage        event.Stage
+		wantPromoted int
+	}{
+		{"participant becomes reviewer", 2, event.RoleReviewer, event.StageParticipation, 1},
+		{"participant becomes observer", 2, event.RoleObserver, event.StageParticipation, 1},
+		{"participant becomes co-organizer", 3, event.RoleCoOrganizer, event.StageParticipation, 1},
+		{"co-organizer becomes observer", 1, event.RoleObserver, event.StageParticipation, 0},
+		{"co-organizer becomes participant", 1, event.RoleParticipant, event.StageParticipation, 0},
+		{"new member added as participant", -1, event.RoleParticipant, event.StageParticipation, 0},
+		{"participant becomes reviewer before participation opens", 2, event.RoleReviewer, event.StageCreation, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, evt, store := newTestWaitlist(t, 2)
+			queued := queueUsers(t, service, evt, 2)
+			evt.Stage = tt.stage
+			store.locks = 0
+
+			member := uuid.New()
+			if tt.member >= 0 {
+				member = store.order[tt.member]
+			}
+
+			promoted, err := service.SetRole(evt, member.String(), tt.role)
+			if err != nil {
+				t.Fatalf("SetRole: %v", err)
+			}
+			if store.members[member] != tt.role {
+				t.Errorf("member has role %q, want %q", store.members[member], tt.role)
+			}
+			assertPromoted(t, store, promoted, queued[:tt.wantPromoted]...)
+			if store.locks == 0 {
+				t.Error("event not locked")
+			}
+		})
+	}
+}
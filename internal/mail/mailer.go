@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails. Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer creates a Mailer based on configuration
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.Mail.Provider {
+	case "outbox":
+		return NewFileOutbox(cfg.Mail.OutboxDir, cfg.Mail.From)
+
+	case "smtp":
+		if cfg.Mail.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP host not configured")
+		}
+
+		return NewSMTPMailer(
+			cfg.Mail.SMTPHost,
+			cfg.Mail.SMTPPort,
+			cfg.Mail.SMTPUsername,
+			cfg.Mail.SMTPPassword,
+			cfg.Mail.From,
+		), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported mail provider: %s (must be 'outbox' or 'smtp')", cfg.Mail.Provider)
+	}
+}
+
+// format renders the message in RFC 5322 form with CRLF line endings
+func format(from string, msg Message, now time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// validate rejects header injection through the recipient or the subject
+func validate(msg Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("email recipient is required")
+	}
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("email headers must not contain line breaks")
+	}
+	return nil
+}
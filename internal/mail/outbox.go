@@ -0,0 +1,53 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// FileOutbox writes every email as an .eml file instead of sending it. It is meant for
+// local development and tests: open the files or point a mail viewer at the directory.
+type FileOutbox struct {
+	dir  string
+	from string
+	log  *log.Logger
+}
+
+// NewFileOutbox creates a file outbox in the given directory
+func NewFileOutbox(dir, from string) (*FileOutbox, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	return &FileOutbox{
+		dir:  dir,
+		from: from,
+		log:  logger.Service("mail-outbox"),
+	}, nil
+}
+
+// Send writes the email to the outbox directory
+func (o *FileOutbox) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(o.dir, name)
+
+	if err := os.WriteFile(path, format(o.from, msg, now), 0644); err != nil {
+		return fmt.Errorf("failed to write email to outbox: %w", err)
+	}
+
+	o.log.Info("email written to outbox", "to", msg.To, "subject", msg.Subject, "file", path)
+	return nil
+}
@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// SMTPMailer sends emails through an SMTP server. STARTTLS is used when the server offers
+// it; credentials are only sent over TLS (or to localhost, as net/smtp enforces).
+type SMTPMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	log      *log.Logger
+}
+
+// NewSMTPMailer creates a mailer for the given SMTP server
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		log:      logger.Service("mail-smtp"),
+	}
+}
+
+// Send delivers the email
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, auth, envelopeAddress(m.from), []string{envelopeAddress(msg.To)}, format(m.from, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			m.log.Error("failed to send email", "to", msg.To, "subject", msg.Subject, "error", err)
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+	case <-ctx.Done():
+		return fmt.Errorf("failed to send email: %w", ctx.Err())
+	}
+
+	m.log.Info("email sent", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
+
+// envelopeAddress strips the display name from an address such as "Telescopio <no-reply@example.org>"
+func envelopeAddress(address string) string {
+	if parsed, err := netmail.ParseAddress(address); err == nil {
+		return parsed.Address
+	}
+	return address
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// JWTOrAPIKeyAuthMiddleware authenticates like JWTAuthMiddleware, but also accepts an API
+// key granted the scope. Keys are sent as "Authorization: Bearer tsk_..." or in the
+// X-API-Key header, and act as their owner within the organization they were created in.
+// Routes without this middleware reject API keys.
+func JWTOrAPIKeyAuthMiddleware(userRepo postgres.UserRepository, apiKeys postgres.APIKeyRepository, orgs postgres.OrganizationRepository, scope session.Scope) gin.HandlerFunc {
+	jwtAuth := JWTAuthMiddleware(userRepo)
+
+	return func(c *gin.Context) {
+		plain := apiKeyFromRequest(c)
+		if plain == "" {
+			jwtAuth(c)
+			return
+		}
+
+		now := time.Now()
+		key, err := apiKeys.GetByHash(session.HashToken(plain))
+		if err != nil || key.IsRevoked() || key.IsExpired(now) {
+			c.JSON(401, gin.H{
+				"error":   "UNAUTHORIZED",
+				"message": "Invalid, expired or revoked API key",
+			})
+			c.Abort()
+			return
+		}
+
+		if !key.HasScope(scope) {
+			c.JSON(403, gin.H{
+				"error":   "FORBIDDEN",
+				"message": "API key lacks the " + string(scope) + " scope",
+			})
+			c.Abort()
+			return
+		}
+
+		// Keys of deactivated accounts stop working with the account
+		owner, err := userRepo.GetByID(key.UserID.String())
+		if err != nil || owner.IsDeactivated() {
+			c.JSON(401, gin.H{
+				"error":   "UNAUTHORIZED",
+				"message": "Invalid, expired or revoked API key",
+			})
+			c.Abort()
+			return
+		}
+
+		// Keys stop working once their owner leaves the organization
+		orgID, err := tenant.OrganizationID(c)
+		if err != nil || orgID != key.OrganizationID {
+			c.JSON(401, gin.H{
+				"error":   "UNAUTHORIZED",
+				"message": "API key was not issued for this organization",
+			})
+			c.Abort()
+			return
+		}
+		member, err := orgs.GetMember(key.OrganizationID, owner.ID)
+		if err != nil {
+			c.JSON(401, gin.H{
+				"error":   "UNAUTHORIZED",
+				"message": "Invalid, expired or revoked API key",
+			})
+			c.Abort()
+			return
+		}
+
+		// Failing to record the use must not fail the request
+		_ = apiKeys.Touch(key.ID, now, c.ClientIP())
+
+		c.Set("user_id", owner.ID.String())
+		c.Set("user_email", owner.Email)
+		c.Set("user_role", owner.Role)
+		c.Set("organization_role", member.Role)
+		c.Set("api_key_id", key.ID.String())
+
+		c.Next()
+	}
+}
+
+// IsAPIKeyRequest reports whether the request was authenticated with an API key
+func IsAPIKeyRequest(c *gin.Context) bool {
+	_, exists := c.Get("api_key_id")
+	return exists
+}
+
+// CredentialOrganization returns the organization of the API key or access token sent with
+// the request, for tenant.Resolve
+func CredentialOrganization(apiKeys postgres.APIKeyRepository) tenant.CredentialFunc {
+	return func(c *gin.Context) (uuid.UUID, bool) {
+		if plain := apiKeyFromRequest(c); plain != "" {
+			key, err := apiKeys.GetByHash(session.HashToken(plain))
+			if err != nil || key.IsRevoked() || key.IsExpired(time.Now()) {
+				return uuid.Nil, false
+			}
+			return key.OrganizationID, true
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			return uuid.Nil, false
+		}
+
+		claims, err := ValidateToken(token)
+		if err != nil {
+			return uuid.Nil, false
+		}
+
+		orgID, err := uuid.Parse(claims.OrganizationID)
+		return orgID, err == nil
+	}
+}
+
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := strings.TrimSpace(c.GetHeader("X-API-Key")); key != "" {
+		return key
+	}
+
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && session.IsAPIKey(token) {
+		return token
+	}
+
+	return ""
+}
@@ -3,26 +3,30 @@ package auth
 import (
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
-// JWT secret key - should be loaded from environment variable
-var jwtSecret []byte
+// accessTokenTTL is the lifetime of access tokens; sessions are extended with refresh tokens
+var accessTokenTTL = 15 * time.Minute
 
-func init() {
-	// Get JWT secret from environment, use default for development
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "telescopio-dev-secret-change-in-production"
-		fmt.Println("⚠️  WARNING: Using default JWT secret. Set JWT_SECRET environment variable in production!")
+// SetAccessTokenTTL sets the lifetime of the access tokens issued from now on
+func SetAccessTokenTTL(ttl time.Duration) {
+	if ttl > 0 {
+		accessTokenTTL = ttl
 	}
-	jwtSecret = []byte(secret)
+}
+
+// AccessTokenTTL returns the lifetime of access tokens
+func AccessTokenTTL() time.Duration {
+	return accessTokenTTL
 }
 
 // Claims represents the JWT claims
@@ -30,19 +34,28 @@ type Claims struct {
 	UserID string            `json:"user_id"`
 	Email  string            `json:"email"`
 	Role   participant.Role  `json:"role"`
+	// OrganizationID is the organization the token was issued for; it is refused in any other
+	OrganizationID   string                  `json:"org"`
+	OrganizationRole organization.MemberRole `json:"org_role"`
+	// TokenVersion is the user's token version at issue time; the token is revoked once it changes
+	TokenVersion int `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a JWT token for a user
-func GenerateToken(userID uuid.UUID, email string, role participant.Role) (string, error) {
-	// Token expires in 24 hours
-	expirationTime := time.Now().Add(24 * time.Hour)
+// GenerateToken generates a short-lived access token for a user, valid in the organization
+// of their membership
+func GenerateToken(userID uuid.UUID, email string, role participant.Role, member *organization.Member, tokenVersion int) (string, error) {
+	expirationTime := time.Now().Add(accessTokenTTL)
 
 	claims := &Claims{
-		UserID: userID.String(),
-		Email:  email,
-		Role:   role,
+		UserID:           userID.String(),
+		Email:            email,
+		Role:             role,
+		OrganizationID:   member.OrganizationID.String(),
+		OrganizationRole: member.Role,
+		TokenVersion:     tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -50,8 +63,11 @@ func GenerateToken(userID uuid.UUID, email string, role participant.Role) (strin
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+	if keys == nil {
+		return "", errors.New("signing keys not configured")
+	}
+
+	tokenString, err := keys.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -59,17 +75,16 @@ func GenerateToken(userID uuid.UUID, email string, role participant.Role) (strin
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT token against the key named by its kid and returns the claims
 func ValidateToken(tokenString string) (*Claims, error) {
+	if keys == nil {
+		return nil, errors.New("signing keys not configured")
+	}
+
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.verificationKey,
+		jwt.WithValidMethods([]string{"HS256", "RS256", "EdDSA"}))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -82,8 +97,9 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// JWTAuthMiddleware is a Gin middleware that validates JWT tokens
-func JWTAuthMiddleware() gin.HandlerFunc {
+// JWTAuthMiddleware is a Gin middleware that validates JWT tokens. Tokens issued before the
+// user's token version was bumped (logout everywhere) are rejected.
+func JWTAuthMiddleware(userRepo postgres.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -120,10 +136,32 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Check the token was not revoked
+		tokenVersion, err := userRepo.GetTokenVersion(claims.UserID)
+		if err != nil || tokenVersion != claims.TokenVersion {
+			c.JSON(401, gin.H{
+				"error": "UNAUTHORIZED",
+				"message": "Token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
+		// Tokens only work in the organization they were issued for
+		if orgID, err := tenant.OrganizationID(c); err != nil || orgID.String() != claims.OrganizationID {
+			c.JSON(401, gin.H{
+				"error": "UNAUTHORIZED",
+				"message": "Token was not issued for this organization",
+			})
+			c.Abort()
+			return
+		}
+
 		// Store claims in context for handlers to use
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
+		c.Set("organization_role", claims.OrganizationRole)
 
 		c.Next()
 	}
@@ -154,6 +192,14 @@ func GetUserRoleFromContext(c *gin.Context) (participant.Role, error) {
 	return role.(participant.Role), nil
 }
 
+// GetOrganizationRoleFromContext returns the role of the signed-in user in the organization
+// of the request, empty when it is unknown
+func GetOrganizationRoleFromContext(c *gin.Context) organization.MemberRole {
+	role, _ := c.Get("organization_role")
+	memberRole, _ := role.(organization.MemberRole)
+	return memberRole
+}
+
 // GetUserEmailFromContext extracts the user email from the Gin context
 func GetUserEmailFromContext(c *gin.Context) (string, error) {
 	email, exists := c.Get("user_email")
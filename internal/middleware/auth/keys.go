@@ -0,0 +1,300 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing tokens
+const minRSAKeyBits = 2048
+
+// signingKey is a key of the set, identified by the kid header of the tokens it signs.
+// private is nil for retired keys kept only to verify tokens still in circulation.
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private interface{}
+	public  interface{}
+}
+
+// KeySet holds the key that signs new access tokens and every key whose tokens are still
+// accepted. Rotating keys means adding the new key to every replica, switching the signing
+// key once all of them accept it, and removing the old key after an access token lifetime.
+type KeySet struct {
+	signing *signingKey
+	keys    map[string]*signingKey
+}
+
+// keys is the key set used to sign and verify access tokens, see SetKeySet
+var keys *KeySet
+
+// SetKeySet sets the key set used to sign and verify access tokens
+func SetKeySet(set *KeySet) {
+	keys = set
+}
+
+// LoadKeySet builds the key set from configuration. With HS256 tokens are signed with
+// JWT_SECRET and JWT_PREVIOUS_SECRETS stay valid; with RS256 or EdDSA the PEM keys of
+// JWT_KEYS_DIR are loaded, named <kid>.pem, and retired keys may be public keys only.
+func LoadKeySet(cfg *config.Config) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*signingKey)}
+
+	switch cfg.Auth.SigningAlgorithm {
+	case "HS256":
+		set.signing = set.addSecret(cfg.Auth.JWTSecret)
+		for _, secret := range cfg.Auth.PreviousSecrets {
+			set.addSecret(secret)
+		}
+
+	case "RS256", "EdDSA":
+		if err := set.loadDir(cfg.Auth.KeysDir); err != nil {
+			return nil, err
+		}
+		if cfg.Auth.VerifyLegacyHS256 {
+			set.addSecret(cfg.Auth.JWTSecret)
+			for _, secret := range cfg.Auth.PreviousSecrets {
+				set.addSecret(secret)
+			}
+		}
+
+		signing, err := set.pickSigningKey(cfg.Auth.SigningKeyID, cfg.Auth.SigningAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		set.signing = signing
+
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing algorithm: %s (must be 'HS256', 'RS256' or 'EdDSA')", cfg.Auth.SigningAlgorithm)
+	}
+
+	return set, nil
+}
+
+// SigningKeyID returns the kid of the key that signs new tokens
+func (s *KeySet) SigningKeyID() string {
+	return s.signing.id
+}
+
+// Algorithm returns the algorithm of the key that signs new tokens
+func (s *KeySet) Algorithm() string {
+	return s.signing.method.Alg()
+}
+
+// KeyIDs returns the kids of every key accepted for verification
+func (s *KeySet) KeyIDs() []string {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// addSecret adds an HMAC secret, identified by a digest so the kid doesn't reveal it
+func (s *KeySet) addSecret(secret string) *signingKey {
+	digest := sha256.Sum256([]byte(secret))
+	key := &signingKey{
+		id:      "hs-" + hex.EncodeToString(digest[:6]),
+		method:  jwt.SigningMethodHS256,
+		private: []byte(secret),
+		public:  []byte(secret),
+	}
+	s.keys[key.id] = key
+	return key
+}
+
+// loadDir loads every <kid>.pem file of the directory
+func (s *KeySet) loadDir(dir string) error {
+	if dir == "" {
+		return errors.New("JWT_KEYS_DIR is required for asymmetric signing")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no signing keys (*.pem) found in %s", dir)
+	}
+
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := loadPEMKey(kid, path)
+		if err != nil {
+			return err
+		}
+		s.keys[kid] = key
+	}
+
+	return nil
+}
+
+// pickSigningKey returns the private key that signs new tokens: the one named by
+// JWT_SIGNING_KEY_ID, or the only private key of the algorithm when it isn't set
+func (s *KeySet) pickSigningKey(kid, algorithm string) (*signingKey, error) {
+	if kid != "" {
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("signing key %q not found", kid)
+		}
+		if key.private == nil {
+			return nil, fmt.Errorf("signing key %q is a public key; a private key is needed to sign", kid)
+		}
+		if key.method.Alg() != algorithm {
+			return nil, fmt.Errorf("signing key %q is a %s key, not %s", kid, key.method.Alg(), algorithm)
+		}
+		return key, nil
+	}
+
+	var candidates []*signingKey
+	for _, id := range s.KeyIDs() {
+		if key := s.keys[id]; key.private != nil && key.method.Alg() == algorithm {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("found %d private %s keys; set JWT_SIGNING_KEY_ID to choose the signing key", len(candidates), algorithm)
+	}
+	return candidates[0], nil
+}
+
+// loadPEMKey reads an RSA or Ed25519 key: a private key (PKCS#8, or PKCS#1 for RSA) or,
+// for retired keys, a public key (PKIX)
+func loadPEMKey(kid, path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported PEM type %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+
+	key := &signingKey{id: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("signing key %s must be an RSA or Ed25519 key, got %T", path, parsed)
+	}
+
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("signing key %s is too short: RSA keys need at least %d bits", path, minRSAKeyBits)
+	}
+
+	return key, nil
+}
+
+// verificationKey returns the key for the kid of a token. Tokens issued before key IDs
+// were introduced have none and are checked against the HMAC secrets.
+func (s *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		var secrets []jwt.VerificationKey
+		for _, id := range s.KeyIDs() {
+			if key := s.keys[id]; key.method == jwt.SigningMethodHS256 {
+				secrets = append(secrets, key.public)
+			}
+		}
+		if len(secrets) == 0 || token.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("token has no key ID")
+		}
+		return jwt.VerificationKeySet{Keys: secrets}, nil
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// JSONWebKey is a public key of the set in RFC 7517 form
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// PublicKeys returns the asymmetric keys of the set for publishing as a JWKS. HMAC secrets
+// are never published, so with HS256 the list is empty.
+func (s *KeySet) PublicKeys() []JSONWebKey {
+	jwks := make([]JSONWebKey, 0, len(s.keys))
+	for _, id := range s.KeyIDs() {
+		key := s.keys[id]
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwks = append(jwks, JSONWebKey{
+				Kty: "RSA",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks = append(jwks, JSONWebKey{
+				Kty: "OKP",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return jwks
+}
+
+// sign signs the claims with the signing key, naming it in the kid header
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.method, claims)
+	token.Header["kid"] = s.signing.id
+	return token.SignedString(s.signing.private)
+}
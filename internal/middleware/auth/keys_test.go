@@ -0,0 +1,332 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+)
+
+const (
+	testSecret         = "current-secret"
+	testPreviousSecret = "previous-secret"
+)
+
+// testKeys are generated once; RSA key generation is slow
+var (
+	currentRSA = mustRSAKey(2048)
+	retiredRSA = mustRSAKey(2048)
+	currentEd  = mustEd25519Key()
+)
+
+func mustRSAKey(bits int) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func mustEd25519Key() ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// writeKey writes key to dir/<kid>.pem: PKCS#8 for private keys, PKIX for public keys
+func writeKey(t *testing.T, dir, kid string, key interface{}) {
+	t.Helper()
+
+	var block *pem.Block
+	switch k := key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testConfig(algorithm, keysDir string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Auth.SigningAlgorithm = algorithm
+	cfg.Auth.JWTSecret = testSecret
+	cfg.Auth.PreviousSecrets = []string{testPreviousSecret}
+	cfg.Auth.KeysDir = keysDir
+	return cfg
+}
+
+// rsaKeySet loads a directory with the current RSA key, the public half of a retired RSA
+// key and an Ed25519 key, signing with "current"
+func rsaKeySet(t *testing.T, legacyHS256 bool) *KeySet {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeKey(t, dir, "current", currentRSA)
+	writeKey(t, dir, "retired", &retiredRSA.PublicKey)
+	writeKey(t, dir, "ed", currentEd)
+
+	cfg := testConfig("RS256", dir)
+	cfg.Auth.SigningKeyID = "current"
+	cfg.Auth.VerifyLegacyHS256 = legacyHS256
+
+	set, err := LoadKeySet(cfg)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	return set
+}
+
+func testClaims(expiresAt time.Time) *Claims {
+	return &Claims{
+		UserID: uuid.NewString(),
+		Email:  "ana@example.org",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(expiresAt.Add(-15 * time.Minute)),
+		},
+	}
+}
+
+// signToken signs claims with the given method and key, setting kid when it isn't empty
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+// unsignedToken builds an alg "none" token naming kid
+func unsignedToken(t *testing.T, kid string, claims jwt.Claims) string {
+	t.Helper()
+	return signToken(t, jwt.SigningMethodNone, kid, jwt.UnsafeAllowNoneSignatureType, claims)
+}
+
+func validate(t *testing.T, set *KeySet, token string) error {
+	t.Helper()
+
+	previous := keys
+	SetKeySet(set)
+	defer SetKeySet(previous)
+
+	_, err := ValidateToken(token)
+	return err
+}
+
+func TestKeySetAcceptsTokensOfEveryKnownKey(t *testing.T) {
+	set := rsaKeySet(t, true)
+	valid := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"signed by the signing key", mustSign(t, set, testClaims(valid))},
+		{"signed by a retired key", signToken(t, jwt.SigningMethodRS256, "retired", retiredRSA, testClaims(valid))},
+		{"signed by another algorithm's key", signToken(t, jwt.SigningMethodEdDSA, "ed", currentEd, testClaims(valid))},
+		{"legacy token without kid", signToken(t, jwt.SigningMethodHS256, "", []byte(testSecret), testClaims(valid))},
+		{"legacy token of a previous secret", signToken(t, jwt.SigningMethodHS256, "", []byte(testPreviousSecret), testClaims(valid))},
+		{"HS256 token naming its secret", signToken(t, jwt.SigningMethodHS256, set.addSecret(testSecret).id, []byte(testSecret), testClaims(valid))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validate(t, set, tt.token); err != nil {
+				t.Errorf("ValidateToken = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestKeySetRejectsForgedAndStaleTokens(t *testing.T) {
+	set := rsaKeySet(t, true)
+	valid := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Minute)
+
+	// The classic confusion attack: HMAC-sign with the public key everyone can fetch
+	rsaPublicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustPKIX(t, &currentRSA.PublicKey)})
+	otherRSA := mustRSAKey(2048)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"HS256 header on an RS256 kid", signToken(t, jwt.SigningMethodHS256, "current", rsaPublicPEM, testClaims(valid)), "unexpected signing method"},
+		{"EdDSA kid with an RS256 header", signToken(t, jwt.SigningMethodRS256, "ed", currentRSA, testClaims(valid)), "unexpected signing method"},
+		{"alg none with a kid", unsignedToken(t, "current", testClaims(valid)), "signing method"},
+		{"alg none without a kid", unsignedToken(t, "", testClaims(valid)), "signing method"},
+		{"RS256 without a kid", signToken(t, jwt.SigningMethodRS256, "", currentRSA, testClaims(valid)), "no key ID"},
+		{"unknown kid", signToken(t, jwt.SigningMethodRS256, "removed", otherRSA, testClaims(valid)), "unknown signing key"},
+		{"known kid, wrong key", signToken(t, jwt.SigningMethodRS256, "current", otherRSA, testClaims(valid)), "verification error"},
+		{"expired, signed by a retired key", signToken(t, jwt.SigningMethodRS256, "retired", retiredRSA, testClaims(expired)), "expired"},
+		{"expired, signed by the signing key", mustSign(t, set, testClaims(expired)), "expired"},
+		{"legacy token of an unknown secret", signToken(t, jwt.SigningMethodHS256, "", []byte("leaked-elsewhere"), testClaims(valid)), "signature is invalid"},
+		{"expired legacy token", signToken(t, jwt.SigningMethodHS256, "", []byte(testSecret), testClaims(expired)), "expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(t, set, tt.token)
+			if err == nil {
+				t.Fatal("ValidateToken = nil, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateToken = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeySetWithoutLegacyHS256(t *testing.T) {
+	set := rsaKeySet(t, false)
+	token := signToken(t, jwt.SigningMethodHS256, "", []byte(testSecret), testClaims(time.Now().Add(time.Hour)))
+
+	if err := validate(t, set, token); err == nil || !strings.Contains(err.Error(), "no key ID") {
+		t.Errorf("ValidateToken of a legacy token = %v, want it rejected", err)
+	}
+	for _, id := range set.KeyIDs() {
+		if strings.HasPrefix(id, "hs-") {
+			t.Errorf("key set holds HMAC secret %s without JWT_VERIFY_LEGACY_HS256", id)
+		}
+	}
+}
+
+func TestKeySetHS256(t *testing.T) {
+	set, err := LoadKeySet(testConfig("HS256", ""))
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	if set.Algorithm() != "HS256" || len(set.KeyIDs()) != 2 {
+		t.Errorf("key set = %s with keys %v, want HS256 with the current and previous secrets", set.Algorithm(), set.KeyIDs())
+	}
+	if strings.Contains(set.SigningKeyID(), testSecret) {
+		t.Errorf("kid %q reveals the secret", set.SigningKeyID())
+	}
+	if jwks := set.PublicKeys(); len(jwks) != 0 {
+		t.Errorf("PublicKeys = %v, want HMAC secrets never published", jwks)
+	}
+
+	valid := time.Now().Add(time.Hour)
+	if err := validate(t, set, mustSign(t, set, testClaims(valid))); err != nil {
+		t.Errorf("ValidateToken of a new token = %v", err)
+	}
+	previousKid := set.addSecret(testPreviousSecret).id
+	if err := validate(t, set, signToken(t, jwt.SigningMethodHS256, previousKid, []byte(testPreviousSecret), testClaims(valid))); err != nil {
+		t.Errorf("ValidateToken of a previous secret's token = %v", err)
+	}
+	// A kid can't make one secret verify another secret's token
+	if err := validate(t, set, signToken(t, jwt.SigningMethodHS256, set.SigningKeyID(), []byte(testPreviousSecret), testClaims(valid))); err == nil {
+		t.Error("ValidateToken accepted a token naming another secret's kid")
+	}
+}
+
+func TestLoadKeySetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    map[string]interface{}
+		kid     string
+		wantErr string
+	}{
+		{"no keys", map[string]interface{}{}, "", "no signing keys"},
+		{"signing key not found", map[string]interface{}{"current": currentRSA}, "missing", "not found"},
+		{"public signing key", map[string]interface{}{"retired": &retiredRSA.PublicKey}, "retired", "a private key is needed"},
+		{"signing key of another algorithm", map[string]interface{}{"ed": currentEd}, "ed", "is a EdDSA key"},
+		{"several private keys without a kid", map[string]interface{}{"a": currentRSA, "b": retiredRSA}, "", "set JWT_SIGNING_KEY_ID"},
+		{"short RSA key", map[string]interface{}{"weak": mustRSAKey(1024)}, "", "too short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for kid, key := range tt.keys {
+				writeKey(t, dir, kid, key)
+			}
+			cfg := testConfig("RS256", dir)
+			cfg.Auth.SigningKeyID = tt.kid
+
+			_, err := LoadKeySet(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadKeySet = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadKeySet(testConfig("RS256", "")); err == nil {
+		t.Error("LoadKeySet without JWT_KEYS_DIR succeeded")
+	}
+	if _, err := LoadKeySet(testConfig("none", "")); err == nil {
+		t.Error("LoadKeySet with algorithm none succeeded")
+	}
+}
+
+func TestPublicKeys(t *testing.T) {
+	set := rsaKeySet(t, true)
+
+	jwks := set.PublicKeys()
+	got := make(map[string]string)
+	for _, jwk := range jwks {
+		got[jwk.Kid] = jwk.Kty + "/" + jwk.Alg
+	}
+
+	want := map[string]string{"current": "RSA/RS256", "retired": "RSA/RS256", "ed": "OKP/EdDSA"}
+	if len(got) != len(want) {
+		t.Fatalf("PublicKeys = %v, want %v", got, want)
+	}
+	for kid, kind := range want {
+		if got[kid] != kind {
+			t.Errorf("key %s = %q, want %q", kid, got[kid], kind)
+		}
+	}
+}
+
+func mustSign(t *testing.T, set *KeySet, claims jwt.Claims) string {
+	t.Helper()
+
+	token, err := set.sign(claims)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func mustPKIX(t *testing.T, key interface{}) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
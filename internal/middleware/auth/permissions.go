@@ -5,113 +5,69 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/permission"
+	"github.com/gravadigital/telescopio-api/internal/middleware/tenant"
 	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
-// RequireRole is a middleware that checks if the user has one of the required roles
-func RequireRole(roles ...participant.Role) gin.HandlerFunc {
+// RequirePermission is a middleware that lets through users holding the permission.
+// For event scoped permissions the event comes from the event_id URL parameter and the
+// participant_id parameter, when present, names the member the request acts for.
+func RequirePermission(eventRepo postgres.EventRepository, p permission.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, err := GetUserRoleFromContext(c)
+		subject, err := SubjectFromContext(c)
 		if err != nil {
 			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User role not found in context",
+				"error":   "UNAUTHORIZED",
+				"message": "User not found in context",
 			})
 			c.Abort()
 			return
 		}
 
-		// Check if user has one of the required roles
-		hasRole := false
-		for _, role := range roles {
-			if userRole == role {
-				hasRole = true
-				break
+		var target permission.Target
+		if p.EventScoped() {
+			eventID, err := uuid.Parse(c.Param("event_id"))
+			if err != nil {
+				c.JSON(400, gin.H{
+					"error":   "BAD_REQUEST",
+					"message": "Invalid event ID format",
+				})
+				c.Abort()
+				return
 			}
-		}
-
-		if !hasRole {
-			c.JSON(403, gin.H{
-				"error": "FORBIDDEN",
-				"message": fmt.Sprintf("This action requires one of the following roles: %v", roles),
-			})
-			c.Abort()
-			return
-		}
-
-		c.Next()
-	}
-}
-
-// RequireEventOwner is a middleware that checks if the user is the owner of the event
-// or has admin privileges
-func RequireEventOwner(eventRepo postgres.EventRepository) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get user info from context
-		userID, err := GetUserIDFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User ID not found in context",
-			})
-			c.Abort()
-			return
-		}
-
-		userRole, err := GetUserRoleFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User role not found in context",
-			})
-			c.Abort()
-			return
-		}
-
-		// Admins can do anything
-		if userRole == participant.RoleAdmin {
-			c.Next()
-			return
-		}
-
-		// Get event ID from URL parameter
-		eventIDStr := c.Param("event_id")
-		if eventIDStr == "" {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Event ID is required",
-			})
-			c.Abort()
-			return
-		}
 
-		eventID, err := uuid.Parse(eventIDStr)
-		if err != nil {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Invalid event ID format",
-			})
-			c.Abort()
-			return
-		}
+			evt, err := eventRepo.GetByIDInOrganization(tenant.Scope(c), eventID.String())
+			if err != nil {
+				c.JSON(404, gin.H{
+					"error":   "NOT_FOUND",
+					"message": "Event not found",
+				})
+				c.Abort()
+				return
+			}
 
-		// Get event from database
-		event, err := eventRepo.GetByID(eventID.String())
-		if err != nil {
-			c.JSON(404, gin.H{
-				"error": "NOT_FOUND",
-				"message": "Event not found",
-			})
-			c.Abort()
-			return
+			target = EventTarget(eventRepo, subject, evt)
+
+			if participantIDStr := c.Param("participant_id"); participantIDStr != "" {
+				participantID, err := uuid.Parse(participantIDStr)
+				if err != nil {
+					c.JSON(400, gin.H{
+						"error":   "BAD_REQUEST",
+						"message": "Invalid participant ID format",
+					})
+					c.Abort()
+					return
+				}
+				target.Owners = []uuid.UUID{participantID}
+			}
 		}
 
-		// Check if user is the event author
-		if event.AuthorID != userID {
+		if !permission.Allowed(p, subject, target) {
 			c.JSON(403, gin.H{
-				"error": "FORBIDDEN",
-				"message": "Only the event creator or an admin can perform this action",
+				"error":   "FORBIDDEN",
+				"message": fmt.Sprintf("This action requires the %s permission", p),
 			})
 			c.Abort()
 			return
@@ -121,178 +77,85 @@ func RequireEventOwner(eventRepo postgres.EventRepository) gin.HandlerFunc {
 	}
 }
 
-// RequireEventOwnerOrOrganizer is a middleware that checks if the user is the owner of the event
-// or has admin/organizer privileges
-func RequireEventOwnerOrOrganizer(eventRepo postgres.EventRepository) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get user info from context
-		userID, err := GetUserIDFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User ID not found in context",
-			})
-			c.Abort()
-			return
-		}
-
-		userRole, err := GetUserRoleFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User role not found in context",
-			})
-			c.Abort()
-			return
-		}
-
-		// Admins and organizers can manage events
-		if userRole == participant.RoleAdmin || userRole == participant.RoleOrganizer {
-			c.Next()
-			return
-		}
-
-		// Get event ID from URL parameter
-		eventIDStr := c.Param("event_id")
-		if eventIDStr == "" {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Event ID is required",
-			})
-			c.Abort()
-			return
-		}
+// Authorize reports whether the signed-in user holds the permission on the event, for
+// checks that need data only handlers load, such as the authors of a proposal
+func Authorize(c *gin.Context, eventRepo postgres.EventRepository, p permission.Permission, evt *event.Event, owners ...uuid.UUID) bool {
+	subject, err := SubjectFromContext(c)
+	if err != nil {
+		return false
+	}
 
-		eventID, err := uuid.Parse(eventIDStr)
-		if err != nil {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Invalid event ID format",
-			})
-			c.Abort()
-			return
-		}
+	target := permission.Target{Owners: owners}
+	if evt != nil {
+		target = EventTarget(eventRepo, subject, evt)
+		target.Owners = owners
+	}
 
-		// Get event from database
-		event, err := eventRepo.GetByID(eventID.String())
-		if err != nil {
-			c.JSON(404, gin.H{
-				"error": "NOT_FOUND",
-				"message": "Event not found",
-			})
-			c.Abort()
-			return
-		}
+	return permission.Allowed(p, subject, target)
+}
 
-		// Check if user is the event author
-		if event.AuthorID != userID {
-			c.JSON(403, gin.H{
-				"error": "FORBIDDEN",
-				"message": "Only the event creator, organizers, or admins can perform this action",
-			})
-			c.Abort()
-			return
-		}
+// SubjectFromContext returns the signed-in user as a permission subject
+func SubjectFromContext(c *gin.Context) (permission.Subject, error) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		return permission.Subject{}, err
+	}
 
-		c.Next()
+	role, err := GetUserRoleFromContext(c)
+	if err != nil {
+		return permission.Subject{}, err
 	}
-}
 
-// RequireParticipantOrOwner is a middleware that checks if the user is the participant
-// specified in the URL or the event owner/admin
-func RequireParticipantOrOwner(eventRepo postgres.EventRepository) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get user info from context
-		userID, err := GetUserIDFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User ID not found in context",
-			})
-			c.Abort()
-			return
-		}
+	return permission.Subject{UserID: userID, Role: role, OrganizationRole: GetOrganizationRoleFromContext(c)}, nil
+}
 
-		userRole, err := GetUserRoleFromContext(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "UNAUTHORIZED",
-				"message": "User role not found in context",
-			})
-			c.Abort()
-			return
-		}
+// IsAdmin reports whether the signed-in user administers the organization of the request
+func IsAdmin(c *gin.Context) bool {
+	subject, err := SubjectFromContext(c)
+	return err == nil && subject.IsAdmin()
+}
 
-		// Admins can do anything
-		if userRole == participant.RoleAdmin {
-			c.Next()
-			return
-		}
+// EventTarget builds the target of an event scoped permission with the subject's role in
+// the event. Admins hold every permission, so their role is not looked up.
+func EventTarget(eventRepo postgres.EventRepository, subject permission.Subject, evt *event.Event) permission.Target {
+	target := permission.Target{Event: evt}
+	if subject.IsAdmin() {
+		return target
+	}
 
-		// Get participant ID from URL parameter
-		participantIDStr := c.Param("participant_id")
-		if participantIDStr == "" {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Participant ID is required",
-			})
-			c.Abort()
-			return
-		}
+	if role, err := eventRepo.GetParticipantRole(evt.ID.String(), subject.UserID.String()); err == nil {
+		target.EventRole = *role
+	}
 
-		participantID, err := uuid.Parse(participantIDStr)
-		if err != nil {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Invalid participant ID format",
-			})
-			c.Abort()
-			return
-		}
+	return target
+}
 
-		// If user is the participant themselves, allow
-		if userID == participantID {
+// RequireWritableEvent is a middleware that rejects changes to archived events.
+// Read-only requests pass through; unknown events are left for the handler to report.
+func RequireWritableEvent(eventRepo postgres.EventRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case "GET", "HEAD", "OPTIONS":
 			c.Next()
 			return
 		}
 
-		// Otherwise, check if user is event owner
 		eventIDStr := c.Param("event_id")
 		if eventIDStr == "" {
-			c.JSON(403, gin.H{
-				"error": "FORBIDDEN",
-				"message": "You can only access your own resources",
-			})
-			c.Abort()
-			return
-		}
-
-		eventID, err := uuid.Parse(eventIDStr)
-		if err != nil {
-			c.JSON(400, gin.H{
-				"error": "BAD_REQUEST",
-				"message": "Invalid event ID format",
-			})
-			c.Abort()
+			c.Next()
 			return
 		}
 
-		// Get event from database
-		event, err := eventRepo.GetByID(eventID.String())
+		event, err := eventRepo.GetByIDInOrganization(tenant.Scope(c), eventIDStr)
 		if err != nil {
-			c.JSON(404, gin.H{
-				"error": "NOT_FOUND",
-				"message": "Event not found",
-			})
-			c.Abort()
+			c.Next()
 			return
 		}
 
-		// Check if user is the event author
-		if event.AuthorID != userID {
-			c.JSON(403, gin.H{
-				"error": "FORBIDDEN",
-				"message": "You can only access your own resources or resources from events you created",
+		if event.IsArchived() {
+			c.JSON(409, gin.H{
+				"error":   "EVENT_ARCHIVED",
+				"message": "This event is archived and read-only. Unarchive it to make changes",
 			})
 			c.Abort()
 			return
@@ -0,0 +1,195 @@
+// Package tenant resolves the organization a request is served for and keeps requests from
+// reaching the events, users and other records of another organization
+package tenant
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// organizationKey is the context key of the resolved organization
+const organizationKey = "organization"
+
+// CredentialFunc returns the organization of the credential sent with the request, if any.
+// Invalid credentials name no organization; authentication rejects them later.
+type CredentialFunc func(c *gin.Context) (uuid.UUID, bool)
+
+// Resolve is a middleware that finds the organization of the request: the one of the
+// signed-in credential, else the one of the subdomain, else the default organization.
+// A credential used on the subdomain of another organization is refused.
+func Resolve(orgs postgres.OrganizationRepository, cfg *config.Config, credential CredentialFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var org *organization.Organization
+
+		if slug := subdomain(c.Request.Host, cfg.Tenant.BaseDomain); slug != "" {
+			found, err := orgs.GetBySlug(slug)
+			if err != nil {
+				c.JSON(404, gin.H{
+					"error":   "NOT_FOUND",
+					"message": "Organization not found",
+				})
+				c.Abort()
+				return
+			}
+			org = found
+		}
+
+		if orgID, ok := credential(c); ok {
+			if org != nil && org.ID != orgID {
+				c.JSON(403, gin.H{
+					"error":   "ORGANIZATION_MISMATCH",
+					"message": "These credentials belong to another organization; sign in on its own address",
+				})
+				c.Abort()
+				return
+			}
+
+			if org == nil {
+				found, err := orgs.GetByID(orgID.String())
+				if err != nil {
+					c.JSON(401, gin.H{
+						"error":   "UNAUTHORIZED",
+						"message": "The organization of these credentials no longer exists",
+					})
+					c.Abort()
+					return
+				}
+				org = found
+			}
+		}
+
+		if org == nil {
+			found, err := orgs.GetBySlug(cfg.Tenant.DefaultOrganization)
+			if err != nil {
+				c.JSON(503, gin.H{
+					"error":   "SERVICE_UNAVAILABLE",
+					"message": "Default organization not available",
+				})
+				c.Abort()
+				return
+			}
+			org = found
+		}
+
+		c.Set(organizationKey, org)
+		c.Next()
+	}
+}
+
+// Guard is a middleware that answers 404 for records of another organization named in the
+// URL: events, attachments, users, event templates and API keys. Unknown records pass, so
+// handlers report them as before. Handlers look records up scoped to the organization
+// as well; Guard is defense in depth.
+func Guard(container *postgres.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orgID, err := OrganizationID(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if eventID := c.Param("event_id"); eventID != "" {
+			if evt, err := container.Events().GetByID(eventID); err == nil && evt.OrganizationID != orgID {
+				notFound(c, "Event not found")
+				return
+			}
+		}
+
+		if attachmentID := c.Param("attachment_id"); attachmentID != "" {
+			if att, err := container.Attachments().GetByID(attachmentID); err == nil {
+				if evt, err := container.Events().GetByID(att.EventID.String()); err == nil && evt.OrganizationID != orgID {
+					notFound(c, "Attachment not found")
+					return
+				}
+			}
+		}
+
+		if userID, err := uuid.Parse(c.Param("user_id")); err == nil {
+			if _, err := container.Organizations().GetMember(orgID, userID); err != nil {
+				notFound(c, "User not found")
+				return
+			}
+		}
+
+		if templateID := c.Param("template_id"); templateID != "" {
+			if template, err := container.EventTemplates().GetByID(templateID); err == nil && template.OrganizationID != orgID {
+				notFound(c, "Template not found")
+				return
+			}
+		}
+
+		if keyID := c.Param("key_id"); keyID != "" {
+			if key, err := container.APIKeys().GetByID(keyID); err == nil && key.OrganizationID != orgID {
+				notFound(c, "API key not found")
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// Organization returns the organization the request is served for
+func Organization(c *gin.Context) (*organization.Organization, error) {
+	value, exists := c.Get(organizationKey)
+	if !exists {
+		return nil, errors.New("organization not found in context")
+	}
+
+	return value.(*organization.Organization), nil
+}
+
+// OrganizationID returns the ID of the organization the request is served for
+func OrganizationID(c *gin.Context) (uuid.UUID, error) {
+	org, err := Organization(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return org.ID, nil
+}
+
+// Scope returns the ID of the organization the request is served for, to scope repository
+// lookups by. Without a resolved organization it is uuid.Nil, which matches no records.
+func Scope(c *gin.Context) uuid.UUID {
+	orgID, err := OrganizationID(c)
+	if err != nil {
+		return uuid.Nil
+	}
+	return orgID
+}
+
+// subdomain returns the organization slug in the host, empty when the host is not a
+// subdomain of the base domain
+func subdomain(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	slug, ok := strings.CutSuffix(host, "."+baseDomain)
+	if !ok {
+		return ""
+	}
+	return slug
+}
+
+func notFound(c *gin.Context, message string) {
+	c.JSON(404, gin.H{
+		"error":   "NOT_FOUND",
+		"message": message,
+	})
+	c.Abort()
+}
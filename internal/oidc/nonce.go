@@ -0,0 +1,65 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidNonce is returned for nonces that weren't issued by this API or have expired
+var ErrInvalidNonce = errors.New("invalid or expired nonce")
+
+const nonceSignaturePrefix = "telescopio-oidc-nonce:"
+
+// NewNonce issues a nonce for a sign-in. The frontend passes it to the provider's
+// authorization request and sends it back with the ID token. It is signed, so nothing
+// needs to be stored until the sign-in completes.
+func NewNonce(secret []byte, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+
+	payload := make([]byte, 24)
+	if _, err := rand.Read(payload[:16]); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signNonce(secret, payload)), expiresAt, nil
+}
+
+// CheckNonce verifies that the nonce was issued by NewNonce and hasn't expired
+func CheckNonce(secret []byte, nonce string, now time.Time) error {
+	encodedPayload, encodedSignature, ok := strings.Cut(nonce, ".")
+	if !ok {
+		return ErrInvalidNonce
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 24 {
+		return ErrInvalidNonce
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signNonce(secret, payload)) {
+		return ErrInvalidNonce
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !now.Before(expiresAt) {
+		return ErrInvalidNonce
+	}
+
+	return nil
+}
+
+func signNonce(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(nonceSignaturePrefix))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,255 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned for ID tokens that fail verification: bad signature, wrong
+// issuer or audience, expired, or a missing or mismatched nonce
+var ErrInvalidToken = errors.New("invalid or expired ID token")
+
+// ErrProviderUnavailable is returned when the discovery document or the signing keys of a
+// provider can't be fetched
+var ErrProviderUnavailable = errors.New("identity provider unavailable")
+
+// clockSkew is the leeway granted on exp, iat and nbf
+const clockSkew = time.Minute
+
+// signingMethods are the accepted ID token algorithms. Symmetric algorithms are excluded,
+// since the keys come from a public key set.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// ProviderConfig describes an OpenID Connect provider
+type ProviderConfig struct {
+	Name        string   // Identifier used in URLs and stored with linked identities
+	DisplayName string   // Shown on the sign-in button
+	Issuer      string   // Expected iss claim; also the base URL of the discovery document
+	Issuers     []string // Further accepted iss values, e.g. Google's "accounts.google.com"
+	ClientIDs   []string // Accepted audiences
+	JWKSURL     string   // Optional: discovered from the issuer when empty
+	// RequireNonce rejects tokens verified without a nonce. Only disable it for providers
+	// whose clients can't pass one through.
+	RequireNonce bool
+	KeyCacheTTL  time.Duration
+}
+
+// Identity is the verified subject of an ID token
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider verifies ID tokens of one OpenID Connect provider against its cached key set
+type Provider struct {
+	config ProviderConfig
+	client *http.Client
+
+	mu   sync.Mutex
+	keys *keySet
+}
+
+// NewProvider creates a provider. The discovery document and the keys are fetched on the
+// first verification, so an unreachable provider doesn't prevent startup.
+func NewProvider(cfg ProviderConfig, client *http.Client) (*Provider, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("provider name is required")
+	}
+	if cfg.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required for provider %s", cfg.Name)
+	}
+	if len(cfg.ClientIDs) == 0 {
+		return nil, fmt.Errorf("client ID is required for provider %s", cfg.Name)
+	}
+	if cfg.DisplayName == "" {
+		cfg.DisplayName = cfg.Name
+	}
+	if cfg.KeyCacheTTL <= 0 {
+		cfg.KeyCacheTTL = time.Hour
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	p := &Provider{config: cfg, client: client}
+	if cfg.JWKSURL != "" {
+		p.keys = newKeySet(cfg.JWKSURL, cfg.KeyCacheTTL, client)
+	}
+	return p, nil
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// DisplayName returns the human readable provider name
+func (p *Provider) DisplayName() string {
+	return p.config.DisplayName
+}
+
+// Issuer returns the expected issuer
+func (p *Provider) Issuer() string {
+	return p.config.Issuer
+}
+
+// ClientID returns the primary client ID, the one frontends request tokens for
+func (p *Provider) ClientID() string {
+	return p.config.ClientIDs[0]
+}
+
+// RequiresNonce reports whether tokens must be verified with a nonce
+func (p *Provider) RequiresNonce() bool {
+	return p.config.RequireNonce
+}
+
+// idTokenClaims are the ID token claims read on top of the registered ones
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty string       `json:"azp"`
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+	GivenName       string       `json:"given_name"`
+	FamilyName      string       `json:"family_name"`
+}
+
+// Verify checks the signature, issuer, audience and expiry of an ID token and returns its
+// subject. When nonce isn't empty, the token must carry the same nonce.
+func (p *Provider) Verify(ctx context.Context, rawToken, nonce string) (*Identity, error) {
+	if nonce == "" && p.config.RequireNonce {
+		return nil, fmt.Errorf("%w: nonce is required", ErrInvalidToken)
+	}
+
+	keys, err := p.keySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims idTokenClaims
+	_, err = jwt.ParseWithClaims(rawToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		if errors.Is(err, ErrProviderUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !p.validIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if !p.validAudience(claims.Audience, claims.AuthorizedParty) {
+		return nil, fmt.Errorf("%w: token was issued for another client", ErrInvalidToken)
+	}
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = strings.TrimSpace(claims.GivenName + " " + claims.FamilyName)
+	}
+
+	return &Identity{
+		Provider:      p.config.Name,
+		Subject:       claims.Subject,
+		Email:         strings.TrimSpace(claims.Email),
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          name,
+	}, nil
+}
+
+func (p *Provider) validIssuer(issuer string) bool {
+	return issuer == p.config.Issuer || slices.Contains(p.config.Issuers, issuer)
+}
+
+// validAudience accepts tokens whose audience includes one of the client IDs. Tokens with
+// several audiences must name one of the client IDs as authorized party.
+func (p *Provider) validAudience(audience jwt.ClaimStrings, authorizedParty string) bool {
+	matched := false
+	for _, aud := range audience {
+		if slices.Contains(p.config.ClientIDs, aud) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	if len(audience) > 1 || authorizedParty != "" {
+		return slices.Contains(p.config.ClientIDs, authorizedParty)
+	}
+	return true
+}
+
+// keySet returns the key set, discovering its URL on first use
+func (p *Provider) keySet(ctx context.Context) (*keySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil {
+		return p.keys, nil
+	}
+
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimRight(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, p.client, discoveryURL, &doc); err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch discovery document: %v", ErrProviderUnavailable, err)
+	}
+	if doc.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%w: discovery document names issuer %q", ErrProviderUnavailable, doc.Issuer)
+	}
+	if doc.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery document has no jwks_uri", ErrProviderUnavailable)
+	}
+
+	p.keys = newKeySet(doc.JWKSURI, p.config.KeyCacheTTL, p.client)
+	return p.keys, nil
+}
+
+// flexibleBool accepts both true and "true", since some providers send email_verified as a string
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*b = flexibleBool(v)
+	case string:
+		*b = flexibleBool(v == "true")
+	default:
+		*b = false
+	}
+	return nil
+}
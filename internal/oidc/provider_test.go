@@ -0,0 +1,408 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "telescopio-test"
+
+// standInIssuer is a local OpenID Connect provider serving a discovery document and a key
+// set, and minting ID tokens with its keys
+type standInIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	keys      map[string]crypto.Signer
+	jwksHits  int
+	jwksError bool
+}
+
+func newStandInIssuer(t *testing.T) *standInIssuer {
+	t.Helper()
+
+	issuer := &standInIssuer{t: t, keys: make(map[string]crypto.Signer)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", issuer.serveKeys)
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	issuer.addRSAKey("rsa-1")
+	return issuer
+}
+
+func (s *standInIssuer) URL() string {
+	return s.server.URL
+}
+
+func (s *standInIssuer) addRSAKey(kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	s.mu.Lock()
+	s.keys[kid] = key
+	s.mu.Unlock()
+}
+
+func (s *standInIssuer) addKey(kid string, key crypto.Signer) {
+	s.mu.Lock()
+	s.keys[kid] = key
+	s.mu.Unlock()
+}
+
+func (s *standInIssuer) removeKey(kid string) {
+	s.mu.Lock()
+	delete(s.keys, kid)
+	s.mu.Unlock()
+}
+
+func (s *standInIssuer) serveKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jwksHits++
+	if s.jwksError {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	var keys []map[string]string
+	for kid, signer := range s.keys {
+		switch pub := signer.Public().(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, map[string]string{
+				"kty": "RSA", "kid": kid, "use": "sig",
+				"n": encode(pub.N.Bytes()), "e": encode(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			keys = append(keys, map[string]string{
+				"kty": "EC", "kid": kid, "crv": pub.Curve.Params().Name,
+				"x": encode(pub.X.FillBytes(make([]byte, size))), "y": encode(pub.Y.FillBytes(make([]byte, size))),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": encode(pub)})
+		}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+}
+
+func (s *standInIssuer) hits() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jwksHits
+}
+
+// sign mints an ID token with the given key. The claims default to a valid token for
+// testClientID; overrides replace them, a nil override removes the claim.
+func (s *standInIssuer) sign(kid string, overrides map[string]any) string {
+	s.t.Helper()
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.server.URL,
+		"sub":            "user-123",
+		"aud":            testClientID,
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          "expected-nonce",
+		"email":          "ada@example.org",
+		"email_verified": true,
+		"name":           "Ada Lovelace",
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+		} else {
+			claims[k] = v
+		}
+	}
+
+	s.mu.Lock()
+	key := s.keys[kid]
+	s.mu.Unlock()
+
+	var method jwt.SigningMethod
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PrivateKey:
+		method = jwt.SigningMethodES256
+		if k.Curve == elliptic.P384() {
+			method = jwt.SigningMethodES384
+		}
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		s.t.Fatalf("no key %q", kid)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return signed
+}
+
+func newTestProvider(t *testing.T, issuer *standInIssuer, requireNonce bool) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(ProviderConfig{
+		Name:         "standin",
+		Issuer:       issuer.URL(),
+		ClientIDs:    []string{testClientID},
+		RequireNonce: requireNonce,
+	}, issuer.server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestVerifyValidToken(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+
+	identity, err := provider.Verify(context.Background(), issuer.sign("rsa-1", nil), "expected-nonce")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	want := Identity{
+		Provider:      "standin",
+		Subject:       "user-123",
+		Email:         "ada@example.org",
+		EmailVerified: true,
+		Name:          "Ada Lovelace",
+	}
+	if *identity != want {
+		t.Errorf("Verify() = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		overrides map[string]any
+		nonce     string
+	}{
+		{"wrong issuer", map[string]any{"iss": "https://evil.example.org"}, "expected-nonce"},
+		{"wrong audience", map[string]any{"aud": "another-client"}, "expected-nonce"},
+		{"several audiences without azp", map[string]any{"aud": []string{testClientID, "another-client"}}, "expected-nonce"},
+		{"azp of another client", map[string]any{"azp": "another-client"}, "expected-nonce"},
+		{"expired", map[string]any{"exp": past.Unix(), "iat": past.Add(-time.Minute).Unix()}, "expected-nonce"},
+		{"missing expiry", map[string]any{"exp": nil}, "expected-nonce"},
+		{"issued in the future", map[string]any{"iat": time.Now().Add(time.Hour).Unix()}, "expected-nonce"},
+		{"missing subject", map[string]any{"sub": nil}, "expected-nonce"},
+		{"nonce mismatch", map[string]any{"nonce": "another-nonce"}, "expected-nonce"},
+		{"missing nonce claim", map[string]any{"nonce": nil}, "expected-nonce"},
+		{"nonce required", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.Verify(context.Background(), issuer.sign("rsa-1", tt.overrides), tt.nonce)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifyAcceptsSeveralAudiencesWithAuthorizedParty(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+
+	token := issuer.sign("rsa-1", map[string]any{"aud": []string{"another-client", testClientID}, "azp": testClientID})
+	if _, err := provider.Verify(context.Background(), token, "expected-nonce"); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestVerifyWithoutNonceWhenOptional(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, false)
+
+	if _, err := provider.Verify(context.Background(), issuer.sign("rsa-1", map[string]any{"nonce": nil}), ""); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestVerifyRejectsForgedSignatures(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+
+	t.Run("key not in the key set", func(t *testing.T) {
+		forger := newStandInIssuer(t)
+		token := forger.sign("rsa-1", map[string]any{"iss": issuer.URL()})
+		if _, err := provider.Verify(context.Background(), token, "expected-nonce"); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("symmetric algorithm", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iss": issuer.URL(), "sub": "user-123", "aud": testClientID,
+			"exp": time.Now().Add(time.Minute).Unix(), "nonce": "expected-nonce",
+		})
+		token.Header["kid"] = "rsa-1"
+		signed, err := token.SignedString([]byte("guessed-secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := provider.Verify(context.Background(), signed, "expected-nonce"); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+		}
+	})
+}
+
+func TestVerifySupportsECAndEd25519Keys(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer.addKey("ec-1", ecKey)
+	issuer.addKey("ed-1", edKey)
+
+	for _, kid := range []string{"ec-1", "ed-1"} {
+		if _, err := provider.Verify(context.Background(), issuer.sign(kid, nil), "expected-nonce"); err != nil {
+			t.Errorf("Verify() with %s error = %v", kid, err)
+		}
+	}
+}
+
+func TestKeysAreCachedAndRefetchedOnRotation(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := provider.Verify(ctx, issuer.sign("rsa-1", nil), "expected-nonce"); err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+	}
+	if hits := issuer.hits(); hits != 1 {
+		t.Fatalf("key set fetched %d times, want 1", hits)
+	}
+
+	// A new key right after a fetch isn't picked up yet, so made-up key IDs can't force refetches
+	issuer.addRSAKey("rsa-2")
+	if _, err := provider.Verify(ctx, issuer.sign("rsa-2", nil), "expected-nonce"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify() error = %v, want ErrInvalidToken", err)
+	}
+	if hits := issuer.hits(); hits != 1 {
+		t.Fatalf("key set fetched %d times, want 1", hits)
+	}
+
+	// Once the minimum interval passed, an unknown key ID triggers a refetch
+	provider.keys.fetchedAt = time.Now().Add(-2 * minRefreshInterval)
+	issuer.removeKey("rsa-1")
+	if _, err := provider.Verify(ctx, issuer.sign("rsa-2", nil), "expected-nonce"); err != nil {
+		t.Fatalf("Verify() after rotation error = %v", err)
+	}
+	if hits := issuer.hits(); hits != 2 {
+		t.Fatalf("key set fetched %d times, want 2", hits)
+	}
+}
+
+func TestCachedKeysAreUsedWhileProviderIsDown(t *testing.T) {
+	issuer := newStandInIssuer(t)
+	provider := newTestProvider(t, issuer, true)
+	ctx := context.Background()
+
+	if _, err := provider.Verify(ctx, issuer.sign("rsa-1", nil), "expected-nonce"); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	issuer.mu.Lock()
+	issuer.jwksError = true
+	issuer.mu.Unlock()
+	provider.keys.fetchedAt = time.Now().Add(-2 * time.Hour)
+
+	if _, err := provider.Verify(ctx, issuer.sign("rsa-1", nil), "expected-nonce"); err != nil {
+		t.Errorf("Verify() with stale keys error = %v", err)
+	}
+
+	issuer.addRSAKey("rsa-2")
+	if _, err := provider.Verify(ctx, issuer.sign("rsa-2", nil), "expected-nonce"); !errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("Verify() with unknown key error = %v, want ErrProviderUnavailable", err)
+	}
+}
+
+func TestDiscoveryFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderConfig{
+		Name:      "down",
+		Issuer:    server.URL,
+		ClientIDs: []string{testClientID},
+	}, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Verify(context.Background(), "a.b.c", ""); !errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("Verify() error = %v, want ErrProviderUnavailable", err)
+	}
+}
+
+func TestNonce(t *testing.T) {
+	secret := []byte("nonce-secret")
+
+	nonce, expiresAt, err := NewNonce(secret, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckNonce(secret, nonce, time.Now()); err != nil {
+		t.Errorf("CheckNonce() error = %v", err)
+	}
+	if err := CheckNonce(secret, nonce, expiresAt); !errors.Is(err, ErrInvalidNonce) {
+		t.Errorf("CheckNonce() after expiry error = %v, want ErrInvalidNonce", err)
+	}
+	if err := CheckNonce([]byte("another-secret"), nonce, time.Now()); !errors.Is(err, ErrInvalidNonce) {
+		t.Errorf("CheckNonce() with another secret error = %v, want ErrInvalidNonce", err)
+	}
+	if err := CheckNonce(secret, "expected-nonce", time.Now()); !errors.Is(err, ErrInvalidNonce) {
+		t.Errorf("CheckNonce() with a made-up nonce error = %v, want ErrInvalidNonce", err)
+	}
+}
@@ -0,0 +1,91 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+)
+
+// GoogleProviderName is the name of the provider configured from GOOGLE_CLIENT_ID
+const GoogleProviderName = "google"
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// Registry holds the configured providers by name
+type Registry struct {
+	providers map[string]*Provider
+	order     []string
+}
+
+// NewRegistry creates the providers from configuration. Google is included when a
+// Google client ID is configured.
+func NewRegistry(cfg *config.Config, client *http.Client) (*Registry, error) {
+	r := &Registry{providers: make(map[string]*Provider)}
+	keyCacheTTL := time.Duration(cfg.OIDC.KeyCacheMinutes) * time.Minute
+
+	if cfg.Google.ClientID != "" {
+		google, err := NewProvider(ProviderConfig{
+			Name:        GoogleProviderName,
+			DisplayName: "Google",
+			Issuer:      "https://accounts.google.com",
+			Issuers:     []string{"accounts.google.com"},
+			ClientIDs:   []string{cfg.Google.ClientID},
+			JWKSURL:     "https://www.googleapis.com/oauth2/v3/certs",
+			// The Google sign-in button doesn't hand a nonce through
+			RequireNonce: false,
+			KeyCacheTTL:  keyCacheTTL,
+		}, client)
+		if err != nil {
+			return nil, err
+		}
+		r.add(google)
+	}
+
+	for _, pc := range cfg.OIDC.Providers {
+		if !providerNamePattern.MatchString(pc.Name) {
+			return nil, fmt.Errorf("invalid OIDC provider name: %q", pc.Name)
+		}
+		if _, exists := r.providers[pc.Name]; exists {
+			return nil, fmt.Errorf("OIDC provider %q is configured twice", pc.Name)
+		}
+
+		provider, err := NewProvider(ProviderConfig{
+			Name:         pc.Name,
+			DisplayName:  pc.DisplayName,
+			Issuer:       pc.Issuer,
+			ClientIDs:    pc.ClientIDs,
+			JWKSURL:      pc.JWKSURL,
+			RequireNonce: pc.RequireNonce,
+			KeyCacheTTL:  keyCacheTTL,
+		}, client)
+		if err != nil {
+			return nil, err
+		}
+		r.add(provider)
+	}
+
+	return r, nil
+}
+
+func (r *Registry) add(p *Provider) {
+	r.providers[p.Name()] = p
+	r.order = append(r.order, p.Name())
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(name string) (*Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// List returns the providers in configuration order
+func (r *Registry) List() []*Provider {
+	providers := make([]*Provider, 0, len(r.order))
+	for _, name := range r.order {
+		providers = append(providers, r.providers[name])
+	}
+	return providers
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// Rule limits how many requests one key can make in a window. Rules are named after the
+// route and the kind of key they count, e.g. "login_ip". A zero Limit disables the rule.
+type Rule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// Limiter counts requests against rules
+type Limiter struct {
+	store Store
+	log   *log.Logger
+}
+
+// NewLimiter creates a limiter keeping its counters in store
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{
+		store: store,
+		log:   logger.Service("rate_limit"),
+	}
+}
+
+// Allow counts a request by key under the rule and reports whether it is within the
+// limit; when it isn't, retryAfter is the time left until the window ends. Requests are
+// let through when the store fails, so an outage of the store doesn't lock everyone out.
+func (l *Limiter) Allow(ctx context.Context, rule Rule, key string) (allowed bool, retryAfter time.Duration) {
+	if rule.Limit <= 0 || key == "" {
+		return true, 0
+	}
+
+	counter, err := l.store.Increment(ctx, "rate:"+rule.Name+":"+key, rule.Window)
+	if err != nil {
+		l.log.Error("rate limit check failed, letting the request through", "rule", rule.Name, "error", err)
+		return true, 0
+	}
+
+	if counter.Count <= rule.Limit {
+		return true, 0
+	}
+
+	l.log.Warn("rate limit exceeded", "rule", rule.Name, "key", key, "count", counter.Count)
+	return false, time.Until(counter.ResetAt)
+}
+
+// LockoutPolicy configures the progressive lockout of accounts after failed passwords.
+// Once Threshold failures are counted within Window the account is locked for BaseDelay,
+// doubled for every further failure up to MaxDelay. A zero Threshold disables lockouts.
+type LockoutPolicy struct {
+	Threshold int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Window    time.Duration
+}
+
+// Delay returns how long an account with the given number of failures stays locked
+// after its last failure
+func (p LockoutPolicy) Delay(failures int) time.Duration {
+	if p.Threshold <= 0 || failures < p.Threshold {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.Threshold; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Lockout tracks failed passwords per account and locks accounts that keep failing
+type Lockout struct {
+	store  Store
+	policy LockoutPolicy
+	log    *log.Logger
+}
+
+// NewLockout creates a lockout keeping its counters in store
+func NewLockout(store Store, policy LockoutPolicy) *Lockout {
+	return &Lockout{
+		store:  store,
+		policy: policy,
+		log:    logger.Service("lockout"),
+	}
+}
+
+// Check returns how long the account stays locked, or zero when it isn't locked
+func (l *Lockout) Check(ctx context.Context, account string) time.Duration {
+	_, lockedFor, err := l.Status(ctx, account)
+	if err != nil {
+		l.log.Error("lockout check failed, letting the sign-in through", "error", err)
+		return 0
+	}
+	return lockedFor
+}
+
+// RecordFailure counts a failed password for the account and returns how long the
+// account is now locked
+func (l *Lockout) RecordFailure(ctx context.Context, account string) time.Duration {
+	if l.policy.Threshold <= 0 {
+		return 0
+	}
+
+	counter, err := l.store.Increment(ctx, lockoutKey(account), l.policy.Window)
+	if err != nil {
+		l.log.Error("failed to record failed sign-in", "error", err)
+		return 0
+	}
+
+	lockedFor := l.lockedFor(counter)
+	if lockedFor > 0 {
+		l.log.Warn("account locked after failed sign-ins", "account", NormalizeAccount(account), "failures", counter.Count, "locked_for", lockedFor)
+	}
+	return lockedFor
+}
+
+// Reset clears the failures of the account, after a successful sign-in or when an admin
+// unlocks it
+func (l *Lockout) Reset(ctx context.Context, account string) error {
+	return l.store.Delete(ctx, lockoutKey(account))
+}
+
+// Status returns the failures counted for the account and how long it stays locked
+func (l *Lockout) Status(ctx context.Context, account string) (failures int, lockedFor time.Duration, err error) {
+	if l.policy.Threshold <= 0 {
+		return 0, 0, nil
+	}
+
+	counter, err := l.store.Get(ctx, lockoutKey(account))
+	if err != nil {
+		return 0, 0, err
+	}
+	return counter.Count, l.lockedFor(counter), nil
+}
+
+func (l *Lockout) lockedFor(counter Counter) time.Duration {
+	delay := l.policy.Delay(counter.Count)
+	if delay == 0 {
+		return 0
+	}
+	if remaining := time.Until(counter.LastHit.Add(delay)); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func lockoutKey(account string) string {
+	return "lockout:" + NormalizeAccount(account)
+}
+
+// NormalizeAccount returns the form of an email address used in counter keys
+func NormalizeAccount(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingStore is a Store whose backend is down
+type failingStore struct{}
+
+func (failingStore) Increment(ctx context.Context, key string, window time.Duration) (Counter, error) {
+	return Counter{}, errors.New("store unavailable")
+}
+
+func (failingStore) Get(ctx context.Context, key string) (Counter, error) {
+	return Counter{}, errors.New("store unavailable")
+}
+
+func (failingStore) Delete(ctx context.Context, key string) error {
+	return errors.New("store unavailable")
+}
+
+func TestLockoutPolicyDelay(t *testing.T) {
+	policy := LockoutPolicy{
+		Threshold: 3,
+		BaseDelay: time.Minute,
+		MaxDelay:  10 * time.Minute,
+		Window:    time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		policy   LockoutPolicy
+		failures int
+		want     time.Duration
+	}{
+		{"no failures", policy, 0, 0},
+		{"below threshold", policy, 2, 0},
+		{"at threshold", policy, 3, time.Minute},
+		{"one over threshold", policy, 4, 2 * time.Minute},
+		{"two over threshold", policy, 5, 4 * time.Minute},
+		{"three over threshold", policy, 6, 8 * time.Minute},
+		{"capped at max", policy, 7, 10 * time.Minute},
+		{"far over threshold", policy, 100, 10 * time.Minute},
+		{"disabled", LockoutPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour}, 50, 0},
+		{"base above max", LockoutPolicy{Threshold: 1, BaseDelay: time.Hour, MaxDelay: time.Minute}, 1, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.failures); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		key      string
+		requests int
+		allowed  int
+	}{
+		{"within limit", Rule{Name: "test", Limit: 3, Window: time.Minute}, "ip", 3, 3},
+		{"over limit", Rule{Name: "test", Limit: 2, Window: time.Minute}, "ip", 5, 2},
+		{"disabled rule", Rule{Name: "test", Limit: 0, Window: time.Minute}, "ip", 10, 10},
+		{"empty key", Rule{Name: "test", Limit: 1, Window: time.Minute}, "", 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewLimiter(NewMemoryStore())
+
+			allowed := 0
+			for i := 0; i < tt.requests; i++ {
+				ok, retryAfter := limiter.Allow(context.Background(), tt.rule, tt.key)
+				if ok {
+					allowed++
+					continue
+				}
+				if retryAfter <= 0 || retryAfter > tt.rule.Window {
+					t.Errorf("retryAfter = %v, want within (0, %v]", retryAfter, tt.rule.Window)
+				}
+			}
+			if allowed != tt.allowed {
+				t.Errorf("allowed %d of %d requests, want %d", allowed, tt.requests, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestLimiterLetsRequestsThroughWhenStoreFails(t *testing.T) {
+	limiter := NewLimiter(failingStore{})
+	rule := Rule{Name: "test", Limit: 1, Window: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow(context.Background(), rule, "ip"); !ok {
+			t.Fatalf("request %d was refused while the store is down", i+1)
+		}
+	}
+}
+
+func TestLockoutLocksAfterThreshold(t *testing.T) {
+	ctx := context.Background()
+	lockout := NewLockout(NewMemoryStore(), LockoutPolicy{
+		Threshold: 2,
+		BaseDelay: time.Minute,
+		MaxDelay:  time.Hour,
+		Window:    time.Hour,
+	})
+
+	if lockedFor := lockout.RecordFailure(ctx, "user@example.com"); lockedFor != 0 {
+		t.Fatalf("locked for %v after one failure, want 0", lockedFor)
+	}
+	if lockedFor := lockout.Check(ctx, "user@example.com"); lockedFor != 0 {
+		t.Fatalf("Check after one failure = %v, want 0", lockedFor)
+	}
+
+	// The account is normalized, so case and spaces don't get around the lockout
+	lockedFor := lockout.RecordFailure(ctx, " User@Example.com")
+	if lockedFor <= 0 || lockedFor > time.Minute {
+		t.Fatalf("locked for %v after reaching the threshold, want within (0, 1m]", lockedFor)
+	}
+
+	lockedFor = lockout.RecordFailure(ctx, "user@example.com")
+	if lockedFor <= time.Minute || lockedFor > 2*time.Minute {
+		t.Errorf("locked for %v after one more failure, want within (1m, 2m]", lockedFor)
+	}
+
+	failures, lockedFor, err := lockout.Status(ctx, "USER@example.com")
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if failures != 3 || lockedFor <= 0 {
+		t.Errorf("Status = %d failures, locked for %v; want 3 failures and a lock", failures, lockedFor)
+	}
+
+	if err := lockout.Reset(ctx, "user@example.com"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if lockedFor := lockout.Check(ctx, "user@example.com"); lockedFor != 0 {
+		t.Errorf("Check after Reset = %v, want 0", lockedFor)
+	}
+}
+
+func TestLockoutDisabled(t *testing.T) {
+	ctx := context.Background()
+	lockout := NewLockout(NewMemoryStore(), LockoutPolicy{BaseDelay: time.Minute, MaxDelay: time.Hour, Window: time.Hour})
+
+	for i := 0; i < 10; i++ {
+		if lockedFor := lockout.RecordFailure(ctx, "user@example.com"); lockedFor != 0 {
+			t.Fatalf("disabled lockout locked the account for %v", lockedFor)
+		}
+	}
+}
+
+func TestLockoutLetsSignInThroughWhenStoreFails(t *testing.T) {
+	lockout := NewLockout(failingStore{}, LockoutPolicy{Threshold: 1, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: time.Hour})
+
+	if lockedFor := lockout.RecordFailure(context.Background(), "user@example.com"); lockedFor != 0 {
+		t.Errorf("RecordFailure with a failing store = %v, want 0", lockedFor)
+	}
+	if lockedFor := lockout.Check(context.Background(), "user@example.com"); lockedFor != 0 {
+		t.Errorf("Check with a failing store = %v, want 0", lockedFor)
+	}
+}
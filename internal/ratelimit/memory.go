@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired counters are dropped
+const sweepInterval = time.Minute
+
+// MemoryStore keeps counters in the memory of the process. Each replica counts on its
+// own, so use the Postgres store when running several.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]Counter
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters:  make(map[string]Counter),
+		lastSweep: time.Now(),
+	}
+}
+
+// Increment counts one hit for key
+func (s *MemoryStore) Increment(ctx context.Context, key string, window time.Duration) (Counter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.ResetAt) {
+		counter = Counter{ResetAt: now.Add(window)}
+	}
+	counter.Count++
+	counter.LastHit = now
+	s.counters[key] = counter
+
+	return counter, nil
+}
+
+// Get returns the counter of key, or a zero Counter when its window ended
+func (s *MemoryStore) Get(ctx context.Context, key string) (Counter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !time.Now().Before(counter.ResetAt) {
+		return Counter{}, nil
+	}
+	return counter, nil
+}
+
+// Delete removes the counter of key
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+// sweep drops expired counters so keys seen once don't accumulate. Callers hold the lock.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, counter := range s.counters {
+		if !now.Before(counter.ResetAt) {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreCountsHitsWithinWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		hits int
+	}{
+		{"single hit", 1},
+		{"several hits", 3},
+		{"many hits", 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemoryStore()
+
+			var counter Counter
+			for i := 0; i < tt.hits; i++ {
+				var err error
+				counter, err = store.Increment(ctx, "key", time.Minute)
+				if err != nil {
+					t.Fatalf("Increment: %v", err)
+				}
+			}
+			if counter.Count != tt.hits {
+				t.Errorf("Increment count = %d, want %d", counter.Count, tt.hits)
+			}
+
+			got, err := store.Get(ctx, "key")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.Count != tt.hits {
+				t.Errorf("Get count = %d, want %d", got.Count, tt.hits)
+			}
+			if !got.ResetAt.After(time.Now()) {
+				t.Errorf("ResetAt = %v, want a time in the future", got.ResetAt)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeepsKeysApart(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, _ = store.Increment(ctx, "a", time.Minute)
+	_, _ = store.Increment(ctx, "a", time.Minute)
+	_, _ = store.Increment(ctx, "b", time.Minute)
+
+	for key, want := range map[string]int{"a": 2, "b": 1, "c": 0} {
+		got, _ := store.Get(ctx, key)
+		if got.Count != want {
+			t.Errorf("Get(%q) count = %d, want %d", key, got.Count, want)
+		}
+	}
+}
+
+func TestMemoryStoreStartsOverAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	window := 20 * time.Millisecond
+
+	_, _ = store.Increment(ctx, "key", window)
+	_, _ = store.Increment(ctx, "key", window)
+	time.Sleep(2 * window)
+
+	if got, _ := store.Get(ctx, "key"); got != (Counter{}) {
+		t.Errorf("Get after the window = %+v, want a zero Counter", got)
+	}
+
+	counter, _ := store.Increment(ctx, "key", window)
+	if counter.Count != 1 {
+		t.Errorf("Increment after the window count = %d, want 1", counter.Count)
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, _ = store.Increment(ctx, "key", time.Minute)
+	if err := store.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if got, _ := store.Get(ctx, "key"); got.Count != 0 {
+		t.Errorf("Get after Delete count = %d, want 0", got.Count)
+	}
+	if err := store.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func TestMemoryStoreSweepsExpiredCounters(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	store.counters["expired"] = Counter{Count: 1, ResetAt: now.Add(-time.Second)}
+	store.counters["current"] = Counter{Count: 1, ResetAt: now.Add(time.Hour)}
+
+	store.sweep(now)
+	if len(store.counters) != 2 {
+		t.Fatalf("sweep before the interval dropped counters: %d left", len(store.counters))
+	}
+
+	store.sweep(now.Add(sweepInterval))
+	if _, ok := store.counters["expired"]; ok {
+		t.Error("expired counter survived the sweep")
+	}
+	if _, ok := store.counters["current"]; !ok {
+		t.Error("current counter was swept")
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAccountBody caps how much of a request body ByAccount reads to find the email
+const maxAccountBody = 64 << 10
+
+// KeyFunc returns the key a rule counts a request by; an empty key skips the rule
+type KeyFunc func(c *gin.Context) string
+
+// ByIP counts requests by client address. Forwarded addresses are only used from the
+// trusted proxies set on the router.
+func ByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// ByAccount counts requests by the signed-in user or, on public routes, by the email in
+// the JSON body. The body is left intact for the handler.
+func ByAccount(c *gin.Context) string {
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(string); ok && id != "" {
+			return "user:" + id
+		}
+	}
+
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAccountBody))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return NormalizeAccount(payload.Email)
+}
+
+// Limit is a middleware that rejects requests over the rule with 429 Too Many Requests
+// and a Retry-After header
+func (l *Limiter) Limit(rule Rule, key KeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := l.Allow(c.Request.Context(), rule, key(c))
+		if allowed {
+			c.Next()
+			return
+		}
+
+		c.JSON(429, gin.H{
+			"error":       "TOO_MANY_REQUESTS",
+			"message":     "Too many requests, please try again later",
+			"retry_after": SetRetryAfter(c, retryAfter),
+		})
+		c.Abort()
+	}
+}
+
+// SetRetryAfter sets the Retry-After header in whole seconds, at least one, and returns
+// the seconds set
+func SetRetryAfter(c *gin.Context, retryAfter time.Duration) int {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	return seconds
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresStore keeps counters in the rate_limit_counters table so every replica shares
+// them. Windows are measured with the database clock.
+type PostgresStore struct {
+	db  *gorm.DB
+	log *log.Logger
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresStore creates a store backed by the rate_limit_counters table
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{
+		db:        db,
+		log:       logger.Repository("rate_limit"),
+		lastSweep: time.Now(),
+	}
+}
+
+type counterRow struct {
+	Count     int
+	ResetAt   time.Time
+	LastHitAt time.Time
+}
+
+// Increment counts one hit for key with a single upsert, so concurrent hits from several
+// replicas are all counted
+func (s *PostgresStore) Increment(ctx context.Context, key string, window time.Duration) (Counter, error) {
+	s.sweep(ctx)
+
+	var row counterRow
+	err := s.db.WithContext(ctx).Raw(`
+		INSERT INTO rate_limit_counters (bucket_key, count, reset_at, last_hit_at)
+		VALUES (?, 1, NOW() + ? * INTERVAL '1 millisecond', NOW())
+		ON CONFLICT (bucket_key) DO UPDATE SET
+			count       = CASE WHEN rate_limit_counters.reset_at <= NOW() THEN 1 ELSE rate_limit_counters.count + 1 END,
+			reset_at    = CASE WHEN rate_limit_counters.reset_at <= NOW() THEN EXCLUDED.reset_at ELSE rate_limit_counters.reset_at END,
+			last_hit_at = NOW()
+		RETURNING count, reset_at, last_hit_at`,
+		key, window.Milliseconds(),
+	).Scan(&row).Error
+	if err != nil {
+		s.log.Error("failed to increment rate limit counter", "key", key, "error", err)
+		return Counter{}, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+
+	return Counter{Count: row.Count, ResetAt: row.ResetAt, LastHit: row.LastHitAt}, nil
+}
+
+// Get returns the counter of key, or a zero Counter when its window ended
+func (s *PostgresStore) Get(ctx context.Context, key string) (Counter, error) {
+	var rows []counterRow
+	err := s.db.WithContext(ctx).Raw(
+		`SELECT count, reset_at, last_hit_at FROM rate_limit_counters WHERE bucket_key = ? AND reset_at > NOW()`,
+		key,
+	).Scan(&rows).Error
+	if err != nil {
+		s.log.Error("failed to get rate limit counter", "key", key, "error", err)
+		return Counter{}, fmt.Errorf("failed to get rate limit counter: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return Counter{}, nil
+	}
+	return Counter{Count: rows[0].Count, ResetAt: rows[0].ResetAt, LastHit: rows[0].LastHitAt}, nil
+}
+
+// Delete removes the counter of key
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	if err := s.db.WithContext(ctx).Exec(`DELETE FROM rate_limit_counters WHERE bucket_key = ?`, key).Error; err != nil {
+		s.log.Error("failed to delete rate limit counter", "key", key, "error", err)
+		return fmt.Errorf("failed to delete rate limit counter: %w", err)
+	}
+	return nil
+}
+
+// sweep deletes expired counters, at most once per sweepInterval for each replica
+func (s *PostgresStore) sweep(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	result := s.db.WithContext(ctx).Exec(`DELETE FROM rate_limit_counters WHERE reset_at <= NOW()`)
+	if result.Error != nil {
+		s.log.Warn("failed to delete expired rate limit counters", "error", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		s.log.Debug("expired rate limit counters deleted", "count", result.RowsAffected)
+	}
+}
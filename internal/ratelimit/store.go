@@ -0,0 +1,47 @@
+// Package ratelimit throttles requests and locks out accounts after repeated failed
+// sign-ins. Counters live in a pluggable Store: in memory for a single instance, or in
+// Postgres when several replicas must share them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/config"
+)
+
+// Counter is the state of a fixed-window counter. A zero Counter means nothing was
+// counted in the current window.
+type Counter struct {
+	Count   int
+	ResetAt time.Time // End of the window; the counter starts over after it
+	LastHit time.Time
+}
+
+// Store keeps fixed-window counters. Implementations must be safe for concurrent use.
+type Store interface {
+	// Increment counts one hit for key and returns the updated counter. A new window of
+	// the given length starts when the key has no counter or its window ended.
+	Increment(ctx context.Context, key string, window time.Duration) (Counter, error)
+	// Get returns the counter of key without counting a hit
+	Get(ctx context.Context, key string) (Counter, error)
+	// Delete removes the counter of key
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore creates a Store based on configuration
+func NewStore(cfg *config.Config, db *gorm.DB) (Store, error) {
+	switch cfg.RateLimit.Store {
+	case "memory":
+		return NewMemoryStore(), nil
+
+	case "postgres":
+		return NewPostgresStore(db), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s (must be 'memory' or 'postgres')", cfg.RateLimit.Store)
+	}
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+)
+
+// maxBlockedBackoff caps the wait between attempts to advance a blocked event, so fixing
+// the cause (uploading proposals, correcting the voting configuration) is picked up the
+// same day even when nobody touches the event itself
+const maxBlockedBackoff = 6 * time.Hour
+
+// blockedEvent is an overdue event whose automatic transition was refused
+type blockedEvent struct {
+	stage    event.Stage
+	endDate  time.Time
+	reason   string
+	attempts int
+	retryAt  time.Time
+}
+
+// blockList remembers the events the scheduler could not advance, so each block is
+// reported once and retried with exponential backoff instead of on every run.
+// Changing the event's stage or estimated end date clears its block.
+type blockList struct {
+	mu     sync.Mutex
+	base   time.Duration
+	events map[string]*blockedEvent
+}
+
+func newBlockList(base time.Duration) *blockList {
+	return &blockList{
+		base:   base,
+		events: make(map[string]*blockedEvent),
+	}
+}
+
+// waiting reports whether evt is blocked and its next attempt is still ahead
+func (b *blockList) waiting(evt *event.Event, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	blocked := b.current(evt)
+	return blocked != nil && now.Before(blocked.retryAt)
+}
+
+// block records a refused transition of evt and schedules the next attempt. It also
+// reports whether the block is new or its reason changed, and should be logged.
+func (b *blockList) block(evt *event.Event, reason string, now time.Time) (*blockedEvent, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	blocked := b.current(evt)
+	if blocked == nil {
+		blocked = &blockedEvent{stage: evt.Stage, endDate: estimatedEndDate(evt)}
+		b.events[evt.ID.String()] = blocked
+	}
+
+	report := blocked.attempts == 0 || blocked.reason != reason
+	blocked.reason = reason
+	blocked.attempts++
+
+	delay := b.base
+	for i := 1; i < blocked.attempts && delay < maxBlockedBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBlockedBackoff {
+		delay = maxBlockedBackoff
+	}
+	blocked.retryAt = now.Add(delay)
+
+	return blocked, report
+}
+
+// clear forgets the block of an event
+func (b *blockList) clear(eventID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.events, eventID)
+}
+
+// retain forgets the blocks of events that are no longer due
+func (b *blockList) retain(due []*event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ids := make(map[string]bool, len(due))
+	for _, evt := range due {
+		ids[evt.ID.String()] = true
+	}
+	for id := range b.events {
+		if !ids[id] {
+			delete(b.events, id)
+		}
+	}
+}
+
+// current returns the block of evt, dropping it when the event moved on since
+func (b *blockList) current(evt *event.Event) *blockedEvent {
+	id := evt.ID.String()
+	blocked, ok := b.events[id]
+	if !ok {
+		return nil
+	}
+	if blocked.stage != evt.Stage || !blocked.endDate.Equal(estimatedEndDate(evt)) {
+		delete(b.events, id)
+		return nil
+	}
+	return blocked
+}
+
+func estimatedEndDate(evt *event.Event) time.Time {
+	if endDate := evt.StageEstimatedEndDate(); endDate != nil {
+		return *endDate
+	}
+	return time.Time{}
+}
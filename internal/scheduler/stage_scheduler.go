@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/stage"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// StageScheduler advances events whose estimated stage end date has passed.
+// Each event is processed inside its own transaction guarded by a Postgres advisory
+// lock, so several API replicas can run the scheduler concurrently.
+// Events it can't advance (too few proposals, no voting length configured) are reported
+// once and retried with exponential backoff.
+type StageScheduler struct {
+	container  *postgres.Container
+	interval   time.Duration
+	votingDays int
+	blocked    *blockList
+	log        *log.Logger
+}
+
+// NewStageScheduler creates a scheduler that polls for due events every interval.
+// Voting stages it opens last votingDays; with votingDays 0 it leaves events at the end
+// of participation for an organizer to open voting.
+func NewStageScheduler(container *postgres.Container, interval time.Duration, votingDays int) *StageScheduler {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	return &StageScheduler{
+		container:  container,
+		interval:   interval,
+		votingDays: votingDays,
+		blocked:    newBlockList(interval),
+		log:        logger.Service("stage_scheduler"),
+	}
+}
+
+// Start runs the scheduler until the context is cancelled
+func (s *StageScheduler) Start(ctx context.Context) {
+	s.log.Info("stage scheduler started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(time.Now())
+
+		select {
+		case <-ctx.Done():
+			s.log.Info("stage scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce advances every event that is due at the given time and returns how many moved
+func (s *StageScheduler) RunOnce(now time.Time) int {
+	dueEvents, err := s.container.Events().GetDueForStageTransition(now)
+	if err != nil {
+		s.log.Error("failed to list events due for stage transition", "error", err)
+		return 0
+	}
+
+	s.blocked.retain(dueEvents)
+
+	advanced := 0
+	for _, evt := range dueEvents {
+		if s.blocked.waiting(evt, now) {
+			continue
+		}
+		if s.advance(evt.ID.String(), now) {
+			advanced++
+		}
+	}
+
+	if len(dueEvents) > 0 {
+		s.log.Debug("stage scheduler run finished", "due", len(dueEvents), "advanced", advanced)
+	}
+
+	return advanced
+}
+
+// advance moves a single event to its next stage. Returns true when a transition was committed.
+func (s *StageScheduler) advance(eventID string, now time.Time) bool {
+	tx, err := s.container.BeginTransaction()
+	if err != nil {
+		s.log.Error("failed to begin stage transition transaction", "event_id", eventID, "error", err)
+		return false
+	}
+
+	acquired, err := tx.TryAdvisoryLock(stage.LockKey(eventID))
+	if err != nil || !acquired {
+		_ = tx.Rollback()
+		if err == nil {
+			s.log.Debug("event locked by another scheduler, skipping", "event_id", eventID)
+		}
+		return false
+	}
+
+	// Re-read under the lock: another replica may already have advanced this event
+	evt, err := tx.Events().GetByID(eventID)
+	if err != nil {
+		_ = tx.Rollback()
+		s.log.Error("failed to load event for stage transition", "event_id", eventID, "error", err)
+		return false
+	}
+
+	if !evt.IsStageOverdue(now) {
+		_ = tx.Rollback()
+		return false
+	}
+
+	fromStage := evt.Stage
+	endDate := evt.StageEstimatedEndDate()
+	before := *evt // AdvanceStage updates evt before generating assignments, which may still fail
+
+	service := stage.NewService(tx.Events(), tx.Users(), tx.Attachments(), tx.Votes(), tx.VotingConfigurations(), tx.EventHistory())
+	toStage, generated, err := service.AdvanceStage(evt, s.nextEstimatedEndDate(evt, now))
+	if err != nil {
+		_ = tx.Rollback()
+		s.reportBlocked(&before, err, now)
+		return false
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.log.Error("failed to commit stage transition", "event_id", eventID, "error", err)
+		return false
+	}
+	s.blocked.clear(eventID)
+
+	s.log.Info("event stage advanced automatically",
+		"event_id", eventID,
+		"from", fromStage.String(),
+		"to", toStage.String(),
+		"estimated_end_date", endDate.Format("2006-01-02"),
+		"assignments_generated", generated)
+
+	return true
+}
+
+// nextEstimatedEndDate returns the estimated end date of the stage evt moves to: voting
+// lasts votingDays from today. Nil when the next stage has no end date or votingDays is 0.
+func (s *StageScheduler) nextEstimatedEndDate(evt *event.Event, now time.Time) *time.Time {
+	next, ok := evt.NextStage()
+	if !ok || next != event.StageVoting || s.votingDays <= 0 {
+		return nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := today.AddDate(0, 0, s.votingDays)
+	return &endDate
+}
+
+// reportBlocked records a refused transition, logging it the first time and when the
+// reason changes
+func (s *StageScheduler) reportBlocked(evt *event.Event, reason error, now time.Time) {
+	blocked, report := s.blocked.block(evt, reason.Error(), now)
+
+	logFn := s.log.Debug
+	if report {
+		logFn = s.log.Warn
+	}
+	logFn("automatic stage transition blocked",
+		"event_id", evt.ID,
+		"stage", evt.Stage.String(),
+		"estimated_end_date", estimatedEndDate(evt).Format("2006-01-02"),
+		"reason", reason,
+		"attempts", blocked.attempts,
+		"retry_at", blocked.retryAt)
+}
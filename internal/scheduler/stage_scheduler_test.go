@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+)
+
+func overdueEvent(stage event.Stage, endDate string) *event.Event {
+	d, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		panic(err)
+	}
+	evt := &event.Event{ID: uuid.New(), Stage: stage}
+	if stage == event.StageVoting {
+		evt.VotingEstimatedEndDate = &d
+	} else {
+		evt.ParticipationEstimatedEndDate = &d
+	}
+	return evt
+}
+
+func TestBlockListBacksOff(t *testing.T) {
+	blocked := newBlockList(5 * time.Minute)
+	evt := overdueEvent(event.StageParticipation, "2026-10-01")
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	if blocked.waiting(evt, now) {
+		t.Fatal("event waiting before it was ever blocked")
+	}
+
+	wantDelays := []time.Duration{5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 40 * time.Minute}
+	for i, want := range wantDelays {
+		entry, report := blocked.block(evt, "at least 2 attachments are required for voting", now)
+		if report != (i == 0) {
+			t.Errorf("attempt %d: report = %v, want %v", i+1, report, i == 0)
+		}
+		if got := entry.retryAt.Sub(now); got != want {
+			t.Errorf("attempt %d: retry after %v, want %v", i+1, got, want)
+		}
+
+		if !blocked.waiting(evt, now.Add(want-time.Second)) {
+			t.Errorf("attempt %d: not waiting before the retry time", i+1)
+		}
+		if blocked.waiting(evt, now.Add(want)) {
+			t.Errorf("attempt %d: still waiting at the retry time", i+1)
+		}
+	}
+}
+
+func TestBlockListCapsBackoff(t *testing.T) {
+	blocked := newBlockList(5 * time.Minute)
+	evt := overdueEvent(event.StageParticipation, "2026-10-01")
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	var entry *blockedEvent
+	for i := 0; i < 100; i++ {
+		entry, _ = blocked.block(evt, "blocked", now)
+	}
+	if got := entry.retryAt.Sub(now); got != maxBlockedBackoff {
+		t.Errorf("retry after %v, want the %v cap", got, maxBlockedBackoff)
+	}
+}
+
+func TestBlockListReportsNewReasons(t *testing.T) {
+	blocked := newBlockList(time.Minute)
+	evt := overdueEvent(event.StageParticipation, "2026-10-01")
+	now := time.Now()
+
+	if _, report := blocked.block(evt, "cannot move to voting stage without attachments", now); !report {
+		t.Error("first block not reported")
+	}
+	if _, report := blocked.block(evt, "cannot move to voting stage without attachments", now); report {
+		t.Error("repeated block reported again")
+	}
+	if _, report := blocked.block(evt, "at least 2 attachments are required for voting", now); !report {
+		t.Error("block with a new reason not reported")
+	}
+}
+
+func TestBlockListClearsWhenTheEventChanges(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change func(evt *event.Event)
+	}{
+		{"estimated end date moved", func(evt *event.Event) {
+			later := evt.ParticipationEstimatedEndDate.AddDate(0, 0, 7)
+			evt.ParticipationEstimatedEndDate = &later
+		}},
+		{"stage changed", func(evt *event.Event) {
+			evt.Stage = event.StageVoting
+			evt.VotingEstimatedEndDate = evt.ParticipationEstimatedEndDate
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := newBlockList(time.Hour)
+			evt := overdueEvent(event.StageParticipation, "2026-10-01")
+			blocked.block(evt, "blocked", now)
+
+			tt.change(evt)
+			if blocked.waiting(evt, now) {
+				t.Error("changed event still waiting")
+			}
+			if _, report := blocked.block(evt, "blocked", now); !report {
+				t.Error("block of the changed event not reported as new")
+			}
+		})
+	}
+}
+
+func TestBlockListForgetsEvents(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	blocked := newBlockList(time.Hour)
+	first := overdueEvent(event.StageParticipation, "2026-10-01")
+	second := overdueEvent(event.StageVoting, "2026-10-02")
+	blocked.block(first, "blocked", now)
+	blocked.block(second, "blocked", now)
+
+	blocked.retain([]*event.Event{second})
+	if blocked.waiting(first, now) {
+		t.Error("event no longer due still waiting")
+	}
+	if !blocked.waiting(second, now) {
+		t.Error("event still due no longer waiting")
+	}
+
+	blocked.clear(second.ID.String())
+	if blocked.waiting(second, now) {
+		t.Error("cleared event still waiting")
+	}
+}
+
+func TestNextEstimatedEndDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		stage      event.Stage
+		votingDays int
+		want       string // empty for no date
+	}{
+		{"voting lasts the configured days", event.StageParticipation, 14, "2026-11-01"},
+		{"voting not opened automatically", event.StageParticipation, 0, ""},
+		{"results have no end date", event.StageVoting, 14, ""},
+		{"final stage", event.StageResult, 14, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StageScheduler{votingDays: tt.votingDays}
+			got := s.nextEstimatedEndDate(&event.Event{Stage: tt.stage}, now)
+
+			switch {
+			case tt.want == "" && got != nil:
+				t.Errorf("nextEstimatedEndDate = %v, want nil", got)
+			case tt.want != "" && (got == nil || got.Format("2006-01-02") != tt.want):
+				t.Errorf("nextEstimatedEndDate = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Package stage moves events between stages: the transition rules shared by manual
+// stage updates and the stage scheduler, voting assignment generation and rollbacks.
+package stage
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/attachment"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/vote"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// MinAttachmentsForVoting is the minimum number of proposals needed for a meaningful ranking
+const MinAttachmentsForVoting = 2
+
+var (
+	ErrInvalidTransition       = errors.New("invalid stage transition")
+	ErrNoAttachments           = errors.New("cannot move to voting stage without attachments")
+	ErrInsufficientAttachments = fmt.Errorf("at least %d attachments are required for voting", MinAttachmentsForVoting)
+	ErrInvalidRollbackPolicy   = errors.New("invalid rollback policy")
+	ErrMissingEstimatedDate    = errors.New("an estimated end date is required for the participation and voting stages")
+)
+
+// Service holds the business rules shared by manual stage updates
+// (UpdateEventStage) and the automatic stage scheduler
+type Service struct {
+	eventRepo      postgres.EventRepository
+	userRepo       postgres.UserRepository
+	attachmentRepo postgres.AttachmentRepository
+	voteRepo       postgres.VoteRepository
+	configRepo     postgres.VotingConfigurationRepository
+	historyRepo    postgres.EventHistoryRepository
+	votingService  *vote.VotingService
+	log            *log.Logger
+}
+
+// NewService creates a stage transition service over the given repositories.
+// Pass repositories from a TransactionContainer to run the transition atomically.
+func NewService(
+	eventRepo postgres.EventRepository,
+	userRepo postgres.UserRepository,
+	attachmentRepo postgres.AttachmentRepository,
+	voteRepo postgres.VoteRepository,
+	configRepo postgres.VotingConfigurationRepository,
+	historyRepo postgres.EventHistoryRepository,
+) *Service {
+	return &Service{
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		voteRepo:       voteRepo,
+		configRepo:     configRepo,
+		historyRepo:    historyRepo,
+		votingService: vote.NewVotingService(
+			postgres.NewVoteRepositoryAdapter(voteRepo),
+			postgres.NewAttachmentRepositoryAdapter(attachmentRepo),
+			postgres.NewUserRepositoryAdapter(userRepo),
+		),
+		log: logger.Service("stage_transition"),
+	}
+}
+
+// ValidateVotingReadiness checks that an event has enough attachments to start voting.
+// Returns the attachment count together with ErrNoAttachments or ErrInsufficientAttachments.
+func (s *Service) ValidateVotingReadiness(eventID string) (int, error) {
+	attachments, err := s.attachmentRepo.GetByEventID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	if len(attachments) == 0 {
+		return 0, ErrNoAttachments
+	}
+
+	if len(attachments) < MinAttachmentsForVoting {
+		return len(attachments), ErrInsufficientAttachments
+	}
+
+	return len(attachments), nil
+}
+
+// ValidateTransition applies the forward-transition rules and the stage-specific preconditions
+func (s *Service) ValidateTransition(evt *event.Event, newStage event.Stage) error {
+	if !evt.CanTransitionTo(newStage) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, evt.Stage, newStage)
+	}
+
+	if newStage == event.StageVoting {
+		if _, err := s.ValidateVotingReadiness(evt.ID.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateAssignmentsIfConfigured creates voting assignments when the event has a voting
+// configuration and no assignments yet. Returns the number of assignments created.
+func (s *Service) GenerateAssignmentsIfConfigured(evt *event.Event) (int, error) {
+	eventID := evt.ID.String()
+
+	config, err := s.configRepo.GetByEventID(eventID)
+	if err != nil {
+		s.log.Debug("no voting configuration, skipping assignment generation", "event_id", eventID)
+		return 0, nil
+	}
+
+	existingAssignments, err := s.voteRepo.GetAssignmentsByEventID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check existing assignments: %w", err)
+	}
+	if len(existingAssignments) > 0 {
+		s.log.Debug("assignments already exist, skipping generation", "event_id", eventID, "count", len(existingAssignments))
+		return 0, nil
+	}
+
+	participantUsers, err := s.userRepo.GetEventParticipants(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	participants := EvaluatorIDs(participantUsers)
+	if len(participants) < 2 {
+		return 0, fmt.Errorf("at least 2 evaluators are required for distributed voting, have %d", len(participants))
+	}
+
+	attachments, err := s.attachmentRepo.GetByEventID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	attachmentIDs := AttachmentIDs(attachments)
+
+	if err := s.votingService.ValidateVotingConfigurationForEvaluators(config, participants, attachmentIDs); err != nil {
+		return 0, fmt.Errorf("voting configuration is no longer valid: %w", err)
+	}
+
+	assignments, err := s.votingService.GenerateAssignments(evt.ID, participants, attachmentIDs, config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate assignments: %w", err)
+	}
+
+	for _, assignment := range assignments {
+		if err := s.voteRepo.CreateAssignment(assignment); err != nil {
+			return 0, fmt.Errorf("failed to save assignment: %w", err)
+		}
+	}
+
+	s.log.Info("assignments generated automatically",
+		"event_id", eventID,
+		"assignments", len(assignments),
+		"participants", len(participants),
+		"attachments", len(attachmentIDs))
+
+	return len(assignments), nil
+}
+
+// AdvanceStage moves an event to its next stage after validating the transition and
+// records it in the stage history as a scheduler-triggered change.
+// estimatedDate is the estimated end date of the new stage; like manual stage updates, the
+// participation and voting stages can't be entered without one (ErrMissingEstimatedDate).
+// Entering voting freezes the proposal file versions reviewers get and generates assignments
+// when a voting configuration exists.
+// Returns the new stage and the number of assignments generated.
+func (s *Service) AdvanceStage(evt *event.Event, estimatedDate *time.Time) (event.Stage, int, error) {
+	newStage, ok := evt.NextStage()
+	if !ok {
+		return evt.Stage, 0, fmt.Errorf("%w: %s is a final stage", ErrInvalidTransition, evt.Stage)
+	}
+
+	if err := s.ValidateTransition(evt, newStage); err != nil {
+		return evt.Stage, 0, err
+	}
+
+	if estimatedDate == nil && (newStage == event.StageParticipation || newStage == event.StageVoting) {
+		return evt.Stage, 0, ErrMissingEstimatedDate
+	}
+
+	if newStage == event.StageVoting {
+		if err := s.attachmentRepo.FreezeReviewFiles(evt.ID.String()); err != nil {
+			return evt.Stage, 0, err
+		}
+	}
+
+	if err := s.eventRepo.UpdateStageWithEstimatedDate(evt.ID.String(), newStage, estimatedDate); err != nil {
+		return evt.Stage, 0, fmt.Errorf("failed to update event stage: %w", err)
+	}
+
+	if err := s.RecordTransition(evt.ID, evt.Stage, newStage, event.TriggerScheduler, nil); err != nil {
+		return evt.Stage, 0, err
+	}
+
+	generated := 0
+	if newStage == event.StageVoting {
+		evt.Stage = newStage
+		count, err := s.GenerateAssignmentsIfConfigured(evt)
+		if err != nil {
+			return newStage, 0, err
+		}
+		generated = count
+	}
+
+	return newStage, generated, nil
+}
+
+// RecordTransition appends a forward stage change to the event history log.
+// actorID is nil for changes made by the scheduler.
+func (s *Service) RecordTransition(eventID uuid.UUID, from, to event.Stage, trigger event.TransitionTrigger, actorID *uuid.UUID) error {
+	entry := &event.StageHistoryEntry{
+		EventID:   eventID,
+		FromStage: from,
+		ToStage:   to,
+		Direction: event.DirectionForward,
+		Trigger:   trigger,
+		ActorID:   actorID,
+	}
+
+	if err := s.historyRepo.Create(entry); err != nil {
+		return fmt.Errorf("failed to record stage transition: %w", err)
+	}
+
+	return nil
+}
+
+// RevertStage rolls an event back to the previous stage. The rollback policy is applied
+// to assignments, votes and drafts, stored results are archived and the change is recorded
+// in the stage history together with the actor and justification.
+// Run it over repositories from a TransactionContainer so the rollback is all-or-nothing.
+func (s *Service) RevertStage(
+	evt *event.Event,
+	targetStage event.Stage,
+	policy event.RollbackPolicy,
+	justification string,
+	actorID uuid.UUID,
+	estimatedDate *time.Time,
+) (*event.StageHistoryEntry, error) {
+	if !evt.CanRevertTo(targetStage) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, evt.Stage, targetStage)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRollbackPolicy, err)
+	}
+
+	eventID := evt.ID.String()
+	entry := &event.StageHistoryEntry{
+		ID:            uuid.New(),
+		EventID:       evt.ID,
+		FromStage:     evt.Stage,
+		ToStage:       targetStage,
+		Direction:     event.DirectionReverse,
+		Trigger:       event.TriggerManual,
+		ActorID:       &actorID,
+		Justification: justification,
+		Policy:        &policy,
+	}
+
+	summary, err := s.historyRepo.ApplyRollbackPolicy(eventID, entry.ID, policy)
+	if err != nil {
+		return nil, err
+	}
+	entry.Summary = summary
+
+	if err := s.eventRepo.UpdateStageWithEstimatedDate(eventID, targetStage, estimatedDate); err != nil {
+		return nil, fmt.Errorf("failed to update event stage: %w", err)
+	}
+
+	if err := s.historyRepo.Create(entry); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("event stage reverted",
+		"event_id", eventID,
+		"from", entry.FromStage.String(),
+		"to", targetStage.String(),
+		"actor_id", actorID)
+
+	return entry, nil
+}
+
+// LockKey maps an event ID to the advisory lock key taken by everything that changes the
+// event's stage, so the stage scheduler and organizers never move an event concurrently
+func LockKey(eventID string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("telescopio:event_stage:" + eventID))
+	return int64(h.Sum64())
+}
+
+// EvaluatorIDs returns the event members who take part in the distributed evaluation:
+// participants and reviewers. Co-organizers and observers are left out.
+func EvaluatorIDs(members []*participant.UserWithEventRole) []uuid.UUID {
+	evaluators := make([]uuid.UUID, 0, len(members))
+	for _, m := range members {
+		if event.EventParticipantRole(m.EventRole).CanReview() {
+			evaluators = append(evaluators, m.ID)
+		}
+	}
+	return evaluators
+}
+
+// AttachmentIDs returns the IDs of the given attachments
+func AttachmentIDs(attachments []*attachment.Attachment) []uuid.UUID {
+	ids := make([]uuid.UUID, len(attachments))
+	for i, a := range attachments {
+		ids[i] = a.ID
+	}
+	return ids
+}
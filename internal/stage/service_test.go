@@ -0,0 +1,371 @@
+package stage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/attachment"
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/vote"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+// The fakes embed the repository interfaces and implement only what the service calls;
+// anything else panics
+
+type fakeEvents struct {
+	postgres.EventRepository
+	stage         event.Stage
+	estimatedDate *time.Time
+	updates       int
+}
+
+func (f *fakeEvents) UpdateStageWithEstimatedDate(eventID string, stage event.Stage, estimatedDate *time.Time) error {
+	f.stage = stage
+	f.estimatedDate = estimatedDate
+	f.updates++
+	return nil
+}
+
+type fakeAttachments struct {
+	postgres.AttachmentRepository
+	attachments []*attachment.Attachment
+	frozen      bool
+}
+
+func (f *fakeAttachments) GetByEventID(eventID string) ([]*attachment.Attachment, error) {
+	return f.attachments, nil
+}
+
+func (f *fakeAttachments) GetByID(id string) (*attachment.Attachment, error) {
+	for _, a := range f.attachments {
+		if a.ID.String() == id {
+			return a, nil
+		}
+	}
+	return nil, errors.New("attachment not found")
+}
+
+func (f *fakeAttachments) FreezeReviewFiles(eventID string) error {
+	f.frozen = true
+	return nil
+}
+
+type fakeUsers struct {
+	postgres.UserRepository
+	members []*participant.UserWithEventRole
+}
+
+func (f *fakeUsers) GetEventParticipants(eventID string) ([]*participant.UserWithEventRole, error) {
+	return f.members, nil
+}
+
+type fakeVotes struct {
+	postgres.VoteRepository
+	assignments []*vote.Assignment
+}
+
+func (f *fakeVotes) GetAssignmentsByEventID(eventID string) ([]*vote.Assignment, error) {
+	return f.assignments, nil
+}
+
+func (f *fakeVotes) CreateAssignment(assignment *vote.Assignment) error {
+	f.assignments = append(f.assignments, assignment)
+	return nil
+}
+
+type fakeConfigs struct {
+	postgres.VotingConfigurationRepository
+	config *vote.VotingConfiguration
+}
+
+func (f *fakeConfigs) GetByEventID(eventID string) (*vote.VotingConfiguration, error) {
+	if f.config == nil {
+		return nil, postgres.ErrVotingConfigNotFound
+	}
+	return f.config, nil
+}
+
+type fakeHistory struct {
+	postgres.EventHistoryRepository
+	entries  []*event.StageHistoryEntry
+	policies []event.RollbackPolicy
+}
+
+func (f *fakeHistory) Create(entry *event.StageHistoryEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func (f *fakeHistory) ApplyRollbackPolicy(eventID string, historyID uuid.UUID, policy event.RollbackPolicy) (*event.RollbackSummary, error) {
+	f.policies = append(f.policies, policy)
+	return &event.RollbackSummary{}, nil
+}
+
+type fixture struct {
+	events      *fakeEvents
+	attachments *fakeAttachments
+	users       *fakeUsers
+	votes       *fakeVotes
+	configs     *fakeConfigs
+	history     *fakeHistory
+	service     *Service
+}
+
+// newFixture sets up an event whose members each submitted one proposal
+func newFixture(members int) *fixture {
+	f := &fixture{
+		events:      &fakeEvents{},
+		attachments: &fakeAttachments{},
+		users:       &fakeUsers{},
+		votes:       &fakeVotes{},
+		configs:     &fakeConfigs{},
+		history:     &fakeHistory{},
+	}
+	for i := 0; i < members; i++ {
+		user := participant.User{ID: uuid.New()}
+		f.users.members = append(f.users.members, &participant.UserWithEventRole{User: user, EventRole: string(event.RoleParticipant)})
+		f.attachments.attachments = append(f.attachments.attachments, &attachment.Attachment{ID: uuid.New(), ParticipantID: user.ID})
+	}
+	f.service = NewService(f.events, f.users, f.attachments, f.votes, f.configs, f.history)
+	return f
+}
+
+func date(value string) *time.Time {
+	d, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func TestAdvanceStageToVoting(t *testing.T) {
+	f := newFixture(3)
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageParticipation}
+	endDate := date("2026-11-01")
+
+	to, generated, err := f.service.AdvanceStage(evt, endDate)
+	if err != nil {
+		t.Fatalf("AdvanceStage: %v", err)
+	}
+
+	if to != event.StageVoting || f.events.stage != event.StageVoting {
+		t.Errorf("stage = %s (stored %s), want voting", to, f.events.stage)
+	}
+	if f.events.estimatedDate != endDate {
+		t.Errorf("estimated end date = %v, want %v", f.events.estimatedDate, endDate)
+	}
+	if !f.attachments.frozen {
+		t.Error("review files were not frozen")
+	}
+	if generated != 0 {
+		t.Errorf("generated %d assignments without a voting configuration, want 0", generated)
+	}
+
+	if len(f.history.entries) != 1 {
+		t.Fatalf("recorded %d history entries, want 1", len(f.history.entries))
+	}
+	entry := f.history.entries[0]
+	if entry.FromStage != event.StageParticipation || entry.ToStage != event.StageVoting ||
+		entry.Direction != event.DirectionForward || entry.Trigger != event.TriggerScheduler || entry.ActorID != nil {
+		t.Errorf("history entry = %+v, want a scheduler forward transition participation -> voting", entry)
+	}
+}
+
+func TestAdvanceStageGeneratesAssignments(t *testing.T) {
+	f := newFixture(3)
+	f.configs.config = &vote.VotingConfiguration{
+		AttachmentsPerEvaluator: 2,
+		QualityGoodThreshold:    0.8,
+		QualityBadThreshold:     0.2,
+		AdjustmentMagnitude:     1,
+		TeamQualityPolicy:       vote.TeamQualityAverage,
+	}
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageParticipation}
+
+	_, generated, err := f.service.AdvanceStage(evt, date("2026-11-01"))
+	if err != nil {
+		t.Fatalf("AdvanceStage: %v", err)
+	}
+	if generated != 3 || len(f.votes.assignments) != 3 {
+		t.Fatalf("generated %d assignments (stored %d), want one per evaluator", generated, len(f.votes.assignments))
+	}
+
+	authors := make(map[string]uuid.UUID)
+	for _, a := range f.attachments.attachments {
+		authors[a.ID.String()] = a.ParticipantID
+	}
+	for _, assignment := range f.votes.assignments {
+		if len(assignment.AttachmentIDs) != 2 {
+			t.Errorf("assignment of %s has %d attachments, want 2", assignment.ParticipantID, len(assignment.AttachmentIDs))
+		}
+		for _, id := range assignment.AttachmentIDs {
+			if authors[id] == assignment.ParticipantID {
+				t.Errorf("participant %s was assigned their own proposal", assignment.ParticipantID)
+			}
+		}
+	}
+}
+
+func TestAdvanceStageKeepsExistingAssignments(t *testing.T) {
+	f := newFixture(3)
+	f.configs.config = &vote.VotingConfiguration{AttachmentsPerEvaluator: 2, QualityGoodThreshold: 0.8, QualityBadThreshold: 0.2}
+	f.votes.assignments = []*vote.Assignment{{ID: uuid.New()}}
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageParticipation}
+
+	_, generated, err := f.service.AdvanceStage(evt, date("2026-11-01"))
+	if err != nil {
+		t.Fatalf("AdvanceStage: %v", err)
+	}
+	if generated != 0 || len(f.votes.assignments) != 1 {
+		t.Errorf("generated %d assignments over existing ones, want 0", generated)
+	}
+}
+
+func TestAdvanceStageToResults(t *testing.T) {
+	f := newFixture(3)
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageVoting}
+
+	to, _, err := f.service.AdvanceStage(evt, nil)
+	if err != nil {
+		t.Fatalf("AdvanceStage: %v", err)
+	}
+	if to != event.StageResult || f.events.stage != event.StageResult {
+		t.Errorf("stage = %s (stored %s), want results", to, f.events.stage)
+	}
+	if f.attachments.frozen {
+		t.Error("review files frozen when leaving voting")
+	}
+}
+
+func TestAdvanceStageRefused(t *testing.T) {
+	tests := []struct {
+		name          string
+		members       int
+		stage         event.Stage
+		estimatedDate *time.Time
+		wantErr       error
+	}{
+		{"voting without an estimated end date", 3, event.StageParticipation, nil, ErrMissingEstimatedDate},
+		{"participation without an estimated end date", 3, event.StageCreation, nil, ErrMissingEstimatedDate},
+		{"voting without proposals", 0, event.StageParticipation, date("2026-11-01"), ErrNoAttachments},
+		{"voting with a single proposal", 1, event.StageParticipation, date("2026-11-01"), ErrInsufficientAttachments},
+		{"final stage", 3, event.StageResult, nil, ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.members)
+			evt := &event.Event{ID: uuid.New(), Stage: tt.stage}
+
+			to, _, err := f.service.AdvanceStage(evt, tt.estimatedDate)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdvanceStage = %v, want %v", err, tt.wantErr)
+			}
+			if to != tt.stage {
+				t.Errorf("stage = %s, want it unchanged (%s)", to, tt.stage)
+			}
+			if f.events.updates != 0 || len(f.history.entries) != 0 || f.attachments.frozen {
+				t.Errorf("refused transition changed the event: %d updates, %d history entries, frozen %v",
+					f.events.updates, len(f.history.entries), f.attachments.frozen)
+			}
+		})
+	}
+}
+
+func TestAdvanceStageFailsOnInvalidVotingConfiguration(t *testing.T) {
+	f := newFixture(3)
+	f.users.members = f.users.members[:1] // Only one evaluator left
+	f.configs.config = &vote.VotingConfiguration{AttachmentsPerEvaluator: 2, QualityGoodThreshold: 0.8, QualityBadThreshold: 0.2}
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageParticipation}
+
+	if _, _, err := f.service.AdvanceStage(evt, date("2026-11-01")); err == nil || !strings.Contains(err.Error(), "evaluators") {
+		t.Errorf("AdvanceStage = %v, want an error about evaluators", err)
+	}
+}
+
+func TestRevertStage(t *testing.T) {
+	f := newFixture(3)
+	evt := &event.Event{ID: uuid.New(), Stage: event.StageVoting}
+	actorID := uuid.New()
+	policy := event.DefaultRollbackPolicy(event.StageVoting, event.StageParticipation)
+
+	entry, err := f.service.RevertStage(evt, event.StageParticipation, policy, "Proposals need fixing", actorID, date("2026-11-01"))
+	if err != nil {
+		t.Fatalf("RevertStage: %v", err)
+	}
+
+	if f.events.stage != event.StageParticipation || f.events.estimatedDate == nil {
+		t.Errorf("stored stage = %s with end date %v, want participation with a date", f.events.stage, f.events.estimatedDate)
+	}
+	if len(f.history.policies) != 1 || f.history.policies[0] != policy {
+		t.Errorf("applied policies = %v, want %v", f.history.policies, policy)
+	}
+	if len(f.history.entries) != 1 || f.history.entries[0] != entry {
+		t.Fatalf("history entries = %v, want the returned entry", f.history.entries)
+	}
+	if entry.Direction != event.DirectionReverse || entry.Trigger != event.TriggerManual || *entry.ActorID != actorID || entry.Summary == nil {
+		t.Errorf("history entry = %+v, want a manual reverse transition by the actor with a summary", entry)
+	}
+}
+
+func TestRevertStageRefused(t *testing.T) {
+	keep := event.DefaultRollbackPolicy(event.StageResult, event.StageVoting)
+
+	tests := []struct {
+		name    string
+		stage   event.Stage
+		target  event.Stage
+		policy  event.RollbackPolicy
+		wantErr error
+	}{
+		{"skipping a stage", event.StageResult, event.StageParticipation, keep, ErrInvalidTransition},
+		{"forward", event.StageVoting, event.StageResult, keep, ErrInvalidTransition},
+		{"from participation", event.StageParticipation, event.StageCreation, keep, ErrInvalidTransition},
+		{"votes outliving their assignments", event.StageVoting, event.StageParticipation,
+			event.RollbackPolicy{Assignments: event.DataPolicyArchive, Votes: event.DataPolicyKeep, Drafts: event.DataPolicyInvalidate}, ErrInvalidRollbackPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(3)
+			evt := &event.Event{ID: uuid.New(), Stage: tt.stage}
+
+			_, err := f.service.RevertStage(evt, tt.target, tt.policy, "Reopening the stage", uuid.New(), date("2026-11-01"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevertStage = %v, want %v", err, tt.wantErr)
+			}
+			if f.events.updates != 0 || len(f.history.entries) != 0 || len(f.history.policies) != 0 {
+				t.Error("refused rollback changed the event")
+			}
+		})
+	}
+}
+
+func TestEvaluatorIDs(t *testing.T) {
+	roles := []event.EventParticipantRole{event.RoleParticipant, event.RoleReviewer, event.RoleCoOrganizer, event.RoleObserver, event.RoleCreator}
+	members := make([]*participant.UserWithEventRole, len(roles))
+	for i, role := range roles {
+		members[i] = &participant.UserWithEventRole{User: participant.User{ID: uuid.New()}, EventRole: string(role)}
+	}
+
+	got := EvaluatorIDs(members)
+	if len(got) != 2 || got[0] != members[0].ID || got[1] != members[1].ID {
+		t.Errorf("EvaluatorIDs = %v, want the participant and the reviewer", got)
+	}
+}
+
+func TestLockKey(t *testing.T) {
+	id := uuid.New().String()
+	if LockKey(id) != LockKey(id) {
+		t.Error("lock key is not stable")
+	}
+	if LockKey(id) == LockKey(uuid.New().String()) {
+		t.Error("different events share a lock key")
+	}
+}
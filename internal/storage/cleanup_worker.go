@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
+)
+
+const (
+	// CleanupMaxAttempts is how many times a file deletion is retried before the job is left
+	// in storage_cleanup_jobs for manual inspection
+	CleanupMaxAttempts = 10
+	cleanupBatchSize   = 50
+)
+
+// CleanupWorker removes files from FileStorage in the background after their database
+// records were deleted. Work is queued in storage_cleanup_jobs, so pending deletions survive
+// restarts and several replicas can drain the queue concurrently.
+type CleanupWorker struct {
+	container   *postgres.Container
+	fileStorage FileStorage
+	interval    time.Duration
+	wake        chan struct{}
+	log         *log.Logger
+}
+
+// NewCleanupWorker creates a worker that drains the cleanup queue every interval or when notified
+func NewCleanupWorker(container *postgres.Container, fileStorage FileStorage, interval time.Duration) *CleanupWorker {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &CleanupWorker{
+		container:   container,
+		fileStorage: fileStorage,
+		interval:    interval,
+		wake:        make(chan struct{}, 1),
+		log:         logger.Service("storage_cleanup"),
+	}
+}
+
+// Enqueue schedules files for removal outside of any transaction and wakes the worker
+func (w *CleanupWorker) Enqueue(eventID *uuid.UUID, keys ...string) error {
+	if err := w.container.StorageCleanup().Enqueue(eventID, keys); err != nil {
+		return err
+	}
+	w.Notify()
+	return nil
+}
+
+// Notify wakes the worker so jobs committed by a request are processed right away
+func (w *CleanupWorker) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the worker until the context is cancelled
+func (w *CleanupWorker) Start(ctx context.Context) {
+	w.log.Info("storage cleanup worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for w.RunOnce(ctx) == cleanupBatchSize {
+			// A full batch means more work may be waiting
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("storage cleanup worker stopped")
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// RunOnce processes one batch of due jobs and returns how many were claimed
+func (w *CleanupWorker) RunOnce(ctx context.Context) int {
+	tx, err := w.container.BeginTransaction()
+	if err != nil {
+		w.log.Error("failed to begin storage cleanup transaction", "error", err)
+		return 0
+	}
+
+	jobs, err := tx.StorageCleanup().ClaimDue(time.Now(), CleanupMaxAttempts, cleanupBatchSize)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0
+	}
+
+	removed := 0
+	for _, job := range jobs {
+		if err := w.remove(ctx, job.StorageKey); err != nil {
+			next := time.Now().Add(cleanupBackoff(job.Attempts + 1))
+			w.log.Warn("failed to remove stored file",
+				"storage_key", job.StorageKey,
+				"event_id", job.EventID,
+				"attempt", job.Attempts+1,
+				"next_attempt_at", next,
+				"error", err)
+			if job.Attempts+1 >= CleanupMaxAttempts {
+				w.log.Error("giving up removing stored file", "storage_key", job.StorageKey, "job_id", job.ID)
+			}
+			if err := tx.StorageCleanup().Fail(job.ID, err, next); err != nil {
+				_ = tx.Rollback()
+				return len(jobs)
+			}
+			continue
+		}
+
+		if err := tx.StorageCleanup().Complete(job.ID); err != nil {
+			_ = tx.Rollback()
+			return len(jobs)
+		}
+		removed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		w.log.Error("failed to commit storage cleanup batch", "error", err)
+		return len(jobs)
+	}
+
+	if len(jobs) > 0 {
+		w.log.Info("storage cleanup batch finished", "claimed", len(jobs), "removed", removed)
+	}
+
+	return len(jobs)
+}
+
+// remove deletes a stored file; files that are already gone count as removed
+func (w *CleanupWorker) remove(ctx context.Context, key string) error {
+	exists, err := w.fileStorage.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		w.log.Debug("stored file already removed", "storage_key", key)
+		return nil
+	}
+	return w.fileStorage.Delete(ctx, key)
+}
+
+// cleanupBackoff grows quadratically from 1 minute and is capped at 6 hours
+func cleanupBackoff(attempt int) time.Duration {
+	backoff := time.Duration(attempt*attempt) * time.Minute
+	if backoff > 6*time.Hour {
+		backoff = 6 * time.Hour
+	}
+	return backoff
+}
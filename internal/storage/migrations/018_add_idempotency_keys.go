@@ -0,0 +1,36 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration018Up creates the idempotency_keys table used to replay the response of
+// non-idempotent requests (e.g. ranking vote submission) when a client retries with
+// the same Idempotency-Key header.
+func migration018Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE idempotency_keys (
+			id              UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			idempotency_key VARCHAR(255) NOT NULL,
+			user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			scope           VARCHAR(255) NOT NULL,
+			request_hash    VARCHAR(64) NOT NULL,
+			response_status INTEGER,
+			response_body   JSONB,
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			expires_at      TIMESTAMPTZ NOT NULL,
+			CONSTRAINT uq_idempotency_keys_user_key UNIQUE (user_id, idempotency_key)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at)`).Error
+}
+
+// migration018Down removes the idempotency_keys table and its indexes
+func migration018Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_idempotency_keys_expires_at`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`DROP TABLE IF EXISTS idempotency_keys`).Error
+}
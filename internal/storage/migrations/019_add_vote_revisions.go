@@ -0,0 +1,62 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration019Up creates the vote_revisions table that keeps every submitted version of a
+// participant's ranking, and adds an optional organizer-defined amendment deadline to the
+// voting configuration. The votes table keeps holding only the latest version.
+func migration019Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE vote_revisions (
+			id            UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id      UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			assignment_id UUID        NOT NULL REFERENCES assignments(id) ON DELETE CASCADE,
+			voter_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			revision      INTEGER     NOT NULL,
+			rankings      JSONB       NOT NULL DEFAULT '[]',
+			submitted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_vote_revisions_assignment_revision UNIQUE (assignment_id, revision)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX idx_vote_revisions_event ON vote_revisions(event_id)`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX idx_vote_revisions_voter ON vote_revisions(voter_id)`).Error; err != nil {
+		return err
+	}
+
+	// Existing submissions become revision 1
+	if err := db.Exec(`
+		INSERT INTO vote_revisions (event_id, assignment_id, voter_id, revision, rankings, submitted_at)
+		SELECT event_id, assignment_id, voter_id, 1,
+		       jsonb_agg(jsonb_build_object('attachment_id', attachment_id, 'rank', rank_position) ORDER BY rank_position),
+		       MAX(voted_at)
+		FROM votes
+		GROUP BY event_id, assignment_id, voter_id
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`ALTER TABLE voting_configurations ADD COLUMN IF NOT EXISTS amendment_deadline TIMESTAMPTZ`).Error
+}
+
+// migration019Down removes the vote_revisions table and the amendment deadline column
+func migration019Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE voting_configurations DROP COLUMN IF EXISTS amendment_deadline`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_vote_revisions_voter`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_vote_revisions_event`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`DROP TABLE IF EXISTS vote_revisions`).Error
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration020Up creates the event_stage_history log, which records every stage change
+// (forward and rollback), and event_archived_records, which keeps JSON snapshots of the
+// assignments, votes and drafts archived by a rollback.
+func migration020Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE event_stage_history (
+			id            UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id      UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			from_stage    event_stage NOT NULL,
+			to_stage      event_stage NOT NULL,
+			direction     VARCHAR(16) NOT NULL CHECK (direction IN ('forward', 'reverse')),
+			triggered_by  VARCHAR(16) NOT NULL CHECK (triggered_by IN ('manual', 'scheduler')),
+			actor_id      UUID        REFERENCES users(id) ON DELETE SET NULL,
+			justification TEXT        NOT NULL DEFAULT '',
+			policy        JSONB,
+			summary       JSONB,
+			created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_event_stage_history_reverse_justified
+				CHECK (direction = 'forward' OR length(trim(justification)) > 0)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX idx_event_stage_history_event ON event_stage_history(event_id, created_at)`).Error; err != nil {
+		return err
+	}
+
+	// history_id is checked at commit so a rollback can archive records before its log entry is written
+	if err := db.Exec(`
+		CREATE TABLE event_archived_records (
+			id          UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id    UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			history_id  UUID        NOT NULL REFERENCES event_stage_history(id) ON DELETE CASCADE
+			                        DEFERRABLE INITIALLY DEFERRED,
+			record_type VARCHAR(32) NOT NULL,
+			record_id   UUID        NOT NULL,
+			payload     JSONB       NOT NULL,
+			archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_event_archived_records_history ON event_archived_records(history_id, record_type)`).Error
+}
+
+// migration020Down removes the stage history and archived records tables
+func migration020Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS event_archived_records`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`DROP TABLE IF EXISTS event_stage_history`).Error
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration021Up creates the event_templates table used to save named, reusable event setups
+func migration021Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE event_templates (
+			id               UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			owner_id         UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name             VARCHAR(200) NOT NULL,
+			description      TEXT         NOT NULL,
+			organizer        VARCHAR(200) NOT NULL DEFAULT '',
+			max_participants INTEGER,
+			settings         JSONB        NOT NULL DEFAULT '{}',
+			source_event_id  UUID         REFERENCES events(id) ON DELETE SET NULL,
+			created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+			updated_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_event_templates_owner_name UNIQUE (owner_id, name)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_event_templates_owner ON event_templates(owner_id)`).Error
+}
+
+// migration021Down removes the event_templates table
+func migration021Down(db *gorm.DB) error {
+	return db.Exec(`DROP TABLE IF EXISTS event_templates`).Error
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration022Up adds archival columns to events and the storage_cleanup_jobs queue used to
+// remove stored files in the background after hard deletes. Jobs keep the event ID without a
+// foreign key because they outlive the deleted event.
+func migration022Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE events
+			ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS archived_by UUID REFERENCES users(id) ON DELETE SET NULL
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_events_archived_at ON events(archived_at) WHERE archived_at IS NOT NULL`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		CREATE TABLE storage_cleanup_jobs (
+			id              UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id        UUID,
+			storage_key     TEXT        NOT NULL,
+			attempts        INTEGER     NOT NULL DEFAULT 0,
+			last_error      TEXT,
+			next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_storage_cleanup_jobs_due ON storage_cleanup_jobs(next_attempt_at)`).Error
+}
+
+// migration022Down removes the storage cleanup queue and the archival columns
+func migration022Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS storage_cleanup_jobs`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_events_archived_at`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`ALTER TABLE events DROP COLUMN IF EXISTS archived_by, DROP COLUMN IF EXISTS archived_at`).Error
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration023Up adds per-event visibility and the invitation tables used to register for
+// link-only and invite-only events. Every redemption is kept for auditing.
+func migration023Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE events
+			ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+				CHECK (visibility IN ('public', 'link_only', 'invite_only'))
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		CREATE TABLE event_invitations (
+			id               UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id         UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			email            VARCHAR(255),
+			max_uses         INTEGER     CHECK (max_uses IS NULL OR max_uses > 0),
+			use_count        INTEGER     NOT NULL DEFAULT 0,
+			expires_at       TIMESTAMPTZ NOT NULL,
+			issued_by        UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			revoked_at       TIMESTAMPTZ,
+			revoked_by       UUID        REFERENCES users(id) ON DELETE SET NULL,
+			last_redeemed_at TIMESTAMPTZ,
+			created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_event_invitations_use_count CHECK (max_uses IS NULL OR use_count <= max_uses)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX idx_event_invitations_event_id ON event_invitations(event_id, created_at DESC)`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		CREATE TABLE event_invitation_redemptions (
+			id            UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			invitation_id UUID         NOT NULL REFERENCES event_invitations(id) ON DELETE CASCADE,
+			event_id      UUID         NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			user_id       UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			email         VARCHAR(255) NOT NULL,
+			redeemed_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_event_invitation_redemptions_invitation ON event_invitation_redemptions(invitation_id, redeemed_at)`).Error
+}
+
+// migration023Down removes the invitation tables and the visibility column
+func migration023Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS event_invitation_redemptions`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS event_invitations`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`ALTER TABLE events DROP COLUMN IF EXISTS visibility`).Error
+}
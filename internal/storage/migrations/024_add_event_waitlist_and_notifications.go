@@ -0,0 +1,66 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration024Up adds the per-event waitlist used once an event is full and the in-app
+// notifications sent when a waitlisted registrant is promoted
+func migration024Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE event_waitlist_entries (
+			id            UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			event_id      UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			user_id       UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			position      INTEGER     NOT NULL,
+			status        VARCHAR(20) NOT NULL DEFAULT 'waiting'
+				CHECK (status IN ('waiting', 'promoted', 'withdrawn')),
+			invitation_id UUID        REFERENCES event_invitations(id) ON DELETE SET NULL,
+			promoted_at   TIMESTAMPTZ,
+			promoted_by   UUID        REFERENCES users(id) ON DELETE SET NULL,
+			created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	// A user can only wait once per event; promoted and withdrawn entries are kept as history
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX uq_event_waitlist_entries_waiting
+		ON event_waitlist_entries(event_id, user_id) WHERE status = 'waiting'
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX idx_event_waitlist_entries_queue
+		ON event_waitlist_entries(event_id, position) WHERE status = 'waiting'
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		CREATE TABLE user_notifications (
+			id         UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			event_id   UUID         REFERENCES events(id) ON DELETE CASCADE,
+			kind       VARCHAR(50)  NOT NULL,
+			title      VARCHAR(255) NOT NULL,
+			message    TEXT         NOT NULL,
+			read_at    TIMESTAMPTZ,
+			created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX idx_user_notifications_user ON user_notifications(user_id, created_at DESC)`).Error
+}
+
+// migration024Down removes the notifications and waitlist tables
+func migration024Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS user_notifications`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`DROP TABLE IF EXISTS event_waitlist_entries`).Error
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration025Up adds the co-organizer, reviewer and observer event roles.
+// The new enum values are not used within this migration, so adding them inside the
+// migration transaction is safe on PostgreSQL 12+.
+func migration025Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TYPE event_participant_role ADD VALUE IF NOT EXISTS 'co_organizer'`,
+		`ALTER TYPE event_participant_role ADD VALUE IF NOT EXISTS 'reviewer'`,
+		`ALTER TYPE event_participant_role ADD VALUE IF NOT EXISTS 'observer'`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration025Down turns every extra role back into a regular participant and recreates
+// the enum with its original values (PostgreSQL cannot drop enum values)
+func migration025Down(db *gorm.DB) error {
+	sqls := []string{
+		`UPDATE event_participants SET role = 'participant'
+		 WHERE role::text IN ('co_organizer', 'reviewer', 'observer')`,
+		`ALTER TABLE event_participants ALTER COLUMN role DROP DEFAULT`,
+		`ALTER TYPE event_participant_role RENAME TO event_participant_role_old`,
+		`CREATE TYPE event_participant_role AS ENUM ('creator', 'participant')`,
+		`ALTER TABLE event_participants
+		 ALTER COLUMN role TYPE event_participant_role USING role::text::event_participant_role`,
+		`ALTER TABLE event_participants ALTER COLUMN role SET DEFAULT 'participant'`,
+		`DROP TYPE event_participant_role_old`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration026Up adds co-authors to proposals and the policy used to combine the
+// evaluator quality of a proposal's authors in the incentive adjustment
+func migration026Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		CREATE TABLE attachment_co_authors (
+			attachment_id UUID        NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+			user_id       UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			added_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (attachment_id, user_id)
+		)
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`CREATE INDEX idx_attachment_co_authors_user ON attachment_co_authors(user_id)`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		ALTER TABLE voting_configurations
+		ADD COLUMN team_quality_policy VARCHAR(20) NOT NULL DEFAULT 'average'
+			CHECK (team_quality_policy IN ('average', 'minimum'))
+	`).Error
+}
+
+// migration026Down removes the team quality policy and the co-authors table
+func migration026Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE voting_configurations DROP COLUMN IF EXISTS team_quality_policy`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`DROP TABLE IF EXISTS attachment_co_authors`).Error
+}
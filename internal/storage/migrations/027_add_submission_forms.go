@@ -0,0 +1,38 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration027Up adds the per-event submission form schema and stores the form answers
+// with each attachment
+func migration027Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE events ADD COLUMN submission_form JSONB`,
+		`ALTER TABLE attachments ADD COLUMN answers JSONB`,
+		`CREATE INDEX idx_attachments_answers ON attachments USING GIN (answers)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration027Down removes the submission form and the stored answers
+func migration027Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP INDEX IF EXISTS idx_attachments_answers`,
+		`ALTER TABLE attachments DROP COLUMN IF EXISTS answers`,
+		`ALTER TABLE events DROP COLUMN IF EXISTS submission_form`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration028Up keeps every uploaded version of a proposal's files: the main document and
+// named appendices. Existing attachments get their main document as version 1; proposals of
+// events that are already voting keep it as the reviewed version.
+func migration028Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE attachment_files (
+			id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			attachment_id UUID         NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+			slot          VARCHAR(50)  NOT NULL,
+			version       INTEGER      NOT NULL CHECK (version > 0),
+			filename      VARCHAR(255) NOT NULL,
+			original_name VARCHAR(255) NOT NULL,
+			file_path     TEXT         NOT NULL,
+			file_size     BIGINT       NOT NULL CHECK (file_size >= 0),
+			mime_type     VARCHAR(100) NOT NULL,
+			uploaded_by   UUID         NOT NULL REFERENCES users(id),
+			uploaded_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+			replaced_at   TIMESTAMPTZ,
+			in_review     BOOLEAN      NOT NULL DEFAULT FALSE,
+			UNIQUE (attachment_id, slot, version)
+		)`,
+		// At most one current version per slot
+		`CREATE UNIQUE INDEX idx_attachment_files_current ON attachment_files(attachment_id, slot) WHERE replaced_at IS NULL`,
+		`CREATE INDEX idx_attachment_files_review ON attachment_files(attachment_id) WHERE in_review`,
+		`INSERT INTO attachment_files
+			(attachment_id, slot, version, filename, original_name, file_path, file_size, mime_type, uploaded_by, uploaded_at, in_review)
+		SELECT a.id, 'main', 1, a.filename, a.original_name, a.file_path, a.file_size, a.mime_type, a.participant_id, a.uploaded_at,
+			e.stage IN ('voting', 'results')
+		FROM attachments a
+		JOIN events e ON e.id = a.event_id`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration028Down drops the file versions; attachments keep their current main document
+func migration028Down(db *gorm.DB) error {
+	return db.Exec(`DROP TABLE IF EXISTS attachment_files`).Error
+}
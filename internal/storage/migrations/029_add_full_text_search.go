@@ -0,0 +1,52 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration029Up adds full-text search over events (name, description) and proposals
+// (form title, abstract and keywords, file name and the text extracted from the main
+// document). The 'simple' configuration is used because events and proposals are written
+// in several languages.
+func migration029Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+		) STORED`,
+		`CREATE INDEX idx_events_search ON events USING GIN (search_vector)`,
+		`ALTER TABLE attachments ADD COLUMN extracted_text TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE attachments ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(answers->>'title', '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(answers->>'abstract', '') || ' ' || coalesce(answers->>'keywords', '')), 'B') ||
+			setweight(to_tsvector('simple', coalesce(original_name, '')), 'C') ||
+			setweight(to_tsvector('simple', left(extracted_text, 100000)), 'D')
+		) STORED`,
+		`CREATE INDEX idx_attachments_search ON attachments USING GIN (search_vector)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration029Down removes the search columns and indexes
+func migration029Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP INDEX IF EXISTS idx_attachments_search`,
+		`ALTER TABLE attachments DROP COLUMN IF EXISTS search_vector`,
+		`ALTER TABLE attachments DROP COLUMN IF EXISTS extracted_text`,
+		`DROP INDEX IF EXISTS idx_events_search`,
+		`ALTER TABLE events DROP COLUMN IF EXISTS search_vector`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration030Up adds the private calendar feeds that let a user subscribe to the
+// deadlines of their events from a calendar client. Rotating a feed replaces its row,
+// which invalidates the URL handed out before.
+func migration030Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE calendar_feeds (
+			id               UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id          UUID        NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+			created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			last_accessed_at TIMESTAMPTZ
+		)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration030Down drops the calendar feeds
+func migration030Down(db *gorm.DB) error {
+	return db.Exec(`DROP TABLE IF EXISTS calendar_feeds`).Error
+}
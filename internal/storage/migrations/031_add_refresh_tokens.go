@@ -0,0 +1,49 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration031Up adds rotating refresh tokens and a per-user token version. Access tokens
+// carry the version they were issued with; bumping it revokes every outstanding token.
+func migration031Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0`,
+		`CREATE TABLE refresh_tokens (
+			id          UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			family_id   UUID        NOT NULL,
+			token_hash  CHAR(64)    NOT NULL UNIQUE,
+			expires_at  TIMESTAMPTZ NOT NULL,
+			used_at     TIMESTAMPTZ,
+			revoked_at  TIMESTAMPTZ,
+			user_agent  TEXT        NOT NULL DEFAULT '',
+			ip_address  VARCHAR(45) NOT NULL DEFAULT '',
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX idx_refresh_tokens_user ON refresh_tokens(user_id) WHERE revoked_at IS NULL`,
+		`CREATE INDEX idx_refresh_tokens_family ON refresh_tokens(family_id)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration031Down drops the refresh tokens and the token version
+func migration031Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP TABLE IF EXISTS refresh_tokens`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS token_version`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
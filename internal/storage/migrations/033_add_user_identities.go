@@ -0,0 +1,44 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration033Up adds identities from OpenID Connect providers linked to users. Google
+// sign-ins keep using users.google_id; every other provider is linked here.
+func migration033Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE user_identities (
+			id            UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id       UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			provider      VARCHAR(50)  NOT NULL,
+			subject       VARCHAR(255) NOT NULL,
+			email         VARCHAR(255) NOT NULL DEFAULT '',
+			created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+			last_login_at TIMESTAMPTZ,
+			UNIQUE (provider, subject),
+			UNIQUE (user_id, provider)
+		)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration033Down removes the linked identities
+func migration033Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP TABLE IF EXISTS user_identities`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
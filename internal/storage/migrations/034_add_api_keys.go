@@ -0,0 +1,50 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration034Up adds service accounts and scoped API keys (stored hashed) for automation
+func migration034Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE users ADD COLUMN is_service_account BOOLEAN NOT NULL DEFAULT FALSE`,
+		`CREATE TABLE api_keys (
+			id           UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name         VARCHAR(100) NOT NULL,
+			prefix       VARCHAR(20)  NOT NULL,
+			key_hash     CHAR(64)     NOT NULL UNIQUE,
+			scopes       TEXT[]       NOT NULL,
+			created_by   UUID         REFERENCES users(id) ON DELETE SET NULL,
+			expires_at   TIMESTAMPTZ,
+			revoked_at   TIMESTAMPTZ,
+			last_used_at TIMESTAMPTZ,
+			last_used_ip VARCHAR(45)  NOT NULL DEFAULT '',
+			created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX idx_api_keys_user ON api_keys(user_id)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration034Down removes the API keys and service accounts
+func migration034Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP TABLE IF EXISTS api_keys`,
+		`DELETE FROM users WHERE is_service_account`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS is_service_account`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration035Up adds account deactivation and the audit log of admin changes to users.
+// target_user_id has no foreign key so entries outlive accounts merged away.
+func migration035Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMPTZ`,
+		`CREATE TABLE user_audit_log (
+			id             UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			actor_id       UUID         REFERENCES users(id) ON DELETE SET NULL,
+			target_user_id UUID         NOT NULL,
+			target_email   VARCHAR(255) NOT NULL,
+			action         VARCHAR(32)  NOT NULL,
+			details        JSONB        NOT NULL DEFAULT '{}',
+			created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX idx_user_audit_log_target ON user_audit_log(target_user_id, created_at)`,
+		`CREATE INDEX idx_user_audit_log_created ON user_audit_log(created_at)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration035Down removes the audit log and account deactivation
+func migration035Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP TABLE IF EXISTS user_audit_log`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration036Up adds the setting that decides who can see the results of an event.
+// Existing events show their results to their members, the closest match to the
+// previous behaviour that doesn't expose them to every user.
+func migration036Up(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE events ADD COLUMN results_visibility VARCHAR(20) NOT NULL DEFAULT 'members'`,
+		`ALTER TABLE events ADD CONSTRAINT chk_events_results_visibility
+			CHECK (results_visibility IN ('managers', 'members', 'public'))`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration036Down removes the results visibility setting
+func migration036Down(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_results_visibility`,
+		`ALTER TABLE events DROP COLUMN IF EXISTS results_visibility`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
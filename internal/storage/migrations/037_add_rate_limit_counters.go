@@ -0,0 +1,39 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration037Up adds the counters shared by replicas for rate limiting and login lockouts
+func migration037Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE rate_limit_counters (
+			bucket_key  VARCHAR(512) PRIMARY KEY,
+			count       INTEGER      NOT NULL DEFAULT 0,
+			reset_at    TIMESTAMPTZ  NOT NULL,
+			last_hit_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX idx_rate_limit_counters_reset_at ON rate_limit_counters(reset_at)`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration037Down removes the rate limit counters
+func migration037Down(db *gorm.DB) error {
+	sqls := []string{
+		`DROP TABLE IF EXISTS rate_limit_counters`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
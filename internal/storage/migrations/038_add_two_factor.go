@@ -0,0 +1,57 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// migration038Up adds TOTP enrolments, their hashed recovery codes and the challenge
+// tokens handed out between the password and the second factor
+func migration038Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE user_two_factor (
+			user_id        UUID        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			secret         VARCHAR(64) NOT NULL,
+			enabled_at     TIMESTAMPTZ,
+			last_used_step BIGINT      NOT NULL DEFAULT 0,
+			created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE two_factor_recovery_codes (
+			id         UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			code_hash  CHAR(64)    NOT NULL UNIQUE,
+			used_at    TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX idx_two_factor_recovery_codes_user ON two_factor_recovery_codes(user_id) WHERE used_at IS NULL`,
+		`ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS user_tokens_purpose_check`,
+		`ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+			CHECK (purpose IN ('password_reset', 'email_verification', 'two_factor_challenge'))`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration038Down removes the two-factor tables and challenge tokens
+func migration038Down(db *gorm.DB) error {
+	sqls := []string{
+		`DELETE FROM user_tokens WHERE purpose = 'two_factor_challenge'`,
+		`ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS user_tokens_purpose_check`,
+		`ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+			CHECK (purpose IN ('password_reset', 'email_verification'))`,
+		`DROP TABLE IF EXISTS two_factor_recovery_codes`,
+		`DROP TABLE IF EXISTS user_two_factor`,
+	}
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// organizationOwnedTables are the tables whose rows belong to one organization
+var organizationOwnedTables = []string{"events", "event_templates", "api_keys", "refresh_tokens", "user_audit_log"}
+
+// migration039Up adds organizations and their members. Existing data moves to the default
+// organization: every user joins it, admins as org admins, and it owns every event,
+// template, API key, login and audit entry.
+func migration039Up(db *gorm.DB) error {
+	sqls := []string{
+		`CREATE TABLE organizations (
+			id            UUID         PRIMARY KEY DEFAULT uuid_generate_v4(),
+			slug          VARCHAR(63)  NOT NULL UNIQUE,
+			name          VARCHAR(200) NOT NULL,
+			logo_url      VARCHAR(500) NOT NULL DEFAULT '',
+			primary_color VARCHAR(7)   NOT NULL DEFAULT '',
+			created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+			updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE organization_members (
+			organization_id UUID        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+			user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			role            VARCHAR(16) NOT NULL DEFAULT 'member' CHECK (role IN ('org_admin', 'member')),
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (organization_id, user_id)
+		)`,
+		`CREATE INDEX idx_organization_members_user ON organization_members(user_id)`,
+		`INSERT INTO organizations (slug, name) VALUES ('default', 'Telescopio')`,
+		`INSERT INTO organization_members (organization_id, user_id, role)
+			SELECT o.id, u.id, CASE WHEN u.role = 'admin' THEN 'org_admin' ELSE 'member' END
+			FROM organizations o CROSS JOIN users u
+			WHERE o.slug = 'default'`,
+	}
+
+	for _, table := range organizationOwnedTables {
+		sqls = append(sqls,
+			`ALTER TABLE `+table+` ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE`,
+			`UPDATE `+table+` SET organization_id = (SELECT id FROM organizations WHERE slug = 'default')`,
+			`CREATE INDEX idx_`+table+`_organization ON `+table+`(organization_id)`,
+		)
+	}
+
+	// Audit entries of platform operations belong to no organization
+	sqls = append(sqls,
+		`ALTER TABLE events ALTER COLUMN organization_id SET NOT NULL`,
+		`ALTER TABLE event_templates ALTER COLUMN organization_id SET NOT NULL`,
+		`ALTER TABLE api_keys ALTER COLUMN organization_id SET NOT NULL`,
+		`ALTER TABLE refresh_tokens ALTER COLUMN organization_id SET NOT NULL`,
+		`ALTER TABLE event_templates DROP CONSTRAINT IF EXISTS uq_event_templates_owner_name`,
+		`ALTER TABLE event_templates ADD CONSTRAINT uq_event_templates_owner_name UNIQUE (organization_id, owner_id, name)`,
+	)
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration039Down folds every organization back into a single tenant
+func migration039Down(db *gorm.DB) error {
+	sqls := []string{
+		`ALTER TABLE event_templates DROP CONSTRAINT IF EXISTS uq_event_templates_owner_name`,
+		`ALTER TABLE event_templates ADD CONSTRAINT uq_event_templates_owner_name UNIQUE (owner_id, name)`,
+	}
+
+	for _, table := range organizationOwnedTables {
+		sqls = append(sqls, `ALTER TABLE `+table+` DROP COLUMN IF EXISTS organization_id`)
+	}
+
+	sqls = append(sqls,
+		`DROP TABLE IF EXISTS organization_members`,
+		`DROP TABLE IF EXISTS organizations`,
+	)
+
+	for _, sql := range sqls {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -120,6 +120,138 @@ func GetMigrations() []Migration {
 			Up:   migration017Up,
 			Down: migration017Down,
 		},
+		{
+			ID:   "018",
+			Name: "add_idempotency_keys",
+			Up:   migration018Up,
+			Down: migration018Down,
+		},
+		{
+			ID:   "019",
+			Name: "add_vote_revisions",
+			Up:   migration019Up,
+			Down: migration019Down,
+		},
+		{
+			ID:   "020",
+			Name: "add_event_stage_history",
+			Up:   migration020Up,
+			Down: migration020Down,
+		},
+		{
+			ID:   "021",
+			Name: "add_event_templates",
+			Up:   migration021Up,
+			Down: migration021Down,
+		},
+		{
+			ID:   "022",
+			Name: "add_event_archival_and_storage_cleanup",
+			Up:   migration022Up,
+			Down: migration022Down,
+		},
+		{
+			ID:   "023",
+			Name: "add_event_visibility_and_invitations",
+			Up:   migration023Up,
+			Down: migration023Down,
+		},
+		{
+			ID:   "024",
+			Name: "add_event_waitlist_and_notifications",
+			Up:   migration024Up,
+			Down: migration024Down,
+		},
+		{
+			ID:   "025",
+			Name: "add_event_member_roles",
+			Up:   migration025Up,
+			Down: migration025Down,
+		},
+		{
+			ID:   "026",
+			Name: "add_attachment_co_authors",
+			Up:   migration026Up,
+			Down: migration026Down,
+		},
+		{
+			ID:   "027",
+			Name: "add_submission_forms",
+			Up:   migration027Up,
+			Down: migration027Down,
+		},
+		{
+			ID:   "028",
+			Name: "add_attachment_files",
+			Up:   migration028Up,
+			Down: migration028Down,
+		},
+		{
+			ID:   "029",
+			Name: "add_full_text_search",
+			Up:   migration029Up,
+			Down: migration029Down,
+		},
+		{
+			ID:   "030",
+			Name: "add_calendar_feeds",
+			Up:   migration030Up,
+			Down: migration030Down,
+		},
+		{
+			ID:   "031",
+			Name: "add_refresh_tokens",
+			Up:   migration031Up,
+			Down: migration031Down,
+		},
+		{
+			ID:   "032",
+			Name: "add_account_tokens_and_email_verification",
+			Up:   migration032Up,
+			Down: migration032Down,
+		},
+		{
+			ID:   "033",
+			Name: "add_user_identities",
+			Up:   migration033Up,
+			Down: migration033Down,
+		},
+		{
+			ID:   "034",
+			Name: "add_api_keys",
+			Up:   migration034Up,
+			Down: migration034Down,
+		},
+		{
+			ID:   "035",
+			Name: "add_user_deactivation_and_audit_log",
+			Up:   migration035Up,
+			Down: migration035Down,
+		},
+		{
+			ID:   "036",
+			Name: "add_event_results_visibility",
+			Up:   migration036Up,
+			Down: migration036Down,
+		},
+		{
+			ID:   "037",
+			Name: "add_rate_limit_counters",
+			Up:   migration037Up,
+			Down: migration037Down,
+		},
+		{
+			ID:   "038",
+			Name: "add_two_factor",
+			Up:   migration038Up,
+			Down: migration038Down,
+		},
+		{
+			ID:   "039",
+			Name: "add_organizations",
+			Up:   migration039Up,
+			Down: migration039Down,
+		},
 	}
 }
 
@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxPDFStreamBytes caps the inflated size of one PDF stream, so a small compressed
+// stream can't expand into gigabytes
+const maxPDFStreamBytes = 16 << 20
+
+var (
+	pdfStreamStart = regexp.MustCompile(`\bstream\r?\n`)
+	pdfStreamEnd   = []byte("endstream")
+	pdfObjStart    = regexp.MustCompile(`\d+\s+\d+\s+obj\b`)
+)
+
+// extractPDF returns the text drawn by the content streams of a PDF: the strings shown by
+// the Tj, TJ, ' and " operators. Streams are read uncompressed or FlateDecode compressed.
+// Text in fonts with custom encodings (most CID fonts) or in images (scanned pages) can't
+// be mapped back to characters without the font programs, and is skipped.
+func extractPDF(file io.ReaderAt, size int64) (string, error) {
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, size))
+	if err != nil {
+		return "", err
+	}
+
+	objs := pdfObjStart.FindAllIndex(data, -1)
+
+	var sb strings.Builder
+	for pos := 0; sb.Len() < MaxExtractedTextBytes; {
+		loc := pdfStreamStart.FindIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		start, dataStart := pos+loc[0], pos+loc[1]
+
+		end := bytes.Index(data[dataStart:], pdfStreamEnd)
+		if end < 0 {
+			break
+		}
+		pos = dataStart + end + len(pdfStreamEnd)
+
+		dict := pdfStreamDict(data, objs, start)
+		if !pdfIsContentStream(dict) {
+			continue
+		}
+
+		content := bytes.TrimRight(data[dataStart:dataStart+end], "\r\n")
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			content, err = inflatePDFStream(content)
+			if err != nil {
+				// Damaged streams are skipped; the rest of the document is still indexed
+				continue
+			}
+		}
+
+		pdfContentText(content, &sb)
+	}
+
+	return sb.String(), nil
+}
+
+// pdfStreamDict returns the dictionary of the object whose stream keyword is at start:
+// the bytes since the last "N G obj" before it
+func pdfStreamDict(data []byte, objs [][]int, start int) []byte {
+	i := sort.Search(len(objs), func(i int) bool { return objs[i][1] > start })
+	if i == 0 {
+		return nil
+	}
+	return data[objs[i-1][1]:start]
+}
+
+// pdfIsContentStream reports whether the stream may hold page or form content: it is not
+// an image, font, metadata, object or cross-reference stream, and has no filter other
+// than FlateDecode
+func pdfIsContentStream(dict []byte) bool {
+	for _, skip := range []string{"/Image", "/XRef", "/ObjStm", "/Metadata", "/FontFile", "/Length1", "/Subtype /Type1C", "/Subtype/Type1C"} {
+		if bytes.Contains(dict, []byte(skip)) {
+			return false
+		}
+	}
+
+	for _, filter := range []string{"/DCTDecode", "/JPXDecode", "/CCITTFaxDecode", "/JBIG2Decode", "/LZWDecode", "/RunLengthDecode", "/ASCII85Decode", "/ASCIIHexDecode"} {
+		if bytes.Contains(dict, []byte(filter)) {
+			return false
+		}
+	}
+	return true
+}
+
+func inflatePDFStream(raw []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, maxPDFStreamBytes))
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	// Truncated streams still yield the text inflated so far
+	return out, nil
+}
+
+// pdfContentText writes the text shown by a content stream to sb. Text objects end in a
+// new line and line moves within them in a space.
+func pdfContentText(content []byte, sb *strings.Builder) {
+	var operands [][]byte // Strings among the operands of the next operator
+	inArray := false
+	var array [][]byte
+
+	i := 0
+	for i < len(content) && sb.Len() < MaxExtractedTextBytes {
+		c := content[i]
+		switch {
+		case c == '(':
+			s, next := pdfLiteralString(content, i)
+			if inArray {
+				array = append(array, s)
+			} else {
+				operands = append(operands, s)
+			}
+			i = next
+
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2 // Dictionary operands (marked content properties) hold no shown text
+
+		case c == '<':
+			s, next := pdfHexString(content, i)
+			if inArray {
+				array = append(array, s)
+			} else {
+				operands = append(operands, s)
+			}
+			i = next
+
+		case c == '[':
+			inArray = true
+			array = array[:0]
+			i++
+
+		case c == ']':
+			inArray = false
+			i++
+
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+
+		case isPDFDigit(c):
+			start := i
+			for i < len(content) && isPDFRegular(content[i]) {
+				i++
+			}
+			// Wide negative kerning between the strings of a TJ array separates words
+			if inArray {
+				if value, err := strconv.ParseFloat(string(content[start:i]), 64); err == nil && value <= -200 {
+					array = append(array, []byte(" "))
+				}
+			}
+
+		case isPDFRegular(c):
+			start := i
+			for i < len(content) && isPDFRegular(content[i]) {
+				i++
+			}
+			op := string(content[start:i])
+			if start > 0 && content[start-1] == '/' {
+				continue // A name, not an operator
+			}
+
+			switch op {
+			case "Tj":
+				writePDFStrings(sb, operands)
+			case "'", "\"":
+				writePDFBreak(sb, ' ')
+				writePDFStrings(sb, operands)
+			case "TJ":
+				writePDFStrings(sb, array)
+				array = array[:0]
+			case "T*", "Td", "TD":
+				writePDFBreak(sb, ' ')
+			case "ET":
+				writePDFBreak(sb, '\n')
+			case "ID":
+				// Inline image data runs until EI and may contain any byte
+				if end := bytes.Index(content[i:], []byte("EI")); end >= 0 {
+					i += end + 2
+				} else {
+					i = len(content)
+				}
+			}
+			operands = operands[:0]
+
+		default:
+			i++
+		}
+	}
+}
+
+// writePDFBreak separates text with a space or a new line, unless it already ends in one
+func writePDFBreak(sb *strings.Builder, sep byte) {
+	text := sb.String()
+	if text == "" {
+		return
+	}
+	if last := text[len(text)-1]; last == '\n' || last == sep {
+		return
+	}
+	sb.WriteByte(sep)
+}
+
+func writePDFStrings(sb *strings.Builder, strs [][]byte) {
+	for _, s := range strs {
+		sb.WriteString(decodePDFString(s))
+	}
+}
+
+// decodePDFString converts a PDF text string to UTF-8: UTF-16BE when it starts with a byte
+// order mark, otherwise single-byte (Latin-1, close to WinAnsi and PDFDocEncoding).
+// Strings with control characters belong to fonts with custom encodings and are dropped.
+func decodePDFString(s []byte) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		units := make([]uint16, 0, (len(s)-2)/2)
+		for i := 2; i+1 < len(s); i += 2 {
+			units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, b := range s {
+		if b < 0x20 && b != '\t' && b != '\n' && b != '\r' {
+			return ""
+		}
+		runes = append(runes, rune(b))
+	}
+	return string(runes)
+}
+
+// pdfLiteralString reads the (string) starting at content[start], with its escapes and
+// balanced parentheses, and returns it with the index after it
+func pdfLiteralString(content []byte, start int) ([]byte, int) {
+	var out []byte
+	depth := 0
+	i := start
+	for i < len(content) {
+		c := content[i]
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				out = append(out, c)
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return out, i + 1
+			}
+			out = append(out, c)
+		case '\\':
+			i++
+			if i >= len(content) {
+				return out, i
+			}
+			switch e := content[i]; e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+				// Backspace and form feed show nothing
+			case '\r':
+				if i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					value := 0
+					for n := 0; n < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; n++ {
+						value = value*8 + int(content[i]-'0')
+						i++
+					}
+					out = append(out, byte(value))
+					continue
+				}
+				out = append(out, e)
+			}
+		default:
+			out = append(out, c)
+		}
+		i++
+	}
+	return out, i
+}
+
+// pdfHexString reads the <hex string> starting at content[start] and returns it with the
+// index after it
+func pdfHexString(content []byte, start int) ([]byte, int) {
+	var out []byte
+	var digits []byte
+	i := start + 1
+	for ; i < len(content) && content[i] != '>'; i++ {
+		if v, ok := hexValue(content[i]); ok {
+			digits = append(digits, v)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, 0)
+	}
+	for j := 0; j < len(digits); j += 2 {
+		out = append(out, digits[j]<<4|digits[j+1])
+	}
+	return out, i + 1
+}
+
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// isPDFRegular reports whether c is a regular character, neither white-space nor a delimiter
+func isPDFRegular(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return false
+	}
+	return true
+}
+
+func isPDFDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == '-' || c == '+'
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// apiKeyTouchInterval is how often the last use of a busy key is written
+const apiKeyTouchInterval = time.Minute
+
+// PostgresAPIKeyRepository implements APIKeyRepository using GORM
+type PostgresAPIKeyRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresAPIKeyRepository creates a new PostgreSQL API key repository
+func NewPostgresAPIKeyRepository(db *gorm.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{
+		db:  db,
+		log: logger.Repository("api_key"),
+	}
+}
+
+// Create stores a new API key
+func (r *PostgresAPIKeyRepository) Create(key *session.APIKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		r.log.Error("failed to create API key", "user_id", key.UserID, "error", err)
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	r.log.Info("API key created", "api_key_id", key.ID, "user_id", key.UserID, "scopes", key.Scopes)
+	return nil
+}
+
+// GetByID retrieves an API key by its ID
+func (r *PostgresAPIKeyRepository) GetByID(id string) (*session.APIKey, error) {
+	keyUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid API key ID format", "api_key_id", id, "error", err)
+		return nil, errors.New("invalid API key ID format")
+	}
+
+	var key session.APIKey
+	if err := r.db.Where("id = ?", keyUUID).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		r.log.Error("failed to retrieve API key", "api_key_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetByHash retrieves an API key by the hash of the plain key
+func (r *PostgresAPIKeyRepository) GetByHash(keyHash string) (*session.APIKey, error) {
+	var key session.APIKey
+	if err := r.db.Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		r.log.Error("failed to retrieve API key", "error", err)
+		return nil, fmt.Errorf("failed to retrieve API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetByUser retrieves the API keys of a user in the organization, newest first
+func (r *PostgresAPIKeyRepository) GetByUser(organizationID uuid.UUID, userID string) ([]*session.APIKey, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	var keys []*session.APIKey
+	if err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userUUID).Order("created_at DESC").Find(&keys).Error; err != nil {
+		r.log.Error("failed to retrieve API keys", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes an API key; it stops working immediately
+func (r *PostgresAPIKeyRepository) Revoke(id uuid.UUID, at time.Time) error {
+	result := r.db.Model(&session.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		r.log.Error("failed to revoke API key", "api_key_id", id, "error", result.Error)
+		return fmt.Errorf("failed to revoke API key: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("API key not found")
+	}
+
+	r.log.Info("API key revoked", "api_key_id", id)
+	return nil
+}
+
+// Touch records a use of the key. Uses within apiKeyTouchInterval of the recorded one are
+// skipped, so busy scripts don't cause a write per request.
+func (r *PostgresAPIKeyRepository) Touch(id uuid.UUID, at time.Time, ipAddress string) error {
+	err := r.db.Model(&session.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-apiKeyTouchInterval)).
+		Updates(map[string]interface{}{
+			"last_used_at": at,
+			"last_used_ip": ipAddress,
+		}).Error
+	if err != nil {
+		r.log.Error("failed to record API key use", "api_key_id", id, "error", err)
+		return fmt.Errorf("failed to record API key use: %w", err)
+	}
+
+	return nil
+}
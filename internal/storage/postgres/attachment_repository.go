@@ -1,12 +1,15 @@
 package postgres
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	attachmentDomain "github.com/gravadigital/telescopio-api/internal/domain/attachment"
 	"github.com/gravadigital/telescopio-api/internal/logger"
@@ -91,7 +94,14 @@ func (r *PostgresAttachmentRepository) Create(attachment *attachmentDomain.Attac
 		}
 	}
 
-	if err := r.db.Create(attachment).Error; err != nil {
+	// The uploaded file becomes version 1 of the proposal's main document
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("CoAuthors").Create(attachment).Error; err != nil {
+			return err
+		}
+		return tx.Create(attachment.MainFile()).Error
+	})
+	if err != nil {
 		r.log.Error("failed to create attachment", "error", err, "attachment_id", attachment.ID)
 		return fmt.Errorf("failed to create attachment: %w", err)
 	}
@@ -115,7 +125,7 @@ func (r *PostgresAttachmentRepository) GetByID(id string) (*attachmentDomain.Att
 	}
 
 	var att attachmentDomain.Attachment
-	if err := r.db.First(&att, attachmentID).Error; err != nil {
+	if err := r.db.Preload("CoAuthors").First(&att, attachmentID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("attachment not found", "attachment_id", id)
 			return nil, errors.New("attachment not found")
@@ -128,6 +138,37 @@ func (r *PostgresAttachmentRepository) GetByID(id string) (*attachmentDomain.Att
 	return &att, nil
 }
 
+// GetByIDInOrganization retrieves an attachment of an event of the organization; others
+// are reported as not found
+func (r *PostgresAttachmentRepository) GetByIDInOrganization(organizationID uuid.UUID, id string) (*attachmentDomain.Attachment, error) {
+	r.log.Debug("retrieving attachment by ID", "organization_id", organizationID, "attachment_id", id)
+
+	if id == "" {
+		r.log.Error("attachment ID cannot be empty")
+		return nil, errors.New("attachment ID cannot be empty")
+	}
+
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid attachment ID format", "attachment_id", id, "error", err)
+		return nil, fmt.Errorf("invalid attachment ID format: %w", err)
+	}
+
+	var att attachmentDomain.Attachment
+	if err := r.db.Preload("CoAuthors").
+		Where("event_id IN (SELECT id FROM events WHERE organization_id = ?)", organizationID).
+		First(&att, attachmentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("attachment not found", "organization_id", organizationID, "attachment_id", id)
+			return nil, errors.New("attachment not found")
+		}
+		r.log.Error("failed to retrieve attachment", "attachment_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve attachment: %w", err)
+	}
+
+	return &att, nil
+}
+
 func (r *PostgresAttachmentRepository) GetByEventID(eventID string) ([]*attachmentDomain.Attachment, error) {
 	r.log.Debug("retrieving attachments by event ID", "event_id", eventID)
 
@@ -143,7 +184,7 @@ func (r *PostgresAttachmentRepository) GetByEventID(eventID string) ([]*attachme
 	}
 
 	var attachments []*attachmentDomain.Attachment
-	if err := r.db.Where("event_id = ?", eventUUID).Find(&attachments).Error; err != nil {
+	if err := r.db.Preload("CoAuthors").Where("event_id = ?", eventUUID).Find(&attachments).Error; err != nil {
 		r.log.Error("failed to retrieve attachments by event ID", "event_id", eventID, "error", err)
 		return nil, fmt.Errorf("failed to retrieve attachments by event ID: %w", err)
 	}
@@ -291,6 +332,40 @@ func (r *PostgresAttachmentRepository) Update(attachment *attachmentDomain.Attac
 	return nil
 }
 
+// SetCoAuthors replaces the co-authors of an attachment
+func (r *PostgresAttachmentRepository) SetCoAuthors(attachmentID string, userIDs []uuid.UUID) error {
+	r.log.Debug("setting attachment co-authors", "attachment_id", attachmentID, "count", len(userIDs))
+
+	attachmentUUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		r.log.Error("invalid attachment ID format", "attachment_id", attachmentID, "error", err)
+		return fmt.Errorf("invalid attachment ID format: %w", err)
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attachment_id = ?", attachmentUUID).Delete(&attachmentDomain.CoAuthor{}).Error; err != nil {
+			return err
+		}
+
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		coAuthors := make([]attachmentDomain.CoAuthor, len(userIDs))
+		for i, userID := range userIDs {
+			coAuthors[i] = attachmentDomain.CoAuthor{AttachmentID: attachmentUUID, UserID: userID}
+		}
+		return tx.Create(&coAuthors).Error
+	})
+	if err != nil {
+		r.log.Error("failed to set attachment co-authors", "attachment_id", attachmentID, "error", err)
+		return fmt.Errorf("failed to set attachment co-authors: %w", err)
+	}
+
+	r.log.Info("attachment co-authors updated", "attachment_id", attachmentID, "count", len(userIDs))
+	return nil
+}
+
 // GetByEventIDPaginated retrieves attachments by event ID with pagination
 func (r *PostgresAttachmentRepository) GetByEventIDPaginated(eventID string, params PaginationParams) (*PaginatedResult, error) {
 	r.log.Debug("retrieving attachments by event ID with pagination", "event_id", eventID, "page", params.Page, "page_size", params.PageSize)
@@ -457,3 +532,309 @@ func (r *PostgresAttachmentRepository) UpdateVoteCount(id string, count int) err
 	r.log.Info("attachment vote count updated successfully", "attachment_id", id, "old_count", attachment.VoteCount, "new_count", count)
 	return nil
 }
+
+// GetByEventAndParticipant retrieves the proposal a participant submitted to an event
+func (r *PostgresAttachmentRepository) GetByEventAndParticipant(eventID, participantID string) (*attachmentDomain.Attachment, error) {
+	r.log.Debug("retrieving attachment by event and participant", "event_id", eventID, "participant_id", participantID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("invalid event ID format: %w", err)
+	}
+
+	participantUUID, err := uuid.Parse(participantID)
+	if err != nil {
+		r.log.Error("invalid participant ID format", "participant_id", participantID, "error", err)
+		return nil, fmt.Errorf("invalid participant ID format: %w", err)
+	}
+
+	var att attachmentDomain.Attachment
+	if err := r.db.Preload("CoAuthors").
+		Where("event_id = ? AND participant_id = ?", eventUUID, participantUUID).
+		First(&att).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attachment not found")
+		}
+		r.log.Error("failed to retrieve attachment", "event_id", eventID, "participant_id", participantID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve attachment: %w", err)
+	}
+
+	return &att, nil
+}
+
+// AddFileVersion stores a new version of a proposal file. The version number follows the
+// latest one of the slot, which is marked as replaced. A new main document version is
+// mirrored to the attachment so existing downloads serve the current document.
+func (r *PostgresAttachmentRepository) AddFileVersion(file *attachmentDomain.File) error {
+	r.log.Debug("adding attachment file version", "attachment_id", file.AttachmentID, "slot", file.Slot)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Serialize uploads to the same proposal so version numbers don't collide
+		var att attachmentDomain.Attachment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&att, file.AttachmentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("attachment not found")
+			}
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&attachmentDomain.File{}).
+			Where("attachment_id = ? AND slot = ?", file.AttachmentID, file.Slot).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&attachmentDomain.File{}).
+			Where("attachment_id = ? AND slot = ? AND replaced_at IS NULL", file.AttachmentID, file.Slot).
+			Update("replaced_at", now).Error; err != nil {
+			return err
+		}
+
+		file.Version = latest + 1
+		file.ReplacedAt = nil
+		file.InReview = false
+		if err := tx.Create(file).Error; err != nil {
+			return err
+		}
+
+		if !file.IsMain() {
+			return nil
+		}
+		return tx.Model(&att).Updates(map[string]interface{}{
+			"filename":      file.Filename,
+			"original_name": file.OriginalName,
+			"file_path":     file.FilePath,
+			"file_size":     file.FileSize,
+			"mime_type":     file.MimeType,
+		}).Error
+	})
+	if err != nil {
+		r.log.Error("failed to add attachment file version", "attachment_id", file.AttachmentID, "slot", file.Slot, "error", err)
+		return fmt.Errorf("failed to add attachment file version: %w", err)
+	}
+
+	r.log.Info("attachment file version added", "attachment_id", file.AttachmentID, "slot", file.Slot, "version", file.Version)
+	return nil
+}
+
+// GetFiles lists the files of a proposal, main document first. Only current versions are
+// returned unless includeReplaced is set.
+func (r *PostgresAttachmentRepository) GetFiles(attachmentID string, includeReplaced bool) ([]*attachmentDomain.File, error) {
+	attachmentUUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		r.log.Error("invalid attachment ID format", "attachment_id", attachmentID, "error", err)
+		return nil, fmt.Errorf("invalid attachment ID format: %w", err)
+	}
+
+	query := r.db.Where("attachment_id = ?", attachmentUUID)
+	if !includeReplaced {
+		query = query.Where("replaced_at IS NULL")
+	}
+	return r.findFiles(query, attachmentID)
+}
+
+// GetReviewFiles lists the file versions that were current when voting opened
+func (r *PostgresAttachmentRepository) GetReviewFiles(attachmentID string) ([]*attachmentDomain.File, error) {
+	attachmentUUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		r.log.Error("invalid attachment ID format", "attachment_id", attachmentID, "error", err)
+		return nil, fmt.Errorf("invalid attachment ID format: %w", err)
+	}
+
+	return r.findFiles(r.db.Where("attachment_id = ? AND in_review", attachmentUUID), attachmentID)
+}
+
+func (r *PostgresAttachmentRepository) findFiles(query *gorm.DB, attachmentID string) ([]*attachmentDomain.File, error) {
+	var files []*attachmentDomain.File
+	if err := query.
+		Order("slot <> 'main', slot, version DESC").
+		Find(&files).Error; err != nil {
+		r.log.Error("failed to retrieve attachment files", "attachment_id", attachmentID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve attachment files: %w", err)
+	}
+	return files, nil
+}
+
+// GetFile retrieves a single file version
+func (r *PostgresAttachmentRepository) GetFile(fileID string) (*attachmentDomain.File, error) {
+	fileUUID, err := uuid.Parse(fileID)
+	if err != nil {
+		r.log.Error("invalid file ID format", "file_id", fileID, "error", err)
+		return nil, errors.New("invalid file ID format")
+	}
+
+	var file attachmentDomain.File
+	if err := r.db.First(&file, fileUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("file not found")
+		}
+		r.log.Error("failed to retrieve attachment file", "file_id", fileID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve attachment file: %w", err)
+	}
+
+	return &file, nil
+}
+
+// RemoveFile withdraws the current version of a supplementary file. Its versions are kept
+// in the history; the main document cannot be removed.
+func (r *PostgresAttachmentRepository) RemoveFile(fileID string) error {
+	file, err := r.GetFile(fileID)
+	if err != nil {
+		return err
+	}
+	if file.IsMain() {
+		return errors.New("the main document cannot be removed")
+	}
+
+	result := r.db.Model(&attachmentDomain.File{}).
+		Where("id = ? AND replaced_at IS NULL", file.ID).
+		Update("replaced_at", time.Now())
+	if result.Error != nil {
+		r.log.Error("failed to remove attachment file", "file_id", fileID, "error", result.Error)
+		return fmt.Errorf("failed to remove attachment file: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("file is not the current version")
+	}
+
+	r.log.Info("attachment file removed", "file_id", fileID, "attachment_id", file.AttachmentID, "slot", file.Slot)
+	return nil
+}
+
+// FreezeReviewFiles marks the current file versions of every proposal of the event as the
+// versions reviewers get, replacing any earlier snapshot. Called when voting opens.
+func (r *PostgresAttachmentRepository) FreezeReviewFiles(eventID string) error {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return fmt.Errorf("invalid event ID format: %w", err)
+	}
+
+	if err := r.db.Exec(`
+		UPDATE attachment_files f
+		SET in_review = (f.replaced_at IS NULL)
+		FROM attachments a
+		WHERE a.id = f.attachment_id AND a.event_id = ?`, eventUUID).Error; err != nil {
+		r.log.Error("failed to freeze review files", "event_id", eventID, "error", err)
+		return fmt.Errorf("failed to freeze review files: %w", err)
+	}
+
+	r.log.Info("review file versions frozen", "event_id", eventID)
+	return nil
+}
+
+// GetFileStorageKeys returns the storage keys of every file version of the event's proposals
+func (r *PostgresAttachmentRepository) GetFileStorageKeys(eventID string) ([]string, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("invalid event ID format: %w", err)
+	}
+
+	var keys []string
+	if err := r.db.Model(&attachmentDomain.File{}).
+		Joins("JOIN attachments a ON a.id = attachment_files.attachment_id").
+		Where("a.event_id = ?", eventUUID).
+		Pluck("attachment_files.file_path", &keys).Error; err != nil {
+		r.log.Error("failed to retrieve file storage keys", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve file storage keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// attachmentSearchRow is an attachments row with the search rank and highlights computed by Postgres
+type attachmentSearchRow struct {
+	attachmentDomain.Attachment `gorm:"embedded"`
+	Rank                        float64
+	TitleHighlight              string
+	AbstractHighlight           string
+	ContentHighlight            string
+}
+
+// Search runs a full-text search over the proposals of an event of the organization: form
+// title, abstract and keywords, file name and the text of the main document. Without a
+// query every proposal is returned, newest first.
+func (r *PostgresAttachmentRepository) Search(organizationID uuid.UUID, eventID string, search SearchParams, params PaginationParams) (*PaginatedResult, error) {
+	r.log.Debug("searching attachments", "event_id", eventID, "query", search.Query, "page", params.Page)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("invalid event ID format: %w", err)
+	}
+
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 10
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+
+	query := r.db.Table("attachments").
+		Where("event_id = ?", eventUUID).
+		Where("event_id IN (SELECT id FROM events WHERE organization_id = ?)", organizationID)
+	if search.Query != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('simple', ?)", search.Query)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.log.Error("failed to count attachments", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to count attachments: %w", err)
+	}
+
+	order := "uploaded_at DESC"
+	if search.Query != "" {
+		query = query.Select(`attachments.*,
+			ts_rank_cd(search_vector, websearch_to_tsquery('simple', @q)) AS rank,
+			ts_headline('simple', coalesce(answers->>'title', ''), websearch_to_tsquery('simple', @q), @title_opts) AS title_highlight,
+			ts_headline('simple', coalesce(answers->>'abstract', ''), websearch_to_tsquery('simple', @q), @abstract_opts) AS abstract_highlight,
+			ts_headline('simple', left(extracted_text, 100000), websearch_to_tsquery('simple', @q), @content_opts) AS content_highlight`,
+			sql.Named("q", search.Query),
+			sql.Named("title_opts", highlightOptions("HighlightAll=true")),
+			sql.Named("abstract_opts", highlightOptions("MaxFragments=2, MaxWords=30, MinWords=10")),
+			sql.Named("content_opts", highlightOptions("MaxFragments=3, MaxWords=30, MinWords=10")))
+		if search.SortBy == "" || search.SortBy == SortByRelevance {
+			order = "rank DESC, uploaded_at DESC"
+		}
+	}
+
+	var rows []*attachmentSearchRow
+	if err := query.Order(order).Offset(offset).Limit(params.PageSize).Scan(&rows).Error; err != nil {
+		r.log.Error("failed to search attachments", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to search attachments: %w", err)
+	}
+
+	hits := make([]*AttachmentSearchHit, len(rows))
+	for i, row := range rows {
+		att := row.Attachment
+		hits[i] = &AttachmentSearchHit{Attachment: &att, Rank: row.Rank}
+		if search.Query != "" {
+			hits[i].Highlights = map[string]string{
+				"title":    highlightHTML(row.TitleHighlight),
+				"abstract": highlightHTML(row.AbstractHighlight),
+				"content":  highlightHTML(row.ContentHighlight),
+			}
+		}
+	}
+
+	r.log.Debug("attachment search completed", "event_id", eventID, "total", total, "returned_count", len(hits))
+
+	return &PaginatedResult{
+		Data:       hits,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: int((total + int64(params.PageSize) - 1) / int64(params.PageSize)),
+	}, nil
+}
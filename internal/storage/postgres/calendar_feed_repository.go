@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/calendar"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresCalendarFeedRepository implements CalendarFeedRepository using GORM
+type PostgresCalendarFeedRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresCalendarFeedRepository creates a new PostgreSQL calendar feed repository
+func NewPostgresCalendarFeedRepository(db *gorm.DB) *PostgresCalendarFeedRepository {
+	return &PostgresCalendarFeedRepository{
+		db:  db,
+		log: logger.Repository("calendar_feed"),
+	}
+}
+
+// GetByID retrieves a calendar feed by its ID
+func (r *PostgresCalendarFeedRepository) GetByID(id uuid.UUID) (*calendar.Feed, error) {
+	var feed calendar.Feed
+	if err := r.db.Where("id = ?", id).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("calendar feed not found")
+		}
+		r.log.Error("failed to retrieve calendar feed", "feed_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// GetOrCreate returns the user's calendar feed, creating it on first use
+func (r *PostgresCalendarFeedRepository) GetOrCreate(userID string) (*calendar.Feed, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(calendar.NewFeed(userUUID)).Error; err != nil {
+		r.log.Error("failed to create calendar feed", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+
+	var feed calendar.Feed
+	if err := r.db.Where("user_id = ?", userUUID).First(&feed).Error; err != nil {
+		r.log.Error("failed to retrieve calendar feed", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// Rotate replaces the user's calendar feed with a new one, invalidating the previous URL
+func (r *PostgresCalendarFeedRepository) Rotate(userID string) (*calendar.Feed, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	feed := calendar.NewFeed(userUUID)
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userUUID).Delete(&calendar.Feed{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(feed).Error
+	})
+	if err != nil {
+		r.log.Error("failed to rotate calendar feed", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to rotate calendar feed: %w", err)
+	}
+
+	r.log.Info("calendar feed rotated", "user_id", userID, "feed_id", feed.ID)
+	return feed, nil
+}
+
+// Delete revokes the user's calendar feed
+func (r *PostgresCalendarFeedRepository) Delete(userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Where("user_id = ?", userUUID).Delete(&calendar.Feed{})
+	if result.Error != nil {
+		r.log.Error("failed to delete calendar feed", "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to delete calendar feed: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("calendar feed not found")
+	}
+
+	r.log.Info("calendar feed revoked", "user_id", userID)
+	return nil
+}
+
+// Touch records that a calendar client fetched the feed
+func (r *PostgresCalendarFeedRepository) Touch(id uuid.UUID, at time.Time) error {
+	if err := r.db.Model(&calendar.Feed{}).Where("id = ?", id).Update("last_accessed_at", at).Error; err != nil {
+		r.log.Error("failed to record calendar feed access", "feed_id", id, "error", err)
+		return fmt.Errorf("failed to record calendar feed access: %w", err)
+	}
+
+	return nil
+}
@@ -20,6 +20,21 @@ type Container struct {
 	voteRepo                VoteRepository
 	votingConfigurationRepo VotingConfigurationRepository
 	votingResultsRepo       VotingResultsRepository
+	idempotencyRepo         IdempotencyRepository
+	eventHistoryRepo        EventHistoryRepository
+	eventTemplateRepo       EventTemplateRepository
+	storageCleanupRepo      StorageCleanupRepository
+	eventInvitationRepo     EventInvitationRepository
+	eventWaitlistRepo       EventWaitlistRepository
+	notificationRepo        NotificationRepository
+	calendarFeedRepo        CalendarFeedRepository
+	refreshTokenRepo        RefreshTokenRepository
+	userTokenRepo           UserTokenRepository
+	userIdentityRepo        UserIdentityRepository
+	apiKeyRepo              APIKeyRepository
+	twoFactorRepo           TwoFactorRepository
+	userAuditLogRepo        UserAuditLogRepository
+	organizationRepo        OrganizationRepository
 }
 
 // NewContainer creates a new repository container with all repositories initialized
@@ -50,6 +65,21 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		voteRepo:                NewPostgresVoteRepository(db),
 		votingConfigurationRepo: NewPostgresVotingConfigurationRepository(db),
 		votingResultsRepo:       NewPostgresVotingResultsRepository(db),
+		idempotencyRepo:         NewPostgresIdempotencyRepository(db),
+		eventHistoryRepo:        NewPostgresEventHistoryRepository(db),
+		eventTemplateRepo:       NewPostgresEventTemplateRepository(db),
+		storageCleanupRepo:      NewPostgresStorageCleanupRepository(db),
+		eventInvitationRepo:     NewPostgresEventInvitationRepository(db),
+		eventWaitlistRepo:       NewPostgresEventWaitlistRepository(db),
+		notificationRepo:        NewPostgresNotificationRepository(db),
+		calendarFeedRepo:        NewPostgresCalendarFeedRepository(db),
+		refreshTokenRepo:        NewPostgresRefreshTokenRepository(db),
+		userTokenRepo:           NewPostgresUserTokenRepository(db),
+		userIdentityRepo:        NewPostgresUserIdentityRepository(db),
+		apiKeyRepo:              NewPostgresAPIKeyRepository(db),
+		twoFactorRepo:           NewPostgresTwoFactorRepository(db),
+		userAuditLogRepo:        NewPostgresUserAuditLogRepository(db),
+		organizationRepo:        NewPostgresOrganizationRepository(db),
 	}
 
 	// Perform health check
@@ -75,6 +105,21 @@ func NewContainerWithDB(db *gorm.DB) *Container {
 		voteRepo:                NewPostgresVoteRepository(db),
 		votingConfigurationRepo: NewPostgresVotingConfigurationRepository(db),
 		votingResultsRepo:       NewPostgresVotingResultsRepository(db),
+		idempotencyRepo:         NewPostgresIdempotencyRepository(db),
+		eventHistoryRepo:        NewPostgresEventHistoryRepository(db),
+		eventTemplateRepo:       NewPostgresEventTemplateRepository(db),
+		storageCleanupRepo:      NewPostgresStorageCleanupRepository(db),
+		eventInvitationRepo:     NewPostgresEventInvitationRepository(db),
+		eventWaitlistRepo:       NewPostgresEventWaitlistRepository(db),
+		notificationRepo:        NewPostgresNotificationRepository(db),
+		calendarFeedRepo:        NewPostgresCalendarFeedRepository(db),
+		refreshTokenRepo:        NewPostgresRefreshTokenRepository(db),
+		userTokenRepo:           NewPostgresUserTokenRepository(db),
+		userIdentityRepo:        NewPostgresUserIdentityRepository(db),
+		apiKeyRepo:              NewPostgresAPIKeyRepository(db),
+		twoFactorRepo:           NewPostgresTwoFactorRepository(db),
+		userAuditLogRepo:        NewPostgresUserAuditLogRepository(db),
+		organizationRepo:        NewPostgresOrganizationRepository(db),
 	}
 }
 
@@ -108,6 +153,81 @@ func (c *Container) VotingResults() VotingResultsRepository {
 	return c.votingResultsRepo
 }
 
+// IdempotencyKeys returns the idempotency key repository
+func (c *Container) IdempotencyKeys() IdempotencyRepository {
+	return c.idempotencyRepo
+}
+
+// EventHistory returns the event stage history repository
+func (c *Container) EventHistory() EventHistoryRepository {
+	return c.eventHistoryRepo
+}
+
+// EventTemplates returns the event template repository
+func (c *Container) EventTemplates() EventTemplateRepository {
+	return c.eventTemplateRepo
+}
+
+// StorageCleanup returns the storage cleanup queue repository
+func (c *Container) StorageCleanup() StorageCleanupRepository {
+	return c.storageCleanupRepo
+}
+
+// EventInvitations returns the event invitation repository
+func (c *Container) EventInvitations() EventInvitationRepository {
+	return c.eventInvitationRepo
+}
+
+// EventWaitlist returns the event waitlist repository
+func (c *Container) EventWaitlist() EventWaitlistRepository {
+	return c.eventWaitlistRepo
+}
+
+// Notifications returns the user notification repository
+func (c *Container) Notifications() NotificationRepository {
+	return c.notificationRepo
+}
+
+// CalendarFeeds returns the calendar feed repository
+func (c *Container) CalendarFeeds() CalendarFeedRepository {
+	return c.calendarFeedRepo
+}
+
+// RefreshTokens returns the refresh token repository
+func (c *Container) RefreshTokens() RefreshTokenRepository {
+	return c.refreshTokenRepo
+}
+
+// UserTokens returns the password reset and email verification token repository
+func (c *Container) UserTokens() UserTokenRepository {
+	return c.userTokenRepo
+}
+
+// Identities returns the linked OpenID Connect identity repository
+func (c *Container) Identities() UserIdentityRepository {
+	return c.userIdentityRepo
+}
+
+// APIKeys returns the API key repository
+func (c *Container) APIKeys() APIKeyRepository {
+	return c.apiKeyRepo
+}
+
+// TwoFactor returns the TOTP enrolment and recovery code repository
+func (c *Container) TwoFactor() TwoFactorRepository {
+	return c.twoFactorRepo
+}
+
+// Organizations returns the organization and membership repository
+func (c *Container) Organizations() OrganizationRepository {
+	return c.organizationRepo
+}
+
+// UserAuditLog returns the audit log repository of admin changes to users
+func (c *Container) UserAuditLog() UserAuditLogRepository {
+	return c.userAuditLogRepo
+}
+
 // Health performs a health check on all repositories and database connection
 func (c *Container) Health() error {
 	c.log.Debug("Performing container health check...")
@@ -292,6 +412,21 @@ type TransactionContainer struct {
 	voteRepo                VoteRepository
 	votingConfigurationRepo VotingConfigurationRepository
 	votingResultsRepo       VotingResultsRepository
+	idempotencyRepo         IdempotencyRepository
+	eventHistoryRepo        EventHistoryRepository
+	eventTemplateRepo       EventTemplateRepository
+	storageCleanupRepo      StorageCleanupRepository
+	eventInvitationRepo     EventInvitationRepository
+	eventWaitlistRepo       EventWaitlistRepository
+	notificationRepo        NotificationRepository
+	calendarFeedRepo        CalendarFeedRepository
+	refreshTokenRepo        RefreshTokenRepository
+	userTokenRepo           UserTokenRepository
+	userIdentityRepo        UserIdentityRepository
+	apiKeyRepo              APIKeyRepository
+	twoFactorRepo           TwoFactorRepository
+	userAuditLogRepo        UserAuditLogRepository
+	organizationRepo        OrganizationRepository
 }
 
 // NewTransactionContainer creates a new transaction container
@@ -307,6 +442,21 @@ func NewTransactionContainer(tx *gorm.DB) *TransactionContainer {
 		voteRepo:                NewPostgresVoteRepository(tx),
 		votingConfigurationRepo: NewPostgresVotingConfigurationRepository(tx),
 		votingResultsRepo:       NewPostgresVotingResultsRepository(tx),
+		idempotencyRepo:         NewPostgresIdempotencyRepository(tx),
+		eventHistoryRepo:        NewPostgresEventHistoryRepository(tx),
+		eventTemplateRepo:       NewPostgresEventTemplateRepository(tx),
+		storageCleanupRepo:      NewPostgresStorageCleanupRepository(tx),
+		eventInvitationRepo:     NewPostgresEventInvitationRepository(tx),
+		eventWaitlistRepo:       NewPostgresEventWaitlistRepository(tx),
+		notificationRepo:        NewPostgresNotificationRepository(tx),
+		calendarFeedRepo:        NewPostgresCalendarFeedRepository(tx),
+		refreshTokenRepo:        NewPostgresRefreshTokenRepository(tx),
+		userTokenRepo:           NewPostgresUserTokenRepository(tx),
+		userIdentityRepo:        NewPostgresUserIdentityRepository(tx),
+		apiKeyRepo:              NewPostgresAPIKeyRepository(tx),
+		twoFactorRepo:           NewPostgresTwoFactorRepository(tx),
+		userAuditLogRepo:        NewPostgresUserAuditLogRepository(tx),
+		organizationRepo:        NewPostgresOrganizationRepository(tx),
 	}
 }
 
@@ -340,6 +490,105 @@ func (tc *TransactionContainer) VotingResults() VotingResultsRepository {
 	return tc.votingResultsRepo
 }
 
+// IdempotencyKeys returns the idempotency key repository within transaction
+func (tc *TransactionContainer) IdempotencyKeys() IdempotencyRepository {
+	return tc.idempotencyRepo
+}
+
+// EventHistory returns the event stage history repository within transaction
+func (tc *TransactionContainer) EventHistory() EventHistoryRepository {
+	return tc.eventHistoryRepo
+}
+
+// EventTemplates returns the event template repository within transaction
+func (tc *TransactionContainer) EventTemplates() EventTemplateRepository {
+	return tc.eventTemplateRepo
+}
+
+// StorageCleanup returns the storage cleanup queue repository within transaction
+func (tc *TransactionContainer) StorageCleanup() StorageCleanupRepository {
+	return tc.storageCleanupRepo
+}
+
+// EventInvitations returns the event invitation repository within transaction
+func (tc *TransactionContainer) EventInvitations() EventInvitationRepository {
+	return tc.eventInvitationRepo
+}
+
+// EventWaitlist returns the event waitlist repository within transaction
+func (tc *TransactionContainer) EventWaitlist() EventWaitlistRepository {
+	return tc.eventWaitlistRepo
+}
+
+// Notifications returns the user notification repository within transaction
+func (tc *TransactionContainer) Notifications() NotificationRepository {
+	return tc.notificationRepo
+}
+
+// CalendarFeeds returns the calendar feed repository within transaction
+func (tc *TransactionContainer) CalendarFeeds() CalendarFeedRepository {
+	return tc.calendarFeedRepo
+}
+
+// RefreshTokens returns the refresh token repository within transaction
+func (tc *TransactionContainer) RefreshTokens() RefreshTokenRepository {
+	return tc.refreshTokenRepo
+}
+
+// UserTokens returns the password reset and email verification token repository within transaction
+func (tc *TransactionContainer) UserTokens() UserTokenRepository {
+	return tc.userTokenRepo
+}
+
+// Identities returns the linked OpenID Connect identity repository within transaction
+func (tc *TransactionContainer) Identities() UserIdentityRepository {
+	return tc.userIdentityRepo
+}
+
+// APIKeys returns the API key repository within transaction
+func (tc *TransactionContainer) APIKeys() APIKeyRepository {
+	return tc.apiKeyRepo
+}
+
+// TwoFactor returns the TOTP enrolment and recovery code repository within transaction
+func (tc *TransactionContainer) TwoFactor() TwoFactorRepository {
+	return tc.twoFactorRepo
+}
+
+// Organizations returns the organization and membership repository within transaction
+func (tc *TransactionContainer) Organizations() OrganizationRepository {
+	return tc.organizationRepo
+}
+
+// UserAuditLog returns the user audit log repository within transaction
+func (tc *TransactionContainer) UserAuditLog() UserAuditLogRepository {
+	return tc.userAuditLogRepo
+}
+
+// AdvisoryLock takes a transaction-scoped Postgres advisory lock, waiting while another
+// session holds it. The lock is released automatically on Commit or Rollback.
+func (tc *TransactionContainer) AdvisoryLock(key int64) error {
+	if err := tc.tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error; err != nil {
+		tc.log.Error("Failed to acquire advisory lock", "key", key, "error", err)
+		return fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+// TryAdvisoryLock attempts to take a transaction-scoped Postgres advisory lock.
+// The lock is released automatically on Commit or Rollback; false means another
+// session currently holds it.
+func (tc *TransactionContainer) TryAdvisoryLock(key int64) (bool, error) {
+	var acquired bool
+	if err := tc.tx.Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&acquired).Error; err != nil {
+		tc.log.Error("Failed to acquire advisory lock", "key", key, "error", err)
+		return false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+
+	return acquired, nil
+}
+
 // Commit commits the transaction
 func (tc *TransactionContainer) Commit() error {
 	tc.log.Debug("Committing database transaction")
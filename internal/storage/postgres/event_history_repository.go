@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// Record types stored in event_archived_records
+const (
+	ArchivedAssignment   = "assignment"
+	ArchivedVote         = "vote"
+	ArchivedVoteRevision = "vote_revision"
+	ArchivedVoteDraft    = "vote_draft"
+	ArchivedResults      = "voting_results"
+)
+
+// PostgresEventHistoryRepository implements EventHistoryRepository using GORM
+type PostgresEventHistoryRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresEventHistoryRepository creates a new PostgreSQL event history repository
+func NewPostgresEventHistoryRepository(db *gorm.DB) *PostgresEventHistoryRepository {
+	return &PostgresEventHistoryRepository{
+		db:  db,
+		log: logger.Repository("event_history"),
+	}
+}
+
+// Create appends an entry to the event stage history log
+func (r *PostgresEventHistoryRepository) Create(entry *event.StageHistoryEntry) error {
+	r.log.Debug("recording stage change",
+		"event_id", entry.EventID,
+		"from", entry.FromStage.String(),
+		"to", entry.ToStage.String(),
+		"direction", entry.Direction)
+
+	if err := r.db.Create(entry).Error; err != nil {
+		r.log.Error("failed to record stage change", "event_id", entry.EventID, "error", err)
+		return fmt.Errorf("failed to record stage change: %w", err)
+	}
+
+	r.log.Info("stage change recorded", "event_id", entry.EventID, "history_id", entry.ID, "direction", entry.Direction)
+	return nil
+}
+
+// GetByEventID returns the stage history of an event, oldest first
+func (r *PostgresEventHistoryRepository) GetByEventID(eventID string) ([]*event.StageHistoryEntry, error) {
+	r.log.Debug("retrieving event stage history", "event_id", eventID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	var entries []*event.StageHistoryEntry
+	if err := r.db.Where("event_id = ?", eventUUID).Order("created_at ASC").Find(&entries).Error; err != nil {
+		r.log.Error("failed to retrieve event stage history", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve event stage history: %w", err)
+	}
+
+	r.log.Debug("event stage history retrieved", "event_id", eventID, "count", len(entries))
+	return entries, nil
+}
+
+// ApplyRollbackPolicy applies a rollback policy to the voting data of an event.
+// Archived records are copied to event_archived_records under historyID before being deleted;
+// the history entry itself may be created later in the same transaction.
+// Stored voting results are always archived because they no longer match the reopened stage.
+func (r *PostgresEventHistoryRepository) ApplyRollbackPolicy(eventID string, historyID uuid.UUID, policy event.RollbackPolicy) (*event.RollbackSummary, error) {
+	r.log.Debug("applying rollback policy",
+		"event_id", eventID,
+		"assignments", policy.Assignments,
+		"votes", policy.Votes,
+		"drafts", policy.Drafts)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	summary := &event.RollbackSummary{}
+
+	if summary.Drafts, err = r.applyPolicy(eventUUID, historyID, "vote_drafts", ArchivedVoteDraft, policy.Drafts); err != nil {
+		return nil, err
+	}
+
+	if summary.Votes, err = r.applyPolicy(eventUUID, historyID, "votes", ArchivedVote, policy.Votes); err != nil {
+		return nil, err
+	}
+
+	// Assignments that lost their votes must be submitted again
+	if policy.Votes != event.DataPolicyKeep && policy.Assignments == event.DataPolicyKeep {
+		result := r.db.Exec(`
+			UPDATE assignments SET is_completed = false, completed_at = NULL, updated_at = NOW()
+			WHERE event_id = ? AND is_completed = true`, eventUUID)
+		if result.Error != nil {
+			r.log.Error("failed to reopen assignments", "event_id", eventID, "error", result.Error)
+			return nil, fmt.Errorf("failed to reopen assignments: %w", result.Error)
+		}
+		summary.ReopenedAssignments = result.RowsAffected
+	}
+
+	// Revision history is kept as long as the assignments it belongs to
+	if summary.VoteRevisions, err = r.applyPolicy(eventUUID, historyID, "vote_revisions", ArchivedVoteRevision, policy.Assignments); err != nil {
+		return nil, err
+	}
+
+	if summary.Assignments, err = r.applyPolicy(eventUUID, historyID, "assignments", ArchivedAssignment, policy.Assignments); err != nil {
+		return nil, err
+	}
+
+	if summary.VotingResults, err = r.applyPolicy(eventUUID, historyID, "voting_results", ArchivedResults, event.DataPolicyArchive); err != nil {
+		return nil, err
+	}
+
+	r.log.Info("rollback policy applied",
+		"event_id", eventID,
+		"history_id", historyID,
+		"assignments", summary.Assignments,
+		"votes", summary.Votes,
+		"drafts", summary.Drafts,
+		"voting_results", summary.VotingResults,
+		"reopened_assignments", summary.ReopenedAssignments)
+
+	return summary, nil
+}
+
+// applyPolicy archives and/or deletes the rows of an event-scoped table.
+// table and recordType are never user input.
+func (r *PostgresEventHistoryRepository) applyPolicy(eventID, historyID uuid.UUID, table, recordType string, policy event.DataPolicy) (int64, error) {
+	if policy == event.DataPolicyKeep {
+		return 0, nil
+	}
+
+	if policy == event.DataPolicyArchive {
+		if err := r.db.Exec(`
+			INSERT INTO event_archived_records (event_id, history_id, record_type, record_id, payload)
+			SELECT t.event_id, ?, ?, t.id, to_jsonb(t) FROM `+table+` t WHERE t.event_id = ?`,
+			historyID, recordType, eventID).Error; err != nil {
+			r.log.Error("failed to archive records", "table", table, "event_id", eventID, "error", err)
+			return 0, fmt.Errorf("failed to archive %s: %w", table, err)
+		}
+	}
+
+	result := r.db.Exec(`DELETE FROM `+table+` WHERE event_id = ?`, eventID)
+	if result.Error != nil {
+		r.log.Error("failed to delete records", "table", table, "event_id", eventID, "error", result.Error)
+		return 0, fmt.Errorf("failed to delete %s: %w", table, result.Error)
+	}
+
+	r.log.Debug("records removed", "table", table, "event_id", eventID, "policy", policy, "count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
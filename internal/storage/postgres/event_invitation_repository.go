@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// ErrInvitationUnavailable is returned when an invitation is revoked, expired or has no
+// redemptions left at the time it is redeemed
+var ErrInvitationUnavailable = errors.New("invitation is no longer available")
+
+// PostgresEventInvitationRepository implements EventInvitationRepository using GORM
+type PostgresEventInvitationRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresEventInvitationRepository creates a new PostgreSQL event invitation repository
+func NewPostgresEventInvitationRepository(db *gorm.DB) *PostgresEventInvitationRepository {
+	return &PostgresEventInvitationRepository{
+		db:  db,
+		log: logger.Repository("event_invitation"),
+	}
+}
+
+// Create saves a newly issued invitation
+func (r *PostgresEventInvitationRepository) Create(invitation *event.Invitation) error {
+	r.log.Debug("creating event invitation", "event_id", invitation.EventID, "issued_by", invitation.IssuedBy)
+
+	if err := r.db.Create(invitation).Error; err != nil {
+		r.log.Error("failed to create event invitation", "event_id", invitation.EventID, "error", err)
+		return fmt.Errorf("failed to create event invitation: %w", err)
+	}
+
+	r.log.Info("event invitation issued",
+		"invitation_id", invitation.ID,
+		"event_id", invitation.EventID,
+		"issued_by", invitation.IssuedBy,
+		"email_bound", invitation.Email != nil,
+		"expires_at", invitation.ExpiresAt)
+	return nil
+}
+
+// GetByID retrieves an invitation by its ID
+func (r *PostgresEventInvitationRepository) GetByID(id string) (*event.Invitation, error) {
+	r.log.Debug("retrieving event invitation", "invitation_id", id)
+
+	invitationUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid invitation ID format", "invitation_id", id, "error", err)
+		return nil, errors.New("invalid invitation ID format")
+	}
+
+	var invitation event.Invitation
+	if err := r.db.First(&invitation, "id = ?", invitationUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("event invitation not found", "invitation_id", id)
+			return nil, errors.New("invitation not found")
+		}
+		r.log.Error("failed to retrieve event invitation", "invitation_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve event invitation: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+// GetByEventID lists the invitations issued for an event, newest first
+func (r *PostgresEventInvitationRepository) GetByEventID(eventID string) ([]*event.Invitation, error) {
+	r.log.Debug("retrieving event invitations", "event_id", eventID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	var invitations []*event.Invitation
+	if err := r.db.Where("event_id = ?", eventUUID).Order("created_at DESC").Find(&invitations).Error; err != nil {
+		r.log.Error("failed to retrieve event invitations", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve event invitations: %w", err)
+	}
+
+	r.log.Debug("event invitations retrieved", "event_id", eventID, "count", len(invitations))
+	return invitations, nil
+}
+
+// GetRedemptions lists the registrations made with an invitation, oldest first
+func (r *PostgresEventInvitationRepository) GetRedemptions(invitationID string) ([]*event.InvitationRedemption, error) {
+	r.log.Debug("retrieving invitation redemptions", "invitation_id", invitationID)
+
+	invitationUUID, err := uuid.Parse(invitationID)
+	if err != nil {
+		r.log.Error("invalid invitation ID format", "invitation_id", invitationID, "error", err)
+		return nil, errors.New("invalid invitation ID format")
+	}
+
+	var redemptions []*event.InvitationRedemption
+	if err := r.db.Where("invitation_id = ?", invitationUUID).Order("redeemed_at ASC").Find(&redemptions).Error; err != nil {
+		r.log.Error("failed to retrieve invitation redemptions", "invitation_id", invitationID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve invitation redemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+// Revoke stops an invitation from being redeemed again
+func (r *PostgresEventInvitationRepository) Revoke(id string, revokedBy uuid.UUID) error {
+	r.log.Debug("revoking event invitation", "invitation_id", id, "revoked_by", revokedBy)
+
+	invitationUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid invitation ID format", "invitation_id", id, "error", err)
+		return errors.New("invalid invitation ID format")
+	}
+
+	result := r.db.Model(&event.Invitation{}).
+		Where("id = ? AND revoked_at IS NULL", invitationUUID).
+		Updates(map[string]interface{}{
+			"revoked_at": time.Now(),
+			"revoked_by": revokedBy,
+		})
+	if result.Error != nil {
+		r.log.Error("failed to revoke event invitation", "invitation_id", id, "error", result.Error)
+		return fmt.Errorf("failed to revoke event invitation: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("invitation not found or already revoked")
+	}
+
+	r.log.Info("event invitation revoked", "invitation_id", id, "revoked_by", revokedBy)
+	return nil
+}
+
+// Redeem consumes one use of an invitation and records who redeemed it. The use count is
+// incremented with a conditional update so concurrent registrations cannot exceed MaxUses.
+// Call it in the same transaction that adds the participant to the event.
+func (r *PostgresEventInvitationRepository) Redeem(invitationID, userID uuid.UUID, email string) error {
+	r.log.Debug("redeeming event invitation", "invitation_id", invitationID, "user_id", userID)
+
+	now := time.Now()
+	result := r.db.Model(&event.Invitation{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND (max_uses IS NULL OR use_count < max_uses)", invitationID, now).
+		Updates(map[string]interface{}{
+			"use_count":        gorm.Expr("use_count + 1"),
+			"last_redeemed_at": now,
+		})
+	if result.Error != nil {
+		r.log.Error("failed to redeem event invitation", "invitation_id", invitationID, "error", result.Error)
+		return fmt.Errorf("failed to redeem event invitation: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		r.log.Warn("event invitation no longer available", "invitation_id", invitationID, "user_id", userID)
+		return ErrInvitationUnavailable
+	}
+
+	var invitation event.Invitation
+	if err := r.db.Select("event_id").First(&invitation, "id = ?", invitationID).Error; err != nil {
+		r.log.Error("failed to load redeemed invitation", "invitation_id", invitationID, "error", err)
+		return fmt.Errorf("failed to load redeemed invitation: %w", err)
+	}
+
+	redemption := &event.InvitationRedemption{
+		InvitationID: invitationID,
+		EventID:      invitation.EventID,
+		UserID:       userID,
+		Email:        email,
+		RedeemedAt:   now,
+	}
+	if err := r.db.Create(redemption).Error; err != nil {
+		r.log.Error("failed to record invitation redemption", "invitation_id", invitationID, "error", err)
+		return fmt.Errorf("failed to record invitation redemption: %w", err)
+	}
+
+	r.log.Info("event invitation redeemed", "invitation_id", invitationID, "event_id", invitation.EventID, "user_id", userID)
+	return nil
+}
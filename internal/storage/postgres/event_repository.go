@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/domain/attachment"
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 	"github.com/gravadigital/telescopio-api/internal/logger"
 )
@@ -65,21 +67,69 @@ func (r *PostgresEventRepository) GetByID(id string) (*event.Event, error) {
 	return &evt, nil
 }
 
-func (r *PostgresEventRepository) GetAll() ([]*event.Event, error) {
-	r.log.Debug("retrieving all events")
+// GetByIDInOrganization retrieves an event of the organization; events of other
+// organizations are reported as not found
+func (r *PostgresEventRepository) GetByIDInOrganization(organizationID uuid.UUID, id string) (*event.Event, error) {
+	r.log.Debug("retrieving event by ID", "organization_id", organizationID, "event_id", id)
+
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", id, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	var evt event.Event
+	if err := r.db.Where("organization_id = ?", organizationID).First(&evt, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("event not found", "organization_id", organizationID, "event_id", id)
+			return nil, errors.New("event not found")
+		}
+		r.log.Error("failed to retrieve event", "event_id", id, "error", err)
+		return nil, err
+	}
+
+	r.log.Debug("event retrieved successfully", "event_id", id, "name", evt.Name)
+	return &evt, nil
+}
+
+// GetByOrganization returns every event of an organization, archived and non-public ones included
+func (r *PostgresEventRepository) GetByOrganization(organizationID uuid.UUID) ([]*event.Event, error) {
+	r.log.Debug("retrieving events of organization", "organization_id", organizationID)
 
 	var events []*event.Event
-	if err := r.db.Find(&events).Error; err != nil {
-		r.log.Error("failed to retrieve events", "error", err)
+	if err := r.db.Where("organization_id = ?", organizationID).Find(&events).Error; err != nil {
+		r.log.Error("failed to retrieve events", "organization_id", organizationID, "error", err)
 		return nil, err
 	}
 
-	r.log.Debug("events retrieved successfully", "count", len(events))
+	r.log.Debug("events retrieved successfully", "organization_id", organizationID, "count", len(events))
+	return events, nil
+}
+
+// GetDueForStageTransition returns events whose estimated end date for the current stage
+// is before the given day (end dates are inclusive)
+func (r *PostgresEventRepository) GetDueForStageTransition(now time.Time) ([]*event.Event, error) {
+	today := now.Format("2006-01-02")
+	r.log.Debug("retrieving events due for stage transition", "today", today)
+
+	var events []*event.Event
+	if err := r.db.
+		Where("(stage = ? AND participation_estimated_end_date < ?) OR (stage = ? AND voting_estimated_end_date < ?)",
+			event.StageParticipation, today, event.StageVoting, today).
+		Where("archived_at IS NULL").
+		Order("created_at ASC").
+		Find(&events).Error; err != nil {
+		r.log.Error("failed to retrieve events due for stage transition", "error", err)
+		return nil, fmt.Errorf("failed to retrieve events due for stage transition: %w", err)
+	}
+
+	r.log.Debug("events due for stage transition retrieved", "count", len(events))
 	return events, nil
 }
 
-func (r *PostgresEventRepository) GetByAuthor(authorID string) ([]*event.Event, error) {
-	r.log.Debug("retrieving events by author", "author_id", authorID)
+// GetByAuthor returns the events a user created in the organization
+func (r *PostgresEventRepository) GetByAuthor(organizationID uuid.UUID, authorID string) ([]*event.Event, error) {
+	r.log.Debug("retrieving events by author", "organization_id", organizationID, "author_id", authorID)
 
 	authorUUID, err := uuid.Parse(authorID)
 	if err != nil {
@@ -88,7 +138,7 @@ func (r *PostgresEventRepository) GetByAuthor(authorID string) ([]*event.Event,
 	}
 
 	var events []*event.Event
-	if err := r.db.Where("author_id = ?", authorUUID).Find(&events).Error; err != nil {
+	if err := r.db.Where("organization_id = ? AND author_id = ?", organizationID, authorUUID).Find(&events).Error; err != nil {
 		r.log.Error("failed to retrieve events by author", "author_id", authorID, "error", err)
 		return nil, err
 	}
@@ -97,7 +147,8 @@ func (r *PostgresEventRepository) GetByAuthor(authorID string) ([]*event.Event,
 	return events, nil
 }
 
-func (r *PostgresEventRepository) GetByParticipant(participantID string) ([]*event.Event, error) {
+// GetByParticipant returns the events of the organization a user is registered for
+func (r *PostgresEventRepository) GetByParticipant(organizationID uuid.UUID, participantID string) ([]*event.Event, error) {
 	participantUUID, err := uuid.Parse(participantID)
 	if err != nil {
 		return nil, errors.New("invalid participant ID format")
@@ -106,15 +157,17 @@ func (r *PostgresEventRepository) GetByParticipant(participantID string) ([]*eve
 	var events []*event.Event
 	if err := r.db.Joins("JOIN event_participants ON events.id = event_participants.event_id").
 		Where("event_participants.user_id = ?", participantUUID).
+		Where("events.organization_id = ?", organizationID).
 		Find(&events).Error; err != nil {
 		return nil, err
 	}
 	return events, nil
 }
 
-// GetUserParticipatingEvents returns all events where a user participates (excluding events they created)
-func (r *PostgresEventRepository) GetUserParticipatingEvents(userID string) ([]*event.Event, error) {
-	r.log.Debug("retrieving participating events for user", "user_id", userID)
+// GetUserParticipatingEvents returns all events of the organization where a user participates
+// (excluding events they created)
+func (r *PostgresEventRepository) GetUserParticipatingEvents(organizationID uuid.UUID, userID string) ([]*event.Event, error) {
+	r.log.Debug("retrieving participating events for user", "organization_id", organizationID, "user_id", userID)
 
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
@@ -126,7 +179,9 @@ func (r *PostgresEventRepository) GetUserParticipatingEvents(userID string) ([]*
 	err = r.db.
 		Joins("JOIN event_participants ON events.id = event_participants.event_id").
 		Where("event_participants.user_id = ?", userUUID).
+		Where("events.organization_id = ?", organizationID).
 		Where("events.author_id != ?", userUUID).
+		Where("events.archived_at IS NULL").
 		Order("events.created_at DESC").
 		Find(&events).Error
 
@@ -280,7 +335,6 @@ func (r *PostgresEventRepository) RemoveParticipant(eventID, userID string) erro
 		return errors.New("invalid user ID format")
 	}
 
-	// Use GORM's association mode to remove participant
 	var evt event.Event
 	if err := r.db.First(&evt, eventUUID).Error; err != nil {
 		r.log.Error("event not found for participant removal", "event_id", eventID, "error", err)
@@ -293,9 +347,15 @@ func (r *PostgresEventRepository) RemoveParticipant(eventID, userID string) erro
 		return errors.New("user not found")
 	}
 
-	if err := r.db.Model(&evt).Association("Participants").Delete(&user); err != nil {
-		r.log.Error("failed to remove participant from event", "event_id", eventID, "user_id", userID, "error", err)
-		return err
+	// Delete from the event_participants junction table (Event has no Participants association)
+	result := r.db.Where("event_id = ? AND user_id = ?", eventUUID, userUUID).Delete(&event.EventParticipant{})
+	if result.Error != nil {
+		r.log.Error("failed to remove participant from event", "event_id", eventID, "user_id", userID, "error", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("participant not registered for event")
 	}
 
 	r.log.Info("participant removed from event successfully", "event_id", eventID, "user_id", userID)
@@ -329,87 +389,295 @@ func (r *PostgresEventRepository) Delete(id string) error {
 		return errors.New("invalid event ID format")
 	}
 
-	// Start a transaction for safe deletion
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		r.log.Error("failed to start transaction for event deletion", "event_id", id, "error", tx.Error)
-		return fmt.Errorf("failed to start transaction: %w", tx.Error)
-	}
+	var deleted event.Event
 
-	// Check if event exists
-	var event event.Event
-	if err := tx.First(&event, eventID).Error; err != nil {
-		tx.Rollback()
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			r.log.Warn("attempted to delete non-existent event", "event_id", id)
-			return errors.New("event not found")
+	// Transaction nests as a savepoint when the repository already runs inside a transaction
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// Check if event exists
+		if err := tx.First(&deleted, eventID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				r.log.Warn("attempted to delete non-existent event", "event_id", id)
+				return errors.New("event not found")
+			}
+			r.log.Error("failed to check event existence for deletion", "event_id", id, "error", err)
+			return fmt.Errorf("failed to check event existence: %w", err)
+		}
+
+		// Delete related data first (in correct order due to foreign key constraints).
+		// Drafts, vote revisions, stage history and archived records cascade from their parents.
+		// 1. Delete voting results
+		if err := tx.Where("event_id = ?", eventID).Delete(&vote.VotingResults{}).Error; err != nil {
+			r.log.Error("failed to delete voting results", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete voting results: %w", err)
+		}
+
+		// 2. Delete voting configurations
+		if err := tx.Where("event_id = ?", eventID).Delete(&vote.VotingConfiguration{}).Error; err != nil {
+			r.log.Error("failed to delete voting configuration", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete voting configuration: %w", err)
+		}
+
+		// 3. Delete votes
+		if err := tx.Where("event_id = ?", eventID).Delete(&vote.Vote{}).Error; err != nil {
+			r.log.Error("failed to delete votes", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete votes: %w", err)
+		}
+
+		// 4. Delete assignments
+		if err := tx.Where("event_id = ?", eventID).Delete(&vote.Assignment{}).Error; err != nil {
+			r.log.Error("failed to delete assignments", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete assignments: %w", err)
+		}
+
+		// 5. Delete attachments (stored files are removed separately by the caller)
+		if err := tx.Where("event_id = ?", eventID).Delete(&attachment.Attachment{}).Error; err != nil {
+			r.log.Error("failed to delete attachments", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete attachments: %w", err)
+		}
+
+		// 6. Remove participant associations
+		if err := tx.Where("event_id = ?", eventID).Delete(&event.EventParticipant{}).Error; err != nil {
+			r.log.Error("failed to remove event participants", "event_id", id, "error", err)
+			return fmt.Errorf("failed to remove event participants: %w", err)
 		}
-		r.log.Error("failed to check event existence for deletion", "event_id", id, "error", err)
-		return fmt.Errorf("failed to check event existence: %w", err)
+
+		// 7. Finally delete the event
+		if err := tx.Delete(&deleted).Error; err != nil {
+			r.log.Error("failed to delete event", "event_id", id, "error", err)
+			return fmt.Errorf("failed to delete event: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.log.Info("event deleted successfully", "event_id", id, "name", deleted.Name)
+	return nil
+}
+
+// Archive marks an event as archived: it becomes read-only and is hidden from listings
+func (r *PostgresEventRepository) Archive(eventID, userID string) error {
+	r.log.Debug("archiving event", "event_id", eventID, "user_id", userID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&event.Event{}).
+		Where("id = ? AND archived_at IS NULL", eventUUID).
+		Updates(map[string]interface{}{
+			"archived_at": time.Now(),
+			"archived_by": userUUID,
+		})
+	if result.Error != nil {
+		r.log.Error("failed to archive event", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to archive event: %w", result.Error)
 	}
 
-	// Delete related data first (in correct order due to foreign key constraints)
-	// 1. Delete voting results
-	if err := tx.Where("event_id = ?", eventID).Delete(&vote.VotingResults{}).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete voting results", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete voting results: %w", err)
+	if result.RowsAffected == 0 {
+		return errors.New("event not found or already archived")
 	}
 
-	// 2. Delete voting configurations
-	if err := tx.Where("event_id = ?", eventID).Delete(&vote.VotingConfiguration{}).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete voting configuration", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete voting configuration: %w", err)
+	r.log.Info("event archived", "event_id", eventID, "user_id", userID)
+	return nil
+}
+
+// Unarchive restores an archived event
+func (r *PostgresEventRepository) Unarchive(eventID string) error {
+	r.log.Debug("unarchiving event", "event_id", eventID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
 	}
 
-	// 3. Delete votes
-	if err := tx.Where("event_id = ?", eventID).Delete(&vote.Vote{}).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete votes", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete votes: %w", err)
+	result := r.db.Model(&event.Event{}).
+		Where("id = ? AND archived_at IS NOT NULL", eventUUID).
+		Updates(map[string]interface{}{
+			"archived_at": nil,
+			"archived_by": nil,
+		})
+	if result.Error != nil {
+		r.log.Error("failed to unarchive event", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to unarchive event: %w", result.Error)
 	}
 
-	// 4. Delete assignments
-	if err := tx.Where("event_id = ?", eventID).Delete(&vote.Assignment{}).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete assignments", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete assignments: %w", err)
+	if result.RowsAffected == 0 {
+		return errors.New("event not found or not archived")
 	}
 
-	// 5. Delete attachments
-	if err := tx.Where("event_id = ?", eventID).Delete(&attachment.Attachment{}).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete attachments", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete attachments: %w", err)
+	r.log.Info("event unarchived", "event_id", eventID)
+	return nil
+}
+
+// UpdateVisibility changes who can find and register for an event
+func (r *PostgresEventRepository) UpdateVisibility(eventID string, visibility event.Visibility) error {
+	r.log.Debug("updating event visibility", "event_id", eventID, "visibility", visibility)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
 	}
 
-	// 6. Remove participant associations
-	if err := tx.Model(&event).Association("Participants").Clear(); err != nil {
-		tx.Rollback()
-		r.log.Error("failed to clear participant associations", "event_id", id, "error", err)
-		return fmt.Errorf("failed to clear participant associations: %w", err)
+	if !visibility.IsValid() {
+		return fmt.Errorf("invalid visibility: %s", visibility)
 	}
 
-	// 7. Finally delete the event
-	if err := tx.Delete(&event).Error; err != nil {
-		tx.Rollback()
-		r.log.Error("failed to delete event", "event_id", id, "error", err)
-		return fmt.Errorf("failed to delete event: %w", err)
+	result := r.db.Model(&event.Event{}).Where("id = ?", eventUUID).Update("visibility", visibility)
+	if result.Error != nil {
+		r.log.Error("failed to update event visibility", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to update event visibility: %w", result.Error)
 	}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		r.log.Error("failed to commit event deletion transaction", "event_id", id, "error", err)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if result.RowsAffected == 0 {
+		return errors.New("event not found")
 	}
 
-	r.log.Info("event deleted successfully", "event_id", id, "name", event.Name)
+	r.log.Info("event visibility updated", "event_id", eventID, "visibility", visibility)
 	return nil
 }
 
-func (r *PostgresEventRepository) GetAllPaginated(params PaginationParams) (*PaginatedResult, error) {
-	r.log.Debug("retrieving events with pagination", "page", params.Page, "page_size", params.PageSize)
+// UpdateRequireVerifiedEmail sets whether registrants need a verified email address
+func (r *PostgresEventRepository) UpdateRequireVerifiedEmail(eventID string, required bool) error {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	result := r.db.Model(&event.Event{}).Where("id = ?", eventUUID).Update("require_verified_email", required)
+	if result.Error != nil {
+		r.log.Error("failed to update event registration requirements", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to update event registration requirements: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("event not found")
+	}
+
+	r.log.Info("event registration requirements updated", "event_id", eventID, "require_verified_email", required)
+	return nil
+}
+
+// UpdateMaxParticipants sets the participant limit of an event
+func (r *PostgresEventRepository) UpdateMaxParticipants(eventID string, maxParticipants int) error {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	result := r.db.Model(&event.Event{}).Where("id = ?", eventUUID).Update("max_participants", maxParticipants)
+	if result.Error != nil {
+		r.log.Error("failed to update event participant limit", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to update event participant limit: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("event not found")
+	}
+
+	r.log.Info("event participant limit updated", "event_id", eventID, "max_participants", maxParticipants)
+	return nil
+}
+
+// UpdateResultsVisibility changes who can see the results of an event
+func (r *PostgresEventRepository) UpdateResultsVisibility(eventID string, visibility event.ResultsVisibility) error {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	if !visibility.IsValid() {
+		return fmt.Errorf("invalid results visibility: %s", visibility)
+	}
+
+	result := r.db.Model(&event.Event{}).Where("id = ?", eventUUID).Update("results_visibility", visibility)
+	if result.Error != nil {
+		r.log.Error("failed to update event results visibility", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to update event results visibility: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("event not found")
+	}
+
+	r.log.Info("event results visibility updated", "event_id", eventID, "results_visibility", visibility)
+	return nil
+}
+
+// UpdateSubmissionForm sets the submission form schema of an event; nil removes the form
+func (r *PostgresEventRepository) UpdateSubmissionForm(eventID string, form *submission.Form) error {
+	r.log.Debug("updating event submission form", "event_id", eventID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	result := r.db.Model(&event.Event{}).Where("id = ?", eventUUID).Update("submission_form", form)
+	if result.Error != nil {
+		r.log.Error("failed to update submission form", "event_id", eventID, "error", result.Error)
+		return fmt.Errorf("failed to update submission form: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("event not found")
+	}
+
+	r.log.Info("event submission form updated", "event_id", eventID, "removed", form == nil)
+	return nil
+}
+
+// GetArchived returns the archived events of the organization, most recently archived first.
+// An empty authorID returns the archived events of every author.
+func (r *PostgresEventRepository) GetArchived(organizationID uuid.UUID, authorID string) ([]*event.Event, error) {
+	r.log.Debug("retrieving archived events", "organization_id", organizationID, "author_id", authorID)
+
+	query := r.db.Where("organization_id = ? AND archived_at IS NOT NULL", organizationID)
+	if authorID != "" {
+		authorUUID, err := uuid.Parse(authorID)
+		if err != nil {
+			r.log.Error("invalid author ID format", "author_id", authorID, "error", err)
+			return nil, errors.New("invalid author ID format")
+		}
+		query = query.Where("author_id = ?", authorUUID)
+	}
+
+	var events []*event.Event
+	if err := query.Order("archived_at DESC").Find(&events).Error; err != nil {
+		r.log.Error("failed to retrieve archived events", "author_id", authorID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve archived events: %w", err)
+	}
+
+	r.log.Debug("archived events retrieved", "author_id", authorID, "count", len(events))
+	return events, nil
+}
+
+// eventSearchRow is an events row with the search rank and highlights computed by Postgres
+type eventSearchRow struct {
+	event.Event          `gorm:"embedded"`
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+func (r *PostgresEventRepository) GetAllPaginated(organizationID uuid.UUID, params PaginationParams, search SearchParams) (*PaginatedResult, error) {
+	r.log.Debug("retrieving events with pagination", "page", params.Page, "page_size", params.PageSize, "query", search.Query)
 
 	// Set default values
 	if params.Page <= 0 {
@@ -424,26 +692,65 @@ func (r *PostgresEventRepository) GetAllPaginated(params PaginationParams) (*Pag
 
 	offset := (params.Page - 1) * params.PageSize
 
+	// Archived and non-public events are hidden from listings
+	query := r.db.Table("events").
+		Where("organization_id = ?", organizationID).
+		Where("archived_at IS NULL AND visibility = ?", event.VisibilityPublic)
+	if stage := search.Filters["stage"]; stage != "" {
+		query = query.Where("stage = ?", stage)
+	}
+	if from := search.Filters["from"]; from != "" {
+		query = query.Where("end_date >= ?", from)
+	}
+	if to := search.Filters["to"]; to != "" {
+		query = query.Where("start_date <= ?", to)
+	}
+	if search.Query != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('simple', ?)", search.Query)
+	}
+
 	// Get total count
 	var total int64
-	if err := r.db.Model(&event.Event{}).Count(&total).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		r.log.Error("failed to count events", "error", err)
 		return nil, fmt.Errorf("failed to count events: %w", err)
 	}
 
+	if search.Query != "" {
+		query = query.Select(`events.*,
+			ts_rank_cd(search_vector, websearch_to_tsquery('simple', @q)) AS rank,
+			ts_headline('simple', name, websearch_to_tsquery('simple', @q), @title_opts) AS name_highlight,
+			ts_headline('simple', description, websearch_to_tsquery('simple', @q), @fragment_opts) AS description_highlight`,
+			sql.Named("q", search.Query),
+			sql.Named("title_opts", highlightOptions("HighlightAll=true")),
+			sql.Named("fragment_opts", highlightOptions("MaxFragments=2, MaxWords=30, MinWords=10")))
+	}
+
 	// Get paginated events
-	var events []*event.Event
-	if err := r.db.Offset(offset).Limit(params.PageSize).
-		Order("created_at DESC").
-		Find(&events).Error; err != nil {
+	var rows []*eventSearchRow
+	if err := query.Order(eventSortOrder(search)).
+		Offset(offset).Limit(params.PageSize).
+		Scan(&rows).Error; err != nil {
 		r.log.Error("failed to retrieve paginated events", "error", err)
 		return nil, fmt.Errorf("failed to retrieve paginated events: %w", err)
 	}
 
+	hits := make([]*EventSearchHit, len(rows))
+	for i, row := range rows {
+		evt := row.Event
+		hits[i] = &EventSearchHit{Event: &evt, Rank: row.Rank}
+		if search.Query != "" {
+			hits[i].Highlights = map[string]string{
+				"name":        highlightHTML(row.NameHighlight),
+				"description": highlightHTML(row.DescriptionHighlight),
+			}
+		}
+	}
+
 	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
 
 	result := &PaginatedResult{
-		Data:       events,
+		Data:       hits,
 		Total:      total,
 		Page:       params.Page,
 		PageSize:   params.PageSize,
@@ -455,11 +762,32 @@ func (r *PostgresEventRepository) GetAllPaginated(params PaginationParams) (*Pag
 		"page_size", params.PageSize,
 		"total", total,
 		"total_pages", totalPages,
-		"returned_count", len(events))
+		"returned_count", len(hits))
 
 	return result, nil
 }
 
+// eventSortOrder builds the ORDER BY clause from whitelisted sort keys. Searches sort by
+// relevance unless told otherwise; plain listings show the newest events first.
+func eventSortOrder(search SearchParams) string {
+	direction := "ASC"
+	if search.SortDesc {
+		direction = "DESC"
+	}
+
+	switch search.SortBy {
+	case SortByStartDate:
+		return "start_date " + direction + ", created_at DESC"
+	case SortByCreatedAt:
+		return "created_at " + direction
+	}
+
+	if search.Query != "" {
+		return "rank DESC, created_at DESC"
+	}
+	return "created_at DESC"
+}
+
 // AddParticipantWithRole adds a participant to an event with a specific role
 func (r *PostgresEventRepository) AddParticipantWithRole(eventID, userID string, role event.EventParticipantRole) error {
 	r.log.Debug("adding participant with role to event", "event_id", eventID, "user_id", userID, "role", role)
@@ -530,6 +858,11 @@ func (r *PostgresEventRepository) GetParticipantRole(eventID, userID string) (*e
 		return nil, err
 	}
 
+	// Raw scans don't report missing rows
+	if roleStr == "" {
+		return nil, errors.New("participant not found in event")
+	}
+
 	role := event.EventParticipantRole(roleStr)
 	return &role, nil
 }
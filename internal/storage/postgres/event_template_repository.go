@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// ErrTemplateNameTaken is returned when an owner already has a template with the same name
+// in the organization
+var ErrTemplateNameTaken = errors.New("a template with this name already exists")
+
+// PostgresEventTemplateRepository implements EventTemplateRepository using GORM
+type PostgresEventTemplateRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresEventTemplateRepository creates a new PostgreSQL event template repository
+func NewPostgresEventTemplateRepository(db *gorm.DB) *PostgresEventTemplateRepository {
+	return &PostgresEventTemplateRepository{
+		db:  db,
+		log: logger.Repository("event_template"),
+	}
+}
+
+// Create saves a new event template
+func (r *PostgresEventTemplateRepository) Create(template *event.EventTemplate) error {
+	r.log.Debug("creating event template", "owner_id", template.OwnerID, "name", template.Name)
+
+	if err := template.Validate(); err != nil {
+		r.log.Error("event template validation failed", "error", err)
+		return fmt.Errorf("event template validation failed: %w", err)
+	}
+
+	// Check if the owner already has a template with this name
+	var count int64
+	if err := r.db.Model(&event.EventTemplate{}).
+		Where("organization_id = ? AND owner_id = ? AND name = ?", template.OrganizationID, template.OwnerID, template.Name).
+		Count(&count).Error; err != nil {
+		r.log.Error("failed to check existing template names", "owner_id", template.OwnerID, "error", err)
+		return fmt.Errorf("failed to check existing template names: %w", err)
+	}
+	if count > 0 {
+		r.log.Warn("duplicate event template name", "owner_id", template.OwnerID, "name", template.Name)
+		return ErrTemplateNameTaken
+	}
+
+	if err := r.db.Create(template).Error; err != nil {
+		r.log.Error("failed to create event template", "owner_id", template.OwnerID, "error", err)
+		return fmt.Errorf("failed to create event template: %w", err)
+	}
+
+	r.log.Info("event template created", "template_id", template.ID, "owner_id", template.OwnerID, "name", template.Name)
+	return nil
+}
+
+// GetByID retrieves an event template by its ID
+func (r *PostgresEventTemplateRepository) GetByID(id string) (*event.EventTemplate, error) {
+	r.log.Debug("retrieving event template", "template_id", id)
+
+	templateUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid template ID format", "template_id", id, "error", err)
+		return nil, errors.New("invalid template ID format")
+	}
+
+	var template event.EventTemplate
+	if err := r.db.First(&template, "id = ?", templateUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("event template not found", "template_id", id)
+			return nil, errors.New("template not found")
+		}
+		r.log.Error("failed to retrieve event template", "template_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve event template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// GetByOwner lists the templates saved by a user in the organization, sorted by name
+func (r *PostgresEventTemplateRepository) GetByOwner(organizationID uuid.UUID, ownerID string) ([]*event.EventTemplate, error) {
+	r.log.Debug("retrieving event templates by owner", "organization_id", organizationID, "owner_id", ownerID)
+
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		r.log.Error("invalid owner ID format", "owner_id", ownerID, "error", err)
+		return nil, errors.New("invalid owner ID format")
+	}
+
+	var templates []*event.EventTemplate
+	if err := r.db.Where("organization_id = ? AND owner_id = ?", organizationID, ownerUUID).Order("name ASC").Find(&templates).Error; err != nil {
+		r.log.Error("failed to retrieve event templates", "owner_id", ownerID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve event templates: %w", err)
+	}
+
+	r.log.Debug("event templates retrieved", "owner_id", ownerID, "count", len(templates))
+	return templates, nil
+}
+
+// Delete removes an event template
+func (r *PostgresEventTemplateRepository) Delete(id string) error {
+	r.log.Debug("deleting event template", "template_id", id)
+
+	templateUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid template ID format", "template_id", id, "error", err)
+		return errors.New("invalid template ID format")
+	}
+
+	result := r.db.Delete(&event.EventTemplate{}, "id = ?", templateUUID)
+	if result.Error != nil {
+		r.log.Error("failed to delete event template", "template_id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete event template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("template not found")
+	}
+
+	r.log.Info("event template deleted", "template_id", id)
+	return nil
+}
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// ErrAlreadyWaitlisted is returned when a user is already waiting for the event
+var ErrAlreadyWaitlisted = errors.New("user is already on the waitlist for this event")
+
+// PostgresEventWaitlistRepository implements EventWaitlistRepository using GORM
+type PostgresEventWaitlistRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresEventWaitlistRepository creates a new PostgreSQL event waitlist repository
+func NewPostgresEventWaitlistRepository(db *gorm.DB) *PostgresEventWaitlistRepository {
+	return &PostgresEventWaitlistRepository{
+		db:  db,
+		log: logger.Repository("event_waitlist"),
+	}
+}
+
+// LockEvent takes a row lock on the event until the transaction ends, serializing
+// registrations, removals and promotions so the participant limit is never exceeded.
+// Only meaningful on a repository created from a TransactionContainer.
+func (r *PostgresEventWaitlistRepository) LockEvent(eventID string) error {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return errors.New("invalid event ID format")
+	}
+
+	var locked []uuid.UUID
+	if err := r.db.Raw("SELECT id FROM events WHERE id = ? FOR UPDATE", eventUUID).Scan(&locked).Error; err != nil {
+		r.log.Error("failed to lock event", "event_id", eventID, "error", err)
+		return fmt.Errorf("failed to lock event: %w", err)
+	}
+
+	if len(locked) == 0 {
+		return errors.New("event not found")
+	}
+
+	return nil
+}
+
+// Add appends an entry to the end of the event's queue and sets its position
+func (r *PostgresEventWaitlistRepository) Add(entry *event.WaitlistEntry) error {
+	r.log.Debug("adding waitlist entry", "event_id", entry.EventID, "user_id", entry.UserID)
+
+	var count int64
+	if err := r.db.Model(&event.WaitlistEntry{}).
+		Where("event_id = ? AND user_id = ? AND status = ?", entry.EventID, entry.UserID, event.WaitlistWaiting).
+		Count(&count).Error; err != nil {
+		r.log.Error("failed to check existing waitlist entry", "event_id", entry.EventID, "error", err)
+		return fmt.Errorf("failed to check existing waitlist entry: %w", err)
+	}
+	if count > 0 {
+		return ErrAlreadyWaitlisted
+	}
+
+	var lastPosition int
+	if err := r.db.Model(&event.WaitlistEntry{}).
+		Select("COALESCE(MAX(position), 0)").
+		Where("event_id = ? AND status = ?", entry.EventID, event.WaitlistWaiting).
+		Scan(&lastPosition).Error; err != nil {
+		r.log.Error("failed to compute waitlist position", "event_id", entry.EventID, "error", err)
+		return fmt.Errorf("failed to compute waitlist position: %w", err)
+	}
+
+	entry.Position = lastPosition + 1
+	entry.Status = event.WaitlistWaiting
+
+	if err := r.db.Create(entry).Error; err != nil {
+		r.log.Error("failed to create waitlist entry", "event_id", entry.EventID, "error", err)
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+
+	r.log.Info("user added to waitlist", "event_id", entry.EventID, "user_id", entry.UserID, "position", entry.Position)
+	return nil
+}
+
+// GetByID retrieves a waitlist entry by its ID
+func (r *PostgresEventWaitlistRepository) GetByID(id string) (*event.WaitlistEntry, error) {
+	entryUUID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid waitlist entry ID format", "entry_id", id, "error", err)
+		return nil, errors.New("invalid waitlist entry ID format")
+	}
+
+	var entry event.WaitlistEntry
+	if err := r.db.First(&entry, "id = ?", entryUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("waitlist entry not found")
+		}
+		r.log.Error("failed to retrieve waitlist entry", "entry_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve waitlist entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetWaiting returns the entries still waiting for an event, in queue order
+func (r *PostgresEventWaitlistRepository) GetWaiting(eventID string) ([]*event.WaitlistEntry, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	var entries []*event.WaitlistEntry
+	if err := r.db.Where("event_id = ? AND status = ?", eventUUID, event.WaitlistWaiting).
+		Order("position ASC, created_at ASC").
+		Find(&entries).Error; err != nil {
+		r.log.Error("failed to retrieve waitlist", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve waitlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Move places a waiting entry at the given 1-based position and renumbers the queue
+func (r *PostgresEventWaitlistRepository) Move(eventID string, entryID uuid.UUID, position int) error {
+	r.log.Debug("moving waitlist entry", "event_id", eventID, "entry_id", entryID, "position", position)
+
+	entries, err := r.GetWaiting(eventID)
+	if err != nil {
+		return err
+	}
+
+	// Remember the stored positions; MoveWaitlistEntry renumbers the entries
+	stored := make(map[uuid.UUID]int, len(entries))
+	for _, entry := range entries {
+		stored[entry.ID] = entry.Position
+	}
+
+	entries, found := event.MoveWaitlistEntry(entries, entryID, position)
+	if !found {
+		return errors.New("waitlist entry not found")
+	}
+
+	for _, entry := range entries {
+		if stored[entry.ID] == entry.Position {
+			continue
+		}
+		if err := r.db.Model(&event.WaitlistEntry{}).Where("id = ?", entry.ID).Update("position", entry.Position).Error; err != nil {
+			r.log.Error("failed to renumber waitlist", "event_id", eventID, "error", err)
+			return fmt.Errorf("failed to renumber waitlist: %w", err)
+		}
+	}
+
+	r.log.Info("waitlist entry moved", "event_id", eventID, "entry_id", entryID, "position", position)
+	return nil
+}
+
+// MarkPromoted takes an entry out of the queue after its user was registered.
+// promotedBy is nil for automatic promotions.
+func (r *PostgresEventWaitlistRepository) MarkPromoted(id uuid.UUID, promotedBy *uuid.UUID) error {
+	return r.leaveQueue(id, map[string]interface{}{
+		"status":      event.WaitlistPromoted,
+		"promoted_at": time.Now(),
+		"promoted_by": promotedBy,
+	})
+}
+
+// MarkWithdrawn takes an entry out of the queue without registering its user
+func (r *PostgresEventWaitlistRepository) MarkWithdrawn(id uuid.UUID) error {
+	return r.leaveQueue(id, map[string]interface{}{
+		"status": event.WaitlistWithdrawn,
+	})
+}
+
+func (r *PostgresEventWaitlistRepository) leaveQueue(id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.Model(&event.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, event.WaitlistWaiting).
+		Updates(updates)
+	if result.Error != nil {
+		r.log.Error("failed to update waitlist entry", "entry_id", id, "error", result.Error)
+		return fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("waitlist entry not found or no longer waiting")
+	}
+
+	r.log.Info("waitlist entry left the queue", "entry_id", id, "status", updates["status"])
+	return nil
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// IdempotencyKeyTTL is how long a stored response can be replayed
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKey stores the outcome of a request made with an Idempotency-Key header.
+// Keys are scoped per user; the request hash guards against reusing a key for a different payload.
+type IdempotencyKey struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Key            string    `json:"idempotency_key" gorm:"column:idempotency_key;not null"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Scope          string    `json:"scope" gorm:"not null"`
+	RequestHash    string    `json:"request_hash" gorm:"not null"`
+	ResponseStatus *int      `json:"response_status"`
+	ResponseBody   *string   `json:"response_body" gorm:"type:jsonb"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"not null"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsCompleted reports whether a response has been recorded for this key
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus != nil
+}
+
+// PostgresIdempotencyRepository implements IdempotencyRepository using GORM
+type PostgresIdempotencyRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresIdempotencyRepository creates a new PostgreSQL idempotency key repository
+func NewPostgresIdempotencyRepository(db *gorm.DB) *PostgresIdempotencyRepository {
+	return &PostgresIdempotencyRepository{
+		db:  db,
+		log: logger.Repository("idempotency"),
+	}
+}
+
+// Reserve inserts the key if it does not exist yet. When another request already holds
+// the key, the stored record is returned instead and nothing is inserted.
+// Inside a transaction a concurrent reservation of the same key blocks until the
+// first transaction finishes, so at most one request per key does the work.
+func (r *PostgresIdempotencyRepository) Reserve(record *IdempotencyKey) (*IdempotencyKey, error) {
+	r.log.Debug("reserving idempotency key", "user_id", record.UserID, "scope", record.Scope)
+
+	if record.Key == "" {
+		return nil, errors.New("idempotency key cannot be empty")
+	}
+
+	// Expired keys can be reused
+	if err := r.db.
+		Where("user_id = ? AND idempotency_key = ? AND expires_at < ?", record.UserID, record.Key, time.Now()).
+		Delete(&IdempotencyKey{}).Error; err != nil {
+		r.log.Error("failed to purge expired idempotency key", "user_id", record.UserID, "error", err)
+		return nil, fmt.Errorf("failed to purge expired idempotency key: %w", err)
+	}
+
+	if record.ExpiresAt.IsZero() {
+		record.ExpiresAt = time.Now().Add(IdempotencyKeyTTL)
+	}
+
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "idempotency_key"}},
+		DoNothing: true,
+	}).Create(record)
+	if result.Error != nil {
+		r.log.Error("failed to reserve idempotency key", "user_id", record.UserID, "error", result.Error)
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing IdempotencyKey
+	if err := r.db.
+		Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).
+		First(&existing).Error; err != nil {
+		r.log.Error("failed to load existing idempotency key", "user_id", record.UserID, "error", err)
+		return nil, fmt.Errorf("failed to load existing idempotency key: %w", err)
+	}
+
+	r.log.Info("idempotency key already used", "user_id", record.UserID, "scope", existing.Scope)
+	return &existing, nil
+}
+
+// Complete records the response that will be replayed for subsequent retries
+func (r *PostgresIdempotencyRepository) Complete(id uuid.UUID, status int, body []byte) error {
+	r.log.Debug("completing idempotency key", "id", id, "status", status)
+
+	result := r.db.Model(&IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"response_status": status,
+			"response_body":   string(body),
+		})
+	if result.Error != nil {
+		r.log.Error("failed to complete idempotency key", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to complete idempotency key: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("idempotency key not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/notification"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresNotificationRepository implements NotificationRepository using GORM
+type PostgresNotificationRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresNotificationRepository creates a new PostgreSQL notification repository
+func NewPostgresNotificationRepository(db *gorm.DB) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{
+		db:  db,
+		log: logger.Repository("notification"),
+	}
+}
+
+// Create stores a notification for a user
+func (r *PostgresNotificationRepository) Create(n *notification.Notification) error {
+	if err := r.db.Create(n).Error; err != nil {
+		r.log.Error("failed to create notification", "user_id", n.UserID, "kind", n.Kind, "error", err)
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	r.log.Info("notification created", "notification_id", n.ID, "user_id", n.UserID, "kind", n.Kind)
+	return nil
+}
+
+// GetByUser lists a user's notifications, newest first
+func (r *PostgresNotificationRepository) GetByUser(userID string, unreadOnly bool) ([]*notification.Notification, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	query := r.db.Where("user_id = ?", userUUID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []*notification.Notification
+	if err := query.Order("created_at DESC").Limit(100).Find(&notifications).Error; err != nil {
+		r.log.Error("failed to retrieve notifications", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (r *PostgresNotificationRepository) MarkRead(id, userID string) error {
+	notificationUUID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.New("invalid notification ID format")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&notification.Notification{}).
+		Where("id = ? AND user_id = ?", notificationUUID, userUUID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		r.log.Error("failed to mark notification as read", "notification_id", id, "error", result.Error)
+		return fmt.Errorf("failed to mark notification as read: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("notification not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// ErrOrganizationSlugTaken is returned when another organization already uses the slug
+var ErrOrganizationSlugTaken = errors.New("an organization with this slug already exists")
+
+// PostgresOrganizationRepository implements OrganizationRepository using GORM
+type PostgresOrganizationRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresOrganizationRepository creates a new PostgreSQL organization repository
+func NewPostgresOrganizationRepository(db *gorm.DB) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{
+		db:  db,
+		log: logger.Repository("organization"),
+	}
+}
+
+// Create saves a new organization
+func (r *PostgresOrganizationRepository) Create(org *organization.Organization) error {
+	if err := org.Validate(); err != nil {
+		r.log.Error("organization validation failed", "error", err)
+		return fmt.Errorf("organization validation failed: %w", err)
+	}
+
+	var count int64
+	if err := r.db.Model(&organization.Organization{}).Where("slug = ?", org.Slug).Count(&count).Error; err != nil {
+		r.log.Error("failed to check existing organization slugs", "slug", org.Slug, "error", err)
+		return fmt.Errorf("failed to check existing organization slugs: %w", err)
+	}
+	if count > 0 {
+		return ErrOrganizationSlugTaken
+	}
+
+	if err := r.db.Create(org).Error; err != nil {
+		r.log.Error("failed to create organization", "slug", org.Slug, "error", err)
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	r.log.Info("organization created", "organization_id", org.ID, "slug", org.Slug)
+	return nil
+}
+
+// GetByID retrieves an organization by its ID
+func (r *PostgresOrganizationRepository) GetByID(id string) (*organization.Organization, error) {
+	orgUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid organization ID format")
+	}
+
+	var org organization.Organization
+	if err := r.db.First(&org, "id = ?", orgUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("organization not found")
+		}
+		r.log.Error("failed to retrieve organization", "organization_id", id, "error", err)
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// GetBySlug retrieves an organization by its slug
+func (r *PostgresOrganizationRepository) GetBySlug(slug string) (*organization.Organization, error) {
+	var org organization.Organization
+	if err := r.db.First(&org, "slug = ?", slug).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("organization not found")
+		}
+		r.log.Error("failed to retrieve organization", "slug", slug, "error", err)
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// GetAll lists every organization, sorted by slug
+func (r *PostgresOrganizationRepository) GetAll() ([]*organization.Organization, error) {
+	var orgs []*organization.Organization
+	if err := r.db.Order("slug ASC").Find(&orgs).Error; err != nil {
+		r.log.Error("failed to retrieve organizations", "error", err)
+		return nil, fmt.Errorf("failed to retrieve organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// Update saves the name and branding of an organization; the slug can't change
+func (r *PostgresOrganizationRepository) Update(org *organization.Organization) error {
+	if err := org.Validate(); err != nil {
+		r.log.Error("organization validation failed", "error", err)
+		return fmt.Errorf("organization validation failed: %w", err)
+	}
+
+	result := r.db.Model(&organization.Organization{}).
+		Where("id = ?", org.ID).
+		Updates(map[string]interface{}{
+			"name":          org.Name,
+			"logo_url":      org.LogoURL,
+			"primary_color": org.PrimaryColor,
+			"updated_at":    gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		r.log.Error("failed to update organization", "organization_id", org.ID, "error", result.Error)
+		return fmt.Errorf("failed to update organization: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("organization not found")
+	}
+
+	r.log.Info("organization updated", "organization_id", org.ID)
+	return nil
+}
+
+// GetMember retrieves the membership of a user in an organization
+func (r *PostgresOrganizationRepository) GetMember(organizationID, userID uuid.UUID) (*organization.Member, error) {
+	var member organization.Member
+	if err := r.db.First(&member, "organization_id = ? AND user_id = ?", organizationID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("organization member not found")
+		}
+		r.log.Error("failed to retrieve organization member", "organization_id", organizationID, "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve organization member: %w", err)
+	}
+
+	return &member, nil
+}
+
+// AddMember adds a user to an organization; existing members keep their role
+func (r *PostgresOrganizationRepository) AddMember(member *organization.Member) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit("User").Create(member)
+	if result.Error != nil {
+		r.log.Error("failed to add organization member", "organization_id", member.OrganizationID, "user_id", member.UserID, "error", result.Error)
+		return fmt.Errorf("failed to add organization member: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		r.log.Info("organization member added", "organization_id", member.OrganizationID, "user_id", member.UserID, "role", member.Role)
+	}
+	return nil
+}
+
+// SetMemberRole changes the role of a member
+func (r *PostgresOrganizationRepository) SetMemberRole(organizationID, userID uuid.UUID, role organization.MemberRole) error {
+	result := r.db.Model(&organization.Member{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		r.log.Error("failed to change organization member role", "organization_id", organizationID, "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to change organization member role: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("organization member not found")
+	}
+
+	r.log.Info("organization member role changed", "organization_id", organizationID, "user_id", userID, "role", role)
+	return nil
+}
+
+// RemoveMember removes a user from an organization. Their events and registrations stay.
+func (r *PostgresOrganizationRepository) RemoveMember(organizationID, userID uuid.UUID) error {
+	result := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&organization.Member{})
+	if result.Error != nil {
+		r.log.Error("failed to remove organization member", "organization_id", organizationID, "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to remove organization member: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("organization member not found")
+	}
+
+	r.log.Info("organization member removed", "organization_id", organizationID, "user_id", userID)
+	return nil
+}
+
+// GetMembers lists the members of an organization with their user, newest first
+func (r *PostgresOrganizationRepository) GetMembers(organizationID uuid.UUID, params PaginationParams) (*PaginatedResult, error) {
+	// Set default values
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100 // Maximum page size limit
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+
+	query := r.db.Model(&organization.Member{}).Where("organization_id = ?", organizationID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.log.Error("failed to count organization members", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to count organization members: %w", err)
+	}
+
+	var members []*organization.Member
+	if err := query.Preload("User").Order("created_at DESC").Offset(offset).Limit(params.PageSize).Find(&members).Error; err != nil {
+		r.log.Error("failed to retrieve organization members", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve organization members: %w", err)
+	}
+
+	return &PaginatedResult{
+		Data:       members,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: int((total + int64(params.PageSize) - 1) / int64(params.PageSize)),
+	}, nil
+}
+
+// GetMemberships lists the organizations a user belongs to, oldest membership first
+func (r *PostgresOrganizationRepository) GetMemberships(userID uuid.UUID) ([]*organization.Member, error) {
+	var members []*organization.Member
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&members).Error; err != nil {
+		r.log.Error("failed to retrieve memberships", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve memberships: %w", err)
+	}
+
+	return members, nil
+}
+
+// CountAdmins counts the org admins of an organization
+func (r *PostgresOrganizationRepository) CountAdmins(organizationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&organization.Member{}).
+		Where("organization_id = ? AND role = ?", organizationID, organization.RoleOrgAdmin).
+		Count(&count).Error
+	if err != nil {
+		r.log.Error("failed to count organization admins", "organization_id", organizationID, "error", err)
+		return 0, fmt.Errorf("failed to count organization admins: %w", err)
+	}
+
+	return count, nil
+}
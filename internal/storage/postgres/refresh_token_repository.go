@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresRefreshTokenRepository implements RefreshTokenRepository using GORM
+type PostgresRefreshTokenRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresRefreshTokenRepository creates a new PostgreSQL refresh token repository
+func NewPostgresRefreshTokenRepository(db *gorm.DB) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{
+		db:  db,
+		log: logger.Repository("refresh_token"),
+	}
+}
+
+// Create stores a new refresh token
+func (r *PostgresRefreshTokenRepository) Create(token *session.RefreshToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		r.log.Error("failed to create refresh token", "user_id", token.UserID, "error", err)
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHashForUpdate retrieves a refresh token by its hash and locks the row, so two
+// concurrent refreshes with the same token cannot both succeed
+func (r *PostgresRefreshTokenRepository) GetByHashForUpdate(tokenHash string) (*session.RefreshToken, error) {
+	var token session.RefreshToken
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("refresh token not found")
+		}
+		r.log.Error("failed to retrieve refresh token", "error", err)
+		return nil, fmt.Errorf("failed to retrieve refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed records that a refresh token was exchanged for a new one
+func (r *PostgresRefreshTokenRepository) MarkUsed(id uuid.UUID, at time.Time) error {
+	if err := r.db.Model(&session.RefreshToken{}).Where("id = ?", id).Update("used_at", at).Error; err != nil {
+		r.log.Error("failed to mark refresh token as used", "token_id", id, "error", err)
+		return fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every token issued from the same login
+func (r *PostgresRefreshTokenRepository) RevokeFamily(familyID uuid.UUID) error {
+	err := r.db.Model(&session.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.log.Error("failed to revoke refresh token family", "family_id", familyID, "error", err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	r.log.Info("refresh token family revoked", "family_id", familyID)
+	return nil
+}
+
+// RevokeAllForUser revokes every refresh token of a user
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return errors.New("invalid user ID format")
+	}
+
+	err = r.db.Model(&session.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userUUID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.log.Error("failed to revoke refresh tokens", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	r.log.Info("all refresh tokens revoked", "user_id", userID)
+	return nil
+}
@@ -5,8 +5,13 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gravadigital/telescopio-api/internal/domain/attachment"
+	"github.com/gravadigital/telescopio-api/internal/domain/calendar"
 	"github.com/gravadigital/telescopio-api/internal/domain/event"
+	"github.com/gravadigital/telescopio-api/internal/domain/notification"
+	"github.com/gravadigital/telescopio-api/internal/domain/organization"
 	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/domain/submission"
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 )
 
@@ -33,6 +38,30 @@ type SearchParams struct {
 	SortDesc bool              `json:"sort_desc"`
 }
 
+// Sort keys accepted by the search methods
+const (
+	SortByRelevance = "relevance"
+	SortByCreatedAt = "created_at"
+	SortByStartDate = "start_date"
+)
+
+// EventSearchHit is an event returned by a search, with its relevance and the matching
+// fragments of its name and description (HTML-escaped, matches wrapped in <mark> tags)
+type EventSearchHit struct {
+	Event      *event.Event      `json:"event"`
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// AttachmentSearchHit is a proposal returned by a search, with its relevance and the matching
+// fragments of its title, abstract and document text (HTML-escaped, matches wrapped in
+// <mark> tags)
+type AttachmentSearchHit struct {
+	Attachment *attachment.Attachment `json:"attachment"`
+	Rank       float64                `json:"rank"`
+	Highlights map[string]string      `json:"highlights,omitempty"`
+}
+
 // RepositoryTransaction defines transaction interface for atomic operations
 type RepositoryTransaction interface {
 	Commit() error
@@ -48,13 +77,27 @@ type TransactionalRepository interface {
 type EventRepository interface {
 	Create(event *event.Event) error
 	GetByID(id string) (*event.Event, error)
-	GetAll() ([]*event.Event, error)
-	GetAllPaginated(params PaginationParams) (*PaginatedResult, error)
-	GetByAuthor(authorID string) ([]*event.Event, error)
-	GetByParticipant(participantID string) ([]*event.Event, error)
-	GetUserParticipatingEvents(userID string) ([]*event.Event, error)
+	// GetByIDInOrganization reports events of other organizations as not found
+	GetByIDInOrganization(organizationID uuid.UUID, id string) (*event.Event, error)
+	GetByOrganization(organizationID uuid.UUID) ([]*event.Event, error)
+	// GetAllPaginated lists the public, non-archived events of the organization. Filters:
+	// "stage", "from" and "to" (YYYY-MM-DD, events overlapping the range).
+	// Data is []*EventSearchHit.
+	GetAllPaginated(organizationID uuid.UUID, params PaginationParams, search SearchParams) (*PaginatedResult, error)
+	GetDueForStageTransition(now time.Time) ([]*event.Event, error)
+	GetByAuthor(organizationID uuid.UUID, authorID string) ([]*event.Event, error)
+	GetByParticipant(organizationID uuid.UUID, participantID string) ([]*event.Event, error)
+	GetUserParticipatingEvents(organizationID uuid.UUID, userID string) ([]*event.Event, error)
 	Update(event *event.Event) error
 	Delete(id string) error
+	Archive(eventID, userID string) error
+	Unarchive(eventID string) error
+	GetArchived(organizationID uuid.UUID, authorID string) ([]*event.Event, error)
+	UpdateVisibility(eventID string, visibility event.Visibility) error
+	UpdateRequireVerifiedEmail(eventID string, required bool) error
+	UpdateMaxParticipants(eventID string, maxParticipants int) error
+	UpdateResultsVisibility(eventID string, visibility event.ResultsVisibility) error
+	UpdateSubmissionForm(eventID string, form *submission.Form) error
 	UpdateStage(eventID string, stage event.Stage) error
 	UpdateStageWithEstimatedDate(eventID string, stage event.Stage, estimatedDate *time.Time) error
 	UpdateEstimatedEndDate(eventID string, stage event.Stage, newDate time.Time) error
@@ -69,22 +112,45 @@ type EventRepository interface {
 // UserRepository define los métodos para interactuar con los usuarios en la DB.
 type UserRepository interface {
 	Create(user *participant.User) error
+	// GetByID and GetByEmail look up accounts, which sign in to every organization they
+	// are a member of; lookups for a request use the InOrganization variants
 	GetByID(id string) (*participant.User, error)
 	GetByEmail(email string) (*participant.User, error)
+	// GetByIDInOrganization and GetByEmailInOrganization only find members of the organization
+	GetByIDInOrganization(organizationID uuid.UUID, id string) (*participant.User, error)
+	GetByEmailInOrganization(organizationID uuid.UUID, email string) (*participant.User, error)
 	GetByGoogleID(googleID string) (*participant.User, error)
 	GetAll() ([]*participant.User, error)
-	GetAllPaginated(params PaginationParams) (*PaginatedResult, error)
+	// GetAllPaginated lists the members of the organization. Filters: "role", "status" and "type".
+	GetAllPaginated(organizationID uuid.UUID, params PaginationParams, search SearchParams) (*PaginatedResult, error)
 	Update(user *participant.User) error
 	Delete(id string) error
 	GetEventParticipants(eventID string) ([]*participant.UserWithEventRole, error)
 	GetEventParticipantsPaginated(eventID string, params PaginationParams) (*PaginatedResult, error)
 	UsernameExists(username string) (bool, error)
+	GetTokenVersion(userID string) (int, error)
+	IncrementTokenVersion(userID string) error
+	MarkEmailVerified(userID string, at time.Time) error
+	GetServiceAccounts(organizationID uuid.UUID) ([]*participant.User, error)
+	SetRole(userID string, role participant.Role) error
+	Deactivate(userID string, at time.Time) error
+	Reactivate(userID string) error
+	GetSharedEvents(userID, otherUserID uuid.UUID) ([]uuid.UUID, error)
+	Merge(source, target *participant.User) (map[string]int64, error)
+}
+
+// UserAuditLogRepository defines the methods of the audit log of admin changes to users
+type UserAuditLogRepository interface {
+	Create(entry *participant.AuditEntry) error
+	GetPaginated(organizationID uuid.UUID, targetUserID string, params PaginationParams) (*PaginatedResult, error)
 }
 
 // AttachmentRepository define los métodos para interactuar con los archivos adjuntos
 type AttachmentRepository interface {
 	Create(attachment *attachment.Attachment) error
 	GetByID(id string) (*attachment.Attachment, error)
+	// GetByIDInOrganization reports attachments of events of other organizations as not found
+	GetByIDInOrganization(organizationID uuid.UUID, id string) (*attachment.Attachment, error)
 	GetByEventID(eventID string) ([]*attachment.Attachment, error)
 	GetByEventIDPaginated(eventID string, params PaginationParams) (*PaginatedResult, error)
 	GetByParticipantID(participantID string) ([]*attachment.Attachment, error)
@@ -92,6 +158,20 @@ type AttachmentRepository interface {
 	UpdatePartial(id string, updates map[string]interface{}) error
 	Delete(id string) error
 	UpdateVoteCount(id string, count int) error
+	SetCoAuthors(attachmentID string, userIDs []uuid.UUID) error
+	GetByEventAndParticipant(eventID, participantID string) (*attachment.Attachment, error)
+	// Search runs a full-text search over the proposals of an event of the organization.
+	// Data is []*AttachmentSearchHit.
+	Search(organizationID uuid.UUID, eventID string, search SearchParams, params PaginationParams) (*PaginatedResult, error)
+
+	// File versions (main document and appendices)
+	AddFileVersion(file *attachment.File) error
+	GetFiles(attachmentID string, includeReplaced bool) ([]*attachment.File, error)
+	GetReviewFiles(attachmentID string) ([]*attachment.File, error)
+	GetFile(fileID string) (*attachment.File, error)
+	RemoveFile(fileID string) error
+	FreezeReviewFiles(eventID string) error
+	GetFileStorageKeys(eventID string) ([]string, error)
 }
 
 // VoteRepository define los métodos para interactuar con los votos
@@ -114,6 +194,12 @@ type VoteRepository interface {
 	GetAssignmentByParticipant(eventID, participantID string) (*vote.Assignment, error)
 	UpdateAssignment(assignment *vote.Assignment) error
 	DeleteAssignment(id string) error
+	LockAssignment(id string) (*vote.Assignment, error)
+
+	// Revision methods
+	DeleteByAssignmentID(assignmentID string) error
+	CreateRevision(revision *vote.VoteRevision) error
+	GetRevisionsByEventID(eventID string) ([]*vote.VoteRevision, error)
 }
 
 // VotingConfigurationRepository define los métodos para interactuar con configuraciones de votación
@@ -132,6 +218,144 @@ type VoteDraftRepository interface {
 	GetByAssignmentAndParticipant(assignmentID, participantID uuid.UUID) (*vote.VoteDraft, error)
 }
 
+// IdempotencyRepository stores responses of requests made with an Idempotency-Key header
+type IdempotencyRepository interface {
+	Reserve(record *IdempotencyKey) (*IdempotencyKey, error)
+	Complete(id uuid.UUID, status int, body []byte) error
+}
+
+// EventHistoryRepository records stage changes and applies rollback data policies
+type EventHistoryRepository interface {
+	Create(entry *event.StageHistoryEntry) error
+	GetByEventID(eventID string) ([]*event.StageHistoryEntry, error)
+	ApplyRollbackPolicy(eventID string, historyID uuid.UUID, policy event.RollbackPolicy) (*event.RollbackSummary, error)
+}
+
+// EventTemplateRepository stores named templates used to create new events
+type EventTemplateRepository interface {
+	Create(template *event.EventTemplate) error
+	GetByID(id string) (*event.EventTemplate, error)
+	GetByOwner(organizationID uuid.UUID, ownerID string) ([]*event.EventTemplate, error)
+	Delete(id string) error
+}
+
+// EventInvitationRepository stores invitations to non-public events and their redemptions
+type EventInvitationRepository interface {
+	Create(invitation *event.Invitation) error
+	GetByID(id string) (*event.Invitation, error)
+	GetByEventID(eventID string) ([]*event.Invitation, error)
+	GetRedemptions(invitationID string) ([]*event.InvitationRedemption, error)
+	Revoke(id string, revokedBy uuid.UUID) error
+	Redeem(invitationID, userID uuid.UUID, email string) error
+}
+
+// EventWaitlistRepository queues registrants of full events
+type EventWaitlistRepository interface {
+	LockEvent(eventID string) error
+	Add(entry *event.WaitlistEntry) error
+	GetByID(id string) (*event.WaitlistEntry, error)
+	GetWaiting(eventID string) ([]*event.WaitlistEntry, error)
+	Move(eventID string, entryID uuid.UUID, position int) error
+	MarkPromoted(id uuid.UUID, promotedBy *uuid.UUID) error
+	MarkWithdrawn(id uuid.UUID) error
+}
+
+// NotificationRepository stores in-app notifications for users
+type NotificationRepository interface {
+	Create(n *notification.Notification) error
+	GetByUser(userID string, unreadOnly bool) ([]*notification.Notification, error)
+	MarkRead(id, userID string) error
+}
+
+// RefreshTokenRepository stores the hashed refresh tokens of user sessions
+type RefreshTokenRepository interface {
+	Create(token *session.RefreshToken) error
+	GetByHashForUpdate(tokenHash string) (*session.RefreshToken, error)
+	MarkUsed(id uuid.UUID, at time.Time) error
+	RevokeFamily(familyID uuid.UUID) error
+	RevokeAllForUser(userID string) error
+}
+
+// UserTokenRepository stores the hashed single-use tokens mailed for password resets and
+// email verification
+type UserTokenRepository interface {
+	Create(token *session.UserToken) error
+	// Consume marks an unused, unexpired token as used and returns it
+	Consume(tokenHash string, purpose session.Purpose, now time.Time) (*session.UserToken, error)
+	InvalidateForUser(userID uuid.UUID, purpose session.Purpose) error
+	// GetValid returns an unused, unexpired token without using it up
+	GetValid(tokenHash string, purpose session.Purpose, now time.Time) (*session.UserToken, error)
+	// GetLatestPending returns the most recently issued unused, unexpired token of a user
+	GetLatestPending(userID uuid.UUID, purpose session.Purpose, now time.Time) (*session.UserToken, error)
+}
+
+// TwoFactorRepository stores TOTP enrolments and their hashed recovery codes
+type TwoFactorRepository interface {
+	Get(userID uuid.UUID) (*session.TwoFactor, error)
+	SavePending(enrolment *session.TwoFactor) error
+	Enable(userID uuid.UUID, at time.Time, step int64) error
+	// UseStep records the time step of an accepted code; steps at or before the last one are refused
+	UseStep(userID uuid.UUID, step int64) error
+	Delete(userID uuid.UUID) error
+	ReplaceRecoveryCodes(userID uuid.UUID, codes []*session.RecoveryCode) error
+	ConsumeRecoveryCode(userID uuid.UUID, codeHash string, now time.Time) error
+	CountRecoveryCodes(userID uuid.UUID) (int64, error)
+}
+
+// OrganizationRepository stores the organizations of the deployment and their members
+type OrganizationRepository interface {
+	Create(org *organization.Organization) error
+	GetByID(id string) (*organization.Organization, error)
+	GetBySlug(slug string) (*organization.Organization, error)
+	GetAll() ([]*organization.Organization, error)
+	Update(org *organization.Organization) error
+	GetMember(organizationID, userID uuid.UUID) (*organization.Member, error)
+	// AddMember adds a user to an organization; existing members keep their role
+	AddMember(member *organization.Member) error
+	SetMemberRole(organizationID, userID uuid.UUID, role organization.MemberRole) error
+	RemoveMember(organizationID, userID uuid.UUID) error
+	// GetMembers lists the members with their user, newest first. Data is []*organization.Member.
+	GetMembers(organizationID uuid.UUID, params PaginationParams) (*PaginatedResult, error)
+	GetMemberships(userID uuid.UUID) ([]*organization.Member, error)
+	CountAdmins(organizationID uuid.UUID) (int64, error)
+}
+
+// APIKeyRepository stores the hashed API keys of users and service accounts
+type APIKeyRepository interface {
+	Create(key *session.APIKey) error
+	GetByID(id string) (*session.APIKey, error)
+	GetByHash(keyHash string) (*session.APIKey, error)
+	GetByUser(organizationID uuid.UUID, userID string) ([]*session.APIKey, error)
+	Revoke(id uuid.UUID, at time.Time) error
+	// Touch records a use of the key; repeated uses within a minute are recorded once
+	Touch(id uuid.UUID, at time.Time, ipAddress string) error
+}
+
+// UserIdentityRepository stores the OpenID Connect identities linked to users
+type UserIdentityRepository interface {
+	Create(identity *participant.Identity) error
+	GetByProviderSubject(provider, subject string) (*participant.Identity, error)
+	GetByUser(userID string) ([]*participant.Identity, error)
+	Touch(id uuid.UUID, at time.Time) error
+}
+
+// CalendarFeedRepository stores the private calendar feeds of users
+type CalendarFeedRepository interface {
+	GetByID(id uuid.UUID) (*calendar.Feed, error)
+	GetOrCreate(userID string) (*calendar.Feed, error)
+	Rotate(userID string) (*calendar.Feed, error)
+	Delete(userID string) error
+	Touch(id uuid.UUID, at time.Time) error
+}
+
+// StorageCleanupRepository queues stored files for background removal
+type StorageCleanupRepository interface {
+	Enqueue(eventID *uuid.UUID, keys []string) error
+	ClaimDue(now time.Time, maxAttempts, limit int) ([]*StorageCleanupJob, error)
+	Complete(id uuid.UUID) error
+	Fail(id uuid.UUID, cause error, nextAttemptAt time.Time) error
+}
+
 // VotingResultsRepository define los métodos para interactuar con resultados de votación
 type VotingResultsRepository interface {
 	Create(results *vote.VotingResults) error
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"html"
+	"strings"
+)
+
+// Search snippets are highlighted between these control characters, not <mark> tags, so the
+// text around the matches can be escaped for HTML before the markers become tags. The text
+// comes from users and would otherwise reach clients as markup.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+// highlightOptions returns the ts_headline options marking matches for highlightHTML,
+// followed by the given extra options
+func highlightOptions(extra string) string {
+	return "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", " + extra
+}
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlightHTML escapes a ts_headline snippet for HTML and wraps its matches in <mark> tags
+func highlightHTML(snippet string) string {
+	return highlightTags.Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import "testing"
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{"plain text", "no matches here", "no matches here"},
+		{"match", "the " + highlightStart + "telescope" + highlightStop + " array", "the <mark>telescope</mark> array"},
+		{"markup in text", `<script>alert("x")</script> ` + highlightStart + "radio" + highlightStop, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>radio</mark>"},
+		{"markup in match", highlightStart + "<b>" + highlightStop, "<mark>&lt;b&gt;</mark>"},
+		{"entities", "Q&A 'session'", "Q&amp;A &#39;session&#39;"},
+		{"user-typed mark tags", "<mark>fake</mark>", "&lt;mark&gt;fake&lt;/mark&gt;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightHTML(tt.snippet); got != tt.want {
+				t.Errorf("highlightHTML(%q) = %q, want %q", tt.snippet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightOptions(t *testing.T) {
+	want := "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	if got := highlightOptions("HighlightAll=true"); got != want {
+		t.Errorf("highlightOptions = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// StorageCleanupJob is a stored file waiting to be removed from FileStorage after its
+// database record was deleted
+type StorageCleanupJob struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID       *uuid.UUID `json:"event_id,omitempty" gorm:"type:uuid"`
+	StorageKey    string     `json:"storage_key" gorm:"not null"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (StorageCleanupJob) TableName() string {
+	return "storage_cleanup_jobs"
+}
+
+func (j *StorageCleanupJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
+
+// PostgresStorageCleanupRepository implements StorageCleanupRepository using GORM
+type PostgresStorageCleanupRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresStorageCleanupRepository creates a new PostgreSQL storage cleanup repository
+func NewPostgresStorageCleanupRepository(db *gorm.DB) *PostgresStorageCleanupRepository {
+	return &PostgresStorageCleanupRepository{
+		db:  db,
+		log: logger.Repository("storage_cleanup"),
+	}
+}
+
+// Enqueue schedules stored files for removal. Call it in the same transaction that deletes
+// the records pointing to them so files are never removed for a rolled-back delete.
+func (r *PostgresStorageCleanupRepository) Enqueue(eventID *uuid.UUID, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	r.log.Debug("enqueueing storage cleanup", "event_id", eventID, "count", len(keys))
+
+	now := time.Now()
+	jobs := make([]*StorageCleanupJob, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		jobs = append(jobs, &StorageCleanupJob{
+			EventID:       eventID,
+			StorageKey:    key,
+			NextAttemptAt: now,
+		})
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	if err := r.db.Create(&jobs).Error; err != nil {
+		r.log.Error("failed to enqueue storage cleanup", "event_id", eventID, "error", err)
+		return fmt.Errorf("failed to enqueue storage cleanup: %w", err)
+	}
+
+	r.log.Info("storage cleanup enqueued", "event_id", eventID, "count", len(jobs))
+	return nil
+}
+
+// ClaimDue locks up to limit jobs that are due and below maxAttempts. Must run inside a
+// transaction; rows locked by another worker are skipped.
+func (r *PostgresStorageCleanupRepository) ClaimDue(now time.Time, maxAttempts, limit int) ([]*StorageCleanupJob, error) {
+	var jobs []*StorageCleanupJob
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("next_attempt_at <= ? AND attempts < ?", now, maxAttempts).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		r.log.Error("failed to claim storage cleanup jobs", "error", err)
+		return nil, fmt.Errorf("failed to claim storage cleanup jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Complete removes a finished job
+func (r *PostgresStorageCleanupRepository) Complete(id uuid.UUID) error {
+	if err := r.db.Delete(&StorageCleanupJob{}, "id = ?", id).Error; err != nil {
+		r.log.Error("failed to complete storage cleanup job", "job_id", id, "error", err)
+		return fmt.Errorf("failed to complete storage cleanup job: %w", err)
+	}
+	return nil
+}
+
+// Fail records a failed attempt and schedules the next one
+func (r *PostgresStorageCleanupRepository) Fail(id uuid.UUID, cause error, nextAttemptAt time.Time) error {
+	message := cause.Error()
+	if err := r.db.Model(&StorageCleanupJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      message,
+			"next_attempt_at": nextAttemptAt,
+		}).Error; err != nil {
+		r.log.Error("failed to record storage cleanup failure", "job_id", id, "error", err)
+		return fmt.Errorf("failed to record storage cleanup failure: %w", err)
+	}
+	return nil
+}
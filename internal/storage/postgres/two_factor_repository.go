@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresTwoFactorRepository implements TwoFactorRepository using GORM
+type PostgresTwoFactorRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresTwoFactorRepository creates a new PostgreSQL two-factor repository
+func NewPostgresTwoFactorRepository(db *gorm.DB) *PostgresTwoFactorRepository {
+	return &PostgresTwoFactorRepository{
+		db:  db,
+		log: logger.Repository("two_factor"),
+	}
+}
+
+// Get retrieves the TOTP enrolment of a user, confirmed or pending
+func (r *PostgresTwoFactorRepository) Get(userID uuid.UUID) (*session.TwoFactor, error) {
+	var enrolment session.TwoFactor
+	if err := r.db.Where("user_id = ?", userID).First(&enrolment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("two-factor enrolment not found")
+		}
+		r.log.Error("failed to retrieve two-factor enrolment", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve two-factor enrolment: %w", err)
+	}
+
+	return &enrolment, nil
+}
+
+// SavePending stores a new pending enrolment, replacing any earlier pending one
+func (r *PostgresTwoFactorRepository) SavePending(enrolment *session.TwoFactor) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "created_at", "updated_at"}),
+	}).Create(enrolment).Error
+	if err != nil {
+		r.log.Error("failed to save two-factor enrolment", "user_id", enrolment.UserID, "error", err)
+		return fmt.Errorf("failed to save two-factor enrolment: %w", err)
+	}
+
+	return nil
+}
+
+// Enable confirms a pending enrolment with the step of the code that proved it
+func (r *PostgresTwoFactorRepository) Enable(userID uuid.UUID, at time.Time, step int64) error {
+	result := r.db.Model(&session.TwoFactor{}).
+		Where("user_id = ? AND enabled_at IS NULL", userID).
+		Updates(map[string]interface{}{"enabled_at": at, "last_used_step": step, "updated_at": at})
+	if result.Error != nil {
+		r.log.Error("failed to enable two-factor", "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to enable two-factor: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("two-factor enrolment not found")
+	}
+
+	r.log.Info("two-factor enabled", "user_id", userID)
+	return nil
+}
+
+// UseStep records the step of an accepted code. The check against the last used step and
+// the update are one statement, so a code can't be redeemed twice by concurrent requests.
+func (r *PostgresTwoFactorRepository) UseStep(userID uuid.UUID, step int64) error {
+	result := r.db.Model(&session.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		r.log.Error("failed to record two-factor code use", "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to record two-factor code use: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("two-factor code already used")
+	}
+
+	return nil
+}
+
+// Delete removes the enrolment of a user together with their recovery codes
+func (r *PostgresTwoFactorRepository) Delete(userID uuid.UUID) error {
+	if err := r.db.Where("user_id = ?", userID).Delete(&session.RecoveryCode{}).Error; err != nil {
+		r.log.Error("failed to delete recovery codes", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Delete(&session.TwoFactor{}).Error; err != nil {
+		r.log.Error("failed to delete two-factor enrolment", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to delete two-factor enrolment: %w", err)
+	}
+
+	r.log.Info("two-factor removed", "user_id", userID)
+	return nil
+}
+
+// ReplaceRecoveryCodes deletes the recovery codes of a user and stores new ones
+func (r *PostgresTwoFactorRepository) ReplaceRecoveryCodes(userID uuid.UUID, codes []*session.RecoveryCode) error {
+	if err := r.db.Where("user_id = ?", userID).Delete(&session.RecoveryCode{}).Error; err != nil {
+		r.log.Error("failed to delete recovery codes", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if err := r.db.Create(&codes).Error; err != nil {
+		r.log.Error("failed to create recovery codes", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code of the user as used. The check and the
+// update are one statement, so a code can't be redeemed twice.
+func (r *PostgresTwoFactorRepository) ConsumeRecoveryCode(userID uuid.UUID, codeHash string, now time.Time) error {
+	result := r.db.Model(&session.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	if result.Error != nil {
+		r.log.Error("failed to consume recovery code", "user_id", userID, "error", result.Error)
+		return fmt.Errorf("failed to consume recovery code: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("recovery code not found")
+	}
+
+	r.log.Info("recovery code used", "user_id", userID)
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has left
+func (r *PostgresTwoFactorRepository) CountRecoveryCodes(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&session.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		r.log.Error("failed to count recovery codes", "user_id", userID, "error", err)
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
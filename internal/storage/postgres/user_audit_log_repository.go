@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresUserAuditLogRepository implements UserAuditLogRepository using GORM
+type PostgresUserAuditLogRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresUserAuditLogRepository creates a new PostgreSQL user audit log repository
+func NewPostgresUserAuditLogRepository(db *gorm.DB) *PostgresUserAuditLogRepository {
+	return &PostgresUserAuditLogRepository{
+		db:  db,
+		log: logger.Repository("user_audit_log"),
+	}
+}
+
+// Create appends an entry to the audit log
+func (r *PostgresUserAuditLogRepository) Create(entry *participant.AuditEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		r.log.Error("failed to record audit entry", "target_user_id", entry.TargetUserID, "action", entry.Action, "error", err)
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	r.log.Info("audit entry recorded", "audit_id", entry.ID, "target_user_id", entry.TargetUserID, "action", entry.Action, "actor_id", entry.ActorID)
+	return nil
+}
+
+// GetPaginated returns the audit log of the organization, newest first; an empty targetUserID
+// returns every entry
+func (r *PostgresUserAuditLogRepository) GetPaginated(organizationID uuid.UUID, targetUserID string, params PaginationParams) (*PaginatedResult, error) {
+	// Set default values
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100 // Maximum page size limit
+	}
+
+	offset := (params.Page - 1) * params.PageSize
+
+	query := r.db.Model(&participant.AuditEntry{}).Where("organization_id = ?", organizationID)
+	if targetUserID != "" {
+		targetUUID, err := uuid.Parse(targetUserID)
+		if err != nil {
+			r.log.Error("invalid user ID format", "user_id", targetUserID, "error", err)
+			return nil, errors.New("invalid user ID format")
+		}
+		query = query.Where("target_user_id = ?", targetUUID)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.log.Error("failed to count audit entries", "error", err)
+		return nil, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var entries []*participant.AuditEntry
+	if err := query.Order("created_at DESC").Offset(offset).Limit(params.PageSize).Find(&entries).Error; err != nil {
+		r.log.Error("failed to retrieve audit entries", "error", err)
+		return nil, fmt.Errorf("failed to retrieve audit entries: %w", err)
+	}
+
+	return &PaginatedResult{
+		Data:       entries,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: int((total + int64(params.PageSize) - 1) / int64(params.PageSize)),
+	}, nil
+}
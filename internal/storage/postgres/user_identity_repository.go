@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/participant"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// ErrIdentityAlreadyLinked is returned when the provider account is linked to a user already,
+// or the user already has an identity at the provider
+var ErrIdentityAlreadyLinked = errors.New("identity is already linked")
+
+// PostgresUserIdentityRepository implements UserIdentityRepository using GORM
+type PostgresUserIdentityRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresUserIdentityRepository creates a new PostgreSQL user identity repository
+func NewPostgresUserIdentityRepository(db *gorm.DB) *PostgresUserIdentityRepository {
+	return &PostgresUserIdentityRepository{
+		db:  db,
+		log: logger.Repository("user_identity"),
+	}
+}
+
+// Create links a new identity to a user
+func (r *PostgresUserIdentityRepository) Create(identity *participant.Identity) error {
+	var count int64
+	if err := r.db.Model(&participant.Identity{}).
+		Where("(provider = ? AND subject = ?) OR (provider = ? AND user_id = ?)",
+			identity.Provider, identity.Subject, identity.Provider, identity.UserID).
+		Count(&count).Error; err != nil {
+		r.log.Error("failed to check existing identities", "user_id", identity.UserID, "provider", identity.Provider, "error", err)
+		return fmt.Errorf("failed to check existing identities: %w", err)
+	}
+	if count > 0 {
+		r.log.Warn("identity already linked", "user_id", identity.UserID, "provider", identity.Provider)
+		return ErrIdentityAlreadyLinked
+	}
+
+	if err := r.db.Create(identity).Error; err != nil {
+		r.log.Error("failed to create identity", "user_id", identity.UserID, "provider", identity.Provider, "error", err)
+		return fmt.Errorf("failed to create identity: %w", err)
+	}
+
+	r.log.Info("identity linked", "identity_id", identity.ID, "user_id", identity.UserID, "provider", identity.Provider)
+	return nil
+}
+
+// GetByProviderSubject retrieves the identity of a provider account
+func (r *PostgresUserIdentityRepository) GetByProviderSubject(provider, subject string) (*participant.Identity, error) {
+	var identity participant.Identity
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("identity not found")
+		}
+		r.log.Error("failed to retrieve identity", "provider", provider, "error", err)
+		return nil, fmt.Errorf("failed to retrieve identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// GetByUser retrieves the identities linked to a user
+func (r *PostgresUserIdentityRepository) GetByUser(userID string) ([]*participant.Identity, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		r.log.Error("invalid user ID format", "user_id", userID, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	var identities []*participant.Identity
+	if err := r.db.Where("user_id = ?", userUUID).Order("created_at").Find(&identities).Error; err != nil {
+		r.log.Error("failed to retrieve identities", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Touch records a sign-in with the identity
+func (r *PostgresUserIdentityRepository) Touch(id uuid.UUID, at time.Time) error {
+	if err := r.db.Model(&participant.Identity{}).Where("id = ?", id).Update("last_login_at", at).Error; err != nil {
+		r.log.Error("failed to record identity sign-in", "identity_id", id, "error", err)
+		return fmt.Errorf("failed to record identity sign-in: %w", err)
+	}
+
+	return nil
+}
@@ -1,8 +1,10 @@
 package postgres
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -13,6 +15,17 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/logger"
 )
 
+// Values of the "status" and "type" filters of the user listing
+const (
+	UserStatusActive       = "active"
+	UserStatusDeactivated  = "deactivated"
+	UserTypeUser           = "user"
+	UserTypeServiceAccount = "service_account"
+)
+
+// ErrUserNotFound is returned when no user matches the lookup
+var ErrUserNotFound = errors.New("user not found")
+
 // PostgresUserRepository implements UserRepository using GORM
 type PostgresUserRepository struct {
 	db  *gorm.DB
@@ -68,7 +81,7 @@ func (r *PostgresUserRepository) GetByID(id string) (*participant.User, error) {
 	if err := r.db.First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("User not found", "id", id)
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		r.log.Error("Failed to get user by ID", "id", id, "error", err)
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
@@ -90,7 +103,7 @@ func (r *PostgresUserRepository) GetByEmail(email string) (*participant.User, er
 	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("User not found", "email", email)
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		r.log.Error("Failed to get user by email", "email", email, "error", err)
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
@@ -100,6 +113,56 @@ func (r *PostgresUserRepository) GetByEmail(email string) (*participant.User, er
 	return &user, nil
 }
 
+// GetByIDInOrganization retrieves a member of the organization; other users are reported
+// as not found
+func (r *PostgresUserRepository) GetByIDInOrganization(organizationID uuid.UUID, id string) (*participant.User, error) {
+	r.log.Debug("retrieving member by ID", "organization_id", organizationID, "user_id", id)
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("Invalid user ID format", "id", id, "error", err)
+		return nil, errors.New("invalid user ID format")
+	}
+
+	var user participant.User
+	if err := r.db.Where("id IN (SELECT user_id FROM organization_members WHERE organization_id = ?)", organizationID).
+		First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("User not found", "organization_id", organizationID, "id", id)
+			return nil, ErrUserNotFound
+		}
+		r.log.Error("Failed to get user by ID", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetByEmailInOrganization retrieves a member of the organization by email; other users
+// are reported as not found
+func (r *PostgresUserRepository) GetByEmailInOrganization(organizationID uuid.UUID, email string) (*participant.User, error) {
+	r.log.Debug("retrieving member by email", "organization_id", organizationID, "email", email)
+
+	if email == "" {
+		r.log.Error("empty email provided")
+		return nil, errors.New("email cannot be empty")
+	}
+
+	var user participant.User
+	if err := r.db.Where("email = ?", email).
+		Where("id IN (SELECT user_id FROM organization_members WHERE organization_id = ?)", organizationID).
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("User not found", "organization_id", organizationID, "email", email)
+			return nil, ErrUserNotFound
+		}
+		r.log.Error("Failed to get user by email", "email", email, "error", err)
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (r *PostgresUserRepository) GetByGoogleID(googleID string) (*participant.User, error) {
 	r.log.Debug("retrieving user by google_id", "google_id", googleID)
 
@@ -111,7 +174,7 @@ func (r *PostgresUserRepository) GetByGoogleID(googleID string) (*participant.Us
 	if err := r.db.Where("google_id = ?", googleID).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("User not found", "google_id", googleID)
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		r.log.Error("Failed to get user by google_id", "google_id", googleID, "error", err)
 		return nil, fmt.Errorf("failed to get user by google_id: %w", err)
@@ -132,8 +195,11 @@ func (r *PostgresUserRepository) GetAll() ([]*participant.User, error) {
 	return users, nil
 }
 
-func (r *PostgresUserRepository) GetAllPaginated(params PaginationParams) (*PaginatedResult, error) {
-	r.log.Debug("retrieving users with pagination", "page", params.Page, "page_size", params.PageSize)
+// GetAllPaginated lists the members of the organization in the "organization_id" filter, newest
+// first. The query matches name, last name or email; the "role", "status" (active, deactivated)
+// and "type" (user, service_account) filters narrow the list.
+func (r *PostgresUserRepository) GetAllPaginated(organizationID uuid.UUID, params PaginationParams, search SearchParams) (*PaginatedResult, error) {
+	r.log.Debug("retrieving users with pagination", "page", params.Page, "page_size", params.PageSize, "query", search.Query)
 
 	// Set default values
 	if params.Page <= 0 {
@@ -148,16 +214,38 @@ func (r *PostgresUserRepository) GetAllPaginated(params PaginationParams) (*Pagi
 
 	offset := (params.Page - 1) * params.PageSize
 
+	query := r.db.Model(&participant.User{}).
+		Where("id IN (SELECT user_id FROM organization_members WHERE organization_id = ?)", organizationID)
+	if search.Query != "" {
+		pattern := "%" + escapeLike(search.Query) + "%"
+		query = query.Where("(name ILIKE @p OR email ILIKE @p OR (name || ' ' || COALESCE(lastname, '')) ILIKE @p)", sql.Named("p", pattern))
+	}
+	if role := search.Filters["role"]; role != "" {
+		query = query.Where("role = ?", role)
+	}
+	switch search.Filters["status"] {
+	case UserStatusActive:
+		query = query.Where("deactivated_at IS NULL")
+	case UserStatusDeactivated:
+		query = query.Where("deactivated_at IS NOT NULL")
+	}
+	switch search.Filters["type"] {
+	case UserTypeUser:
+		query = query.Where("NOT is_service_account")
+	case UserTypeServiceAccount:
+		query = query.Where("is_service_account")
+	}
+
 	// Get total count
 	var total int64
-	if err := r.db.Model(&participant.User{}).Count(&total).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		r.log.Error("failed to count users", "error", err)
 		return nil, fmt.Errorf("failed to count users: %w", err)
 	}
 
 	// Get paginated users
 	var users []*participant.User
-	if err := r.db.Offset(offset).Limit(params.PageSize).
+	if err := query.Offset(offset).Limit(params.PageSize).
 		Order("created_at DESC").
 		Find(&users).Error; err != nil {
 		r.log.Error("failed to retrieve paginated users", "error", err)
@@ -198,7 +286,7 @@ func (r *PostgresUserRepository) Update(user *participant.User) error {
 	if err := r.db.First(&existingUser, user.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Error("User not found for update", "id", user.ID)
-			return errors.New("user not found")
+			return ErrUserNotFound
 		}
 		r.log.Error("Failed to check user existence for update", "id", user.ID, "error", err)
 		return fmt.Errorf("failed to check user existence: %w", err)
@@ -245,7 +333,7 @@ func (r *PostgresUserRepository) Delete(id string) error {
 		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Warn("attempted to delete non-existent user", "user_id", id)
-			return errors.New("user not found")
+			return ErrUserNotFound
 		}
 		r.log.Error("failed to check user existence for deletion", "user_id", id, "error", err)
 		return fmt.Errorf("failed to check user existence: %w", err)
@@ -482,7 +570,7 @@ func (r *PostgresUserRepository) GetUserStatistics(userID string) (map[string]in
 	if err := r.db.First(&user, userUUID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Error("user not found for statistics", "user_id", userID)
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		r.log.Error("failed to check user existence for statistics", "user_id", userID, "error", err)
 		return nil, fmt.Errorf("failed to check user existence: %w", err)
@@ -537,3 +625,289 @@ func (r *PostgresUserRepository) GetUserStatistics(userID string) (map[string]in
 	r.log.Debug("user statistics retrieved successfully", "user_id", userID, "stats", stats)
 	return stats, nil
 }
+
+// GetTokenVersion returns the current token version of a user; tokens issued with an
+// older version are revoked
+func (r *PostgresUserRepository) GetTokenVersion(userID string) (int, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+
+	var versions []int
+	if err := r.db.Model(&participant.User{}).Where("id = ?", userUUID).Limit(1).Pluck("token_version", &versions).Error; err != nil {
+		r.log.Error("Failed to get token version", "id", userID, "error", err)
+		return 0, fmt.Errorf("failed to get token version: %w", err)
+	}
+
+	if len(versions) == 0 {
+		return 0, ErrUserNotFound
+	}
+
+	return versions[0], nil
+}
+
+// IncrementTokenVersion revokes every access token issued to the user so far
+func (r *PostgresUserRepository) IncrementTokenVersion(userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&participant.User{}).Where("id = ?", userUUID).
+		Update("token_version", gorm.Expr("token_version + 1"))
+	if result.Error != nil {
+		r.log.Error("Failed to increment token version", "id", userID, "error", result.Error)
+		return fmt.Errorf("failed to increment token version: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	r.log.Info("User token version incremented", "id", userID)
+	return nil
+}
+
+// MarkEmailVerified records that the user proved they own their email address
+func (r *PostgresUserRepository) MarkEmailVerified(userID string, at time.Time) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&participant.User{}).Where("id = ?", userUUID).
+		Update("email_verified_at", gorm.Expr("COALESCE(email_verified_at, ?)", at))
+	if result.Error != nil {
+		r.log.Error("Failed to mark email as verified", "id", userID, "error", result.Error)
+		return fmt.Errorf("failed to mark email as verified: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	r.log.Info("User email verified", "id", userID)
+	return nil
+}
+
+// GetServiceAccounts retrieves the service accounts of the organization, oldest first
+func (r *PostgresUserRepository) GetServiceAccounts(organizationID uuid.UUID) ([]*participant.User, error) {
+	var users []*participant.User
+	if err := r.db.Where("is_service_account").
+		Where("id IN (SELECT user_id FROM organization_members WHERE organization_id = ?)", organizationID).
+		Order("created_at").Find(&users).Error; err != nil {
+		r.log.Error("Failed to get service accounts", "error", err)
+		return nil, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetRole changes the global role of a user and revokes the access tokens carrying the old
+// role; the next refresh issues tokens with the new one
+func (r *PostgresUserRepository) SetRole(userID string, role participant.Role) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	if !role.IsValid() {
+		return fmt.Errorf("invalid role: %s", role)
+	}
+
+	result := r.db.Model(&participant.User{}).Where("id = ?", userUUID).Updates(map[string]interface{}{
+		"role":          role,
+		"token_version": gorm.Expr("token_version + 1"),
+		"updated_at":    time.Now(),
+	})
+	if result.Error != nil {
+		r.log.Error("Failed to change user role", "id", userID, "error", result.Error)
+		return fmt.Errorf("failed to change user role: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	r.log.Info("User role changed", "id", userID, "role", role)
+	return nil
+}
+
+// Deactivate blocks the account: the access tokens issued so far are revoked and no new
+// login, refresh or API key use is accepted until it is reactivated
+func (r *PostgresUserRepository) Deactivate(userID string, at time.Time) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&participant.User{}).Where("id = ?", userUUID).Updates(map[string]interface{}{
+		"deactivated_at": at,
+		"token_version":  gorm.Expr("token_version + 1"),
+		"updated_at":     at,
+	})
+	if result.Error != nil {
+		r.log.Error("Failed to deactivate user", "id", userID, "error", result.Error)
+		return fmt.Errorf("failed to deactivate user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	r.log.Info("User deactivated", "id", userID)
+	return nil
+}
+
+// Reactivate lets a deactivated account sign in again
+func (r *PostgresUserRepository) Reactivate(userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	result := r.db.Model(&participant.User{}).Where("id = ?", userUUID).Updates(map[string]interface{}{
+		"deactivated_at": nil,
+		"updated_at":     time.Now(),
+	})
+	if result.Error != nil {
+		r.log.Error("Failed to reactivate user", "id", userID, "error", result.Error)
+		return fmt.Errorf("failed to reactivate user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	r.log.Info("User reactivated", "id", userID)
+	return nil
+}
+
+// GetSharedEvents returns the events both users take part in, as participants, organizers
+// or waitlisted registrants
+func (r *PostgresUserRepository) GetSharedEvents(userID, otherUserID uuid.UUID) ([]uuid.UUID, error) {
+	var eventIDs []uuid.UUID
+	if err := r.db.Raw(`
+		WITH memberships AS (
+			SELECT event_id, user_id FROM event_participants
+			UNION
+			SELECT event_id, user_id FROM event_waitlist_entries
+			UNION
+			SELECT id, author_id FROM events
+		)
+		SELECT a.event_id FROM memberships a
+		JOIN memberships b ON a.event_id = b.event_id
+		WHERE a.user_id = ? AND b.user_id = ?
+		GROUP BY a.event_id
+	`, userID, otherUserID).Scan(&eventIDs).Error; err != nil {
+		r.log.Error("Failed to get shared events", "user_id", userID, "other_user_id", otherUserID, "error", err)
+		return nil, fmt.Errorf("failed to get shared events: %w", err)
+	}
+
+	return eventIDs, nil
+}
+
+// userReferences lists the columns pointing at a user that a merge hands over to the
+// surviving account. Tables where a user may appear once (per provider, per attachment,
+// ...) are handled separately in Merge. Attachments come before assignments and votes, so
+// the moved rows keep passing the conflict of interest checks of their triggers.
+var userReferences = []struct{ table, column string }{
+	{"events", "author_id"},
+	{"events", "archived_by"},
+	{"event_participants", "user_id"},
+	{"event_waitlist_entries", "user_id"},
+	{"event_waitlist_entries", "promoted_by"},
+	{"event_invitations", "issued_by"},
+	{"event_invitations", "revoked_by"},
+	{"event_invitation_redemptions", "user_id"},
+	{"event_stage_history", "actor_id"},
+	{"event_templates", "owner_id"},
+	{"attachments", "participant_id"},
+	{"attachment_files", "uploaded_by"},
+	{"assignments", "participant_id"},
+	{"votes", "voter_id"},
+	{"vote_revisions", "voter_id"},
+	{"vote_drafts", "participant_id"},
+	{"user_notifications", "user_id"},
+	{"api_keys", "user_id"},
+	{"api_keys", "created_by"},
+	{"user_audit_log", "actor_id"},
+}
+
+// Merge moves everything the source account owns to the target account and deletes the
+// source. Sign-in methods the target lacks (password, Google, other providers) are taken
+// over. The accounts must not share events; run it in a transaction.
+// It returns the number of rows moved per table.
+func (r *PostgresUserRepository) Merge(source, target *participant.User) (map[string]int64, error) {
+	r.log.Debug("merging users", "source_id", source.ID, "target_id", target.ID)
+
+	moved := make(map[string]int64)
+
+	for _, ref := range userReferences {
+		result := r.db.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", ref.table, ref.column, ref.column), target.ID, source.ID)
+		if result.Error != nil {
+			r.log.Error("failed to move user references", "table", ref.table, "column", ref.column, "error", result.Error)
+			return nil, fmt.Errorf("failed to move %s.%s: %w", ref.table, ref.column, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			moved[ref.table] += result.RowsAffected
+		}
+	}
+
+	// One row per user and key: only the rows the target doesn't have move, the rest is
+	// dropped with the source account
+	unique := []struct{ table, sql string }{
+		{"attachment_co_authors", `UPDATE attachment_co_authors SET user_id = @target WHERE user_id = @source
+			AND attachment_id NOT IN (SELECT attachment_id FROM attachment_co_authors WHERE user_id = @target)`},
+		{"user_identities", `UPDATE user_identities SET user_id = @target WHERE user_id = @source
+			AND provider NOT IN (SELECT provider FROM user_identities WHERE user_id = @target)`},
+		{"calendar_feeds", `UPDATE calendar_feeds SET user_id = @target WHERE user_id = @source
+			AND NOT EXISTS (SELECT 1 FROM calendar_feeds WHERE user_id = @target)`},
+		{"organization_members", `UPDATE organization_members SET user_id = @target WHERE user_id = @source
+			AND organization_id NOT IN (SELECT organization_id FROM organization_members WHERE user_id = @target)`},
+	}
+	for _, u := range unique {
+		result := r.db.Exec(u.sql, sql.Named("source", source.ID), sql.Named("target", target.ID))
+		if result.Error != nil {
+			r.log.Error("failed to move user references", "table", u.table, "error", result.Error)
+			return nil, fmt.Errorf("failed to move %s: %w", u.table, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			moved[u.table] += result.RowsAffected
+		}
+	}
+
+	// Sign-in methods: the source row goes first, google_id and email are unique
+	updates := map[string]interface{}{
+		"token_version": gorm.Expr("token_version + 1"),
+		"updated_at":    time.Now(),
+	}
+	if target.PasswordHash == nil && source.PasswordHash != nil {
+		updates["password_hash"] = *source.PasswordHash
+	}
+	if target.GoogleID == nil && source.GoogleID != nil {
+		updates["google_id"] = *source.GoogleID
+	}
+	if target.EmailVerifiedAt == nil && source.EmailVerifiedAt != nil && source.Email == target.Email {
+		updates["email_verified_at"] = *source.EmailVerifiedAt
+	}
+
+	if err := r.db.Exec("DELETE FROM users WHERE id = ?", source.ID).Error; err != nil {
+		r.log.Error("failed to delete merged user", "source_id", source.ID, "error", err)
+		return nil, fmt.Errorf("failed to delete merged user: %w", err)
+	}
+
+	if err := r.db.Model(&participant.User{}).Where("id = ?", target.ID).Updates(updates).Error; err != nil {
+		r.log.Error("failed to update merged user", "target_id", target.ID, "error", err)
+		return nil, fmt.Errorf("failed to update merged user: %w", err)
+	}
+
+	r.log.Info("users merged", "source_id", source.ID, "target_id", target.ID, "moved", moved)
+	return moved, nil
+}
+
+// escapeLike escapes the LIKE wildcards of user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gravadigital/telescopio-api/internal/domain/session"
+	"github.com/gravadigital/telescopio-api/internal/logger"
+)
+
+// PostgresUserTokenRepository implements UserTokenRepository using GORM
+type PostgresUserTokenRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+// NewPostgresUserTokenRepository creates a new PostgreSQL user token repository
+func NewPostgresUserTokenRepository(db *gorm.DB) *PostgresUserTokenRepository {
+	return &PostgresUserTokenRepository{
+		db:  db,
+		log: logger.Repository("user_token"),
+	}
+}
+
+// Create stores a new user token
+func (r *PostgresUserTokenRepository) Create(token *session.UserToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		r.log.Error("failed to create user token", "user_id", token.UserID, "purpose", token.Purpose, "error", err)
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume marks an unused, unexpired token as used and returns it. The check and the
+// update are one statement, so a token can't be redeemed twice.
+func (r *PostgresUserTokenRepository) Consume(tokenHash string, purpose session.Purpose, now time.Time) (*session.UserToken, error) {
+	var token session.UserToken
+	result := r.db.Model(&token).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		r.log.Error("failed to consume user token", "purpose", purpose, "error", result.Error)
+		return nil, fmt.Errorf("failed to consume user token: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("user token not found")
+	}
+
+	return &token, nil
+}
+
+// GetValid returns an unused, unexpired token without using it up
+func (r *PostgresUserTokenRepository) GetValid(tokenHash string, purpose session.Purpose, now time.Time) (*session.UserToken, error) {
+	var token session.UserToken
+	err := r.db.Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user token not found")
+		}
+		r.log.Error("failed to retrieve user token", "purpose", purpose, "error", err)
+		return nil, fmt.Errorf("failed to retrieve user token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// GetLatestPending returns the most recently issued unused, unexpired token of a user for
+// the given purpose
+func (r *PostgresUserTokenRepository) GetLatestPending(userID uuid.UUID, purpose session.Purpose, now time.Time) (*session.UserToken, error) {
+	var token session.UserToken
+	err := r.db.Where("user_id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", userID, purpose, now).
+		Order("created_at DESC").
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user token not found")
+		}
+		r.log.Error("failed to retrieve pending user token", "user_id", userID, "purpose", purpose, "error", err)
+		return nil, fmt.Errorf("failed to retrieve pending user token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// InvalidateForUser uses up the pending tokens of a user for the given purpose, so only the
+// most recently mailed link works
+func (r *PostgresUserTokenRepository) InvalidateForUser(userID uuid.UUID, purpose session.Purpose) error {
+	err := r.db.Model(&session.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		r.log.Error("failed to invalidate user tokens", "user_id", userID, "purpose", purpose, "error", err)
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+
+	return nil
+}
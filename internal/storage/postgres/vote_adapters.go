@@ -1,4 +1,4 @@
-package handlers
+package postgres
 
 import (
 	"fmt"
@@ -8,17 +8,16 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/domain/common"
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 	"github.com/gravadigital/telescopio-api/internal/logger"
-	"github.com/gravadigital/telescopio-api/internal/storage/postgres"
 )
 
-// VoteRepositoryAdapter adapts postgres.VoteRepository to vote.VoteRepository
+// VoteRepositoryAdapter adapts VoteRepository to vote.VoteRepository
 // with enhanced error handling, logging, and validation
 type VoteRepositoryAdapter struct {
-	repo postgres.VoteRepository
+	repo VoteRepository
 	log  *log.Logger
 }
 
-func NewVoteRepositoryAdapter(repo postgres.VoteRepository) *VoteRepositoryAdapter {
+func NewVoteRepositoryAdapter(repo VoteRepository) *VoteRepositoryAdapter {
 	return &VoteRepositoryAdapter{
 		repo: repo,
 		log:  logger.Repository("vote_adapter"),
@@ -224,14 +223,14 @@ func (a *VoteRepositoryAdapter) UpdateAssignment(assignment *vote.Assignment) er
 	return nil
 }
 
-// AttachmentRepositoryAdapter adapts postgres.AttachmentRepository to vote.AttachmentRepository
+// AttachmentRepositoryAdapter adapts AttachmentRepository to vote.AttachmentRepository
 // with enhanced error handling, logging, and validation
 type AttachmentRepositoryAdapter struct {
-	repo postgres.AttachmentRepository
+	repo AttachmentRepository
 	log  *log.Logger
 }
 
-func NewAttachmentRepositoryAdapter(repo postgres.AttachmentRepository) *AttachmentRepositoryAdapter {
+func NewAttachmentRepositoryAdapter(repo AttachmentRepository) *AttachmentRepositoryAdapter {
 	return &AttachmentRepositoryAdapter{
 		repo: repo,
 		log:  logger.Repository("attachment_adapter"),
@@ -283,14 +282,14 @@ func (a *AttachmentRepositoryAdapter) GetByEventID(eventID string) ([]common.Att
 	return attachmentInterfaces, nil
 }
 
-// UserRepositoryAdapter adapts postgres.UserRepository to vote.UserRepository
+// UserRepositoryAdapter adapts UserRepository to vote.UserRepository
 // with enhanced error handling, logging, and validation
 type UserRepositoryAdapter struct {
-	repo postgres.UserRepository
+	repo UserRepository
 	log  *log.Logger
 }
 
-func NewUserRepositoryAdapter(repo postgres.UserRepository) *UserRepositoryAdapter {
+func NewUserRepositoryAdapter(repo UserRepository) *UserRepositoryAdapter {
 	return &UserRepositoryAdapter{
 		repo: repo,
 		log:  logger.Repository("user_adapter"),
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/gravadigital/telescopio-api/internal/domain/vote"
 	"github.com/gravadigital/telescopio-api/internal/logger"
@@ -604,6 +605,97 @@ func (r *PostgresVoteRepository) DeleteAssignment(id string) error {
 	return nil
 }
 
+// LockAssignment retrieves an assignment with a row-level lock (SELECT ... FOR UPDATE).
+// It only serializes concurrent writers when the repository is bound to a transaction.
+func (r *PostgresVoteRepository) LockAssignment(id string) (*vote.Assignment, error) {
+	r.log.Debug("locking assignment", "assignment_id", id)
+
+	assignmentID, err := uuid.Parse(id)
+	if err != nil {
+		r.log.Error("invalid assignment ID format", "assignment_id", id, "error", err)
+		return nil, errors.New("invalid assignment ID format")
+	}
+
+	var assignment vote.Assignment
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&assignment, assignmentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Debug("assignment not found", "assignment_id", id)
+			return nil, errors.New("assignment not found")
+		}
+		r.log.Error("failed to lock assignment", "assignment_id", id, "error", err)
+		return nil, fmt.Errorf("failed to lock assignment: %w", err)
+	}
+
+	return &assignment, nil
+}
+
+// DeleteByAssignmentID removes the current votes of an assignment so an amended ranking can replace them.
+// Prior versions remain available in vote_revisions.
+func (r *PostgresVoteRepository) DeleteByAssignmentID(assignmentID string) error {
+	r.log.Debug("deleting votes by assignment ID", "assignment_id", assignmentID)
+
+	assignmentUUID, err := uuid.Parse(assignmentID)
+	if err != nil {
+		r.log.Error("invalid assignment ID format", "assignment_id", assignmentID, "error", err)
+		return errors.New("invalid assignment ID format")
+	}
+
+	result := r.db.Where("assignment_id = ?", assignmentUUID).Delete(&vote.Vote{})
+	if result.Error != nil {
+		r.log.Error("failed to delete votes by assignment", "assignment_id", assignmentID, "error", result.Error)
+		return fmt.Errorf("failed to delete votes by assignment: %w", result.Error)
+	}
+
+	r.log.Info("votes deleted for assignment", "assignment_id", assignmentID, "count", result.RowsAffected)
+	return nil
+}
+
+// CreateRevision appends a ranking snapshot, numbering it after the latest revision of the assignment.
+// Callers should hold the assignment lock (LockAssignment) so revision numbers stay sequential.
+func (r *PostgresVoteRepository) CreateRevision(revision *vote.VoteRevision) error {
+	r.log.Debug("creating vote revision", "assignment_id", revision.AssignmentID, "voter_id", revision.VoterID)
+
+	var latest int
+	if err := r.db.Model(&vote.VoteRevision{}).
+		Where("assignment_id = ?", revision.AssignmentID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error; err != nil {
+		r.log.Error("failed to get latest revision", "assignment_id", revision.AssignmentID, "error", err)
+		return fmt.Errorf("failed to get latest revision: %w", err)
+	}
+
+	revision.Revision = latest + 1
+	if err := r.db.Create(revision).Error; err != nil {
+		r.log.Error("failed to create vote revision", "assignment_id", revision.AssignmentID, "error", err)
+		return fmt.Errorf("failed to create vote revision: %w", err)
+	}
+
+	r.log.Info("vote revision created", "assignment_id", revision.AssignmentID, "revision", revision.Revision)
+	return nil
+}
+
+// GetRevisionsByEventID retrieves every ranking revision of an event, oldest first per assignment
+func (r *PostgresVoteRepository) GetRevisionsByEventID(eventID string) ([]*vote.VoteRevision, error) {
+	r.log.Debug("retrieving vote revisions by event ID", "event_id", eventID)
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		r.log.Error("invalid event ID format", "event_id", eventID, "error", err)
+		return nil, errors.New("invalid event ID format")
+	}
+
+	var revisions []*vote.VoteRevision
+	if err := r.db.Where("event_id = ?", eventUUID).
+		Order("voter_id, revision ASC").
+		Find(&revisions).Error; err != nil {
+		r.log.Error("failed to retrieve vote revisions", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to retrieve vote revisions: %w", err)
+	}
+
+	r.log.Debug("vote revisions retrieved", "event_id", eventID, "count", len(revisions))
+	return revisions, nil
+}
+
 // GetVotesByAssignmentID retrieves all votes for a specific assignment
 func (r *PostgresVoteRepository) GetVotesByAssignmentID(assignmentID string) ([]*vote.Vote, error) {
 	r.log.Debug("retrieving votes by assignment ID", "assignment_id", assignmentID)
@@ -13,6 +13,9 @@ import (
 	"github.com/gravadigital/telescopio-api/internal/logger"
 )
 
+// ErrVotingConfigNotFound is returned when an event or ID has no voting configuration
+var ErrVotingConfigNotFound = errors.New("voting configuration not found")
+
 // PostgresVotingConfigurationRepository implements VotingConfigurationRepository using GORM
 type PostgresVotingConfigurationRepository struct {
 	db  *gorm.DB
@@ -68,7 +71,7 @@ func (r *PostgresVotingConfigurationRepository) GetByID(id string) (*vote.Voting
 	if err := r.db.Preload("Event").First(&config, configID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("voting configuration not found", "config_id", id)
-			return nil, errors.New("voting configuration not found")
+			return nil, ErrVotingConfigNotFound
 		}
 		r.log.Error("failed to retrieve voting configuration", "config_id", id, "error", err)
 		return nil, fmt.Errorf("failed to retrieve voting configuration: %w", err)
@@ -96,7 +99,7 @@ func (r *PostgresVotingConfigurationRepository) GetByEventID(eventID string) (*v
 	if err := r.db.Where("event_id = ?", eventUUID).First(&config).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Debug("voting configuration not found", "event_id", eventID)
-			return nil, errors.New("voting configuration not found")
+			return nil, ErrVotingConfigNotFound
 		}
 		r.log.Error("failed to retrieve voting configuration", "event_id", eventID, "error", err)
 		return nil, fmt.Errorf("failed to retrieve voting configuration: %w", err)
@@ -120,7 +123,7 @@ func (r *PostgresVotingConfigurationRepository) Update(config *vote.VotingConfig
 	if err := r.db.First(&existingConfig, config.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Error("voting configuration not found for update", "config_id", config.ID)
-			return errors.New("voting configuration not found")
+			return ErrVotingConfigNotFound
 		}
 		r.log.Error("failed to check configuration existence for update", "config_id", config.ID, "error", err)
 		return fmt.Errorf("failed to check configuration existence: %w", err)
@@ -154,7 +157,7 @@ func (r *PostgresVotingConfigurationRepository) Delete(eventID string) error {
 	if err := r.db.Where("event_id = ?", eventUUID).First(&config).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.log.Warn("attempted to delete non-existent voting configuration", "event_id", eventID)
-			return errors.New("voting configuration not found")
+			return ErrVotingConfigNotFound
 		}
 		r.log.Error("failed to check configuration existence for deletion", "event_id", eventID, "error", err)
 		return fmt.Errorf("failed to check configuration existence: %w", err)
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxExtractedTextBytes caps the text kept from an uploaded document for full-text search
+const MaxExtractedTextBytes = 100_000
+
+const (
+	docxMimeType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	pdfMimeType  = "application/pdf"
+)
+
+// ExtractText returns the searchable text of an uploaded document. Plain text, DOCX and
+// PDF files are supported; other types (images, legacy Word) yield an empty string, as do
+// scanned PDFs without a text layer. The file is read through ReadAt, so the caller's read
+// offset is left untouched.
+func ExtractText(file io.ReaderAt, size int64, contentType string) (string, error) {
+	var text string
+	var err error
+
+	switch contentType {
+	case "text/plain":
+		text, err = readLimited(io.NewSectionReader(file, 0, size))
+	case docxMimeType:
+		text, err = extractDocx(file, size)
+	case pdfMimeType:
+		text, err = extractPDF(file, size)
+	default:
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return cleanText(text), nil
+}
+
+func readLimited(r io.Reader) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(r, MaxExtractedTextBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// extractDocx reads the paragraphs of word/document.xml
+func extractDocx(file io.ReaderAt, size int64) (string, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range archive.File {
+		if entry.Name != "word/document.xml" {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+
+		var sb strings.Builder
+		decoder := xml.NewDecoder(rc)
+		inText := false
+		for sb.Len() < MaxExtractedTextBytes {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", err
+			}
+
+			switch t := token.(type) {
+			case xml.StartElement:
+				inText = t.Name.Local == "t"
+			case xml.EndElement:
+				if t.Name.Local == "t" {
+					inText = false
+				}
+				if t.Name.Local == "p" {
+					sb.WriteByte('\n')
+				}
+			case xml.CharData:
+				if inText {
+					sb.Write(t)
+				}
+			}
+		}
+		return sb.String(), nil
+	}
+
+	return "", nil
+}
+
+// cleanText makes the text storable in Postgres: valid UTF-8, no NUL bytes, within the limit
+func cleanText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\x00", "")
+	if len(text) > MaxExtractedTextBytes {
+		text = text[:MaxExtractedTextBytes]
+		for !utf8.ValidString(text) {
+			text = text[:len(text)-1]
+		}
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// docx builds a DOCX archive holding the given word/document.xml body
+func docx(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"/>`,
+		"word/styles.xml":     `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:t>style text</w:t></w:styles>`,
+	}
+	if body != "" {
+		files["word/document.xml"] = `<?xml version="1.0" encoding="UTF-8"?>` +
+			`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			body + `</w:body></w:document>`
+	}
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractTextDocx(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			body: `<w:p><w:r><w:t>First paragraph</w:t></w:r></w:p><w:p><w:r><w:t>Second paragraph</w:t></w:r></w:p>`,
+			want: "First paragraph\nSecond paragraph",
+		},
+		{
+			name: "runs within a paragraph",
+			body: `<w:p><w:r><w:t xml:space="preserve">Radio </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>astronomy</w:t></w:r></w:p>`,
+			want: "Radio astronomy",
+		},
+		{
+			name: "entities",
+			body: `<w:p><w:r><w:t>Stars &amp; galaxies &lt;3</w:t></w:r></w:p>`,
+			want: "Stars & galaxies <3",
+		},
+		{
+			name: "text outside w:t ignored",
+			body: `<w:p><w:r><w:instrText>PAGE</w:instrText><w:t>Visible</w:t></w:r><w:r><w:delText>deleted</w:delText></w:r></w:p>`,
+			want: "Visible",
+		},
+		{
+			name: "tables",
+			body: `<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Cell one</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Cell two</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`,
+			want: "Cell one\nCell two",
+		},
+		{
+			name: "unicode",
+			body: `<w:p><w:r><w:t>Vía Láctea – 銀河</w:t></w:r></w:p>`,
+			want: "Vía Láctea – 銀河",
+		},
+		{
+			name: "empty paragraphs",
+			body: `<w:p/><w:p><w:r><w:t>Text</w:t></w:r></w:p><w:p/>`,
+			want: "Text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := docx(t, tt.body)
+			got, err := ExtractText(bytes.NewReader(data), int64(len(data)), docxMimeType)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractText = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextDocxWithoutDocument(t *testing.T) {
+	data := docx(t, "")
+	got, err := ExtractText(bytes.NewReader(data), int64(len(data)), docxMimeType)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if got != "" {
+		t.Errorf("ExtractText = %q, want empty", got)
+	}
+}
+
+func TestExtractTextDocxErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not a zip archive", []byte("plain text pretending to be a DOCX")},
+		{"malformed XML", docx(t, `<w:p><w:r><w:t>unclosed</w:r></w:p>`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExtractText(bytes.NewReader(tt.data), int64(len(tt.data)), docxMimeType); err == nil {
+				t.Error("ExtractText returned no error")
+			}
+		})
+	}
+}
+
+func TestExtractTextDocxStopsAtLimit(t *testing.T) {
+	paragraph := `<w:p><w:r><w:t>` + strings.Repeat("x", 1000) + `</w:t></w:r></w:p>`
+	data := docx(t, strings.Repeat(paragraph, 200))
+
+	got, err := ExtractText(bytes.NewReader(data), int64(len(data)), docxMimeType)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if len(got) > MaxExtractedTextBytes {
+		t.Errorf("extracted %d bytes, want at most %d", len(got), MaxExtractedTextBytes)
+	}
+}
+
+func TestExtractTextPlain(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"trimmed", "  abstract text \n", "abstract text"},
+		{"NUL bytes removed", "a\x00b", "ab"},
+		{"invalid UTF-8 removed", "ok\xff\xfeok", "okok"},
+		{"capped", strings.Repeat("a", MaxExtractedTextBytes+10), strings.Repeat("a", MaxExtractedTextBytes)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractText(strings.NewReader(tt.text), int64(len(tt.text)), "text/plain")
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractText = %q (%d bytes), want %d bytes", truncate(got), len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestExtractTextUnsupportedType(t *testing.T) {
+	for _, contentType := range []string{"image/png", "application/msword", ""} {
+		got, err := ExtractText(strings.NewReader("data"), 4, contentType)
+		if err != nil || got != "" {
+			t.Errorf("ExtractText(%q) = %q, %v; want empty, nil", contentType, got, err)
+		}
+	}
+}
+
+func TestCleanTextKeepsRunesWholeAtLimit(t *testing.T) {
+	// A two-byte rune straddles the limit
+	text := strings.Repeat("a", MaxExtractedTextBytes-1) + "é"
+
+	got := cleanText(text)
+	if len(got) != MaxExtractedTextBytes-1 {
+		t.Errorf("cleanText kept %d bytes, want %d", len(got), MaxExtractedTextBytes-1)
+	}
+	if !strings.HasSuffix(got, "a") {
+		t.Errorf("cleanText split a rune: %q", got[len(got)-4:])
+	}
+}
+
+// pdf builds a PDF whose only page draws the given content stream
+func pdf(t *testing.T, content string, compress bool) []byte {
+	t.Helper()
+
+	stream := []byte(content)
+	dict := fmt.Sprintf("<< /Length %d >>", len(stream))
+	if compress {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		if _, err := w.Write(stream); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		stream = buf.Bytes()
+		dict = fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", len(stream))
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+	doc.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	doc.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	doc.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>\nendobj\n")
+	doc.WriteString("4 0 obj\n" + dict + "\nstream\n")
+	doc.Write(stream)
+	doc.WriteString("\nendstream\nendobj\n")
+	doc.WriteString("5 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n")
+	doc.WriteString("6 0 obj\n<< /Subtype /Image /Width 1 /Height 1 /Length 12 >>\nstream\n(hidden) Tj\n\nendstream\nendobj\n")
+	doc.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return doc.Bytes()
+}
+
+func TestExtractTextPDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "show string",
+			content: "BT /F1 12 Tf 72 720 Td (Hello telescope) Tj ET",
+			want:    "Hello telescope",
+		},
+		{
+			name:    "text objects on separate lines",
+			content: "BT /F1 12 Tf (First line) Tj ET BT (Second line) Tj ET",
+			want:    "First line\nSecond line",
+		},
+		{
+			name:    "TJ array with kerning",
+			content: "BT /F1 12 Tf [(Ra) 20 (dio) -250 (waves)] TJ ET",
+			want:    "Radio waves",
+		},
+		{
+			name:    "line moves",
+			content: "BT /F1 12 Tf (one) Tj 0 -14 Td (two) Tj T* (three) ' ET",
+			want:    "one two three",
+		},
+		{
+			name:    "escapes and nested parentheses",
+			content: `BT (a \(b\) \\ c) Tj ( \(nested (parens)\)) Tj (\101\102) Tj ET`,
+			want:    `a (b) \ c (nested (parens))AB`,
+		},
+		{
+			name:    "hex string",
+			content: "BT <48656c6c6f> Tj ET",
+			want:    "Hello",
+		},
+		{
+			name:    "UTF-16 string",
+			content: "BT <FEFF00C1006E0067> Tj ET",
+			want:    "Áng",
+		},
+		{
+			name:    "custom encoded glyphs dropped",
+			content: "BT <00480065> Tj (kept) Tj ET",
+			want:    "kept",
+		},
+		{
+			name:    "names and marked content are not text",
+			content: "/Span << /ActualText (alt) >> BDC BT /F1 12 Tf (shown) Tj ET EMC % (comment) Tj",
+			want:    "shown",
+		},
+		{
+			name:    "inline image skipped",
+			content: "BI /W 1 /H 1 ID (junk) Tj EI BT (after image) Tj ET",
+			want:    "after image",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, compress := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/compressed=%v", tt.name, compress), func(t *testing.T) {
+				data := pdf(t, tt.content, compress)
+				got, err := ExtractText(bytes.NewReader(data), int64(len(data)), pdfMimeType)
+				if err != nil {
+					t.Fatalf("ExtractText: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("ExtractText = %q, want %q", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestExtractTextPDFSkipsDamagedStreams(t *testing.T) {
+	data := []byte("%PDF-1.4\n" +
+		"1 0 obj\n<< /Length 5 /Filter /FlateDecode >>\nstream\nnot zlib\nendstream\nendobj\n" +
+		"2 0 obj\n<< /Length 20 >>\nstream\nBT (readable) Tj ET\nendstream\nendobj\n")
+
+	got, err := ExtractText(bytes.NewReader(data), int64(len(data)), pdfMimeType)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if got != "readable" {
+		t.Errorf("ExtractText = %q, want %q", got, "readable")
+	}
+}
+
+func TestExtractTextPDFWithoutText(t *testing.T) {
+	data := []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n%%EOF\n")
+
+	got, err := ExtractText(bytes.NewReader(data), int64(len(data)), pdfMimeType)
+	if err != nil || got != "" {
+		t.Errorf("ExtractText = %q, %v; want empty, nil", got, err)
+	}
+}
+
+func truncate(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of the RFC 6238 test vectors, "12345678901234567890"
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeMatchesRFC6238Vectors(t *testing.T) {
+	// RFC 6238 appendix B lists eight digit codes; six digit codes are their last six digits
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, v := range vectors {
+		got, err := Code(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", v.unix, err)
+		}
+		if want := v.code[len(v.code)-Digits:]; got != want {
+			t.Errorf("Code(%d) = %s, want %s", v.unix, got, want)
+		}
+	}
+}
+
+func TestVerifyAcceptsAdjacentStepsOnce(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	previous, _ := Code(rfcSecret, current-1)
+	step, ok := Verify(rfcSecret, previous, now, 0)
+	if !ok || step != current-1 {
+		t.Fatalf("Verify(previous step) = %d, %v; want %d, true", step, ok, current-1)
+	}
+
+	if _, ok := Verify(rfcSecret, previous, now, step); ok {
+		t.Error("a code was accepted again after its step was used")
+	}
+
+	tooOld, _ := Code(rfcSecret, current-2)
+	if _, ok := Verify(rfcSecret, tooOld, now, 0); ok {
+		t.Error("a code two steps old was accepted")
+	}
+}
+
+func TestVerifyRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := Code(rfcSecret, Step(now))
+
+	if _, ok := Verify(rfcSecret, code[:3]+" "+code[3:], now, 0); !ok {
+		t.Error("a code typed with a space was rejected")
+	}
+	for _, bad := range []string{"", "12345", "1234567", "abcdef"} {
+		if _, ok := Verify(rfcSecret, bad, now, 0); ok {
+			t.Errorf("Verify(%q) accepted a malformed code", bad)
+		}
+	}
+}
+
+func TestGenerateSecretRoundTripsThroughProvisioningURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := url.Parse(ProvisioningURI("Telescopio", "ada@example.org", secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Fatalf("unexpected URI %s", uri)
+	}
+	if !strings.HasPrefix(uri.Path, "/Telescopio:ada@example.org") {
+		t.Errorf("label = %q", uri.Path)
+	}
+	if got := uri.Query().Get("secret"); got != secret {
+		t.Errorf("secret = %q, want %q", got, secret)
+	}
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("generated secret doesn't decode: %v", err)
+	}
+}